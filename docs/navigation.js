@@ -63,6 +63,16 @@ export const navigation = [
                 href: '/docs/advanced-guide/setting-custom-response-headers',
                 desc: "Learn how to include custom headers in HTTP responses to provide additional context and control to your API clients."
             },
+            {
+                title: 'ETags and Conditional Requests',
+                href: '/docs/advanced-guide/conditional-requests',
+                desc: "Learn how GoFr answers conditional requests with ETags, and how handlers declare resource versions to prevent lost updates."
+            },
+            {
+                title: 'Response Encoding and Compression',
+                href: '/docs/advanced-guide/response-encoding-and-compression',
+                desc: "Learn how to render responses as XML, MessagePack, CSV or protobuf based on the Accept header, and how to compress them with gzip, zstd or brotli."
+            },
             {
                 title: 'Custom Spans in Tracing',
                 href: '/docs/advanced-guide/custom-spans-in-tracing',
@@ -78,6 +88,11 @@ export const navigation = [
                 href: '/docs/advanced-guide/http-communication',
                 desc: "Get familiar with making HTTP requests and handling responses within your GoFr application to facilitate seamless communication."
             },
+            {
+                title: 'Caching',
+                href: '/docs/advanced-guide/caching',
+                desc: "Cache the values read by your handlers in memory and in Redis, and invalidate them across all instances of your application."
+            },
             {
                 title: 'HTTP Authentication',
                 href: '/docs/advanced-guide/http-authentication',
@@ -0,0 +1,404 @@
+// Package cache provides a two-tier cache-aside helper. Values are looked up in an in-process cache (L1), then in
+// a store shared by the instances of an application (L2) such as Redis, and loaded on a miss by a single caller per
+// key while the concurrent callers wait for its result.
+package cache
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultTTL         = 5 * time.Minute
+	defaultNegativeTTL = 30 * time.Second
+	defaultJitter      = 0.1
+	defaultL1Size      = 10000
+	defaultL1TTL       = time.Minute
+)
+
+// ErrNotFound is returned by loaders when the value does not exist. The absence is cached for the negative TTL,
+// during which ErrNotFound is returned without calling the loader. It responds with 404 Not Found when returned
+// by a handler.
+var ErrNotFound error = notFoundError{}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string {
+	return "value not found"
+}
+
+func (notFoundError) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// Logger logs the failures of the L2 store, which are not returned to the callers.
+type Logger interface {
+	Errorf(format string, args ...any)
+}
+
+// Config holds the defaults of a Cache.
+type Config struct {
+	// TTL is how long values are cached, 5 minutes by default.
+	TTL time.Duration
+	// NegativeTTL is how long the absence of values is cached, 30 seconds by default.
+	NegativeTTL time.Duration
+	// Jitter randomizes the TTLs by up to this fraction, so that values cached together do not expire together.
+	// 0.1 by default, and disabled by a negative value.
+	Jitter float64
+	// L1Size is the number of values kept in memory, 10000 by default.
+	L1Size int
+	// L1TTL bounds how long values are kept in memory, 1 minute by default, as the memory of an instance is
+	// updated by the invalidations of the other instances only when a Broadcaster is used.
+	L1TTL time.Duration
+}
+
+// Store is the L2 store of a Cache, shared by the instances of an application.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl, tagging it with tags.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags []string) error
+	Delete(ctx context.Context, keys ...string) error
+	// DeleteTags deletes the values tagged with any of tags.
+	DeleteTags(ctx context.Context, tags ...string) error
+}
+
+// Broadcaster sends the invalidations of an instance to the other instances of an application, which remove
+// the invalidated values from their memory.
+type Broadcaster interface {
+	Publish(ctx context.Context, message []byte) error
+	// Subscribe calls handler with the messages published by all instances, until stop is called.
+	Subscribe(handler func(message []byte)) (stop func())
+}
+
+// Cache is a two-tier cache, whose values are read and loaded with Get.
+type Cache struct {
+	config Config
+	logger Logger
+	id     string // identifies the instance in the invalidations it broadcasts
+
+	l1    *memory
+	group singleflight.Group
+
+	mu          sync.RWMutex
+	store       Store
+	broadcaster Broadcaster
+	unsubscribe func()
+}
+
+// New creates a Cache keeping the values in memory only, until a Store is set with UseStore.
+func New(config Config, logger Logger) *Cache {
+	if config.TTL <= 0 {
+		config.TTL = defaultTTL
+	}
+
+	if config.NegativeTTL <= 0 {
+		config.NegativeTTL = defaultNegativeTTL
+	}
+
+	if config.Jitter == 0 {
+		config.Jitter = defaultJitter
+	}
+
+	if config.L1Size <= 0 {
+		config.L1Size = defaultL1Size
+	}
+
+	if config.L1TTL <= 0 {
+		config.L1TTL = defaultL1TTL
+	}
+
+	id := make([]byte, 8)
+	_, _ = cryptorand.Read(id)
+
+	return &Cache{config: config, logger: logger, id: hex.EncodeToString(id), l1: newMemory(config.L1Size)}
+}
+
+// UseStore sets the L2 store of the cache.
+func (c *Cache) UseStore(store Store) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store = store
+}
+
+// UseBroadcaster sets the broadcaster sending the invalidations to the other instances, and receiving theirs.
+func (c *Cache) UseBroadcaster(broadcaster Broadcaster) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+	}
+
+	c.broadcaster = broadcaster
+	c.unsubscribe = broadcaster.Subscribe(c.receive)
+}
+
+// Close stops receiving the invalidations of the other instances.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+		c.unsubscribe = nil
+	}
+
+	return nil
+}
+
+// Option overrides the defaults of the cache for a value.
+type Option func(*options)
+
+type options struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	tags        []string
+}
+
+// WithTTL sets how long the value is cached.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) { o.ttl = ttl }
+}
+
+// WithNegativeTTL sets how long the absence of the value is cached.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(o *options) { o.negativeTTL = ttl }
+}
+
+// WithTags tags the value, which is then invalidated by InvalidateTags with any of tags.
+func WithTags(tags ...string) Option {
+	return func(o *options) { o.tags = append(o.tags, tags...) }
+}
+
+// entry is a cached value, or the absence of a value.
+type entry struct {
+	Value   json.RawMessage `json:"v,omitempty"`
+	Missing bool            `json:"missing,omitempty"`
+	Tags    []string        `json:"tags,omitempty"`
+}
+
+// Get returns the value cached under key, calling load on a miss and caching its result. Concurrent misses of
+// a key call load once, the others waiting for its result; load is not cancelled when the caller which started
+// it is. Values are encoded in JSON.
+//
+// A nil cache calls load every time.
+func Get[T any](ctx context.Context, c *Cache, key string, load func(ctx context.Context) (T, error),
+	opts ...Option) (T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	if data, ok := c.lookup(ctx, key); ok {
+		return decode[T](data)
+	}
+
+	o := options{ttl: c.config.TTL, negativeTTL: c.config.NegativeTTL}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err, _ := c.group.Do(key, func() (any, error) {
+		// the value may have been cached while this caller was waiting for the previous load of the key.
+		if data, ok := c.lookup(ctx, key); ok {
+			return data, nil
+		}
+
+		value, err := load(context.WithoutCancel(ctx))
+
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return c.set(ctx, key, entry{Missing: true, Tags: o.tags}, o.negativeTTL)
+		case err != nil:
+			return nil, err
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.set(ctx, key, entry{Value: encoded, Tags: o.tags}, o.ttl)
+	})
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	return decode[T](data.([]byte))
+}
+
+func decode[T any](data []byte) (T, error) {
+	var (
+		value T
+		e     entry
+	)
+
+	if err := json.Unmarshal(data, &e); err != nil {
+		return value, err
+	}
+
+	if e.Missing {
+		return value, ErrNotFound
+	}
+
+	err := json.Unmarshal(e.Value, &value)
+
+	return value, err
+}
+
+// lookup returns the entry of key from memory, or from the store, keeping it in memory then.
+func (c *Cache) lookup(ctx context.Context, key string) ([]byte, bool) {
+	if data, ok := c.l1.get(key); ok {
+		return data, true
+	}
+
+	store := c.getStore()
+	if store == nil {
+		return nil, false
+	}
+
+	data, ok, err := store.Get(ctx, key)
+	if err != nil {
+		c.logger.Errorf("failed to read cached value %s: %v", key, err)
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	var e entry
+
+	if json.Unmarshal(data, &e) != nil {
+		return nil, false
+	}
+
+	c.l1.set(key, data, c.config.L1TTL, e.Tags)
+
+	return data, true
+}
+
+func (c *Cache) set(ctx context.Context, key string, e entry, ttl time.Duration) ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl = c.jitter(ttl)
+
+	c.l1.set(key, data, min(ttl, c.config.L1TTL), e.Tags)
+
+	if store := c.getStore(); store != nil {
+		if err := store.Set(ctx, key, data, ttl, e.Tags); err != nil {
+			c.logger.Errorf("failed to cache value %s: %v", key, err)
+		}
+	}
+
+	return data, nil
+}
+
+// jitter shortens a TTL by a random fraction of up to the configured jitter.
+func (c *Cache) jitter(ttl time.Duration) time.Duration {
+	if c.config.Jitter <= 0 {
+		return ttl
+	}
+
+	//nolint:gosec // the jitter needs no cryptographic randomness.
+	return ttl - time.Duration(rand.Float64()*c.config.Jitter*float64(ttl))
+}
+
+func (c *Cache) getStore() Store {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.store
+}
+
+// invalidation is the message broadcast to the other instances when values are invalidated.
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// Invalidate removes the values cached under keys, from all instances.
+func (c *Cache) Invalidate(ctx context.Context, keys ...string) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, key := range keys {
+		c.l1.delete(key)
+		c.group.Forget(key)
+	}
+
+	var err error
+
+	if store := c.getStore(); store != nil {
+		err = store.Delete(ctx, keys...)
+	}
+
+	return errors.Join(err, c.broadcast(ctx, invalidation{Origin: c.id, Keys: keys}))
+}
+
+// InvalidateTags removes the values tagged with any of tags, from all instances.
+func (c *Cache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, key := range c.l1.deleteTags(tags...) {
+		c.group.Forget(key)
+	}
+
+	var err error
+
+	if store := c.getStore(); store != nil {
+		err = store.DeleteTags(ctx, tags...)
+	}
+
+	return errors.Join(err, c.broadcast(ctx, invalidation{Origin: c.id, Tags: tags}))
+}
+
+func (c *Cache) broadcast(ctx context.Context, inv invalidation) error {
+	c.mu.RLock()
+	broadcaster := c.broadcaster
+	c.mu.RUnlock()
+
+	if broadcaster == nil {
+		return nil
+	}
+
+	message, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+
+	return broadcaster.Publish(ctx, message)
+}
+
+// receive applies the invalidations of the other instances to the memory of this one.
+func (c *Cache) receive(message []byte) {
+	var inv invalidation
+
+	if json.Unmarshal(message, &inv) != nil || inv.Origin == c.id {
+		return
+	}
+
+	for _, key := range inv.Keys {
+		c.l1.delete(key)
+	}
+
+	c.l1.deleteTags(inv.Tags...)
+}
@@ -0,0 +1,284 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errLoad = errors.New("load failed")
+
+type testLogger struct{}
+
+func (testLogger) Errorf(string, ...any) {}
+
+type product struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// loader counts its calls, returning the given product or error.
+type loader struct {
+	calls atomic.Int32
+	value product
+	err   error
+}
+
+func (l *loader) load(context.Context) (product, error) {
+	l.calls.Add(1)
+
+	return l.value, l.err
+}
+
+func newRedisCache(t *testing.T, s *miniredis.Miniredis) *Cache {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	c := New(Config{}, testLogger{})
+	c.UseStore(NewRedisStore(client))
+	c.UseBroadcaster(NewRedisBroadcaster(client))
+
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+func TestGet_CachesValues(t *testing.T) {
+	c := New(Config{}, testLogger{})
+	l := &loader{value: product{ID: 1, Name: "shoes"}}
+
+	for range 3 {
+		value, err := Get(t.Context(), c, "product:1", l.load)
+
+		require.NoError(t, err)
+		assert.Equal(t, l.value, value)
+	}
+
+	assert.Equal(t, int32(1), l.calls.Load())
+}
+
+func TestGet_Errors(t *testing.T) {
+	c := New(Config{}, testLogger{})
+
+	testCases := []struct {
+		desc  string
+		err   error
+		calls int32
+	}{
+		{desc: "absent values are cached", err: ErrNotFound, calls: 1},
+		{desc: "errors are not cached", err: errLoad, calls: 3},
+	}
+
+	for i, tc := range testCases {
+		l := &loader{err: tc.err}
+
+		for range 3 {
+			_, err := Get(t.Context(), c, tc.desc, l.load)
+
+			require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+
+		assert.Equal(t, tc.calls, l.calls.Load(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGet_SingleFlight(t *testing.T) {
+	c := New(Config{}, testLogger{})
+	release := make(chan struct{})
+
+	var calls atomic.Int32
+
+	load := func(context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			value, err := Get(t.Context(), c, "answer", load)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 42, value)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestGet_NilCache(t *testing.T) {
+	l := &loader{value: product{ID: 1}}
+
+	for range 2 {
+		value, err := Get(t.Context(), nil, "product:1", l.load)
+
+		require.NoError(t, err)
+		assert.Equal(t, l.value, value)
+	}
+
+	assert.Equal(t, int32(2), l.calls.Load())
+}
+
+func TestCache_InvalidateTags(t *testing.T) {
+	s := miniredis.RunT(t)
+	c := newRedisCache(t, s)
+
+	shoes := &loader{value: product{ID: 1, Name: "shoes"}}
+	hats := &loader{value: product{ID: 2, Name: "hats"}}
+
+	for range 2 {
+		_, err := Get(t.Context(), c, "product:1", shoes.load, WithTags("products", "product:1"))
+		require.NoError(t, err)
+
+		_, err = Get(t.Context(), c, "product:2", hats.load, WithTags("products"))
+		require.NoError(t, err)
+	}
+
+	assert.True(t, s.Exists("gofr:cache:product:1"))
+	assert.True(t, s.Exists("gofr:cache:tag:products"))
+
+	require.NoError(t, c.InvalidateTags(t.Context(), "products"))
+
+	assert.False(t, s.Exists("gofr:cache:product:1"))
+	assert.False(t, s.Exists("gofr:cache:product:2"))
+	assert.False(t, s.Exists("gofr:cache:tag:products"))
+
+	_, err := Get(t.Context(), c, "product:1", shoes.load)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), shoes.calls.Load())
+}
+
+func TestCache_SharedStore(t *testing.T) {
+	s := miniredis.RunT(t)
+	first, second := newRedisCache(t, s), newRedisCache(t, s)
+
+	l := &loader{value: product{ID: 1, Name: "shoes"}}
+
+	for _, c := range []*Cache{first, second} {
+		value, err := Get(t.Context(), c, "product:1", l.load, WithTTL(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, l.value, value)
+	}
+
+	assert.Equal(t, int32(1), l.calls.Load(), "the second instance reads the value stored by the first")
+
+	ttl := s.TTL("gofr:cache:product:1")
+	assert.LessOrEqual(t, ttl, time.Hour)
+	assert.GreaterOrEqual(t, ttl, 54*time.Minute, "the TTL is shortened by up to 10%")
+
+	require.NoError(t, first.Invalidate(t.Context(), "product:1"))
+
+	// the invalidation reaches the memory of the second instance asynchronously.
+	assert.Eventually(t, func() bool {
+		_, ok := second.l1.get("product:1")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := Get(t.Context(), second, "product:1", l.load)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), l.calls.Load())
+}
+
+func TestMemory_Eviction(t *testing.T) {
+	m := newMemory(2)
+
+	m.set("a", []byte("a"), time.Minute, []string{"letters"})
+	m.set("b", []byte("b"), time.Minute, nil)
+	m.get("a")
+	m.set("c", []byte("c"), time.Minute, nil)
+
+	_, ok := m.get("a")
+	assert.True(t, ok, "recently used entries are kept")
+
+	_, ok = m.get("b")
+	assert.False(t, ok, "least recently used entries are evicted")
+
+	m.set("c", []byte("c"), -time.Second, nil)
+
+	_, ok = m.get("c")
+	assert.False(t, ok, "expired entries are not returned")
+
+	assert.Equal(t, []string{"a"}, m.deleteTags("letters"))
+	assert.Empty(t, m.tags)
+}
+
+// mapStore is a key-value store keeping its values in a map.
+type mapStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (m *mapStore) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.values[key]
+	if !ok {
+		return "", errLoad
+	}
+
+	return value, nil
+}
+
+func (m *mapStore) Set(_ context.Context, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[key] = value
+
+	return nil
+}
+
+func (m *mapStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.values, key)
+
+	return nil
+}
+
+func TestKVStoreCache(t *testing.T) {
+	kv := &mapStore{values: make(map[string]string)}
+	store := NewKVStoreCache(kv)
+
+	require.NoError(t, store.Set(t.Context(), "a", []byte("1"), time.Minute, []string{"t"}))
+	require.NoError(t, store.Set(t.Context(), "b", []byte("2"), -time.Second, []string{"t"}))
+
+	data, ok, err := store.Get(t.Context(), "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), data)
+
+	_, ok, err = store.Get(t.Context(), "b")
+	require.NoError(t, err)
+	assert.False(t, ok, "expired values are not returned")
+	assert.NotContains(t, kv.values, "gofr:cache:b", "expired values are deleted once read")
+
+	require.NoError(t, store.DeleteTags(t.Context(), "t"))
+	assert.Empty(t, kv.values)
+}
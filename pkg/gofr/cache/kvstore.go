@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+)
+
+// KVStore is the key-value store the values of a Cache are kept in by a KVStoreCache, such as the one added with
+// AddKVStore.
+type KVStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string) error
+	Delete(ctx context.Context, key string) error
+}
+
+// KVStoreCache keeps the values of a Cache in a key-value store, under keys prefixed by "gofr:cache:". The values
+// are expired by the stores supporting SetWithTTL, and otherwise deleted once they are read after expiring. The keys of the values
+// tagged with a tag are kept in a list, which is not updated atomically: the values cached by two instances for
+// the same tag at the same time may not both be invalidated with it.
+type KVStoreCache struct {
+	store KVStore
+}
+
+// NewKVStoreCache creates a store of the values of a Cache in a key-value store.
+func NewKVStoreCache(store KVStore) *KVStoreCache {
+	return &KVStoreCache{store: store}
+}
+
+// kvEntry is a value stored with its expiry.
+type kvEntry struct {
+	Value   []byte    `json:"value"`
+	Expires time.Time `json:"expires"`
+}
+
+// Get reports the errors of the store as misses, as key-value stores report missing keys with errors of their own.
+func (s *KVStoreCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := s.store.Get(ctx, keyPrefix+key)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	var e kvEntry
+
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return nil, false, err
+	}
+
+	if time.Now().After(e.Expires) {
+		return nil, false, s.store.Delete(ctx, keyPrefix+key)
+	}
+
+	return e.Value, true, nil
+}
+
+func (s *KVStoreCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags []string) error {
+	data, err := json.Marshal(kvEntry{Value: value, Expires: time.Now().Add(ttl)})
+	if err != nil {
+		return err
+	}
+
+	if err := s.set(ctx, keyPrefix+key, string(data), ttl); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		keys := s.tagged(ctx, tag)
+		if slices.Contains(keys, key) {
+			continue
+		}
+
+		encoded, err := json.Marshal(append(keys, key))
+		if err != nil {
+			return err
+		}
+
+		if err := s.store.Set(ctx, keyPrefix+"tag:"+tag, string(encoded)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *KVStoreCache) Delete(ctx context.Context, keys ...string) error {
+	var errs []error
+
+	for _, key := range keys {
+		errs = append(errs, s.store.Delete(ctx, keyPrefix+key))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *KVStoreCache) DeleteTags(ctx context.Context, tags ...string) error {
+	var errs []error
+
+	for _, tag := range tags {
+		keys := s.tagged(ctx, tag)
+		if len(keys) == 0 {
+			continue
+		}
+
+		errs = append(errs, s.Delete(ctx, keys...), s.store.Delete(ctx, keyPrefix+"tag:"+tag))
+	}
+
+	return errors.Join(errs...)
+}
+
+// set expires the key in the store when the store supports it, so that the values never read again are deleted too.
+func (s *KVStoreCache) set(ctx context.Context, key, value string, ttl time.Duration) error {
+	if store, ok := s.store.(interface {
+		SetWithTTL(ctx context.Context, key, value string, ttl time.Duration) error
+	}); ok {
+		return store.SetWithTTL(ctx, key, value, ttl)
+	}
+
+	return s.store.Set(ctx, key, value)
+}
+
+// tagged returns the keys of the values tagged with tag.
+func (s *KVStoreCache) tagged(ctx context.Context, tag string) []string {
+	data, err := s.store.Get(ctx, keyPrefix+"tag:"+tag)
+	if err != nil {
+		return nil
+	}
+
+	var keys []string
+
+	_ = json.Unmarshal([]byte(data), &keys)
+
+	return keys
+}
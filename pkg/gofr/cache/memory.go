@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// memory is the L1 cache of an instance, evicting the least recently used entries beyond its size.
+type memory struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List                     // most recently used first
+	tags    map[string]map[string]struct{} // keys by tag
+}
+
+type memoryEntry struct {
+	key     string
+	data    []byte
+	tags    []string
+	expires time.Time
+}
+
+func newMemory(size int) *memory {
+	return &memory{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		tags:    make(map[string]map[string]struct{}),
+	}
+}
+
+func (m *memory) get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*memoryEntry)
+
+	if time.Now().After(e.expires) {
+		m.remove(elem)
+
+		return nil, false
+	}
+
+	m.order.MoveToFront(elem)
+
+	return e.data, true
+}
+
+func (m *memory) set(key string, data []byte, ttl time.Duration, tags []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.remove(elem)
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, data: data, tags: tags, expires: time.Now().Add(ttl)})
+
+	for _, tag := range tags {
+		if m.tags[tag] == nil {
+			m.tags[tag] = make(map[string]struct{})
+		}
+
+		m.tags[tag][key] = struct{}{}
+	}
+
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *memory) delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.remove(elem)
+	}
+}
+
+// deleteTags deletes the entries tagged with any of tags, returning their keys.
+func (m *memory) deleteTags(tags ...string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var keys []string
+
+	for _, tag := range tags {
+		for key := range m.tags[tag] {
+			if elem, ok := m.entries[key]; ok {
+				m.remove(elem)
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// remove deletes an entry and its tags, the lock being held by the caller.
+func (m *memory) remove(elem *list.Element) {
+	e := elem.Value.(*memoryEntry)
+
+	m.order.Remove(elem)
+	delete(m.entries, e.key)
+
+	for _, tag := range e.tags {
+		delete(m.tags[tag], e.key)
+
+		if len(m.tags[tag]) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix    = "gofr:cache:"
+	redisChannel = "gofr:cache:invalidations"
+
+	subscribeTimeout = 5 * time.Second
+)
+
+// RedisStore keeps the values of a Cache in Redis, under keys prefixed by "gofr:cache:". The keys of the values
+// tagged with a tag are kept in a set expiring with the last of them.
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a store of the values of a Cache in Redis.
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := s.client.Get(ctx, keyPrefix+key).Bytes()
+
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags []string) error {
+	pipe := s.client.Pipeline()
+
+	pipe.Set(ctx, keyPrefix+key, value, ttl)
+
+	for _, tag := range tags {
+		tagKey := keyPrefix + "tag:" + tag
+
+		pipe.SAdd(ctx, tagKey, key)
+		// the set gets the TTL of its first value, and is extended by the values expiring after it.
+		pipe.ExpireNX(ctx, tagKey, ttl)
+		pipe.ExpireGT(ctx, tagKey, ttl)
+	}
+
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
+
+func (s *RedisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// the keys are deleted one by one, as they may be in different slots of a cluster.
+	pipe := s.client.Pipeline()
+
+	for _, key := range keys {
+		pipe.Del(ctx, keyPrefix+key)
+	}
+
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
+
+func (s *RedisStore) DeleteTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		tagKey := keyPrefix + "tag:" + tag
+
+		keys, err := s.client.SMembers(ctx, tagKey).Result()
+		if err != nil {
+			return err
+		}
+
+		if err := s.Delete(ctx, keys...); err != nil {
+			return err
+		}
+
+		if err := s.client.Del(ctx, tagKey).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RedisBroadcaster broadcasts the invalidations of a Cache on a Redis channel.
+type RedisBroadcaster struct {
+	client redis.UniversalClient
+}
+
+// NewRedisBroadcaster creates a broadcaster of the invalidations of a Cache on Redis.
+func NewRedisBroadcaster(client redis.UniversalClient) *RedisBroadcaster {
+	return &RedisBroadcaster{client: client}
+}
+
+func (b *RedisBroadcaster) Publish(ctx context.Context, message []byte) error {
+	return b.client.Publish(ctx, redisChannel, message).Err()
+}
+
+func (b *RedisBroadcaster) Subscribe(handler func(message []byte)) (stop func()) {
+	sub := b.client.Subscribe(context.Background(), redisChannel)
+	done := make(chan struct{})
+
+	// waits for the confirmation of the subscription, so that no invalidation published afterwards is missed. When
+	// Redis does not confirm it in time, the subscription is made as soon as the connection is restored.
+	ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+	_, _ = sub.Receive(ctx)
+
+	cancel()
+
+	go func() {
+		defer close(done)
+
+		for msg := range sub.Channel() {
+			handler([]byte(msg.Payload))
+		}
+	}()
+
+	return func() {
+		_ = sub.Close()
+		<-done
+	}
+}
@@ -0,0 +1,58 @@
+package container
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr/cache"
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/datasource/redis"
+)
+
+// createCache creates the cache of the application, storing its values in Redis unless CACHE_STORE tells
+// otherwise. With "kvstore", the store is set once the key-value store is added with AddKVStore.
+//
+// The invalidations are sent to the other instances through Redis when CACHE_INVALIDATION is "redis", whatever
+// the store; otherwise the other instances keep the invalidated values in their memory for up to CACHE_L1_TTL.
+func (c *Container) createCache(conf config.Config, rc *redis.Redis) *cache.Cache {
+	cacheConfig := cache.Config{
+		TTL:         parseDuration(conf, "CACHE_TTL"),
+		NegativeTTL: parseDuration(conf, "CACHE_NEGATIVE_TTL"),
+		L1TTL:       parseDuration(conf, "CACHE_L1_TTL"),
+	}
+
+	if size := conf.Get("CACHE_L1_SIZE"); size != "" {
+		cacheConfig.L1Size, _ = strconv.Atoi(size)
+	}
+
+	cc := cache.New(cacheConfig, c.Logger)
+
+	if strings.ToLower(conf.GetOrDefault("CACHE_STORE", "redis")) == "redis" && rc != nil {
+		cc.UseStore(cache.NewRedisStore(rc.Universal()))
+	}
+
+	if strings.EqualFold(conf.Get("CACHE_INVALIDATION"), "redis") {
+		if rc == nil {
+			c.Logger.Errorf("CACHE_INVALIDATION is redis, but Redis is not configured")
+		} else {
+			cc.UseBroadcaster(cache.NewRedisBroadcaster(rc.Universal()))
+		}
+	}
+
+	return cc
+}
+
+func parseDuration(conf config.Config, key string) time.Duration {
+	value := conf.Get(key)
+	if value == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0
+	}
+
+	return d
+}
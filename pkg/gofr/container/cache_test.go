@@ -0,0 +1,50 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestContainer_CacheInvalidation(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		configs     map[string]string
+		subscribers int
+	}{
+		{desc: "default", configs: map[string]string{}, subscribers: 0},
+		{desc: "redis", configs: map[string]string{"CACHE_INVALIDATION": "redis"}, subscribers: 1},
+		{desc: "kvstore", configs: map[string]string{"CACHE_STORE": "kvstore", "CACHE_INVALIDATION": "redis"}, subscribers: 1},
+	}
+
+	for i, tc := range testCases {
+		s, err := miniredis.Run()
+		require.NoError(t, err)
+
+		tc.configs["REDIS_HOST"] = s.Host()
+		tc.configs["REDIS_PORT"] = s.Port()
+
+		c := NewContainer(config.NewMockConfig(tc.configs))
+
+		assert.Equal(t, tc.subscribers, s.PubSubNumSub("gofr:cache:invalidations")["gofr:cache:invalidations"],
+			"TEST[%d], Failed.\n%s", i, tc.desc)
+
+		require.NoError(t, c.Close())
+		s.Close()
+	}
+}
+
+func TestContainer_CacheInvalidationWithoutRedis(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		c := NewContainer(config.NewMockConfig(map[string]string{"CACHE_INVALIDATION": "redis"}))
+
+		assert.NotNil(t, c.Cache)
+	})
+
+	assert.Contains(t, logs, "Redis is not configured")
+}
@@ -20,8 +20,11 @@ import (
 	"time"
 
 	_ "github.com/go-sql-driver/mysql" // This is required to be blank import
+	goRedis "github.com/redis/go-redis/v9"
 
+	"gofr.dev/pkg/gofr/cache"
 	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/datasource"
 	"gofr.dev/pkg/gofr/datasource/file"
 	"gofr.dev/pkg/gofr/datasource/pubsub"
 	"gofr.dev/pkg/gofr/datasource/pubsub/google"
@@ -45,6 +48,7 @@ type Container struct {
 
 	appName    string
 	appVersion string
+	redactor   *logging.Redactor
 
 	Services       map[string]service.HTTP
 	metricsManager metrics.Manager
@@ -72,6 +76,11 @@ type Container struct {
 	KVStore KVStore
 
 	File file.FileSystem
+	// FileStores holds the file stores added with a name, which are read with FileStore.
+	FileStores map[string]file.FileSystem
+
+	// Cache is the two-tier cache read with gofr.Cached, and invalidated by the handlers changing its values.
+	Cache *cache.Cache
 }
 
 func NewContainer(conf config.Config) *Container {
@@ -112,6 +121,12 @@ func (c *Container) Create(conf config.Config) {
 		}
 	}
 
+	c.redactor = newRedactor(conf, c.Logger)
+
+	if rs, ok := c.Logger.(logging.RedactorSetter); ok {
+		rs.SetRedactor(c.redactor)
+	}
+
 	c.Logger.Debug("Container is being created")
 
 	c.metricsManager = metrics.NewMetricsManager(exporters.Prometheus(c.GetAppName(), c.GetAppVersion()), c.Logger)
@@ -125,7 +140,9 @@ func (c *Container) Create(conf config.Config) {
 	c.Metrics().SetGauge("app_info", 1,
 		"app_name", c.GetAppName(), "app_version", c.GetAppVersion(), "framework_version", version.Framework)
 
-	c.Redis = redis.NewClient(conf, c.Logger, c.metricsManager)
+	rc := redis.NewClient(conf, c.Logger, c.metricsManager)
+	c.Redis = redisDatasource(rc)
+	c.Cache = c.createCache(conf, rc)
 
 	c.SQL = sql.NewSQL(conf, c.Logger, c.metricsManager)
 
@@ -176,6 +193,29 @@ func (c *Container) Create(conf config.Config) {
 	c.WSManager = websocket.New()
 }
 
+// clusterRedis is the Redis datasource of a container connected to a cluster, sending its commands through the
+// cluster client as the embedded client of redis.Redis is nil then.
+type clusterRedis struct {
+	goRedis.UniversalClient
+	redis *redis.Redis
+}
+
+func redisDatasource(rc *redis.Redis) Redis {
+	if rc != nil && rc.Client == nil && rc.Universal() != nil {
+		return &clusterRedis{UniversalClient: rc.Universal(), redis: rc}
+	}
+
+	return rc
+}
+
+func (r *clusterRedis) HealthCheck() datasource.Health {
+	return r.redis.HealthCheck()
+}
+
+func (r *clusterRedis) Close() error {
+	return r.redis.Close()
+}
+
 func (c *Container) Close() error {
 	var err error
 
@@ -183,6 +223,10 @@ func (c *Container) Close() error {
 		err = errors.Join(err, c.SQL.Close())
 	}
 
+	if c.Cache != nil {
+		err = errors.Join(err, c.Cache.Close())
+	}
+
 	if !isNil(c.Redis) {
 		err = errors.Join(err, c.Redis.Close())
 	}
@@ -191,6 +235,10 @@ func (c *Container) Close() error {
 		err = errors.Join(err, c.PubSub.Close())
 	}
 
+	for _, svc := range c.Services {
+		err = errors.Join(err, service.Close(svc))
+	}
+
 	for _, conn := range c.WSManager.ListConnections() {
 		c.WSManager.CloseConnection(conn)
 	}
@@ -262,6 +310,10 @@ func (c *Container) registerFrameworkMetrics() {
 		httpBuckets := []float64{.001, .003, .005, .01, .02, .03, .05, .1, .2, .3, .5, .75, 1, 2, 3, 5, 10, 30}
 		c.Metrics().NewHistogram("app_http_response", "Response time of HTTP requests in seconds.", httpBuckets...)
 		c.Metrics().NewHistogram("app_http_service_response", "Response time of HTTP service requests in seconds.", httpBuckets...)
+		c.Metrics().NewCounter("app_http_service_retry_count", "Number of retried HTTP service requests.")
+		c.Metrics().NewCounter("app_http_service_cache_count", "Number of HTTP service responses looked up in the cache.")
+		c.Metrics().NewCounter("app_http_service_bulkhead_rejected_count", "Number of HTTP service requests rejected by the bulkhead.")
+		c.Metrics().NewCounter("app_http_service_hedge_count", "Number of hedged HTTP service requests sent and won.")
 	}
 
 	{ // Redis metrics
@@ -272,6 +324,7 @@ func (c *Container) registerFrameworkMetrics() {
 	{ // SQL metrics
 		sqlBuckets := getDefaultDatasourceBuckets()
 		c.Metrics().NewHistogram("app_sql_stats", "Response time of SQL queries in milliseconds.", sqlBuckets...)
+		c.Metrics().NewHistogram("app_sql_query_stats", "Response time of SQL queries by query fingerprint in milliseconds.", sqlBuckets...)
 		c.Metrics().NewGauge("app_sql_open_connections", "Number of open SQL connections.")
 		c.Metrics().NewGauge("app_sql_inUse_connections", "Number of inUse SQL connections.")
 	}
@@ -281,6 +334,12 @@ func (c *Container) registerFrameworkMetrics() {
 	c.Metrics().NewCounter("app_pubsub_publish_success_count", "Number of successful publish operations.")
 	c.Metrics().NewCounter("app_pubsub_subscribe_total_count", "Number of total subscribe operations.")
 	c.Metrics().NewCounter("app_pubsub_subscribe_success_count", "Number of successful subscribe operations.")
+
+	{ // File metrics
+		c.Metrics().NewCounter("app_file_copy_count", "Number of files copied between file stores.")
+		c.Metrics().NewHistogram("app_file_copy_duration", "Duration of the copies of files between file stores in seconds.",
+			.01, .05, .1, .5, 1, 5, 10, 30, 60, 300)
+	}
 }
 
 func (c *Container) GetAppName() string {
@@ -291,6 +350,11 @@ func (c *Container) GetAppVersion() string {
 	return c.appVersion
 }
 
+// GetRedactor returns the rules used to redact sensitive values from logs and exported spans.
+func (c *Container) GetRedactor() *logging.Redactor {
+	return c.redactor
+}
+
 func (c *Container) GetPublisher() pubsub.Publisher {
 	return c.PubSub
 }
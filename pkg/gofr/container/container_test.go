@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +51,27 @@ func Test_newContainerDBInitializationFail(t *testing.T) {
 	assert.NotNil(t, redis.Client, "TEST, Failed.\ninvalid redis connections")
 }
 
+func Test_newContainerRedisCluster(t *testing.T) {
+	// miniredis answers CLUSTER SLOTS as a cluster of a single node.
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	t.Setenv("REDIS_CLUSTER_ADDRS", s.Addr())
+
+	container := NewContainer(config.NewEnvFile("", logging.NewMockLogger(logging.DEBUG)))
+
+	defer container.Close()
+
+	require.NoError(t, container.Redis.Set(t.Context(), "key", "value", 0).Err(),
+		"TEST, Failed.\nthe commands must go through the cluster client")
+
+	value, err := container.Redis.Get(t.Context(), "key").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
 func Test_newContainerPubSubInitializationFail(t *testing.T) {
 	testCases := []struct {
 		desc    string
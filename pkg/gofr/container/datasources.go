@@ -24,7 +24,12 @@ type DB interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	Prepare(query string) (*sql.Stmt, error)
 	Begin() (*gofrSQL.Tx, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*gofrSQL.Tx, error)
+	// WithTx runs fn in a transaction, committed when fn returns nil and rolled back otherwise.
+	WithTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *gofrSQL.Tx) error) error
 	Select(ctx context.Context, data any, query string, args ...any)
+	// SelectWithError binds the result of the query to data as Select does, returning the errors Select logs.
+	SelectWithError(ctx context.Context, data any, query string, args ...any) error
 	HealthCheck() *datasource.Health
 	Dialect() string
 	Close() error
@@ -280,9 +285,50 @@ type Mongo interface {
 	// StartSession starts a session and provide methods to run commands in a transaction.
 	StartSession() (any, error)
 
+	// FindWithOptions executes a query like Find, with opts setting the sort, projection, limit and skip of the
+	// results.
+	FindWithOptions(ctx context.Context, collection string, filter any, results any, opts FindOptions) error
+
+	// Aggregate runs an aggregation pipeline on a collection and stores the resulting documents into results.
+	Aggregate(ctx context.Context, collection string, pipeline any, results any) error
+
+	// BulkWrite runs the write models, such as InsertOneModel or UpdateManyModel, in a single request. Ordered writes
+	// stop at the first failure. It returns the numbers of documents written and an error, if any.
+	BulkWrite(ctx context.Context, collection string, models []WriteModel, ordered bool) (*BulkWriteResult, error)
+
+	// CreateIndex creates an index on the keys of a collection, opts making it unique or expiring the documents.
+	// It returns the name of the index and an error, if any.
+	CreateIndex(ctx context.Context, collection string, keys any, opts IndexOptions) (string, error)
+
+	// ListIndexes returns the specifications of the indexes of a collection.
+	ListIndexes(ctx context.Context, collection string) ([]map[string]any, error)
+
+	// DropIndex drops the index of a collection by its name.
+	DropIndex(ctx context.Context, collection, name string) error
+
+	// WithTransaction runs fn in a transaction, committed when fn returns nil and aborted otherwise. The operations
+	// must be run with the context passed to fn to be part of the transaction.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
 	HealthChecker
 }
 
+// MongoUpdateMatcher is implemented by the Mongo drivers telling the number of documents matched by the filter of
+// an update, which UpdateOne doesn't.
+type MongoUpdateMatcher interface {
+	// UpdateOneMatched updates a single document in a collection based on a filter.
+	// It returns the number of documents matched by the filter and an error if any.
+	UpdateOneMatched(ctx context.Context, collection string, filter any, update any) (int64, error)
+}
+
+// MongoWatcher is implemented by the Mongo datasources streaming the changes of collections, which is checked
+// with a type assertion as for the optional features of the key-value stores.
+type MongoWatcher interface {
+	// Watch calls handler with the change events of collection, as extended JSON, and the resume token following
+	// each of them. It resumes after resumeToken, or starts from the current time when it is empty.
+	Watch(ctx context.Context, collection, resumeToken string, handler func(event []byte, resumeToken string) error) error
+}
+
 type Transaction interface {
 	StartTransaction() error
 	AbortTransaction(context.Context) error
@@ -377,6 +423,58 @@ type KVStoreProvider interface {
 	provider
 }
 
+// The following interfaces are implemented by the key-value stores supporting a feature beyond KVStore. As not
+// all stores support all of them, their support is checked with a type assertion:
+//
+//	if kv, ok := ctx.KVStore.(container.KVStoreTTL); ok {
+//		err = kv.SetWithTTL(ctx, "session:"+id, token, time.Hour)
+//	}
+
+// KVStoreTTL is implemented by the key-value stores expiring keys.
+type KVStoreTTL interface {
+	// SetWithTTL sets the value of key, which is deleted once ttl elapses.
+	SetWithTTL(ctx context.Context, key, value string, ttl time.Duration) error
+}
+
+// KVStoreBatch is implemented by the key-value stores reading and writing keys in batches.
+type KVStoreBatch interface {
+	// MGet returns the values of keys, leaving out the keys which do not exist.
+	MGet(ctx context.Context, keys ...string) (map[string]string, error)
+	MSet(ctx context.Context, values map[string]string) error
+}
+
+// KVStoreScanner is implemented by the key-value stores listing their keys.
+type KVStoreScanner interface {
+	// Scan returns up to limit keys starting with prefix with their values, from the position of cursor, empty for
+	// the first page. It returns the cursor of the next page, which is empty once all keys were returned.
+	Scan(ctx context.Context, prefix, cursor string, limit int) (values map[string]string, next string, err error)
+}
+
+// KVStoreCAS is implemented by the key-value stores updating keys conditionally, for optimistic concurrency.
+type KVStoreCAS interface {
+	// GetWithRevision returns the value of key with its revision, which changes with every write of the key.
+	GetWithRevision(ctx context.Context, key string) (value string, revision uint64, err error)
+	// CompareAndSwap sets the value of key if its revision is still revision, or if it does not exist when revision
+	// is 0. It returns the new revision of the key, and whether the value was set.
+	CompareAndSwap(ctx context.Context, key, value string, revision uint64) (newRevision uint64, swapped bool, err error)
+}
+
+// KVStoreWatcher is implemented by the key-value stores streaming the changes of their keys.
+type KVStoreWatcher interface {
+	// Watch calls handler with the changes of the keys starting with prefix, until ctx is done or the stream fails.
+	// The value is empty for deleted keys.
+	Watch(ctx context.Context, prefix string, handler func(key, value string, deleted bool)) error
+}
+
+// KVStoreRevisionWatcher is implemented by the key-value stores streaming the changes of their keys from a revision,
+// for a watch to resume where it stopped.
+type KVStoreRevisionWatcher interface {
+	// WatchFrom calls handler with the changes of the keys starting with prefix made after revision, until ctx is
+	// done or handler returns an error. The value is empty for deleted keys.
+	WatchFrom(ctx context.Context, prefix string, revision uint64,
+		handler func(key, value string, revision uint64, deleted bool) error) error
+}
+
 type PubSubProvider interface {
 	pubsub.Client
 
@@ -404,6 +502,14 @@ type SolrProvider interface {
 	provider
 }
 
+// SolrCollectionManager is implemented by the Solr clients managing the collections of the cluster, as the
+// migrations of Solr require.
+type SolrCollectionManager interface {
+	ListCollections(ctx context.Context) (any, error)
+	CreateCollection(ctx context.Context, collection string, params map[string]any) (any, error)
+	DeleteCollection(ctx context.Context, collection string) (any, error)
+}
+
 // Dgraph defines the methods for interacting with a Dgraph database.
 type Dgraph interface {
 	// ApplySchema applies or updates the complete database schema.
@@ -732,8 +838,35 @@ type Elasticsearch interface {
 
 	// Search executes a query against one or more indices.
 	// Returns the entire response JSON as a map.
+	// The indices must be empty when the query searches a point in time.
 	Search(ctx context.Context, indices []string, query map[string]any) (map[string]any, error)
 
+	// OpenPointInTime opens a point in time on the indices, kept for keepAlive, and returns its ID,
+	// to be set in the "pit" section of the queries of Search.
+	OpenPointInTime(ctx context.Context, indices []string, keepAlive time.Duration) (string, error)
+
+	// ClosePointInTime releases a point in time.
+	ClosePointInTime(ctx context.Context, id string) error
+
+	// SearchScroll executes a query against one or more indices and keeps its results for keepAlive.
+	// The "_scroll_id" field of the response is used to read the next pages with ScrollNext.
+	SearchScroll(ctx context.Context, indices []string, query map[string]any, keepAlive time.Duration) (map[string]any, error)
+
+	// ScrollNext returns the next page of the results of a scrolled search.
+	ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (map[string]any, error)
+
+	// ClearScroll releases the results of a scrolled search.
+	ClearScroll(ctx context.Context, scrollID string) error
+
+	// UpdateAliases applies add and remove alias actions in one atomic operation.
+	UpdateAliases(ctx context.Context, actions []map[string]any) error
+
+	// GetAliasIndices returns the indices an alias points to.
+	GetAliasIndices(ctx context.Context, alias string) ([]string, error)
+
+	// SwapAlias atomically points an alias to the index only, and returns the indices it pointed to before.
+	SwapAlias(ctx context.Context, alias, index string) ([]string, error)
+
 	HealthChecker
 }
 
@@ -777,6 +910,28 @@ type Couchbase interface {
 	HealthChecker
 }
 
+// CouchbaseManager is implemented by the Couchbase clients managing the buckets, scopes and collections of the
+// cluster, for migrations to change them.
+type CouchbaseManager interface {
+	// CreateBucket creates a bucket in the cluster with a RAM quota of ramQuotaMB megabytes.
+	CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error
+
+	// DropBucket deletes a bucket of the cluster with all its documents.
+	DropBucket(ctx context.Context, bucket string) error
+
+	// CreateScope creates a scope in the bucket of the client.
+	CreateScope(ctx context.Context, scope string) error
+
+	// DropScope deletes a scope of the bucket of the client with all its collections.
+	DropScope(ctx context.Context, scope string) error
+
+	// CreateCollection creates a collection in a scope of the bucket of the client.
+	CreateCollection(ctx context.Context, scope, collection string) error
+
+	// DropCollection deletes a collection of a scope of the bucket of the client with all its documents.
+	DropCollection(ctx context.Context, scope, collection string) error
+}
+
 // CouchbaseProvider is an interface that extends Couchbase with additional methods
 // for logging, metrics, tracing, and connection management, aligning with other
 // data source providers in your package.
@@ -837,3 +992,10 @@ type InfluxDBProvider interface {
 
 	provider
 }
+
+// InfluxDBRetentionManager is implemented by the InfluxDB clients changing the retention of buckets, for
+// migrations to change it.
+type InfluxDBRetentionManager interface {
+	// UpdateBucketRetention sets how long the data of a bucket is kept, 0 keeping it forever.
+	UpdateBucketRetention(ctx context.Context, bucketID string, retention time.Duration) error
+}
@@ -0,0 +1,66 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr/datasource/file"
+)
+
+// ErrFileStoreNotFound is returned by CopyFile for a store not added to the application.
+var ErrFileStoreNotFound = errors.New("file store not found")
+
+const defaultFileStore = "default"
+
+// FileStore returns the file store added with the given name, or the default store File for an empty name.
+// It returns nil when no store has the name.
+func (c *Container) FileStore(name string) file.FileSystem {
+	if name == "" || name == defaultFileStore {
+		return c.File
+	}
+
+	return c.FileStores[name]
+}
+
+// CopyFile streams the file at srcPath of the store named srcStore to dstPath of the store named dstStore,
+// an empty name standing for the default store. It returns the number of bytes copied:
+//
+//	n, err := ctx.CopyFile(ctx, "inbox", "reports/2024.csv", "archive", "2024/reports.csv", file.WithChecksum())
+func (c *Container) CopyFile(ctx context.Context, srcStore, srcPath, dstStore, dstPath string,
+	opts ...file.CopyOption) (int64, error) {
+	src, dst := c.FileStore(srcStore), c.FileStore(dstStore)
+
+	for name, fs := range map[string]file.FileSystem{srcStore: src, dstStore: dst} {
+		if isNil(fs) {
+			return 0, fmt.Errorf("%w: %s", ErrFileStoreNotFound, name)
+		}
+	}
+
+	start := time.Now()
+
+	copied, err := file.Copy(ctx, src, srcPath, dst, dstPath, opts...)
+
+	status := "SUCCESS"
+	if err != nil {
+		status = "ERROR"
+	}
+
+	if c.metricsManager != nil {
+		labels := []string{"source", storeLabel(srcStore), "destination", storeLabel(dstStore), "status", status}
+
+		c.metricsManager.IncrementCounter(ctx, "app_file_copy_count", labels...)
+		c.metricsManager.RecordHistogram(ctx, "app_file_copy_duration", time.Since(start).Seconds(), labels...)
+	}
+
+	return copied, err
+}
+
+func storeLabel(name string) string {
+	if name == "" {
+		return defaultFileStore
+	}
+
+	return name
+}
@@ -0,0 +1,79 @@
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/file"
+)
+
+func TestContainer_FileStore(t *testing.T) {
+	local, archive := file.NewLocalFileSystem(nil), file.NewLocalFileSystem(nil)
+
+	c := &Container{File: local, FileStores: map[string]file.FileSystem{"archive": archive}}
+
+	testCases := []struct {
+		desc     string
+		name     string
+		expected file.FileSystem
+	}{
+		{desc: "empty name", name: "", expected: local},
+		{desc: "default name", name: "default", expected: local},
+		{desc: "named store", name: "archive", expected: archive},
+	}
+
+	for i, tc := range testCases {
+		assert.Same(t, tc.expected, c.FileStore(tc.name), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	assert.Nil(t, c.FileStore("backup"))
+}
+
+func TestContainer_CopyFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	metrics := NewMockMetrics(ctrl)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.csv"), []byte("id,name\n1,gofr\n"), 0o600))
+
+	c := &Container{
+		File:           file.NewLocalFileSystem(nil),
+		FileStores:     map[string]file.FileSystem{"archive": file.NewLocalFileSystem(nil)},
+		metricsManager: metrics,
+	}
+
+	labels := []any{"source", "default", "destination", "archive", "status", "SUCCESS"}
+
+	metrics.EXPECT().IncrementCounter(gomock.Any(), "app_file_copy_count", labels...)
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_file_copy_duration", gomock.Any(), labels...)
+
+	copied, err := c.CopyFile(t.Context(), "", filepath.Join(dir, "report.csv"), "archive",
+		filepath.Join(dir, "2024", "report.csv"), file.WithChecksum())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(15), copied)
+
+	content, err := os.ReadFile(filepath.Join(dir, "2024", "report.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n1,gofr\n", string(content))
+
+	_, err = c.CopyFile(t.Context(), "", filepath.Join(dir, "report.csv"), "backup", "report.csv")
+	require.ErrorIs(t, err, ErrFileStoreNotFound)
+}
+
+func TestContainer_FileStoreHealth(t *testing.T) {
+	c := &Container{FileStores: map[string]file.FileSystem{"archive": file.NewLocalFileSystem(nil)}}
+
+	healthMap := make(map[string]any)
+
+	downCount := checkFileStoreHealth(t.Context(), c, healthMap)
+
+	assert.Equal(t, 0, downCount)
+	assert.Equal(t, datasource.StatusUp, healthMap["file-store:archive"].(*datasource.Health).Status)
+}
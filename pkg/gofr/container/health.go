@@ -41,6 +41,7 @@ func (c *Container) Health(ctx context.Context) any {
 	}
 
 	downCount += checkExternalDBHealth(ctx, c, healthMap)
+	downCount += checkFileStoreHealth(ctx, c, healthMap)
 
 	for name, svc := range c.Services {
 		health := svc.HealthCheck(ctx)
@@ -86,6 +87,27 @@ func checkExternalDBHealth(ctx context.Context, c *Container, healthMap map[stri
 	return downCount
 }
 
+// checkFileStoreHealth reports the health of the named file stores checking it.
+func checkFileStoreHealth(ctx context.Context, c *Container, healthMap map[string]any) (downCount int) {
+	for name, fs := range c.FileStores {
+		checker, ok := fs.(interface {
+			HealthCheck(context.Context) (any, error)
+		})
+		if !ok {
+			continue
+		}
+
+		health, err := checker.HealthCheck(ctx)
+		if err != nil {
+			downCount++
+		}
+
+		healthMap["file-store:"+name] = health
+	}
+
+	return downCount
+}
+
 func (c *Container) appHealth(healthMap map[string]any, downCount int) {
 	healthMap["name"] = c.GetAppName()
 	healthMap["version"] = c.GetAppVersion()
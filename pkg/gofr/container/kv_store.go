@@ -0,0 +1,22 @@
+package container
+
+// ErrKeyNotFound is matched, with errors.Is, by the errors the key-value stores return for missing keys:
+//
+//	if errors.Is(err, container.ErrKeyNotFound) {
+//		value = defaultValue
+//	}
+//
+// The stores, being modules of their own, do not wrap ErrKeyNotFound itself: their errors match the errors
+// reporting KeyNotFound.
+var ErrKeyNotFound error = keyNotFoundError{}
+
+type keyNotFoundError struct{}
+
+func (keyNotFoundError) Error() string {
+	return "key not found"
+}
+
+// KeyNotFound reports the error as the one of a missing key, to the errors of the key-value stores.
+func (keyNotFoundError) KeyNotFound() bool {
+	return true
+}
@@ -0,0 +1,29 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// storeKeyNotFoundError is the error of a key-value store for missing keys.
+type storeKeyNotFoundError struct{}
+
+func (storeKeyNotFoundError) Error() string {
+	return "key not found"
+}
+
+func (storeKeyNotFoundError) Is(target error) bool {
+	nf, ok := target.(interface{ KeyNotFound() bool })
+
+	return ok && nf.KeyNotFound()
+}
+
+func TestErrKeyNotFound(t *testing.T) {
+	err := fmt.Errorf("%w: %s", storeKeyNotFoundError{}, "checkpoint")
+
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.NotErrorIs(t, errors.New("key not found"), ErrKeyNotFound, "the errors are not matched by their message")
+}
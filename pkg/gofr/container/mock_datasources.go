@@ -62,6 +62,21 @@ func (mr *MockDBMockRecorder) Begin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockDB)(nil).Begin))
 }
 
+// BeginTx mocks base method.
+func (m *MockDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql0.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx, opts)
+	ret0, _ := ret[0].(*sql0.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockDBMockRecorder) BeginTx(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockDB)(nil).BeginTx), ctx, opts)
+}
+
 // Close mocks base method.
 func (m *MockDB) Close() error {
 	m.ctrl.T.Helper()
@@ -254,6 +269,39 @@ func (mr *MockDBMockRecorder) Select(ctx, data, query any, args ...any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockDB)(nil).Select), varargs...)
 }
 
+// SelectWithError mocks base method.
+func (m *MockDB) SelectWithError(ctx context.Context, data any, query string, args ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, data, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SelectWithError", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SelectWithError indicates an expected call of SelectWithError.
+func (mr *MockDBMockRecorder) SelectWithError(ctx, data, query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, data, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWithError", reflect.TypeOf((*MockDB)(nil).SelectWithError), varargs...)
+}
+
+// WithTx mocks base method.
+func (m *MockDB) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(*sql0.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockDBMockRecorder) WithTx(ctx, opts, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockDB)(nil).WithTx), ctx, opts, fn)
+}
+
 // MockRedis is a mock of Redis interface.
 type MockRedis struct {
 	ctrl     *gomock.Controller
@@ -10327,6 +10375,35 @@ func (m *MockMongo) EXPECT() *MockMongoMockRecorder {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockMongo) Aggregate(ctx context.Context, collection string, pipeline any, results any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, collection, pipeline, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockMongoMockRecorder) Aggregate(ctx, collection, pipeline, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockMongo)(nil).Aggregate), ctx, collection, pipeline, results)
+}
+
+// BulkWrite mocks base method.
+func (m *MockMongo) BulkWrite(ctx context.Context, collection string, models []WriteModel, ordered bool) (*BulkWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkWrite", ctx, collection, models, ordered)
+	ret0, _ := ret[0].(*BulkWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkWrite indicates an expected call of BulkWrite.
+func (mr *MockMongoMockRecorder) BulkWrite(ctx, collection, models, ordered any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWrite", reflect.TypeOf((*MockMongo)(nil).BulkWrite), ctx, collection, models, ordered)
+}
+
 // CountDocuments mocks base method.
 func (m *MockMongo) CountDocuments(ctx context.Context, collection string, filter any) (int64, error) {
 	m.ctrl.T.Helper()
@@ -10356,6 +10433,21 @@ func (mr *MockMongoMockRecorder) CreateCollection(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockMongo)(nil).CreateCollection), ctx, name)
 }
 
+// CreateIndex mocks base method.
+func (m *MockMongo) CreateIndex(ctx context.Context, collection string, keys any, opts IndexOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", ctx, collection, keys, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockMongoMockRecorder) CreateIndex(ctx, collection, keys, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockMongo)(nil).CreateIndex), ctx, collection, keys, opts)
+}
+
 // DeleteMany mocks base method.
 func (m *MockMongo) DeleteMany(ctx context.Context, collection string, filter any) (int64, error) {
 	m.ctrl.T.Helper()
@@ -10400,6 +10492,20 @@ func (mr *MockMongoMockRecorder) Drop(ctx, collection any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockMongo)(nil).Drop), ctx, collection)
 }
 
+// DropIndex mocks base method.
+func (m *MockMongo) DropIndex(ctx context.Context, collection string, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropIndex", ctx, collection, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropIndex indicates an expected call of DropIndex.
+func (mr *MockMongoMockRecorder) DropIndex(ctx, collection, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropIndex", reflect.TypeOf((*MockMongo)(nil).DropIndex), ctx, collection, name)
+}
+
 // Find mocks base method.
 func (m *MockMongo) Find(ctx context.Context, collection string, filter, results any) error {
 	m.ctrl.T.Helper()
@@ -10428,6 +10534,20 @@ func (mr *MockMongoMockRecorder) FindOne(ctx, collection, filter, result any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockMongo)(nil).FindOne), ctx, collection, filter, result)
 }
 
+// FindWithOptions mocks base method.
+func (m *MockMongo) FindWithOptions(ctx context.Context, collection string, filter, results any, opts FindOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithOptions", ctx, collection, filter, results, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindWithOptions indicates an expected call of FindWithOptions.
+func (mr *MockMongoMockRecorder) FindWithOptions(ctx, collection, filter, results, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithOptions", reflect.TypeOf((*MockMongo)(nil).FindWithOptions), ctx, collection, filter, results, opts)
+}
+
 // HealthCheck mocks base method.
 func (m *MockMongo) HealthCheck(arg0 context.Context) (any, error) {
 	m.ctrl.T.Helper()
@@ -10473,6 +10593,21 @@ func (mr *MockMongoMockRecorder) InsertOne(ctx, collection, document any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOne", reflect.TypeOf((*MockMongo)(nil).InsertOne), ctx, collection, document)
 }
 
+// ListIndexes mocks base method.
+func (m *MockMongo) ListIndexes(ctx context.Context, collection string) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIndexes", ctx, collection)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIndexes indicates an expected call of ListIndexes.
+func (mr *MockMongoMockRecorder) ListIndexes(ctx, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIndexes", reflect.TypeOf((*MockMongo)(nil).ListIndexes), ctx, collection)
+}
+
 // StartSession mocks base method.
 func (m *MockMongo) StartSession() (any, error) {
 	m.ctrl.T.Helper()
@@ -10532,6 +10667,97 @@ func (mr *MockMongoMockRecorder) UpdateOne(ctx, collection, filter, update any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockMongo)(nil).UpdateOne), ctx, collection, filter, update)
 }
 
+// WithTransaction mocks base method.
+func (m *MockMongo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockMongoMockRecorder) WithTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockMongo)(nil).WithTransaction), ctx, fn)
+}
+
+// MockMongoUpdateMatcher is a mock of MongoUpdateMatcher interface.
+type MockMongoUpdateMatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMongoUpdateMatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockMongoUpdateMatcherMockRecorder is the mock recorder for MockMongoUpdateMatcher.
+type MockMongoUpdateMatcherMockRecorder struct {
+	mock *MockMongoUpdateMatcher
+}
+
+// NewMockMongoUpdateMatcher creates a new mock instance.
+func NewMockMongoUpdateMatcher(ctrl *gomock.Controller) *MockMongoUpdateMatcher {
+	mock := &MockMongoUpdateMatcher{ctrl: ctrl}
+	mock.recorder = &MockMongoUpdateMatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMongoUpdateMatcher) EXPECT() *MockMongoUpdateMatcherMockRecorder {
+	return m.recorder
+}
+
+// UpdateOneMatched mocks base method.
+func (m *MockMongoUpdateMatcher) UpdateOneMatched(ctx context.Context, collection string, filter, update any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneMatched", ctx, collection, filter, update)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOneMatched indicates an expected call of UpdateOneMatched.
+func (mr *MockMongoUpdateMatcherMockRecorder) UpdateOneMatched(ctx, collection, filter, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneMatched", reflect.TypeOf((*MockMongoUpdateMatcher)(nil).UpdateOneMatched), ctx, collection, filter, update)
+}
+
+// MockMongoWatcher is a mock of MongoWatcher interface.
+type MockMongoWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMongoWatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockMongoWatcherMockRecorder is the mock recorder for MockMongoWatcher.
+type MockMongoWatcherMockRecorder struct {
+	mock *MockMongoWatcher
+}
+
+// NewMockMongoWatcher creates a new mock instance.
+func NewMockMongoWatcher(ctrl *gomock.Controller) *MockMongoWatcher {
+	mock := &MockMongoWatcher{ctrl: ctrl}
+	mock.recorder = &MockMongoWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMongoWatcher) EXPECT() *MockMongoWatcherMockRecorder {
+	return m.recorder
+}
+
+// Watch mocks base method.
+func (m *MockMongoWatcher) Watch(ctx context.Context, collection, resumeToken string, handler func([]byte, string) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, collection, resumeToken, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockMongoWatcherMockRecorder) Watch(ctx, collection, resumeToken, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockMongoWatcher)(nil).Watch), ctx, collection, resumeToken, handler)
+}
+
 // MockTransaction is a mock of Transaction interface.
 type MockTransaction struct {
 	ctrl     *gomock.Controller
@@ -10634,6 +10860,35 @@ func (m *MockMongoProvider) EXPECT() *MockMongoProviderMockRecorder {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockMongoProvider) Aggregate(ctx context.Context, collection string, pipeline any, results any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, collection, pipeline, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockMongoProviderMockRecorder) Aggregate(ctx, collection, pipeline, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockMongoProvider)(nil).Aggregate), ctx, collection, pipeline, results)
+}
+
+// BulkWrite mocks base method.
+func (m *MockMongoProvider) BulkWrite(ctx context.Context, collection string, models []WriteModel, ordered bool) (*BulkWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkWrite", ctx, collection, models, ordered)
+	ret0, _ := ret[0].(*BulkWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkWrite indicates an expected call of BulkWrite.
+func (mr *MockMongoProviderMockRecorder) BulkWrite(ctx, collection, models, ordered any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWrite", reflect.TypeOf((*MockMongoProvider)(nil).BulkWrite), ctx, collection, models, ordered)
+}
+
 // Connect mocks base method.
 func (m *MockMongoProvider) Connect() {
 	m.ctrl.T.Helper()
@@ -10675,6 +10930,21 @@ func (mr *MockMongoProviderMockRecorder) CreateCollection(ctx, name any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockMongoProvider)(nil).CreateCollection), ctx, name)
 }
 
+// CreateIndex mocks base method.
+func (m *MockMongoProvider) CreateIndex(ctx context.Context, collection string, keys any, opts IndexOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", ctx, collection, keys, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockMongoProviderMockRecorder) CreateIndex(ctx, collection, keys, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockMongoProvider)(nil).CreateIndex), ctx, collection, keys, opts)
+}
+
 // DeleteMany mocks base method.
 func (m *MockMongoProvider) DeleteMany(ctx context.Context, collection string, filter any) (int64, error) {
 	m.ctrl.T.Helper()
@@ -10719,6 +10989,20 @@ func (mr *MockMongoProviderMockRecorder) Drop(ctx, collection any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockMongoProvider)(nil).Drop), ctx, collection)
 }
 
+// DropIndex mocks base method.
+func (m *MockMongoProvider) DropIndex(ctx context.Context, collection string, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropIndex", ctx, collection, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropIndex indicates an expected call of DropIndex.
+func (mr *MockMongoProviderMockRecorder) DropIndex(ctx, collection, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropIndex", reflect.TypeOf((*MockMongoProvider)(nil).DropIndex), ctx, collection, name)
+}
+
 // Find mocks base method.
 func (m *MockMongoProvider) Find(ctx context.Context, collection string, filter, results any) error {
 	m.ctrl.T.Helper()
@@ -10747,6 +11031,20 @@ func (mr *MockMongoProviderMockRecorder) FindOne(ctx, collection, filter, result
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockMongoProvider)(nil).FindOne), ctx, collection, filter, result)
 }
 
+// FindWithOptions mocks base method.
+func (m *MockMongoProvider) FindWithOptions(ctx context.Context, collection string, filter, results any, opts FindOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithOptions", ctx, collection, filter, results, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindWithOptions indicates an expected call of FindWithOptions.
+func (mr *MockMongoProviderMockRecorder) FindWithOptions(ctx, collection, filter, results, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithOptions", reflect.TypeOf((*MockMongoProvider)(nil).FindWithOptions), ctx, collection, filter, results, opts)
+}
+
 // HealthCheck mocks base method.
 func (m *MockMongoProvider) HealthCheck(arg0 context.Context) (any, error) {
 	m.ctrl.T.Helper()
@@ -10792,6 +11090,21 @@ func (mr *MockMongoProviderMockRecorder) InsertOne(ctx, collection, document any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOne", reflect.TypeOf((*MockMongoProvider)(nil).InsertOne), ctx, collection, document)
 }
 
+// ListIndexes mocks base method.
+func (m *MockMongoProvider) ListIndexes(ctx context.Context, collection string) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIndexes", ctx, collection)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIndexes indicates an expected call of ListIndexes.
+func (mr *MockMongoProviderMockRecorder) ListIndexes(ctx, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIndexes", reflect.TypeOf((*MockMongoProvider)(nil).ListIndexes), ctx, collection)
+}
+
 // StartSession mocks base method.
 func (m *MockMongoProvider) StartSession() (any, error) {
 	m.ctrl.T.Helper()
@@ -10887,6 +11200,20 @@ func (mr *MockMongoProviderMockRecorder) UseTracer(tracer any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTracer", reflect.TypeOf((*MockMongoProvider)(nil).UseTracer), tracer)
 }
 
+// WithTransaction mocks base method.
+func (m *MockMongoProvider) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockMongoProviderMockRecorder) WithTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockMongoProvider)(nil).WithTransaction), ctx, fn)
+}
+
 // MockSurrealDB is a mock of SurrealDB interface.
 type MockSurrealDB struct {
 	ctrl     *gomock.Controller
@@ -11598,86 +11925,354 @@ func (mr *MockKVStoreProviderMockRecorder) UseTracer(tracer any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTracer", reflect.TypeOf((*MockKVStoreProvider)(nil).UseTracer), tracer)
 }
 
-// MockPubSubProvider is a mock of PubSubProvider interface.
-type MockPubSubProvider struct {
+// MockKVStoreTTL is a mock of KVStoreTTL interface.
+type MockKVStoreTTL struct {
 	ctrl     *gomock.Controller
-	recorder *MockPubSubProviderMockRecorder
+	recorder *MockKVStoreTTLMockRecorder
 	isgomock struct{}
 }
 
-// MockPubSubProviderMockRecorder is the mock recorder for MockPubSubProvider.
-type MockPubSubProviderMockRecorder struct {
-	mock *MockPubSubProvider
+// MockKVStoreTTLMockRecorder is the mock recorder for MockKVStoreTTL.
+type MockKVStoreTTLMockRecorder struct {
+	mock *MockKVStoreTTL
 }
 
-// NewMockPubSubProvider creates a new mock instance.
-func NewMockPubSubProvider(ctrl *gomock.Controller) *MockPubSubProvider {
-	mock := &MockPubSubProvider{ctrl: ctrl}
-	mock.recorder = &MockPubSubProviderMockRecorder{mock}
+// NewMockKVStoreTTL creates a new mock instance.
+func NewMockKVStoreTTL(ctrl *gomock.Controller) *MockKVStoreTTL {
+	mock := &MockKVStoreTTL{ctrl: ctrl}
+	mock.recorder = &MockKVStoreTTLMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockPubSubProvider) EXPECT() *MockPubSubProviderMockRecorder {
+func (m *MockKVStoreTTL) EXPECT() *MockKVStoreTTLMockRecorder {
 	return m.recorder
 }
 
-// Close mocks base method.
-func (m *MockPubSubProvider) Close() error {
+// SetWithTTL mocks base method.
+func (m *MockKVStoreTTL) SetWithTTL(ctx context.Context, key, value string, ttl time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Close")
+	ret := m.ctrl.Call(m, "SetWithTTL", ctx, key, value, ttl)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Close indicates an expected call of Close.
-func (mr *MockPubSubProviderMockRecorder) Close() *gomock.Call {
+// SetWithTTL indicates an expected call of SetWithTTL.
+func (mr *MockKVStoreTTLMockRecorder) SetWithTTL(ctx, key, value, ttl any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPubSubProvider)(nil).Close))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithTTL", reflect.TypeOf((*MockKVStoreTTL)(nil).SetWithTTL), ctx, key, value, ttl)
 }
 
-// Connect mocks base method.
-func (m *MockPubSubProvider) Connect() {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Connect")
+// MockKVStoreBatch is a mock of KVStoreBatch interface.
+type MockKVStoreBatch struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreBatchMockRecorder
+	isgomock struct{}
 }
 
-// Connect indicates an expected call of Connect.
-func (mr *MockPubSubProviderMockRecorder) Connect() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockPubSubProvider)(nil).Connect))
+// MockKVStoreBatchMockRecorder is the mock recorder for MockKVStoreBatch.
+type MockKVStoreBatchMockRecorder struct {
+	mock *MockKVStoreBatch
 }
 
-// CreateTopic mocks base method.
-func (m *MockPubSubProvider) CreateTopic(arg0 context.Context, name string) error {
+// NewMockKVStoreBatch creates a new mock instance.
+func NewMockKVStoreBatch(ctrl *gomock.Controller) *MockKVStoreBatch {
+	mock := &MockKVStoreBatch{ctrl: ctrl}
+	mock.recorder = &MockKVStoreBatchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreBatch) EXPECT() *MockKVStoreBatchMockRecorder {
+	return m.recorder
+}
+
+// MGet mocks base method.
+func (m *MockKVStoreBatch) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTopic", arg0, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MGet", varargs...)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateTopic indicates an expected call of CreateTopic.
-func (mr *MockPubSubProviderMockRecorder) CreateTopic(arg0, name any) *gomock.Call {
+// MGet indicates an expected call of MGet.
+func (mr *MockKVStoreBatchMockRecorder) MGet(ctx any, keys ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopic", reflect.TypeOf((*MockPubSubProvider)(nil).CreateTopic), arg0, name)
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGet", reflect.TypeOf((*MockKVStoreBatch)(nil).MGet), varargs...)
 }
 
-// DeleteTopic mocks base method.
-func (m *MockPubSubProvider) DeleteTopic(arg0 context.Context, name string) error {
+// MSet mocks base method.
+func (m *MockKVStoreBatch) MSet(ctx context.Context, values map[string]string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTopic", arg0, name)
+	ret := m.ctrl.Call(m, "MSet", ctx, values)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTopic indicates an expected call of DeleteTopic.
-func (mr *MockPubSubProviderMockRecorder) DeleteTopic(arg0, name any) *gomock.Call {
+// MSet indicates an expected call of MSet.
+func (mr *MockKVStoreBatchMockRecorder) MSet(ctx, values any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopic", reflect.TypeOf((*MockPubSubProvider)(nil).DeleteTopic), arg0, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MSet", reflect.TypeOf((*MockKVStoreBatch)(nil).MSet), ctx, values)
 }
 
-// Health mocks base method.
-func (m *MockPubSubProvider) Health() datasource.Health {
+// MockKVStoreScanner is a mock of KVStoreScanner interface.
+type MockKVStoreScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreScannerMockRecorder
+	isgomock struct{}
+}
+
+// MockKVStoreScannerMockRecorder is the mock recorder for MockKVStoreScanner.
+type MockKVStoreScannerMockRecorder struct {
+	mock *MockKVStoreScanner
+}
+
+// NewMockKVStoreScanner creates a new mock instance.
+func NewMockKVStoreScanner(ctrl *gomock.Controller) *MockKVStoreScanner {
+	mock := &MockKVStoreScanner{ctrl: ctrl}
+	mock.recorder = &MockKVStoreScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreScanner) EXPECT() *MockKVStoreScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockKVStoreScanner) Scan(ctx context.Context, prefix, cursor string, limit int) (map[string]string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", ctx, prefix, cursor, limit)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockKVStoreScannerMockRecorder) Scan(ctx, prefix, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockKVStoreScanner)(nil).Scan), ctx, prefix, cursor, limit)
+}
+
+// MockKVStoreCAS is a mock of KVStoreCAS interface.
+type MockKVStoreCAS struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreCASMockRecorder
+	isgomock struct{}
+}
+
+// MockKVStoreCASMockRecorder is the mock recorder for MockKVStoreCAS.
+type MockKVStoreCASMockRecorder struct {
+	mock *MockKVStoreCAS
+}
+
+// NewMockKVStoreCAS creates a new mock instance.
+func NewMockKVStoreCAS(ctrl *gomock.Controller) *MockKVStoreCAS {
+	mock := &MockKVStoreCAS{ctrl: ctrl}
+	mock.recorder = &MockKVStoreCASMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreCAS) EXPECT() *MockKVStoreCASMockRecorder {
+	return m.recorder
+}
+
+// CompareAndSwap mocks base method.
+func (m *MockKVStoreCAS) CompareAndSwap(ctx context.Context, key, value string, revision uint64) (uint64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSwap", ctx, key, value, revision)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockKVStoreCASMockRecorder) CompareAndSwap(ctx, key, value, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockKVStoreCAS)(nil).CompareAndSwap), ctx, key, value, revision)
+}
+
+// GetWithRevision mocks base method.
+func (m *MockKVStoreCAS) GetWithRevision(ctx context.Context, key string) (string, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithRevision", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithRevision indicates an expected call of GetWithRevision.
+func (mr *MockKVStoreCASMockRecorder) GetWithRevision(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithRevision", reflect.TypeOf((*MockKVStoreCAS)(nil).GetWithRevision), ctx, key)
+}
+
+// MockKVStoreWatcher is a mock of KVStoreWatcher interface.
+type MockKVStoreWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreWatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockKVStoreWatcherMockRecorder is the mock recorder for MockKVStoreWatcher.
+type MockKVStoreWatcherMockRecorder struct {
+	mock *MockKVStoreWatcher
+}
+
+// NewMockKVStoreWatcher creates a new mock instance.
+func NewMockKVStoreWatcher(ctrl *gomock.Controller) *MockKVStoreWatcher {
+	mock := &MockKVStoreWatcher{ctrl: ctrl}
+	mock.recorder = &MockKVStoreWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreWatcher) EXPECT() *MockKVStoreWatcherMockRecorder {
+	return m.recorder
+}
+
+// Watch mocks base method.
+func (m *MockKVStoreWatcher) Watch(ctx context.Context, prefix string, handler func(string, string, bool)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, prefix, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockKVStoreWatcherMockRecorder) Watch(ctx, prefix, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockKVStoreWatcher)(nil).Watch), ctx, prefix, handler)
+}
+
+// MockKVStoreRevisionWatcher is a mock of KVStoreRevisionWatcher interface.
+type MockKVStoreRevisionWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreRevisionWatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockKVStoreRevisionWatcherMockRecorder is the mock recorder for MockKVStoreRevisionWatcher.
+type MockKVStoreRevisionWatcherMockRecorder struct {
+	mock *MockKVStoreRevisionWatcher
+}
+
+// NewMockKVStoreRevisionWatcher creates a new mock instance.
+func NewMockKVStoreRevisionWatcher(ctrl *gomock.Controller) *MockKVStoreRevisionWatcher {
+	mock := &MockKVStoreRevisionWatcher{ctrl: ctrl}
+	mock.recorder = &MockKVStoreRevisionWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreRevisionWatcher) EXPECT() *MockKVStoreRevisionWatcherMockRecorder {
+	return m.recorder
+}
+
+// WatchFrom mocks base method.
+func (m *MockKVStoreRevisionWatcher) WatchFrom(ctx context.Context, prefix string, revision uint64, handler func(string, string, uint64, bool) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchFrom", ctx, prefix, revision, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchFrom indicates an expected call of WatchFrom.
+func (mr *MockKVStoreRevisionWatcherMockRecorder) WatchFrom(ctx, prefix, revision, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchFrom", reflect.TypeOf((*MockKVStoreRevisionWatcher)(nil).WatchFrom), ctx, prefix, revision, handler)
+}
+
+// MockPubSubProvider is a mock of PubSubProvider interface.
+type MockPubSubProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPubSubProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockPubSubProviderMockRecorder is the mock recorder for MockPubSubProvider.
+type MockPubSubProviderMockRecorder struct {
+	mock *MockPubSubProvider
+}
+
+// NewMockPubSubProvider creates a new mock instance.
+func NewMockPubSubProvider(ctrl *gomock.Controller) *MockPubSubProvider {
+	mock := &MockPubSubProvider{ctrl: ctrl}
+	mock.recorder = &MockPubSubProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPubSubProvider) EXPECT() *MockPubSubProviderMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPubSubProvider) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPubSubProviderMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPubSubProvider)(nil).Close))
+}
+
+// Connect mocks base method.
+func (m *MockPubSubProvider) Connect() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Connect")
+}
+
+// Connect indicates an expected call of Connect.
+func (mr *MockPubSubProviderMockRecorder) Connect() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockPubSubProvider)(nil).Connect))
+}
+
+// CreateTopic mocks base method.
+func (m *MockPubSubProvider) CreateTopic(arg0 context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTopic", arg0, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTopic indicates an expected call of CreateTopic.
+func (mr *MockPubSubProviderMockRecorder) CreateTopic(arg0, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopic", reflect.TypeOf((*MockPubSubProvider)(nil).CreateTopic), arg0, name)
+}
+
+// DeleteTopic mocks base method.
+func (m *MockPubSubProvider) DeleteTopic(arg0 context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTopic", arg0, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTopic indicates an expected call of DeleteTopic.
+func (mr *MockPubSubProviderMockRecorder) DeleteTopic(arg0, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopic", reflect.TypeOf((*MockPubSubProvider)(nil).DeleteTopic), arg0, name)
+}
+
+// Health mocks base method.
+func (m *MockPubSubProvider) Health() datasource.Health {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Health")
 	ret0, _ := ret[0].(datasource.Health)
@@ -12171,6 +12766,75 @@ func (mr *MockSolrProviderMockRecorder) UseTracer(tracer any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTracer", reflect.TypeOf((*MockSolrProvider)(nil).UseTracer), tracer)
 }
 
+// MockSolrCollectionManager is a mock of SolrCollectionManager interface.
+type MockSolrCollectionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockSolrCollectionManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockSolrCollectionManagerMockRecorder is the mock recorder for MockSolrCollectionManager.
+type MockSolrCollectionManagerMockRecorder struct {
+	mock *MockSolrCollectionManager
+}
+
+// NewMockSolrCollectionManager creates a new mock instance.
+func NewMockSolrCollectionManager(ctrl *gomock.Controller) *MockSolrCollectionManager {
+	mock := &MockSolrCollectionManager{ctrl: ctrl}
+	mock.recorder = &MockSolrCollectionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSolrCollectionManager) EXPECT() *MockSolrCollectionManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateCollection mocks base method.
+func (m *MockSolrCollectionManager) CreateCollection(ctx context.Context, collection string, params map[string]any) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, collection, params)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockSolrCollectionManagerMockRecorder) CreateCollection(ctx, collection, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockSolrCollectionManager)(nil).CreateCollection), ctx, collection, params)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockSolrCollectionManager) DeleteCollection(ctx context.Context, collection string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, collection)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockSolrCollectionManagerMockRecorder) DeleteCollection(ctx, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockSolrCollectionManager)(nil).DeleteCollection), ctx, collection)
+}
+
+// ListCollections mocks base method.
+func (m *MockSolrCollectionManager) ListCollections(ctx context.Context) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", ctx)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockSolrCollectionManagerMockRecorder) ListCollections(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockSolrCollectionManager)(nil).ListCollections), ctx)
+}
+
 // MockDgraph is a mock of Dgraph interface.
 type MockDgraph struct {
 	ctrl     *gomock.Controller
@@ -13896,6 +14560,34 @@ func (mr *MockElasticsearchMockRecorder) Bulk(ctx, operations any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockElasticsearch)(nil).Bulk), ctx, operations)
 }
 
+// ClearScroll mocks base method.
+func (m *MockElasticsearch) ClearScroll(ctx context.Context, scrollID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearScroll", ctx, scrollID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearScroll indicates an expected call of ClearScroll.
+func (mr *MockElasticsearchMockRecorder) ClearScroll(ctx, scrollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearScroll", reflect.TypeOf((*MockElasticsearch)(nil).ClearScroll), ctx, scrollID)
+}
+
+// ClosePointInTime mocks base method.
+func (m *MockElasticsearch) ClosePointInTime(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePointInTime", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePointInTime indicates an expected call of ClosePointInTime.
+func (mr *MockElasticsearchMockRecorder) ClosePointInTime(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePointInTime", reflect.TypeOf((*MockElasticsearch)(nil).ClosePointInTime), ctx, id)
+}
+
 // CreateIndex mocks base method.
 func (m *MockElasticsearch) CreateIndex(ctx context.Context, index string, settings map[string]any) error {
 	m.ctrl.T.Helper()
@@ -13938,6 +14630,21 @@ func (mr *MockElasticsearchMockRecorder) DeleteIndex(ctx, index any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndex", reflect.TypeOf((*MockElasticsearch)(nil).DeleteIndex), ctx, index)
 }
 
+// GetAliasIndices mocks base method.
+func (m *MockElasticsearch) GetAliasIndices(ctx context.Context, alias string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAliasIndices", ctx, alias)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAliasIndices indicates an expected call of GetAliasIndices.
+func (mr *MockElasticsearchMockRecorder) GetAliasIndices(ctx, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAliasIndices", reflect.TypeOf((*MockElasticsearch)(nil).GetAliasIndices), ctx, alias)
+}
+
 // GetDocument mocks base method.
 func (m *MockElasticsearch) GetDocument(ctx context.Context, index, id string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -13982,6 +14689,36 @@ func (mr *MockElasticsearchMockRecorder) IndexDocument(ctx, index, id, document
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexDocument", reflect.TypeOf((*MockElasticsearch)(nil).IndexDocument), ctx, index, id, document)
 }
 
+// OpenPointInTime mocks base method.
+func (m *MockElasticsearch) OpenPointInTime(ctx context.Context, indices []string, keepAlive time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenPointInTime", ctx, indices, keepAlive)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenPointInTime indicates an expected call of OpenPointInTime.
+func (mr *MockElasticsearchMockRecorder) OpenPointInTime(ctx, indices, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPointInTime", reflect.TypeOf((*MockElasticsearch)(nil).OpenPointInTime), ctx, indices, keepAlive)
+}
+
+// ScrollNext mocks base method.
+func (m *MockElasticsearch) ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScrollNext", ctx, scrollID, keepAlive)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScrollNext indicates an expected call of ScrollNext.
+func (mr *MockElasticsearchMockRecorder) ScrollNext(ctx, scrollID, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScrollNext", reflect.TypeOf((*MockElasticsearch)(nil).ScrollNext), ctx, scrollID, keepAlive)
+}
+
 // Search mocks base method.
 func (m *MockElasticsearch) Search(ctx context.Context, indices []string, query map[string]any) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -13997,6 +14734,50 @@ func (mr *MockElasticsearchMockRecorder) Search(ctx, indices, query any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockElasticsearch)(nil).Search), ctx, indices, query)
 }
 
+// SearchScroll mocks base method.
+func (m *MockElasticsearch) SearchScroll(ctx context.Context, indices []string, query map[string]any, keepAlive time.Duration) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchScroll", ctx, indices, query, keepAlive)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchScroll indicates an expected call of SearchScroll.
+func (mr *MockElasticsearchMockRecorder) SearchScroll(ctx, indices, query, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchScroll", reflect.TypeOf((*MockElasticsearch)(nil).SearchScroll), ctx, indices, query, keepAlive)
+}
+
+// SwapAlias mocks base method.
+func (m *MockElasticsearch) SwapAlias(ctx context.Context, alias string, index string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapAlias", ctx, alias, index)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapAlias indicates an expected call of SwapAlias.
+func (mr *MockElasticsearchMockRecorder) SwapAlias(ctx, alias, index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapAlias", reflect.TypeOf((*MockElasticsearch)(nil).SwapAlias), ctx, alias, index)
+}
+
+// UpdateAliases mocks base method.
+func (m *MockElasticsearch) UpdateAliases(ctx context.Context, actions []map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAliases", ctx, actions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAliases indicates an expected call of UpdateAliases.
+func (mr *MockElasticsearchMockRecorder) UpdateAliases(ctx, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAliases", reflect.TypeOf((*MockElasticsearch)(nil).UpdateAliases), ctx, actions)
+}
+
 // UpdateDocument mocks base method.
 func (m *MockElasticsearch) UpdateDocument(ctx context.Context, index, id string, update map[string]any) error {
 	m.ctrl.T.Helper()
@@ -14050,6 +14831,34 @@ func (mr *MockElasticsearchProviderMockRecorder) Bulk(ctx, operations any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockElasticsearchProvider)(nil).Bulk), ctx, operations)
 }
 
+// ClearScroll mocks base method.
+func (m *MockElasticsearchProvider) ClearScroll(ctx context.Context, scrollID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearScroll", ctx, scrollID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearScroll indicates an expected call of ClearScroll.
+func (mr *MockElasticsearchProviderMockRecorder) ClearScroll(ctx, scrollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearScroll", reflect.TypeOf((*MockElasticsearchProvider)(nil).ClearScroll), ctx, scrollID)
+}
+
+// ClosePointInTime mocks base method.
+func (m *MockElasticsearchProvider) ClosePointInTime(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePointInTime", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePointInTime indicates an expected call of ClosePointInTime.
+func (mr *MockElasticsearchProviderMockRecorder) ClosePointInTime(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePointInTime", reflect.TypeOf((*MockElasticsearchProvider)(nil).ClosePointInTime), ctx, id)
+}
+
 // Connect mocks base method.
 func (m *MockElasticsearchProvider) Connect() {
 	m.ctrl.T.Helper()
@@ -14104,6 +14913,21 @@ func (mr *MockElasticsearchProviderMockRecorder) DeleteIndex(ctx, index any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndex", reflect.TypeOf((*MockElasticsearchProvider)(nil).DeleteIndex), ctx, index)
 }
 
+// GetAliasIndices mocks base method.
+func (m *MockElasticsearchProvider) GetAliasIndices(ctx context.Context, alias string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAliasIndices", ctx, alias)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAliasIndices indicates an expected call of GetAliasIndices.
+func (mr *MockElasticsearchProviderMockRecorder) GetAliasIndices(ctx, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAliasIndices", reflect.TypeOf((*MockElasticsearchProvider)(nil).GetAliasIndices), ctx, alias)
+}
+
 // GetDocument mocks base method.
 func (m *MockElasticsearchProvider) GetDocument(ctx context.Context, index, id string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -14148,6 +14972,36 @@ func (mr *MockElasticsearchProviderMockRecorder) IndexDocument(ctx, index, id, d
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexDocument", reflect.TypeOf((*MockElasticsearchProvider)(nil).IndexDocument), ctx, index, id, document)
 }
 
+// OpenPointInTime mocks base method.
+func (m *MockElasticsearchProvider) OpenPointInTime(ctx context.Context, indices []string, keepAlive time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenPointInTime", ctx, indices, keepAlive)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenPointInTime indicates an expected call of OpenPointInTime.
+func (mr *MockElasticsearchProviderMockRecorder) OpenPointInTime(ctx, indices, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPointInTime", reflect.TypeOf((*MockElasticsearchProvider)(nil).OpenPointInTime), ctx, indices, keepAlive)
+}
+
+// ScrollNext mocks base method.
+func (m *MockElasticsearchProvider) ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScrollNext", ctx, scrollID, keepAlive)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScrollNext indicates an expected call of ScrollNext.
+func (mr *MockElasticsearchProviderMockRecorder) ScrollNext(ctx, scrollID, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScrollNext", reflect.TypeOf((*MockElasticsearchProvider)(nil).ScrollNext), ctx, scrollID, keepAlive)
+}
+
 // Search mocks base method.
 func (m *MockElasticsearchProvider) Search(ctx context.Context, indices []string, query map[string]any) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -14163,6 +15017,50 @@ func (mr *MockElasticsearchProviderMockRecorder) Search(ctx, indices, query any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockElasticsearchProvider)(nil).Search), ctx, indices, query)
 }
 
+// SearchScroll mocks base method.
+func (m *MockElasticsearchProvider) SearchScroll(ctx context.Context, indices []string, query map[string]any, keepAlive time.Duration) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchScroll", ctx, indices, query, keepAlive)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchScroll indicates an expected call of SearchScroll.
+func (mr *MockElasticsearchProviderMockRecorder) SearchScroll(ctx, indices, query, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchScroll", reflect.TypeOf((*MockElasticsearchProvider)(nil).SearchScroll), ctx, indices, query, keepAlive)
+}
+
+// SwapAlias mocks base method.
+func (m *MockElasticsearchProvider) SwapAlias(ctx context.Context, alias string, index string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapAlias", ctx, alias, index)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapAlias indicates an expected call of SwapAlias.
+func (mr *MockElasticsearchProviderMockRecorder) SwapAlias(ctx, alias, index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapAlias", reflect.TypeOf((*MockElasticsearchProvider)(nil).SwapAlias), ctx, alias, index)
+}
+
+// UpdateAliases mocks base method.
+func (m *MockElasticsearchProvider) UpdateAliases(ctx context.Context, actions []map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAliases", ctx, actions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAliases indicates an expected call of UpdateAliases.
+func (mr *MockElasticsearchProviderMockRecorder) UpdateAliases(ctx, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAliases", reflect.TypeOf((*MockElasticsearchProvider)(nil).UpdateAliases), ctx, actions)
+}
+
 // UpdateDocument mocks base method.
 func (m *MockElasticsearchProvider) UpdateDocument(ctx context.Context, index, id string, update map[string]any) error {
 	m.ctrl.T.Helper()
@@ -14565,6 +15463,114 @@ func (mr *MockCouchbaseProviderMockRecorder) UseTracer(tracer any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTracer", reflect.TypeOf((*MockCouchbaseProvider)(nil).UseTracer), tracer)
 }
 
+// MockCouchbaseManager is a mock of CouchbaseManager interface.
+type MockCouchbaseManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouchbaseManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockCouchbaseManagerMockRecorder is the mock recorder for MockCouchbaseManager.
+type MockCouchbaseManagerMockRecorder struct {
+	mock *MockCouchbaseManager
+}
+
+// NewMockCouchbaseManager creates a new mock instance.
+func NewMockCouchbaseManager(ctrl *gomock.Controller) *MockCouchbaseManager {
+	mock := &MockCouchbaseManager{ctrl: ctrl}
+	mock.recorder = &MockCouchbaseManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouchbaseManager) EXPECT() *MockCouchbaseManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateBucket mocks base method.
+func (m *MockCouchbaseManager) CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", ctx, bucket, ramQuotaMB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBucket indicates an expected call of CreateBucket.
+func (mr *MockCouchbaseManagerMockRecorder) CreateBucket(ctx, bucket, ramQuotaMB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockCouchbaseManager)(nil).CreateBucket), ctx, bucket, ramQuotaMB)
+}
+
+// CreateCollection mocks base method.
+func (m *MockCouchbaseManager) CreateCollection(ctx context.Context, scope, collection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, scope, collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockCouchbaseManagerMockRecorder) CreateCollection(ctx, scope, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockCouchbaseManager)(nil).CreateCollection), ctx, scope, collection)
+}
+
+// CreateScope mocks base method.
+func (m *MockCouchbaseManager) CreateScope(ctx context.Context, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScope", ctx, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateScope indicates an expected call of CreateScope.
+func (mr *MockCouchbaseManagerMockRecorder) CreateScope(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScope", reflect.TypeOf((*MockCouchbaseManager)(nil).CreateScope), ctx, scope)
+}
+
+// DropBucket mocks base method.
+func (m *MockCouchbaseManager) DropBucket(ctx context.Context, bucket string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropBucket", ctx, bucket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropBucket indicates an expected call of DropBucket.
+func (mr *MockCouchbaseManagerMockRecorder) DropBucket(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropBucket", reflect.TypeOf((*MockCouchbaseManager)(nil).DropBucket), ctx, bucket)
+}
+
+// DropCollection mocks base method.
+func (m *MockCouchbaseManager) DropCollection(ctx context.Context, scope, collection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropCollection", ctx, scope, collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropCollection indicates an expected call of DropCollection.
+func (mr *MockCouchbaseManagerMockRecorder) DropCollection(ctx, scope, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropCollection", reflect.TypeOf((*MockCouchbaseManager)(nil).DropCollection), ctx, scope, collection)
+}
+
+// DropScope mocks base method.
+func (m *MockCouchbaseManager) DropScope(ctx context.Context, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropScope", ctx, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropScope indicates an expected call of DropScope.
+func (mr *MockCouchbaseManagerMockRecorder) DropScope(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropScope", reflect.TypeOf((*MockCouchbaseManager)(nil).DropScope), ctx, scope)
+}
+
 // MockDBResolverProvider is a mock of DBResolverProvider interface.
 type MockDBResolverProvider struct {
 	ctrl     *gomock.Controller
@@ -15040,3 +16046,41 @@ func (mr *MockInfluxDBProviderMockRecorder) WritePoint(ctx, org, bucket, measure
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritePoint", reflect.TypeOf((*MockInfluxDBProvider)(nil).WritePoint), ctx, org, bucket, measurement, tags, fields, timestamp)
 }
+
+// MockInfluxDBRetentionManager is a mock of InfluxDBRetentionManager interface.
+type MockInfluxDBRetentionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockInfluxDBRetentionManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockInfluxDBRetentionManagerMockRecorder is the mock recorder for MockInfluxDBRetentionManager.
+type MockInfluxDBRetentionManagerMockRecorder struct {
+	mock *MockInfluxDBRetentionManager
+}
+
+// NewMockInfluxDBRetentionManager creates a new mock instance.
+func NewMockInfluxDBRetentionManager(ctrl *gomock.Controller) *MockInfluxDBRetentionManager {
+	mock := &MockInfluxDBRetentionManager{ctrl: ctrl}
+	mock.recorder = &MockInfluxDBRetentionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInfluxDBRetentionManager) EXPECT() *MockInfluxDBRetentionManagerMockRecorder {
+	return m.recorder
+}
+
+// UpdateBucketRetention mocks base method.
+func (m *MockInfluxDBRetentionManager) UpdateBucketRetention(ctx context.Context, bucketID string, retention time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBucketRetention", ctx, bucketID, retention)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBucketRetention indicates an expected call of UpdateBucketRetention.
+func (mr *MockInfluxDBRetentionManagerMockRecorder) UpdateBucketRetention(ctx, bucketID, retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBucketRetention", reflect.TypeOf((*MockInfluxDBRetentionManager)(nil).UpdateBucketRetention), ctx, bucketID, retention)
+}
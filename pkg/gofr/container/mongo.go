@@ -0,0 +1,88 @@
+package container
+
+import "time"
+
+// FindOptions sets the order, the fields and the page of the documents found by Mongo.FindWithOptions.
+type FindOptions struct {
+	// Sort orders the documents by fields, such as bson.D{{Key: "age", Value: -1}}.
+	Sort any
+	// Projection selects the fields of the documents, such as bson.M{"name": 1}.
+	Projection any
+	// Limit is the maximum number of documents found, 0 finding all of them.
+	Limit int64
+	// Skip is the number of documents skipped before the first one found.
+	Skip int64
+}
+
+// IndexOptions sets the properties of the index created by Mongo.CreateIndex.
+type IndexOptions struct {
+	// Name is the name of the index, generated from its keys when empty.
+	Name string
+	// Unique rejects the documents having the same keys as another document of the collection.
+	Unique bool
+	// ExpireAfter deletes the documents once the date of their indexed field is older than it, 0 keeping them. It is
+	// rounded down to seconds.
+	ExpireAfter time.Duration
+}
+
+// WriteModel is a write of Mongo.BulkWrite: an InsertOneModel, UpdateOneModel, UpdateManyModel, ReplaceOneModel,
+// DeleteOneModel or DeleteManyModel.
+type WriteModel interface {
+	writeModel()
+}
+
+// InsertOneModel inserts Document.
+type InsertOneModel struct {
+	Document any
+}
+
+// UpdateOneModel applies Update to the first document matching Filter, inserting one when none does and Upsert is
+// set.
+type UpdateOneModel struct {
+	Filter any
+	Update any
+	Upsert bool
+}
+
+// UpdateManyModel applies Update to the documents matching Filter, inserting one when none does and Upsert is set.
+type UpdateManyModel struct {
+	Filter any
+	Update any
+	Upsert bool
+}
+
+// ReplaceOneModel replaces the first document matching Filter by Replacement, inserting it when none does and Upsert
+// is set.
+type ReplaceOneModel struct {
+	Filter      any
+	Replacement any
+	Upsert      bool
+}
+
+// DeleteOneModel deletes the first document matching Filter.
+type DeleteOneModel struct {
+	Filter any
+}
+
+// DeleteManyModel deletes the documents matching Filter.
+type DeleteManyModel struct {
+	Filter any
+}
+
+func (InsertOneModel) writeModel()  {}
+func (UpdateOneModel) writeModel()  {}
+func (UpdateManyModel) writeModel() {}
+func (ReplaceOneModel) writeModel() {}
+func (DeleteOneModel) writeModel()  {}
+func (DeleteManyModel) writeModel() {}
+
+// BulkWriteResult holds the numbers of documents written by Mongo.BulkWrite.
+type BulkWriteResult struct {
+	InsertedCount int64
+	MatchedCount  int64
+	ModifiedCount int64
+	DeletedCount  int64
+	UpsertedCount int64
+	// UpsertedIDs holds the IDs of the upserted documents by the index of their model.
+	UpsertedIDs map[int64]any
+}
@@ -0,0 +1,73 @@
+package container
+
+import (
+	"regexp"
+	"strings"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// newRedactor builds the redaction rules from LOG_REDACT_FIELDS, LOG_REDACT_HEADERS and LOG_REDACT_PATTERNS,
+// which extend the defaults of logging.DefaultRedactor. Invalid patterns are logged and skipped.
+func newRedactor(conf config.Config, logger logging.Logger) *logging.Redactor {
+	var patterns []*regexp.Regexp
+
+	for _, p := range splitPatterns(conf.Get("LOG_REDACT_PATTERNS")) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			logger.Errorf("invalid LOG_REDACT_PATTERNS entry %q: %v", p, err)
+			continue
+		}
+
+		patterns = append(patterns, re)
+	}
+
+	return logging.NewRedactor(splitList(conf.Get("LOG_REDACT_FIELDS")), splitList(conf.Get("LOG_REDACT_HEADERS")),
+		patterns...)
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+// splitPatterns splits a comma separated list of regular expressions, ignoring the commas inside
+// brackets, braces and parentheses so that patterns such as `\d{13,16}` are kept whole.
+func splitPatterns(value string) []string {
+	var (
+		patterns []string
+		depth    int
+		start    int
+		escaped  bool
+	)
+
+	for i, ch := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '(' || ch == '[' || ch == '{':
+			depth++
+		case ch == ')' || ch == ']' || ch == '}':
+			depth--
+		case ch == ',' && depth <= 0:
+			patterns = appendPattern(patterns, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return appendPattern(patterns, value[start:])
+}
+
+func appendPattern(patterns []string, p string) []string {
+	if p = strings.TrimSpace(p); p != "" {
+		patterns = append(patterns, p)
+	}
+
+	return patterns
+}
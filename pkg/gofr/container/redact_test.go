@@ -0,0 +1,41 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func TestNewRedactor(t *testing.T) {
+	cfg := config.NewMockConfig(map[string]string{
+		"LOG_REDACT_FIELDS":   "national_id, iban",
+		"LOG_REDACT_HEADERS":  "X-Tenant-Key",
+		"LOG_REDACT_PATTERNS": `\d{4}-\d{4}-\d{4}-\d{4},[`,
+	})
+
+	r := newRedactor(cfg, logging.NewMockLogger(logging.ERROR))
+
+	assert.True(t, r.IsSensitiveField("nationalID"))
+	assert.True(t, r.IsSensitiveField("password"), "default fields must be retained")
+	assert.True(t, r.IsSensitiveHeader("x-tenant-key"))
+	assert.Equal(t, "card [REDACTED]", r.String("card 4111-1111-1111-1111"))
+}
+
+func TestSplitPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{`a,b`, []string{"a", "b"}},
+		{`\d{13,16}, (x|y),[a,b]`, []string{`\d{13,16}`, "(x|y)", "[a,b]"}},
+		{`a\,b`, []string{`a\,b`}},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, splitPatterns(tc.input), "TEST[%d], Failed.\n%s", i, tc.input)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
+	"gofr.dev/pkg/gofr/cache"
 	"gofr.dev/pkg/gofr/cmd/terminal"
 	"gofr.dev/pkg/gofr/container"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
 	"gofr.dev/pkg/gofr/http/middleware"
 	"gofr.dev/pkg/gofr/logging"
 )
@@ -75,6 +77,59 @@ func (c *Context) Bind(i any) error {
 	return c.Request.Bind(i)
 }
 
+// SetVersion declares the current version of the resource the request operates on. The version is sent as
+// the entity tag of the response, in the ETag header, and used to answer requests carrying If-None-Match.
+func (c *Context) SetVersion(version string) {
+	if r, ok := c.responder.(etagSetter); ok {
+		r.SetETag(gofrHTTP.ETag(version))
+	}
+}
+
+// CheckVersion declares the current version of the resource the request operates on, as SetVersion does,
+// and enforces the If-Match header of the request against it. Handlers modifying a resource call it before
+// applying the change, which must be abandoned if it returns ErrorPreconditionFailed:
+//
+//	if err := ctx.CheckVersion(strconv.Itoa(order.Version)); err != nil {
+//		return nil, err
+//	}
+func (c *Context) CheckVersion(version string) error {
+	c.SetVersion(version)
+
+	if !gofrHTTP.IfMatch(c.header("If-Match"), gofrHTTP.ETag(version)) {
+		return gofrHTTP.ErrorPreconditionFailed{}
+	}
+
+	return nil
+}
+
+// etagSetter is implemented by responders sending entity tags.
+type etagSetter interface {
+	SetETag(etag string)
+}
+
+// header returns a header of the request, or an empty string when the request is not an HTTP request.
+func (c *Context) header(key string) string {
+	if r, ok := c.Request.(interface{ Header(key string) string }); ok {
+		return r.Header(key)
+	}
+
+	return ""
+}
+
+// Cached returns the value cached under key, loading it with load on a miss. The value is looked up in the memory
+// of the instance, then in the store shared by the instances, and loaded once by the concurrent requests missing it.
+// load receives a context which is not cancelled with the request, as concurrent requests may wait for its result.
+// It returns cache.ErrNotFound for values which do not exist, their absence being cached too:
+//
+//	product, err := gofr.Cached(ctx, "product:"+id, func(loadCtx context.Context) (Product, error) {
+//		return getProduct(loadCtx, id)
+//	}, cache.WithTTL(time.Hour), cache.WithTags("products"))
+//
+// Handlers changing the values invalidate them with ctx.Cache.Invalidate or ctx.Cache.InvalidateTags.
+func Cached[T any](ctx *Context, key string, load func(ctx context.Context) (T, error), opts ...cache.Option) (T, error) {
+	return cache.Get(ctx, ctx.Cache, key, load, opts...)
+}
+
 // WriteMessageToSocket writes a message to the WebSocket connection associated with the context.
 // The data parameter can be of type string, []byte, or any struct that can be marshaled to JSON.
 // It retrieves the WebSocket connection from the context and sends the message as a TextMessage.
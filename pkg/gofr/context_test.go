@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -362,3 +363,81 @@ func TestContext_GetCorrelationID(t *testing.T) {
 		assert.Equal(t, expected, correlationID, "Expected empty TraceID when no span present")
 	})
 }
+
+func TestContext_AddLogFields(t *testing.T) {
+	logs := testutil.StdoutOutputForFunc(func() {
+		httpRequest, _ := http.NewRequestWithContext(logging.ContextWithFields(t.Context()), http.MethodGet, "/test",
+			http.NoBody)
+
+		ctx := newContext(nil, gofrHTTP.NewRequest(httpRequest), container.NewContainer(config.NewEnvFile("",
+			logging.NewMockLogger(logging.DEBUG))))
+
+		ctx.Logf("before")
+		ctx.AddLogFields("user_id", "u-1")
+		ctx.Logf("after")
+	})
+
+	assert.Contains(t, logs, "before")
+	assert.Contains(t, logs, "after")
+	assert.Equal(t, 1, strings.Count(logs, `"user_id":"u-1"`))
+}
+
+func TestContext_CheckVersion(t *testing.T) {
+	tests := []struct {
+		desc    string
+		ifMatch string
+		err     error
+	}{
+		{desc: "no precondition", ifMatch: ""},
+		{desc: "matching version", ifMatch: `"5"`},
+		{desc: "any version", ifMatch: "*"},
+		{desc: "stale version", ifMatch: `"4"`, err: gofrHTTP.ErrorPreconditionFailed{}},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodPut, "/orders/1", http.NoBody)
+		req.Header.Set("If-Match", tc.ifMatch)
+
+		w := httptest.NewRecorder()
+		ctx := newContext(gofrHTTP.NewResponderWithEncoders(w, req, nil), gofrHTTP.NewRequest(req),
+			container.NewContainer(config.NewMockConfig(nil)))
+
+		err := ctx.CheckVersion("5")
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		ctx.responder.Respond(nil, err)
+
+		assert.Equal(t, `"5"`, w.Header().Get("ETag"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCached(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/products/1", http.NoBody)
+	ctx := newContext(nil, gofrHTTP.NewRequest(req), container.NewContainer(config.NewMockConfig(nil)))
+
+	calls := 0
+	load := func(loadCtx context.Context) (string, error) {
+		calls++
+
+		assert.NotErrorIs(t, loadCtx.Err(), context.Canceled)
+
+		return "shoes", nil
+	}
+
+	for range 2 {
+		value, err := Cached(ctx, "product:1", load)
+
+		require.NoError(t, err)
+		assert.Equal(t, "shoes", value)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	require.NoError(t, ctx.Cache.Invalidate(ctx, "product:1"))
+
+	_, err := Cached(ctx, "product:1", load)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+}
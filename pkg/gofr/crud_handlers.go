@@ -1,20 +1,41 @@
 package gofr
 
 import (
+	"context"
+	gosql "database/sql"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"gofr.dev/pkg/gofr/datasource/sql"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
 )
 
 var (
 	errInvalidObject     = errors.New("unexpected object given for AddRESTHandlers")
-	errEntityNotFound    = errors.New("entity not found")
 	errObjectIsNil       = errors.New("object given for AddRESTHandlers is nil")
 	errNonPointerObject  = errors.New("passed object is not pointer")
 	errFieldCannotBeNull = errors.New("field cannot be null")
 	errInvalidSQLTag     = errors.New("invalid sql tag")
+	errInvalidVersion    = errors.New("entity must have at most one version field, of an integer type")
+	errInvalidAudit      = errors.New("entity must have at most one field per audit column, of a time or string type")
+	errInvalidRelation   = errors.New("has_many relations must be held by slices of structs, and belongs_to relations " +
+		"by pointers to structs, whose foreign key is a field")
+)
+
+const (
+	auditCreatedAt = "created_at"
+	auditUpdatedAt = "updated_at"
+	auditCreatedBy = "created_by"
+	auditUpdatedBy = "updated_by"
+	auditDeletedAt = "deleted_at"
+
+	relationHasMany   = "has_many"
+	relationBelongsTo = "belongs_to"
 )
 
 type Create interface {
@@ -37,6 +58,10 @@ type Delete interface {
 	Delete(c *Context) (any, error)
 }
 
+type Patch interface {
+	Patch(c *Context) (any, error)
+}
+
 type TableNameOverrider interface {
 	TableName() string
 }
@@ -45,6 +70,45 @@ type RestPathOverrider interface {
 	RestPath() string
 }
 
+// BeforeCreate is implemented by entities checking or modifying the new entities before the default Create handler
+// inserts them. The lifecycle hooks are called in the transaction of the handler, which is rolled back when they
+// return an error, and the error is returned to the client.
+//
+// The transaction is retried on deadlocks and serialization failures, calling the hooks again: they must be
+// idempotent and have no effect outside of the transaction.
+//
+// The hooks of the entities stored by a Repository are called with a nil transaction. Their errors are returned to
+// the client, but the changes made before them are kept.
+type BeforeCreate interface {
+	BeforeCreate(c *Context, tx *sql.Tx) error
+}
+
+// AfterCreate is implemented by entities acting on the new entities once the default Create handler inserted them.
+type AfterCreate interface {
+	AfterCreate(c *Context, tx *sql.Tx) error
+}
+
+// BeforeUpdate is implemented by entities checking or modifying the entities before the default Update handler
+// updates them.
+type BeforeUpdate interface {
+	BeforeUpdate(c *Context, tx *sql.Tx) error
+}
+
+// AfterUpdate is implemented by entities acting on the entities once the default Update handler updated them.
+type AfterUpdate interface {
+	AfterUpdate(c *Context, tx *sql.Tx) error
+}
+
+// BeforeDelete is implemented by entities checking the entities before the default Delete handler deletes them.
+type BeforeDelete interface {
+	BeforeDelete(c *Context, tx *sql.Tx) error
+}
+
+// AfterDelete is implemented by entities acting on the entities once the default Delete handler deleted them.
+type AfterDelete interface {
+	AfterDelete(c *Context, tx *sql.Tx) error
+}
+
 type CRUD interface {
 	Create
 	GetAll
@@ -61,6 +125,42 @@ type entity struct {
 	tableName   string
 	restPath    string
 	constraints map[string]sql.FieldConstraints
+	// versionColumn is the column of the field tagged `sql:"version"`, used for optimistic concurrency control;
+	// it is empty for entities without a version.
+	versionColumn string
+	versionIndex  int
+	// auditFields maps the audit columns the fields are tagged with, e.g. `sql:"created_at"`, to their index.
+	auditFields map[string]int
+	// softDeleteColumn is the column of the field tagged `sql:"deleted_at"`, set instead of deleting rows; it is
+	// empty for entities whose rows are deleted.
+	softDeleteColumn string
+	// hooks tells whether the entity implements lifecycle hooks, which the handlers call in a transaction.
+	hooks bool
+	// relations are the has_many and belongs_to relations of the entity, whose fields are not columns.
+	relations []*relation
+}
+
+// relation is a has_many or belongs_to relation of an entity, held by one of its fields.
+type relation struct {
+	// name is the name of the nested route and of the include parameter of the relation: the lowercased field name.
+	name        string
+	kind        string
+	index       int
+	relatedType reflect.Type
+	// foreignKey is the column referencing the entity in the related table for has_many relations, and the related
+	// entity in the table of the entity for belongs_to relations.
+	foreignKey string
+	// keyIndex is the index of the field of the foreign key, in the related entity or in the entity respectively.
+	keyIndex int
+	// related is the related entity, scanned by resolveRelations.
+	related *entity
+}
+
+// sqlExecutor runs the statements of the default handlers, on the database or in a transaction.
+type sqlExecutor interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*gosql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *gosql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (gosql.Result, error)
 }
 
 // scanEntity extracts entity information for CRUD operations.
@@ -95,6 +195,7 @@ func scanEntity(object any) (*entity, error) {
 		tableName:   tableName,
 		restPath:    restPath,
 		constraints: make(map[string]sql.FieldConstraints),
+		hooks:       hasHooks(object),
 	}
 
 	for i := 0; i < entityType.NumField(); i++ {
@@ -107,44 +208,267 @@ func scanEntity(object any) (*entity, error) {
 		}
 
 		e.constraints[fieldName] = constraints
+
+		if constraints.Relation != "" {
+			if err := e.addRelation(constraints, field, i); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if constraints.Version {
+			if e.versionColumn != "" || !isIntegerKind(field.Type.Kind()) {
+				return nil, fmt.Errorf("%w: %s", errInvalidVersion, structName)
+			}
+
+			e.versionColumn, e.versionIndex = fieldName, i
+		}
+
+		if constraints.Audit != "" {
+			if err := e.addAuditField(constraints.Audit, field, i); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return e, nil
 }
 
-// registerCRUDHandlers registers CRUD handlers for an entity.
-func (a *App) registerCRUDHandlers(e *entity, object any) {
+func (e *entity) addAuditField(audit string, field reflect.StructField, index int) error {
+	if _, ok := e.auditFields[audit]; ok || !isAuditType(field.Type, audit) {
+		return fmt.Errorf("%w: %s", errInvalidAudit, e.name)
+	}
+
+	if e.auditFields == nil {
+		e.auditFields = make(map[string]int)
+	}
+
+	e.auditFields[audit] = index
+
+	if audit == auditDeletedAt {
+		e.softDeleteColumn = toSnakeCase(field.Name)
+	}
+
+	return nil
+}
+
+func (e *entity) addRelation(constraints sql.FieldConstraints, field reflect.StructField, index int) error {
+	rel := &relation{
+		name:       strings.ToLower(field.Name),
+		kind:       constraints.Relation,
+		index:      index,
+		foreignKey: constraints.ForeignKey,
+	}
+
+	var valid bool
+
+	if rel.kind == relationHasMany {
+		valid = field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct
+
+		if rel.foreignKey == "" {
+			rel.foreignKey = toSnakeCase(e.name) + "_id"
+		}
+	} else {
+		valid = field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct
+
+		if rel.foreignKey == "" {
+			rel.foreignKey = toSnakeCase(field.Name) + "_id"
+		}
+	}
+
+	if !valid || index == 0 {
+		return fmt.Errorf("%w: %s", errInvalidRelation, field.Name)
+	}
+
+	rel.relatedType = field.Type.Elem()
+	e.relations = append(e.relations, rel)
+
+	return nil
+}
+
+// resolveRelations scans the related entities of the relations, which scanEntity doesn't do as relations may be
+// mutual, and checks their foreign keys.
+func (e *entity) resolveRelations() error {
+	for _, rel := range e.relations {
+		related, err := scanEntity(reflect.New(rel.relatedType).Interface())
+		if err != nil {
+			return err
+		}
+
+		owner := related
+		if rel.kind == relationBelongsTo {
+			owner = e
+		}
+
+		if rel.keyIndex = owner.columnIndex(rel.foreignKey); rel.keyIndex < 0 {
+			return fmt.Errorf("%w: %s", errInvalidRelation, rel.foreignKey)
+		}
+
+		rel.related = related
+	}
+
+	return nil
+}
+
+// isColumn reports whether a field is a column, which it is unless it holds a relation.
+func (e *entity) isColumn(index int) bool {
+	for _, rel := range e.relations {
+		if rel.index == index {
+			return false
+		}
+	}
+
+	return true
+}
+
+// columnIndex returns the index of the field of a column, or -1.
+func (e *entity) columnIndex(column string) int {
+	for i := 0; i < e.entityType.NumField(); i++ {
+		if e.isColumn(i) && toSnakeCase(e.entityType.Field(i).Name) == column {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// columnPointers returns pointers to the column fields of an entity, to scan its row into.
+func (e *entity) columnPointers(entity any) []any {
+	val := reflect.ValueOf(entity).Elem()
+	dest := make([]any, 0, val.NumField())
+
+	for i := 0; i < val.NumField(); i++ {
+		if e.isColumn(i) {
+			dest = append(dest, val.Field(i).Addr().Interface())
+		}
+	}
+
+	return dest
+}
+
+func hasHooks(object any) bool {
+	switch object.(type) {
+	case BeforeCreate, AfterCreate, BeforeUpdate, AfterUpdate, BeforeDelete, AfterDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *entity) hasDeleteHooks() bool {
+	if !e.hooks {
+		return false
+	}
+
+	switch reflect.New(e.entityType).Interface().(type) {
+	case BeforeDelete, AfterDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// run runs fn in a transaction when the entity has lifecycle hooks, so that their changes are committed or rolled
+// back along with the ones of the handler, and on the database otherwise, with a nil transaction.
+func (e *entity) run(c *Context, fn func(db sqlExecutor, tx *sql.Tx) error) error {
+	if !e.hooks {
+		return fn(c.SQL, nil)
+	}
+
+	return c.SQL.WithTx(c, nil, func(tx *sql.Tx) error {
+		return fn(tx, tx)
+	})
+}
+
+// setAudit sets the given audit fields of the entity to the current time or to the user of the request.
+func (e *entity) setAudit(c *Context, entity any, audits ...string) {
+	if len(e.auditFields) == 0 {
+		return
+	}
+
+	val := reflect.ValueOf(entity).Elem()
+	now := time.Now().UTC()
+	user := requestUser(c)
+
+	for _, audit := range audits {
+		i, ok := e.auditFields[audit]
+		if !ok {
+			continue
+		}
+
+		switch audit {
+		case auditCreatedBy, auditUpdatedBy:
+			setAuditUser(val.Field(i), user)
+		default:
+			setAuditTime(val.Field(i), now)
+		}
+	}
+}
+
+// isUpdatable tells whether the default Update handler sets the field, which it doesn't for the primary key, the
+// version, the audit columns set once and the relations.
+func (e *entity) isUpdatable(index int) bool {
+	if index == 0 || (e.versionColumn != "" && index == e.versionIndex) || !e.isColumn(index) {
+		return false
+	}
+
+	return !e.isAudit(index, auditCreatedAt, auditCreatedBy, auditDeletedAt)
+}
+
+// notDeleted returns the conditions restricting the statements to the rows which are not soft deleted, for entities
+// with a deleted_at field.
+func (e *entity) notDeleted(c *Context) []string {
+	if e.softDeleteColumn == "" {
+		return nil
+	}
+
+	return []string{sql.NotDeleted(c.SQL.Dialect(), e.softDeleteColumn)}
+}
+
+// registerCRUDHandlers registers CRUD handlers for an entity, which are the ones of the object, or the defaults.
+func (a *App) registerCRUDHandlers(e *entity, object any, defaults CRUD) {
 	basePath := fmt.Sprintf("/%s", e.restPath)
 	idPath := fmt.Sprintf("/%s/{%s}", e.restPath, e.primaryKey)
 
 	if fn, ok := object.(Create); ok {
 		a.POST(basePath, fn.Create)
 	} else {
-		a.POST(basePath, e.Create)
+		a.POST(basePath, defaults.Create)
 	}
 
 	if fn, ok := object.(GetAll); ok {
 		a.GET(basePath, fn.GetAll)
 	} else {
-		a.GET(basePath, e.GetAll)
+		a.GET(basePath, defaults.GetAll)
 	}
 
 	if fn, ok := object.(Get); ok {
 		a.GET(idPath, fn.Get)
 	} else {
-		a.GET(idPath, e.Get)
+		a.GET(idPath, defaults.Get)
 	}
 
 	if fn, ok := object.(Update); ok {
 		a.PUT(idPath, fn.Update)
 	} else {
-		a.PUT(idPath, e.Update)
+		a.PUT(idPath, defaults.Update)
+	}
+
+	if fn, ok := object.(Patch); ok {
+		a.PATCH(idPath, fn.Patch)
+	} else if fn, ok := defaults.(Patch); ok {
+		a.PATCH(idPath, fn.Patch)
 	}
 
 	if fn, ok := object.(Delete); ok {
 		a.DELETE(idPath, fn.Delete)
 	} else {
-		a.DELETE(idPath, e.Delete)
+		a.DELETE(idPath, defaults.Delete)
+	}
+
+	for _, rel := range e.relations {
+		a.GET(idPath+"/"+rel.name, e.relationHandler(rel))
 	}
 }
 
@@ -154,6 +478,40 @@ func (e *entity) Create(c *Context) (any, error) {
 		return nil, err
 	}
 
+	if e.versionColumn != "" {
+		e.setVersion(newEntity, 1)
+		c.SetVersion("1")
+	}
+
+	// new entities cannot be created deleted.
+	if i, ok := e.auditFields[auditDeletedAt]; ok {
+		reflect.ValueOf(newEntity).Elem().Field(i).SetZero()
+	}
+
+	e.setAudit(c, newEntity, auditCreatedAt, auditUpdatedAt, auditCreatedBy, auditUpdatedBy)
+
+	var lastID any
+
+	err = e.run(c, func(db sqlExecutor, tx *sql.Tx) error {
+		if err := callHook(newEntity, func(h BeforeCreate) error { return h.BeforeCreate(c, tx) }); err != nil {
+			return err
+		}
+
+		if lastID, err = e.insert(c, db, newEntity); err != nil {
+			return err
+		}
+
+		return callHook(newEntity, func(h AfterCreate) error { return h.AfterCreate(c, tx) })
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fmt.Sprintf("%s successfully created with id: %v", e.name, lastID), nil
+}
+
+// insert inserts the entity and returns its ID, which is set in the entity when it is auto-incremented.
+func (e *entity) insert(c *Context, db sqlExecutor, newEntity any) (any, error) {
 	fieldNames, fieldValues := e.extractFields(newEntity)
 
 	stmt, err := sql.InsertQuery(c.SQL.Dialect(), e.tableName, fieldNames, fieldValues, e.constraints)
@@ -161,23 +519,25 @@ func (e *entity) Create(c *Context) (any, error) {
 		return nil, err
 	}
 
-	result, err := c.SQL.ExecContext(c, stmt, fieldValues...)
+	result, err := db.ExecContext(c, stmt, fieldValues...)
 	if err != nil {
 		return nil, err
 	}
 
-	var lastID any
+	if !hasAutoIncrementID(e.constraints) { // Check for auto-increment ID
+		return fieldValues[0], nil
+	}
 
-	if hasAutoIncrementID(e.constraints) { // Check for auto-increment ID
-		lastID, err = result.LastInsertId()
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		lastID = fieldValues[0]
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
 	}
 
-	return fmt.Sprintf("%s successfully created with id: %v", e.name, lastID), nil
+	if id := reflect.ValueOf(newEntity).Elem().Field(0); id.CanInt() {
+		id.SetInt(lastID)
+	}
+
+	return lastID, nil
 }
 
 func (e *entity) bindAndValidateEntity(c *Context) (any, error) {
@@ -208,8 +568,8 @@ func (e *entity) extractFields(newEntity any) (fieldNames []string, fieldValues
 		field := e.entityType.Field(i)
 		fieldName := toSnakeCase(field.Name)
 
-		if e.constraints[fieldName].AutoIncrement {
-			continue // Skip auto-increment fields for insertion
+		if e.constraints[fieldName].AutoIncrement || !e.isColumn(i) {
+			continue // Skip auto-increment fields and relations for insertion
 		}
 
 		fieldNames = append(fieldNames, fieldName)
@@ -220,7 +580,12 @@ func (e *entity) extractFields(newEntity any) (fieldNames []string, fieldValues
 }
 
 func (e *entity) GetAll(c *Context) (any, error) {
-	query := sql.SelectQuery(c.SQL.Dialect(), e.tableName)
+	relations, err := e.includedRelations(c)
+	if err != nil {
+		return nil, err
+	}
+
+	query := sql.SelectQuery(c.SQL.Dialect(), e.tableName, e.notDeleted(c)...)
 
 	rows, err := c.SQL.QueryContext(c, query)
 	if err != nil || rows.Err() != nil {
@@ -229,29 +594,30 @@ func (e *entity) GetAll(c *Context) (any, error) {
 
 	defer rows.Close()
 
-	dest := make([]any, e.entityType.NumField())
-	val := reflect.New(e.entityType).Elem()
+	entities, err := e.scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
 
-	for i := 0; i < e.entityType.NumField(); i++ {
-		dest[i] = val.Field(i).Addr().Interface()
+	for _, rel := range relations {
+		if err := e.loadRelation(c, rel, entities); err != nil {
+			return nil, err
+		}
 	}
 
+	return entities, nil
+}
+
+func (e *entity) scanRows(rows *gosql.Rows) ([]any, error) {
 	var entities []any
 
 	for rows.Next() {
 		newEntity := reflect.New(e.entityType).Interface()
-		newVal := reflect.ValueOf(newEntity).Elem()
 
-		err = rows.Scan(dest...)
-		if err != nil {
+		if err := rows.Scan(e.columnPointers(newEntity)...); err != nil {
 			return nil, err
 		}
 
-		for i := 0; i < e.entityType.NumField(); i++ {
-			scanVal := reflect.ValueOf(dest[i]).Elem().Interface()
-			newVal.Field(i).Set(reflect.ValueOf(scanVal))
-		}
-
 		entities = append(entities, newEntity)
 	}
 
@@ -259,21 +625,37 @@ func (e *entity) GetAll(c *Context) (any, error) {
 }
 
 func (e *entity) Get(c *Context) (any, error) {
-	newEntity := reflect.New(e.entityType).Interface()
-	id := c.Request.PathParam("id")
-
-	query := sql.SelectByQuery(c.SQL.Dialect(), e.tableName, e.primaryKey)
+	relations, err := e.includedRelations(c)
+	if err != nil {
+		return nil, err
+	}
 
-	row := c.SQL.QueryRowContext(c, query, id)
+	newEntity, err := e.fetch(c, c.SQL, c.Request.PathParam("id"))
+	if err != nil {
+		return nil, err
+	}
 
-	dest := make([]any, e.entityType.NumField())
-	val := reflect.ValueOf(newEntity).Elem()
+	for _, rel := range relations {
+		if err := e.loadRelation(c, rel, []any{newEntity}); err != nil {
+			return nil, err
+		}
+	}
 
-	for i := 0; i < val.NumField(); i++ {
-		dest[i] = val.Field(i).Addr().Interface()
+	if e.versionColumn != "" {
+		c.SetVersion(strconv.FormatInt(e.version(newEntity), 10))
 	}
 
-	err := row.Scan(dest...)
+	return newEntity, nil
+}
+
+func (e *entity) fetch(c *Context, db sqlExecutor, id string) (any, error) {
+	newEntity := reflect.New(e.entityType).Interface()
+
+	query := sql.SelectByQuery(c.SQL.Dialect(), e.tableName, e.primaryKey, e.notDeleted(c)...)
+
+	row := db.QueryRowContext(c, query, id)
+
+	err := row.Scan(e.columnPointers(newEntity)...)
 	if err != nil {
 		return nil, err
 	}
@@ -290,19 +672,62 @@ func (e *entity) Update(c *Context) (any, error) {
 		return nil, err
 	}
 
-	fieldNames := make([]string, 0, e.entityType.NumField())
-	fieldValues := make([]any, 0, e.entityType.NumField())
+	e.setAudit(c, newEntity, auditUpdatedAt, auditUpdatedBy)
 
-	for i := 0; i < e.entityType.NumField(); i++ {
-		field := e.entityType.Field(i)
+	err = e.run(c, func(db sqlExecutor, tx *sql.Tx) error {
+		if err := callHook(newEntity, func(h BeforeUpdate) error { return h.BeforeUpdate(c, tx) }); err != nil {
+			return err
+		}
 
-		fieldNames = append(fieldNames, toSnakeCase(field.Name))
-		fieldValues = append(fieldValues, reflect.ValueOf(newEntity).Elem().Field(i).Interface())
+		if e.versionColumn != "" {
+			err = e.updateVersioned(c, db, newEntity, id)
+		} else {
+			err = e.update(c, db, newEntity, id)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return callHook(newEntity, func(h AfterUpdate) error { return h.AfterUpdate(c, tx) })
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	stmt := sql.UpdateByQuery(c.SQL.Dialect(), e.tableName, fieldNames[1:], e.primaryKey)
+	return fmt.Sprintf("%s successfully updated with id: %s", e.name, id), nil
+}
+
+// Patch updates the columns of an entity supplied by the JSON Merge Patch or the JSON Patch in the body of the
+// request.
+func (e *entity) Patch(c *Context) (any, error) {
+	id := c.PathParam(e.primaryKey)
+
+	err := e.run(c, func(db sqlExecutor, tx *sql.Tx) error {
+		current, err := e.fetch(c, db, id)
+		if errors.Is(err, gosql.ErrNoRows) {
+			return gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
+		}
 
-	_, err = c.SQL.ExecContext(c, stmt, append(fieldValues[1:], id)...)
+		if err != nil {
+			return err
+		}
+
+		patched, supplied, err := e.applyPatch(c, current)
+		if err != nil {
+			return err
+		}
+
+		if err := callHook(patched, func(h BeforeUpdate) error { return h.BeforeUpdate(c, tx) }); err != nil {
+			return err
+		}
+
+		if err := e.patch(c, db, current, patched, supplied, id); err != nil {
+			return err
+		}
+
+		return callHook(patched, func(h AfterUpdate) error { return h.AfterUpdate(c, tx) })
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -310,24 +735,329 @@ func (e *entity) Update(c *Context) (any, error) {
 	return fmt.Sprintf("%s successfully updated with id: %s", e.name, id), nil
 }
 
+// patch updates the supplied columns of an entity, along with its updated_at and updated_by columns. The version
+// of a versioned entity is checked as for Update, the version of the body being the one supplied by the patch.
+func (e *entity) patch(c *Context, db sqlExecutor, current, patched any, supplied []int, id string) error {
+	e.setAudit(c, patched, auditUpdatedAt, auditUpdatedBy)
+
+	var (
+		fieldNames  []string
+		fieldValues []any
+	)
+
+	for i := 0; i < e.entityType.NumField(); i++ {
+		if !e.isUpdatable(i) || !(slices.Contains(supplied, i) || e.isAudit(i, auditUpdatedAt, auditUpdatedBy)) {
+			continue
+		}
+
+		fieldNames = append(fieldNames, toSnakeCase(e.entityType.Field(i).Name))
+		fieldValues = append(fieldValues, reflect.ValueOf(patched).Elem().Field(i).Interface())
+	}
+
+	if e.versionColumn == "" {
+		if len(fieldNames) == 0 {
+			return nil
+		}
+
+		_, err := db.ExecContext(c, sql.UpdateByQuery(c.SQL.Dialect(), e.tableName, fieldNames, e.primaryKey,
+			e.notDeleted(c)...), append(fieldValues, id)...)
+
+		return err
+	}
+
+	body := reflect.New(e.entityType).Interface()
+	if slices.Contains(supplied, e.versionIndex) {
+		body = patched
+	}
+
+	version := e.version(current)
+
+	if err := e.checkVersion(c, body, version); err != nil {
+		return err
+	}
+
+	stmt := sql.UpdateByVersionQuery(c.SQL.Dialect(), e.tableName, fieldNames, e.primaryKey, e.versionColumn,
+		e.notDeleted(c)...)
+
+	result, err := db.ExecContext(c, stmt, append(fieldValues, id, version)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return gofrHTTP.ErrorPreconditionFailed{}
+	}
+
+	c.SetVersion(strconv.FormatInt(version+1, 10))
+
+	return nil
+}
+
+// isAudit reports whether a field is one of the given audit columns.
+func (e *entity) isAudit(index int, audits ...string) bool {
+	for _, audit := range audits {
+		if i, ok := e.auditFields[audit]; ok && i == index {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *entity) update(c *Context, db sqlExecutor, newEntity any, id string) error {
+	fieldNames, fieldValues := e.updatedFields(newEntity)
+
+	stmt := sql.UpdateByQuery(c.SQL.Dialect(), e.tableName, fieldNames, e.primaryKey, e.notDeleted(c)...)
+
+	result, err := db.ExecContext(c, stmt, append(fieldValues, id)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil || rowsAffected > 0 {
+		return err
+	}
+
+	// no row is affected for a missing or soft deleted entity, but also by MySQL for an entity left unchanged.
+	_, err = e.fetch(c, db, id)
+	if errors.Is(err, gosql.ErrNoRows) {
+		return gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
+	}
+
+	return err
+}
+
+// updatedFields returns the columns the default Update handler sets, with their values in the entity.
+func (e *entity) updatedFields(newEntity any) (fieldNames []string, fieldValues []any) {
+	fieldNames = make([]string, 0, e.entityType.NumField())
+	fieldValues = make([]any, 0, e.entityType.NumField())
+
+	for i := 0; i < e.entityType.NumField(); i++ {
+		if !e.isUpdatable(i) {
+			continue
+		}
+
+		fieldNames = append(fieldNames, toSnakeCase(e.entityType.Field(i).Name))
+		fieldValues = append(fieldValues, reflect.ValueOf(newEntity).Elem().Field(i).Interface())
+	}
+
+	return fieldNames, fieldValues
+}
+
 func (e *entity) Delete(c *Context) (any, error) {
 	id := c.PathParam("id")
 
-	query := sql.DeleteByQuery(c.SQL.Dialect(), e.tableName, e.primaryKey)
-
-	result, err := c.SQL.ExecContext(c, query, id)
+	err := e.run(c, func(db sqlExecutor, tx *sql.Tx) error {
+		return e.delete(c, db, tx, id)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return fmt.Sprintf("%s successfully deleted with id: %v", e.name, id), nil
+}
+
+// delete deletes the entity, or soft deletes it if it has a deleted_at field. The entity is read first when it has
+// delete hooks, which are called on it.
+func (e *entity) delete(c *Context, db sqlExecutor, tx *sql.Tx, id string) error {
+	var deleted any
+
+	switch {
+	case e.hasDeleteHooks():
+		current, err := e.fetch(c, db, id)
+		if errors.Is(err, gosql.ErrNoRows) {
+			return gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		deleted = current
+	case e.softDeleteColumn != "":
+		deleted = reflect.New(e.entityType).Interface()
+	}
+
+	if err := callHook(deleted, func(h BeforeDelete) error { return h.BeforeDelete(c, tx) }); err != nil {
+		return err
+	}
+
+	query, args, versioned, err := e.deleteQuery(c, db, deleted, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.ExecContext(c, query, args...)
+	if err != nil {
+		return err
+	}
+
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if rowsAffected == 0 && versioned {
+		return gofrHTTP.ErrorPreconditionFailed{}
 	}
 
 	if rowsAffected == 0 {
-		return nil, errEntityNotFound
+		return gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
 	}
 
-	return fmt.Sprintf("%s successfully deleted with id: %v", e.name, id), nil
+	return callHook(deleted, func(h AfterDelete) error { return h.AfterDelete(c, tx) })
+}
+
+// deleteQuery returns the statement deleting the entity, which checks the version of a versioned entity against the
+// If-Match header the request must have, and which sets its deleted_at, updated_at and updated_by fields instead for
+// soft deleted entities.
+func (e *entity) deleteQuery(c *Context, db sqlExecutor, deleted any, id string) (query string, args []any,
+	versioned bool, err error) {
+	dialect := c.SQL.Dialect()
+
+	var (
+		fieldNames  []string
+		fieldValues []any
+	)
+
+	if e.softDeleteColumn != "" {
+		e.setAudit(c, deleted, auditDeletedAt, auditUpdatedAt, auditUpdatedBy)
+
+		val := reflect.ValueOf(deleted).Elem()
+
+		for _, audit := range []string{auditDeletedAt, auditUpdatedAt, auditUpdatedBy} {
+			if i, ok := e.auditFields[audit]; ok {
+				fieldNames = append(fieldNames, toSnakeCase(e.entityType.Field(i).Name))
+				fieldValues = append(fieldValues, val.Field(i).Interface())
+			}
+		}
+	}
+
+	if e.versionColumn == "" {
+		if e.softDeleteColumn != "" {
+			query = sql.UpdateByQuery(dialect, e.tableName, fieldNames, e.primaryKey, e.notDeleted(c)...)
+		} else {
+			query = sql.DeleteByQuery(dialect, e.tableName, e.primaryKey)
+		}
+
+		return query, append(fieldValues, id), false, nil
+	}
+
+	current, err := e.currentVersion(c, db, id)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	if c.header("If-Match") == "" {
+		c.SetVersion(strconv.FormatInt(current, 10))
+
+		return "", nil, false, gofrHTTP.ErrorPreconditionRequired{}
+	}
+
+	if err = c.CheckVersion(strconv.FormatInt(current, 10)); err != nil {
+		return "", nil, false, err
+	}
+
+	if e.softDeleteColumn != "" {
+		query = sql.UpdateByVersionQuery(dialect, e.tableName, fieldNames, e.primaryKey, e.versionColumn,
+			e.notDeleted(c)...)
+	} else {
+		query = sql.DeleteByVersionQuery(dialect, e.tableName, e.primaryKey, e.versionColumn)
+	}
+
+	return query, append(fieldValues, id, current), true, nil
+}
+
+// updateVersioned updates an entity having a version field, provided the version the client based its changes on,
+// given by the If-Match header or the version field of the body, is the current one.
+func (e *entity) updateVersioned(c *Context, db sqlExecutor, newEntity any, id string) error {
+	current, err := e.currentVersion(c, db, id)
+	if err != nil {
+		return err
+	}
+
+	if err = e.checkVersion(c, newEntity, current); err != nil {
+		return err
+	}
+
+	fieldNames, fieldValues := e.updatedFields(newEntity)
+
+	stmt := sql.UpdateByVersionQuery(c.SQL.Dialect(), e.tableName, fieldNames, e.primaryKey, e.versionColumn,
+		e.notDeleted(c)...)
+
+	result, err := db.ExecContext(c, stmt, append(fieldValues, id, current)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// the entity was modified between reading its version and updating it.
+	if rowsAffected == 0 {
+		return gofrHTTP.ErrorPreconditionFailed{}
+	}
+
+	c.SetVersion(strconv.FormatInt(current+1, 10))
+
+	return nil
+}
+
+func (e *entity) checkVersion(c *Context, newEntity any, current int64) error {
+	if c.header("If-Match") != "" {
+		return c.CheckVersion(strconv.FormatInt(current, 10))
+	}
+
+	c.SetVersion(strconv.FormatInt(current, 10))
+
+	switch e.version(newEntity) {
+	case 0:
+		return gofrHTTP.ErrorPreconditionRequired{}
+	case current:
+		return nil
+	default:
+		return gofrHTTP.ErrorPreconditionFailed{}
+	}
+}
+
+func (e *entity) currentVersion(c *Context, db sqlExecutor, id string) (int64, error) {
+	current, err := e.fetch(c, db, id)
+	if errors.Is(err, gosql.ErrNoRows) {
+		return 0, gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return e.version(current), nil
+}
+
+func (e *entity) version(entity any) int64 {
+	field := reflect.ValueOf(entity).Elem().Field(e.versionIndex)
+
+	if field.CanInt() {
+		return field.Int()
+	}
+
+	return int64(field.Uint()) //nolint:gosec // versions are incremented from 1 and stay far below math.MaxInt64.
+}
+
+func (e *entity) setVersion(entity any, version int64) {
+	field := reflect.ValueOf(entity).Elem().Field(e.versionIndex)
+
+	if field.CanInt() {
+		field.SetInt(version)
+		return
+	}
+
+	field.SetUint(uint64(version))
 }
@@ -2,6 +2,7 @@ package gofr
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
@@ -11,6 +12,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gorilla/mux"
@@ -21,6 +23,7 @@ import (
 	"gofr.dev/pkg/gofr/container"
 	gofrSql "gofr.dev/pkg/gofr/datasource/sql"
 	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/middleware"
 )
 
 var (
@@ -592,7 +595,7 @@ func Test_DeleteHandler(t *testing.T) {
 				id:           "3",
 				mockResp:     sqlmock.NewResult(0, 0),
 				mockErr:      nil,
-				expectedErr:  errEntityNotFound,
+				expectedErr:  gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "3"},
 				expectedResp: nil,
 			},
 		}
@@ -612,3 +615,472 @@ func Test_DeleteHandler(t *testing.T) {
 		}
 	}
 }
+
+type versionedEntity struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version" sql:"version"`
+}
+
+func createVersionedTestContext(method, id, ifMatch string, body []byte, cont *container.Container) (*Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/versioned/"+id, bytes.NewBuffer(body))
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+	req.Header.Set("Content-Type", "application/json")
+
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	w := httptest.NewRecorder()
+
+	return newContext(gofrHTTP.NewResponderWithEncoders(w, req, nil), gofrHTTP.NewRequest(req), cont), w
+}
+
+func Test_scanEntity_Version(t *testing.T) {
+	e, err := scanEntity(&versionedEntity{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "version", e.versionColumn)
+	assert.Equal(t, 2, e.versionIndex)
+
+	type stringVersion struct {
+		ID      int
+		Version string `sql:"version"`
+	}
+
+	_, err = scanEntity(&stringVersion{})
+	require.ErrorIs(t, err, errInvalidVersion)
+
+	type twoVersions struct {
+		ID int
+		A  int `sql:"version"`
+		B  int `sql:"version"`
+	}
+
+	_, err = scanEntity(&twoVersions{})
+	require.ErrorIs(t, err, errInvalidVersion)
+}
+
+func Test_VersionedHandlers(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	e, err := scanEntity(&versionedEntity{})
+	require.NoError(t, err)
+
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	c.SQL = db
+
+	t.Cleanup(func() { db.Close() })
+
+	selectQuery := "SELECT * FROM `versioned_entity` WHERE `id`=?"
+	updateQuery := "UPDATE `versioned_entity` SET `name`=?, `version`=`version`+1 WHERE `id`=? AND `version`=?"
+	deleteQuery := "DELETE FROM `versioned_entity` WHERE `id`=? AND `version`=?"
+	currentRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "gofr", 3)
+	}
+
+	t.Run("create starts at version 1", func(t *testing.T) {
+		ctx, w := createVersionedTestContext(http.MethodPost, "", "", []byte(`{"id":1,"name":"gofr","version":7}`), c)
+
+		mock.ExpectExec("INSERT INTO `versioned_entity` (`id`, `name`, `version`) VALUES (?, ?, ?)").
+			WithArgs(1, "gofr", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		_, err := e.Create(ctx)
+		require.NoError(t, err)
+
+		ctx.responder.Respond(nil, nil)
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("get sends the version as etag", func(t *testing.T) {
+		ctx, w := createVersionedTestContext(http.MethodGet, "1", "", nil, c)
+
+		mock.ExpectQuery(selectQuery).WithArgs("1").WillReturnRows(currentRow())
+
+		resp, err := e.Get(ctx)
+		require.NoError(t, err)
+
+		ctx.responder.Respond(resp, err)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	tests := []struct {
+		desc        string
+		ifMatch     string
+		body        string
+		expectExec  bool
+		rows        int64
+		expectedErr error
+		expectedTag string
+	}{
+		{desc: "matching if-match", ifMatch: `"3"`, body: `{"name":"new"}`, expectExec: true, rows: 1, expectedTag: `"4"`},
+		{desc: "matching body version", body: `{"name":"new","version":3}`, expectExec: true, rows: 1, expectedTag: `"4"`},
+		{desc: "stale if-match", ifMatch: `"2"`, body: `{"name":"new"}`, expectedErr: gofrHTTP.ErrorPreconditionFailed{},
+			expectedTag: `"3"`},
+		{desc: "stale body version", body: `{"name":"new","version":2}`, expectedErr: gofrHTTP.ErrorPreconditionFailed{},
+			expectedTag: `"3"`},
+		{desc: "missing version", body: `{"name":"new"}`, expectedErr: gofrHTTP.ErrorPreconditionRequired{}, expectedTag: `"3"`},
+		{desc: "concurrent modification", ifMatch: `"3"`, body: `{"name":"new"}`, expectExec: true, rows: 0,
+			expectedErr: gofrHTTP.ErrorPreconditionFailed{}, expectedTag: `"3"`},
+	}
+
+	for i, tc := range tests {
+		t.Run("update "+tc.desc, func(t *testing.T) {
+			ctx, w := createVersionedTestContext(http.MethodPut, "1", tc.ifMatch, []byte(tc.body), c)
+
+			mock.ExpectQuery(selectQuery).WithArgs("1").WillReturnRows(currentRow())
+
+			if tc.expectExec {
+				mock.ExpectExec(updateQuery).WithArgs("new", "1", int64(3)).WillReturnResult(sqlmock.NewResult(0, tc.rows))
+			}
+
+			resp, err := e.Update(ctx)
+			ctx.responder.Respond(resp, err)
+
+			assert.Equal(t, tc.expectedErr, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, tc.expectedTag, w.Header().Get("ETag"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		})
+	}
+
+	t.Run("update of missing entity", func(t *testing.T) {
+		ctx, _ := createVersionedTestContext(http.MethodPut, "9", `"1"`, []byte(`{"name":"new"}`), c)
+
+		mock.ExpectQuery(selectQuery).WithArgs("9").WillReturnError(sql.ErrNoRows)
+
+		_, err := e.Update(ctx)
+		assert.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "9"}, err)
+	})
+
+	t.Run("delete with matching if-match", func(t *testing.T) {
+		ctx, _ := createVersionedTestContext(http.MethodDelete, "1", `"3"`, nil, c)
+
+		mock.ExpectQuery(selectQuery).WithArgs("1").WillReturnRows(currentRow())
+		mock.ExpectExec(deleteQuery).WithArgs("1", int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		resp, err := e.Delete(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "versionedEntity successfully deleted with id: 1", resp)
+	})
+
+	t.Run("delete with stale if-match", func(t *testing.T) {
+		ctx, _ := createVersionedTestContext(http.MethodDelete, "1", `"2"`, nil, c)
+
+		mock.ExpectQuery(selectQuery).WithArgs("1").WillReturnRows(currentRow())
+
+		_, err := e.Delete(ctx)
+		assert.Equal(t, gofrHTTP.ErrorPreconditionFailed{}, err)
+	})
+
+	t.Run("delete without if-match", func(t *testing.T) {
+		ctx, w := createVersionedTestContext(http.MethodDelete, "1", "", nil, c)
+
+		mock.ExpectQuery(selectQuery).WithArgs("1").WillReturnRows(currentRow())
+
+		resp, err := e.Delete(ctx)
+		ctx.responder.Respond(resp, err)
+
+		assert.Equal(t, gofrHTTP.ErrorPreconditionRequired{}, err)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+type auditedEntity struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"createdAt" sql:"created_at"`
+	UpdatedAt time.Time  `json:"updatedAt" sql:"updated_at"`
+	CreatedBy string     `json:"createdBy" sql:"created_by"`
+	UpdatedBy *string    `json:"updatedBy" sql:"updated_by"`
+	DeletedAt *time.Time `json:"deletedAt" sql:"deleted_at"`
+}
+
+func createAuthenticatedTestContext(method, id, body string, cont *container.Container) *Context {
+	req := httptest.NewRequest(method, "/audited/"+id, bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.WithValue(req.Context(), middleware.Username, "alice"))
+
+	return newContext(gofrHTTP.NewResponder(httptest.NewRecorder(), method), gofrHTTP.NewRequest(req), cont)
+}
+
+func Test_scanEntity_Audit(t *testing.T) {
+	e, err := scanEntity(&auditedEntity{})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"created_at": 2, "updated_at": 3, "created_by": 4, "updated_by": 5, "deleted_at": 6},
+		e.auditFields)
+	assert.Equal(t, "deleted_at", e.softDeleteColumn)
+	assert.False(t, e.hooks)
+
+	type notNullableDeletedAt struct {
+		ID        int
+		DeletedAt time.Time `sql:"deleted_at"`
+	}
+
+	type intCreatedBy struct {
+		ID        int
+		CreatedBy int `sql:"created_by"`
+	}
+
+	type twoCreatedAt struct {
+		ID int
+		A  time.Time `sql:"created_at"`
+		B  time.Time `sql:"created_at"`
+	}
+
+	type twoAudits struct {
+		ID int
+		A  time.Time `sql:"created_at,updated_at"`
+	}
+
+	testCases := []struct {
+		desc   string
+		object any
+		err    error
+	}{
+		{"deleted_at not nullable", &notNullableDeletedAt{}, errInvalidAudit},
+		{"created_by not a string", &intCreatedBy{}, errInvalidAudit},
+		{"created_at twice", &twoCreatedAt{}, errInvalidAudit},
+		{"two audit columns", &twoAudits{}, errInvalidSQLTag},
+	}
+
+	for i, tc := range testCases {
+		_, err := scanEntity(tc.object)
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func Test_AuditedHandlers(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	e, err := scanEntity(&auditedEntity{})
+	require.NoError(t, err)
+
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	c.SQL = db
+
+	t.Cleanup(func() { db.Close() })
+
+	t.Run("create sets the audit columns", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPost, "", `{"id":1,"name":"gofr","createdBy":"mallory",`+
+			`"deletedAt":"2025-01-01T00:00:00Z"}`, c)
+
+		mock.ExpectExec("INSERT INTO `audited_entity` (`id`, `name`, `created_at`, `updated_at`, `created_by`, `updated_by`, "+
+			"`deleted_at`) VALUES (?, ?, ?, ?, ?, ?, ?)").
+			WithArgs(1, "gofr", sqlmock.AnyArg(), sqlmock.AnyArg(), "alice", "alice", nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		resp, err := e.Create(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "auditedEntity successfully created with id: 1", resp)
+	})
+
+	t.Run("get all excludes soft deleted rows", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "", "", c)
+
+		mock.ExpectQuery("SELECT * FROM `audited_entity` WHERE `deleted_at` IS NULL").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at", "created_by", "updated_by",
+				"deleted_at"}))
+
+		_, err := e.GetAll(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("get excludes soft deleted rows", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "1", "", c)
+
+		mock.ExpectQuery("SELECT * FROM `audited_entity` WHERE `id`=? AND `deleted_at` IS NULL").WithArgs("1").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := e.Get(ctx)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("update keeps the creation columns", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPut, "1", `{"name":"new","createdBy":"mallory"}`, c)
+
+		mock.ExpectExec("UPDATE `audited_entity` SET `name`=?, `updated_at`=?, `updated_by`=? WHERE `id`=? "+
+			"AND `deleted_at` IS NULL").
+			WithArgs("new", sqlmock.AnyArg(), "alice", "1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := e.Update(ctx)
+		require.NoError(t, err)
+	})
+
+	updateQuery := "UPDATE `audited_entity` SET `name`=?, `updated_at`=?, `updated_by`=? WHERE `id`=? AND `deleted_at` IS NULL"
+	selectQuery := "SELECT * FROM `audited_entity` WHERE `id`=? AND `deleted_at` IS NULL"
+
+	t.Run("update of soft deleted entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPut, "1", `{"name":"new"}`, c)
+
+		mock.ExpectExec(updateQuery).WithArgs("new", sqlmock.AnyArg(), "alice", "1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WithArgs("1").WillReturnError(sql.ErrNoRows)
+
+		_, err := e.Update(ctx)
+		assert.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "1"}, err)
+	})
+
+	t.Run("update leaving the entity unchanged", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPut, "1", `{"name":"new"}`, c)
+
+		mock.ExpectExec(updateQuery).WithArgs("new", sqlmock.AnyArg(), "alice", "1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at", "created_by", "updated_by",
+				"deleted_at"}).AddRow(1, "new", time.Now(), time.Now(), "alice", "alice", nil))
+
+		_, err := e.Update(ctx)
+		require.NoError(t, err)
+	})
+
+	deleteQuery := "UPDATE `audited_entity` SET `deleted_at`=?, `updated_at`=?, `updated_by`=? WHERE `id`=? " +
+		"AND `deleted_at` IS NULL"
+
+	t.Run("delete soft deletes once", func(t *testing.T) {
+		mock.ExpectExec(deleteQuery).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "alice", "1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteQuery).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "alice", "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		resp, err := e.Delete(createAuthenticatedTestContext(http.MethodDelete, "1", "", c))
+		require.NoError(t, err)
+		assert.Equal(t, "auditedEntity successfully deleted with id: 1", resp)
+
+		_, err = e.Delete(createAuthenticatedTestContext(http.MethodDelete, "1", "", c))
+		assert.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "1"}, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+type hookedEntity struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (h *hookedEntity) log(c *Context, tx *gofrSql.Tx, event string) error {
+	_, err := tx.ExecContext(c, "INSERT INTO `hook_log` (`event`, `name`) VALUES (?, ?)", event, h.Name)
+
+	return err
+}
+
+func (h *hookedEntity) BeforeCreate(*Context, *gofrSql.Tx) error {
+	if h.Name == "" {
+		return gofrHTTP.ErrorMissingParam{Params: []string{"name"}}
+	}
+
+	return nil
+}
+
+func (h *hookedEntity) AfterCreate(c *Context, tx *gofrSql.Tx) error {
+	return h.log(c, tx, "created")
+}
+
+func (h *hookedEntity) BeforeUpdate(c *Context, tx *gofrSql.Tx) error {
+	return h.log(c, tx, "updating")
+}
+
+func (h *hookedEntity) AfterUpdate(c *Context, tx *gofrSql.Tx) error {
+	return h.log(c, tx, "updated")
+}
+
+func (h *hookedEntity) BeforeDelete(c *Context, tx *gofrSql.Tx) error {
+	return h.log(c, tx, "deleting")
+}
+
+func (h *hookedEntity) AfterDelete(c *Context, tx *gofrSql.Tx) error {
+	return h.log(c, tx, "deleted")
+}
+
+func Test_HookedHandlers(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	e, err := scanEntity(&hookedEntity{})
+	require.NoError(t, err)
+	assert.True(t, e.hooks)
+
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	c.SQL = db
+
+	t.Cleanup(func() { db.Close() })
+
+	logQuery := "INSERT INTO `hook_log` (`event`, `name`) VALUES (?, ?)"
+
+	t.Run("create calls the hooks in the transaction", func(t *testing.T) {
+		ctx := createTestContext(http.MethodPost, "/hooked", "", []byte(`{"id":1,"name":"gofr"}`), c)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `hooked_entity` (`id`, `name`) VALUES (?, ?)").WithArgs(1, "gofr").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(logQuery).WithArgs("created", "gofr").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		_, err := e.Create(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("create rejected by a hook", func(t *testing.T) {
+		ctx := createTestContext(http.MethodPost, "/hooked", "", []byte(`{"id":2}`), c)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		_, err := e.Create(ctx)
+		assert.Equal(t, gofrHTTP.ErrorMissingParam{Params: []string{"name"}}, err)
+	})
+
+	t.Run("update rolled back when the statement fails", func(t *testing.T) {
+		ctx := createTestContext(http.MethodPut, "/hooked", "1", []byte(`{"name":"new"}`), c)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(logQuery).WithArgs("updating", "new").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE `hooked_entity` SET `name`=? WHERE `id`=?").WithArgs("new", "1").WillReturnError(errMock)
+		mock.ExpectRollback()
+
+		_, err := e.Update(ctx)
+		require.ErrorIs(t, err, errMock)
+	})
+
+	t.Run("update of missing entity skips the after hook", func(t *testing.T) {
+		ctx := createTestContext(http.MethodPut, "/hooked", "9", []byte(`{"name":"new"}`), c)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(logQuery).WithArgs("updating", "new").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE `hooked_entity` SET `name`=? WHERE `id`=?").WithArgs("new", "9").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT * FROM `hooked_entity` WHERE `id`=?").WithArgs("9").WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := e.Update(ctx)
+		assert.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "9"}, err)
+	})
+
+	t.Run("delete calls the hooks on the deleted entity", func(t *testing.T) {
+		ctx := createTestContext(http.MethodDelete, "/hooked", "1", nil, c)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT * FROM `hooked_entity` WHERE `id`=?").WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "gofr"))
+		mock.ExpectExec(logQuery).WithArgs("deleting", "gofr").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("DELETE FROM `hooked_entity` WHERE `id`=?").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(logQuery).WithArgs("deleted", "gofr").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		_, err := e.Delete(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete of missing entity", func(t *testing.T) {
+		ctx := createTestContext(http.MethodDelete, "/hooked", "9", nil, c)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT * FROM `hooked_entity` WHERE `id`=?").WithArgs("9").WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := e.Delete(ctx)
+		assert.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "9"}, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
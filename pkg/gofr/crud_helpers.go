@@ -1,9 +1,11 @@
 package gofr
 
 import (
+	gosql "database/sql"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"gofr.dev/pkg/gofr/datasource/sql"
 )
@@ -24,6 +26,17 @@ func getRestPath(object any, structName string) string {
 	return strings.ToLower(structName)
 }
 
+// isIntegerKind reports whether a field can hold the version of an entity.
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
 func hasAutoIncrementID(constraints map[string]sql.FieldConstraints) bool {
 	for _, constraint := range constraints {
 		if constraint.AutoIncrement {
@@ -47,11 +60,30 @@ func parseSQLTag(inputTags reflect.StructTag) (sql.FieldConstraints, error) {
 	for _, tag := range tags {
 		tag = strings.ToLower(tag) // Convert to lowercase for case-insensitivity
 
+		// relations may name their foreign key, e.g. has_many=owner_id.
+		if name, foreignKey, _ := strings.Cut(tag, "="); name == relationHasMany || name == relationBelongsTo {
+			if constraints.Relation != "" {
+				return constraints, fmt.Errorf("%w: %s", errInvalidSQLTag, tag)
+			}
+
+			constraints.Relation, constraints.ForeignKey = name, foreignKey
+
+			continue
+		}
+
 		switch tag {
 		case "auto_increment":
 			constraints.AutoIncrement = true
 		case "not_null":
 			constraints.NotNull = true
+		case "version":
+			constraints.Version = true
+		case auditCreatedAt, auditUpdatedAt, auditCreatedBy, auditUpdatedBy, auditDeletedAt:
+			if constraints.Audit != "" {
+				return constraints, fmt.Errorf("%w: %s", errInvalidSQLTag, tag)
+			}
+
+			constraints.Audit = tag
 		default:
 			return constraints, fmt.Errorf("%w: %s", errInvalidSQLTag, tag)
 		}
@@ -60,6 +92,70 @@ func parseSQLTag(inputTags reflect.StructTag) (sql.FieldConstraints, error) {
 	return constraints, nil
 }
 
+// isAuditType reports whether a field can hold the values of an audit column: a time for the timestamps, which must
+// be nullable for deleted_at, and a string for the users.
+func isAuditType(t reflect.Type, audit string) bool {
+	switch audit {
+	case auditCreatedBy, auditUpdatedBy:
+		return t == reflect.TypeFor[string]() || t == reflect.TypeFor[*string]() || t == reflect.TypeFor[gosql.NullString]()
+	case auditDeletedAt:
+		return t == reflect.TypeFor[*time.Time]() || t == reflect.TypeFor[gosql.NullTime]()
+	default:
+		return t == reflect.TypeFor[time.Time]() || t == reflect.TypeFor[*time.Time]() || t == reflect.TypeFor[gosql.NullTime]()
+	}
+}
+
+func setAuditTime(field reflect.Value, now time.Time) {
+	switch v := field.Addr().Interface().(type) {
+	case *time.Time:
+		*v = now
+	case **time.Time:
+		*v = &now
+	case *gosql.NullTime:
+		*v = gosql.NullTime{Time: now, Valid: true}
+	}
+}
+
+// setAuditUser sets a field of a created_by or updated_by column, which is null when the request is not
+// authenticated if the field is nullable.
+func setAuditUser(field reflect.Value, user string) {
+	switch v := field.Addr().Interface().(type) {
+	case *string:
+		*v = user
+	case **string:
+		if user == "" {
+			*v = nil
+		} else {
+			*v = &user
+		}
+	case *gosql.NullString:
+		*v = gosql.NullString{String: user, Valid: user != ""}
+	}
+}
+
+// requestUser returns the user stored in the created_by and updated_by columns: the username of basic auth, or
+// the subject of the JWT claims of OAuth.
+func requestUser(c *Context) string {
+	info := c.GetAuthInfo()
+
+	if username := info.GetUsername(); username != "" {
+		return username
+	}
+
+	subject, _ := info.GetClaims().GetSubject()
+
+	return subject
+}
+
+// callHook calls the lifecycle hook H of the entity, if it implements it.
+func callHook[H any](entity any, call func(hook H) error) error {
+	if hook, ok := entity.(H); ok {
+		return call(hook)
+	}
+
+	return nil
+}
+
 func toSnakeCase(str string) string {
 	diff := 'a' - 'A'
 	length := len(str)
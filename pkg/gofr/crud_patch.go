@@ -0,0 +1,472 @@
+package gofr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+const contentTypeJSONPatch = "application/json-patch+json"
+
+var (
+	errInvalidPatchPath = errors.New("invalid JSON pointer")
+	errMissingPatchPath = errors.New("no value at JSON pointer")
+	errFailedPatchTest  = errors.New("test operation failed")
+	errUnknownPatchOp   = errors.New("unknown patch operation")
+)
+
+// patchOperation is an operation of a JSON Patch, as defined by RFC 6902.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyPatch applies the patch in the body of the request to a copy of the entity: a JSON Patch for the
+// application/json-patch+json content type, and a JSON Merge Patch otherwise. It returns the patched entity and the
+// indices of the column fields supplied by the patch.
+func (e *entity) applyPatch(c *Context, current any) (patched any, supplied []int, err error) {
+	var patch json.RawMessage
+
+	if err = c.Bind(&patch); err != nil {
+		return nil, nil, err
+	}
+
+	body, err := json.Marshal(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, _ := decodeJSON(body)
+
+	var members map[string]bool
+
+	contentType, _, _ := strings.Cut(c.header("Content-Type"), ";")
+
+	if strings.TrimSpace(contentType) == contentTypeJSONPatch {
+		doc, members, err = applyJSONPatch(doc, patch)
+	} else {
+		doc, members, err = applyMergePatch(doc, patch)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patchedDoc, ok := doc.(map[string]any)
+	if !ok {
+		return nil, nil, gofrHTTP.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	supplied = e.suppliedFields(patchedDoc, members)
+
+	if body, err = json.Marshal(patchedDoc); err != nil {
+		return nil, nil, err
+	}
+
+	patched = reflect.New(e.entityType).Interface()
+
+	if err = json.Unmarshal(body, patched); err != nil {
+		return nil, nil, err
+	}
+
+	return patched, supplied, nil
+}
+
+// suppliedFields returns the indices of the column fields supplied by the members of a patch, which are matched to
+// the fields case-insensitively, as encoding/json does. The members named differently from their field are renamed
+// in doc, for their values to replace the current ones. The fields tagged json:"-" are never supplied.
+func (e *entity) suppliedFields(doc map[string]any, members map[string]bool) []int {
+	var supplied []int
+
+	for i := 0; i < e.entityType.NumField(); i++ {
+		field := e.entityType.Field(i)
+		if field.Tag.Get("json") == "-" || !e.isColumn(i) {
+			continue
+		}
+
+		name := storedFieldName(field, "json", nil)
+
+		// the members of a patch replacing the whole document are all supplied.
+		if members[""] {
+			supplied = append(supplied, i)
+
+			continue
+		}
+
+		for member := range members {
+			if !strings.EqualFold(member, name) {
+				continue
+			}
+
+			supplied = append(supplied, i)
+
+			if member != name {
+				renameMember(doc, member, name)
+			}
+
+			break
+		}
+	}
+
+	return supplied
+}
+
+// renameMember moves the value of a member to the member name, which is removed along with the member when the
+// patch removed it.
+func renameMember(doc map[string]any, member, name string) {
+	value, ok := doc[member]
+	if !ok {
+		delete(doc, name)
+
+		return
+	}
+
+	doc[name] = value
+	delete(doc, member)
+}
+
+// decodeJSON decodes a JSON value, keeping the numbers as json.Number so that they are not rounded.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+
+	err := decoder.Decode(&value)
+
+	return value, err
+}
+
+// applyMergePatch applies a JSON Merge Patch, as defined by RFC 7396, to a document, and returns the members it
+// supplies.
+func applyMergePatch(doc any, data []byte) (patched any, members map[string]bool, err error) {
+	patch, err := decodeJSON(data)
+	if err != nil {
+		return nil, nil, gofrHTTP.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return nil, nil, gofrHTTP.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	members = make(map[string]bool, len(patchObject))
+
+	for member := range patchObject {
+		members[member] = true
+	}
+
+	return mergePatch(doc, patch), members, nil
+}
+
+func mergePatch(doc, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	target, ok := doc.(map[string]any)
+	if !ok {
+		target = make(map[string]any)
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+
+		target[key] = mergePatch(target[key], value)
+	}
+
+	return target
+}
+
+// applyJSONPatch applies the operations of a JSON Patch, as defined by RFC 6902, to a document, and returns the
+// members of the document they operate on, "" being the whole document. A failing operation is reported as an
+// invalid parameter named by its path.
+func applyJSONPatch(doc any, data []byte) (patched any, members map[string]bool, err error) {
+	var operations []patchOperation
+
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return nil, nil, gofrHTTP.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	members = make(map[string]bool, len(operations))
+
+	for _, op := range operations {
+		if doc, err = applyPatchOperation(doc, op); err != nil {
+			return nil, nil, gofrHTTP.ErrorInvalidParam{Params: []string{op.Path}}
+		}
+
+		members[patchMember(op.Path)] = true
+
+		if op.Op == "move" {
+			members[patchMember(op.From)] = true
+		}
+	}
+
+	return doc, members, nil
+}
+
+// patchMember returns the member of the document a valid JSON pointer refers to, or "" for the whole document.
+func patchMember(pointer string) string {
+	tokens, _ := parsePointer(pointer)
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	return tokens[0]
+}
+
+func applyPatchOperation(doc any, op patchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := decodeJSON(op.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(doc, path, value)
+	case "replace":
+		value, err := decodeJSON(op.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return replaceValue(doc, path, value)
+	case "test":
+		value, err := decodeJSON(op.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if current, err := getValue(doc, path); err != nil || !reflect.DeepEqual(current, value) {
+			return nil, errFailedPatchTest
+		}
+
+		return doc, nil
+	case "remove":
+		doc, _, err = removeValue(doc, path)
+
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+
+		if op.Op == "move" {
+			doc, value, err = removeValue(doc, from)
+		} else {
+			value, err = getValue(doc, from)
+			value = copyJSON(value)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(doc, path, value)
+	default:
+		return nil, errUnknownPatchOp
+	}
+}
+
+// parsePointer splits a JSON pointer, as defined by RFC 6901, into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errInvalidPatchPath
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func getValue(doc any, path []string) (any, error) {
+	for _, token := range path {
+		var err error
+
+		if doc, err = childValue(doc, token); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+func childValue(doc any, token string) (any, error) {
+	switch d := doc.(type) {
+	case map[string]any:
+		value, ok := d[token]
+		if !ok {
+			return nil, errMissingPatchPath
+		}
+
+		return value, nil
+	case []any:
+		i, err := arrayIndex(token, len(d)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		return d[i], nil
+	default:
+		return nil, errMissingPatchPath
+	}
+}
+
+// updateChild returns the document with the child of the given token replaced by the result of update.
+func updateChild(doc any, path []string, update func(child any) (any, error)) (any, error) {
+	child, err := childValue(doc, path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if child, err = update(child); err != nil {
+		return nil, err
+	}
+
+	switch d := doc.(type) {
+	case map[string]any:
+		d[path[0]] = child
+	case []any:
+		i, _ := strconv.Atoi(path[0])
+		d[i] = child
+	}
+
+	return doc, nil
+}
+
+func addValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	if len(path) > 1 {
+		return updateChild(doc, path, func(child any) (any, error) { return addValue(child, path[1:], value) })
+	}
+
+	switch d := doc.(type) {
+	case map[string]any:
+		d[path[0]] = value
+
+		return d, nil
+	case []any:
+		if path[0] == "-" {
+			return append(d, value), nil
+		}
+
+		i, err := arrayIndex(path[0], len(d))
+		if err != nil {
+			return nil, err
+		}
+
+		return append(d[:i], append([]any{value}, d[i:]...)...), nil
+	default:
+		return nil, errMissingPatchPath
+	}
+}
+
+func removeValue(doc any, path []string) (newDoc, removed any, err error) {
+	if len(path) == 0 {
+		return nil, nil, errInvalidPatchPath
+	}
+
+	if len(path) > 1 {
+		newDoc, err = updateChild(doc, path, func(child any) (any, error) {
+			var childErr error
+
+			child, removed, childErr = removeValue(child, path[1:])
+
+			return child, childErr
+		})
+
+		return newDoc, removed, err
+	}
+
+	if removed, err = childValue(doc, path[0]); err != nil {
+		return nil, nil, err
+	}
+
+	if d, ok := doc.(map[string]any); ok {
+		delete(d, path[0])
+
+		return d, removed, nil
+	}
+
+	// the document is an array, as it has a child.
+	d := doc.([]any)
+	i, _ := strconv.Atoi(path[0])
+
+	return append(d[:i], d[i+1:]...), removed, nil
+}
+
+func replaceValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	doc, _, err := removeValue(doc, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return addValue(doc, path, value)
+}
+
+// arrayIndex parses the index of an array element, which is at most maxIndex.
+func arrayIndex(token string, maxIndex int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > maxIndex || (len(token) > 1 && token[0] == '0') {
+		return 0, errInvalidPatchPath
+	}
+
+	return i, nil
+}
+
+func copyJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+
+		for key, child := range v {
+			c[key] = copyJSON(child)
+		}
+
+		return c
+	case []any:
+		c := make([]any, len(v))
+
+		for i, child := range v {
+			c[i] = copyJSON(child)
+		}
+
+		return c
+	default:
+		return v
+	}
+}
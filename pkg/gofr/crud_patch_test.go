@@ -0,0 +1,269 @@
+package gofr
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+	gofrSql "gofr.dev/pkg/gofr/datasource/sql"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+func createPatchTestContext(contentType, id, ifMatch, body string, cont *container.Container) *Context {
+	req := httptest.NewRequest(http.MethodPatch, "/user/"+id, bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+	req.Header.Set("Content-Type", contentType)
+
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	return newContext(gofrHTTP.NewResponder(httptest.NewRecorder(), http.MethodPatch), gofrHTTP.NewRequest(req), cont)
+}
+
+func Test_applyJSONPatch(t *testing.T) {
+	doc := `{"name": "gofr", "tags": ["a", "b"], "address": {"city": "Bengaluru"}}`
+
+	testCases := []struct {
+		desc     string
+		patch    string
+		expected string
+		err      error
+	}{
+		{"replace", `[{"op": "replace", "path": "/name", "value": "go"}]`,
+			`{"name": "go", "tags": ["a", "b"], "address": {"city": "Bengaluru"}}`, nil},
+		{"add to object and array", `[{"op": "add", "path": "/address/zip", "value": "560001"},
+			{"op": "add", "path": "/tags/1", "value": "c"}, {"op": "add", "path": "/tags/-", "value": "d"}]`,
+			`{"name": "gofr", "tags": ["a", "c", "b", "d"], "address": {"city": "Bengaluru", "zip": "560001"}}`, nil},
+		{"remove", `[{"op": "remove", "path": "/tags/0"}, {"op": "remove", "path": "/address"}]`,
+			`{"name": "gofr", "tags": ["b"]}`, nil},
+		{"move and copy", `[{"op": "move", "from": "/address/city", "path": "/city"},
+			{"op": "copy", "from": "/tags", "path": "/labels"}]`,
+			`{"name": "gofr", "tags": ["a", "b"], "labels": ["a", "b"], "address": {}, "city": "Bengaluru"}`, nil},
+		{"escaped pointer", `[{"op": "add", "path": "/a~1b~0c", "value": 1}]`,
+			`{"name": "gofr", "tags": ["a", "b"], "address": {"city": "Bengaluru"}, "a/b~c": 1}`, nil},
+		{"successful test", `[{"op": "test", "path": "/tags/1", "value": "b"}]`, doc, nil},
+		{"failed test", `[{"op": "test", "path": "/name", "value": "go"}]`, "",
+			gofrHTTP.ErrorInvalidParam{Params: []string{"/name"}}},
+		{"missing path", `[{"op": "replace", "path": "/age", "value": 1}]`, "",
+			gofrHTTP.ErrorInvalidParam{Params: []string{"/age"}}},
+		{"index out of range", `[{"op": "add", "path": "/tags/3", "value": "c"}]`, "",
+			gofrHTTP.ErrorInvalidParam{Params: []string{"/tags/3"}}},
+		{"missing value", `[{"op": "add", "path": "/age"}]`, "", gofrHTTP.ErrorInvalidParam{Params: []string{"/age"}}},
+		{"unknown operation", `[{"op": "merge", "path": "/name"}]`, "",
+			gofrHTTP.ErrorInvalidParam{Params: []string{"/name"}}},
+		{"not a JSON Patch", `{"name": "go"}`, "", gofrHTTP.ErrorInvalidParam{Params: []string{"body"}}},
+	}
+
+	for i, tc := range testCases {
+		original, err := decodeJSON([]byte(doc))
+		require.NoError(t, err)
+
+		patched, _, err := applyJSONPatch(original, []byte(tc.patch))
+
+		require.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.err == nil {
+			expected, _ := json.Marshal(patched)
+			assert.JSONEq(t, tc.expected, string(expected), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func Test_applyMergePatch(t *testing.T) {
+	doc, err := decodeJSON([]byte(`{"name": "gofr", "age": 3, "address": {"city": "Bengaluru", "zip": "560001"}}`))
+	require.NoError(t, err)
+
+	patched, members, err := applyMergePatch(doc, []byte(`{"age": null, "address": {"zip": null, "street": "MG Road"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"age": true, "address": true}, members)
+
+	body, _ := json.Marshal(patched)
+	assert.JSONEq(t, `{"name": "gofr", "address": {"city": "Bengaluru", "street": "MG Road"}}`, string(body))
+
+	for _, patch := range []string{`{`, `[{"op": "remove", "path": "/age"}]`} {
+		_, _, err = applyMergePatch(doc, []byte(patch))
+		require.Equal(t, gofrHTTP.ErrorInvalidParam{Params: []string{"body"}}, err, patch)
+	}
+}
+
+func Test_PatchHandler(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	e, err := scanEntity(&userEntity{})
+	require.NoError(t, err)
+
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	c.SQL = db
+
+	t.Cleanup(func() { db.Close() })
+
+	currentRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "is_employed"}).AddRow(1, "gofr", false)
+	}
+
+	t.Run("merge patch updates the supplied columns", func(t *testing.T) {
+		ctx := createPatchTestContext("application/merge-patch+json", "1", "", `{"id":5,"isEmployed":true}`, c)
+
+		mock.ExpectQuery("SELECT * FROM `user` WHERE `id`=?").WithArgs("1").WillReturnRows(currentRow())
+		mock.ExpectExec("UPDATE `user` SET `is_employed`=? WHERE `id`=?").WithArgs(true, "1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		resp, err := e.Patch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "userEntity successfully updated with id: 1", resp)
+	})
+
+	t.Run("json patch updates the supplied columns", func(t *testing.T) {
+		ctx := createPatchTestContext("application/json-patch+json", "1", "",
+			`[{"op":"test","path":"/name","value":"gofr"},{"op":"replace","path":"/name","value":"go"}]`, c)
+
+		mock.ExpectQuery("SELECT * FROM `user` WHERE `id`=?").WithArgs("1").WillReturnRows(currentRow())
+		mock.ExpectExec("UPDATE `user` SET `name`=? WHERE `id`=?").WithArgs("go", "1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := e.Patch(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("merge patch members matched case-insensitively", func(t *testing.T) {
+		ctx := createPatchTestContext("application/merge-patch+json", "1", "", `{"IsEmployed":true}`, c)
+
+		mock.ExpectQuery("SELECT * FROM `user` WHERE `id`=?").WithArgs("1").WillReturnRows(currentRow())
+		mock.ExpectExec("UPDATE `user` SET `is_employed`=? WHERE `id`=?").WithArgs(true, "1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := e.Patch(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("empty patch", func(t *testing.T) {
+		ctx := createPatchTestContext("application/merge-patch+json", "1", "", `{}`, c)
+
+		mock.ExpectQuery("SELECT * FROM `user` WHERE `id`=?").WithArgs("1").WillReturnRows(currentRow())
+
+		_, err := e.Patch(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing entity", func(t *testing.T) {
+		ctx := createPatchTestContext("application/merge-patch+json", "2", "", `{"name":"go"}`, c)
+
+		mock.ExpectQuery("SELECT * FROM `user` WHERE `id`=?").WithArgs("2").WillReturnError(sql.ErrNoRows)
+
+		_, err := e.Patch(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+
+	t.Run("failed json patch", func(t *testing.T) {
+		ctx := createPatchTestContext("application/json-patch+json", "1", "", `[{"op":"remove","path":"/age"}]`, c)
+
+		mock.ExpectQuery("SELECT * FROM `user` WHERE `id`=?").WithArgs("1").WillReturnRows(currentRow())
+
+		_, err := e.Patch(ctx)
+		require.Equal(t, gofrHTTP.ErrorInvalidParam{Params: []string{"/age"}}, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+type secretEntity struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Secret string `json:"-"`
+}
+
+func Test_PatchHandler_IgnoredFields(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	e, err := scanEntity(&secretEntity{})
+	require.NoError(t, err)
+
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	c.SQL = db
+
+	t.Cleanup(func() { db.Close() })
+
+	currentRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "secret"}).AddRow(1, "gofr", "s3cr3t")
+	}
+
+	t.Run("document replaced by a json patch", func(t *testing.T) {
+		ctx := createPatchTestContext("application/json-patch+json", "1", "",
+			`[{"op":"replace","path":"","value":{"id":1,"name":"go"}}]`, c)
+
+		mock.ExpectQuery("SELECT * FROM `secret_entity` WHERE `id`=?").WithArgs("1").WillReturnRows(currentRow())
+		mock.ExpectExec("UPDATE `secret_entity` SET `name`=? WHERE `id`=?").WithArgs("go", "1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := e.Patch(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("member named as the field", func(t *testing.T) {
+		ctx := createPatchTestContext("application/merge-patch+json", "1", "", `{"Secret":"leaked"}`, c)
+
+		mock.ExpectQuery("SELECT * FROM `secret_entity` WHERE `id`=?").WithArgs("1").WillReturnRows(currentRow())
+
+		_, err := e.Patch(ctx)
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_PatchHandler_Versioned(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	e, err := scanEntity(&versionedEntity{})
+	require.NoError(t, err)
+
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	c.SQL = db
+
+	t.Cleanup(func() { db.Close() })
+
+	selectQuery := "SELECT * FROM `versioned_entity` WHERE `id`=?"
+	updateQuery := "UPDATE `versioned_entity` SET `name`=?, `version`=`version`+1 WHERE `id`=? AND `version`=?"
+	currentRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "gofr", 3)
+	}
+
+	testCases := []struct {
+		desc    string
+		ifMatch string
+		patch   string
+		update  bool
+		err     error
+	}{
+		{"current version in if-match", `"3"`, `{"name":"go"}`, true, nil},
+		{"current version in patch", "", `{"name":"go","version":3}`, true, nil},
+		{"stale version", `"2"`, `{"name":"go"}`, false, gofrHTTP.ErrorPreconditionFailed{}},
+		{"missing version", "", `{"name":"go"}`, false, gofrHTTP.ErrorPreconditionRequired{}},
+	}
+
+	for i, tc := range testCases {
+		ctx := createPatchTestContext("application/merge-patch+json", "1", tc.ifMatch, tc.patch, c)
+
+		mock.ExpectQuery(selectQuery).WithArgs("1").WillReturnRows(currentRow())
+
+		if tc.update {
+			mock.ExpectExec(updateQuery).WithArgs("go", "1", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		_, err := e.Patch(ctx)
+
+		require.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,178 @@
+package gofr
+
+import (
+	gosql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gofr.dev/pkg/gofr/datasource/sql"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+// includedRelations returns the relations named by the include query parameter, e.g. ?include=orders,owner.
+func (e *entity) includedRelations(c *Context) ([]*relation, error) {
+	names := c.Params("include")
+	relations := make([]*relation, 0, len(names))
+
+	for _, name := range names {
+		rel := e.relation(name)
+		if rel == nil {
+			return nil, gofrHTTP.ErrorInvalidParam{Params: []string{"include"}}
+		}
+
+		relations = append(relations, rel)
+	}
+
+	return relations, nil
+}
+
+func (e *entity) relation(name string) *relation {
+	for _, rel := range e.relations {
+		if rel.name == name {
+			return rel
+		}
+	}
+
+	return nil
+}
+
+// relationHandler returns the handler of the nested route of a relation, e.g. /users/{id}/orders, which responds
+// with the related entities of an entity.
+func (e *entity) relationHandler(rel *relation) Handler {
+	return func(c *Context) (any, error) {
+		id := c.PathParam(e.primaryKey)
+
+		parent, err := e.fetch(c, c.SQL, id)
+		if errors.Is(err, gosql.ErrNoRows) {
+			return nil, gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := e.loadRelation(c, rel, []any{parent}); err != nil {
+			return nil, err
+		}
+
+		val := reflect.ValueOf(parent).Elem()
+
+		// the related entity of a belongs_to relation may be missing, or soft deleted.
+		if related := val.Field(rel.index); rel.kind == relationBelongsTo && related.IsNil() {
+			value := "null"
+
+			if key, ok := relationKey(val.Field(rel.keyIndex)); ok {
+				value = fmt.Sprint(key)
+			}
+
+			return nil, gofrHTTP.ErrorEntityNotFound{Name: rel.foreignKey, Value: value}
+		}
+
+		return val.Field(rel.index).Interface(), nil
+	}
+}
+
+// loadRelation sets the related entities of a relation in the given entities, which are read with a single query.
+func (e *entity) loadRelation(c *Context, rel *relation, entities []any) error {
+	// the entities are matched with their related entities by the foreign key of the relation and the primary key of
+	// the entity, at index 0, on either side.
+	keyIndex, relatedKeyIndex, column := 0, rel.keyIndex, rel.foreignKey
+	if rel.kind == relationBelongsTo {
+		keyIndex, relatedKeyIndex, column = rel.keyIndex, 0, rel.related.primaryKey
+	}
+
+	keys := make([]any, 0, len(entities))
+	seen := make(map[string]bool, len(entities))
+
+	for _, entity := range entities {
+		key, ok := relationKey(reflect.ValueOf(entity).Elem().Field(keyIndex))
+		if ok && !seen[fmt.Sprint(key)] {
+			seen[fmt.Sprint(key)] = true
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	related := rel.related
+	query := sql.SelectByInQuery(c.SQL.Dialect(), related.tableName, column, len(keys), related.notDeleted(c)...)
+
+	rows, err := c.SQL.QueryContext(c, query, keys...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	relatedEntities, err := related.scanRows(rows)
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[string][]reflect.Value, len(relatedEntities))
+
+	for _, relatedEntity := range relatedEntities {
+		val := reflect.ValueOf(relatedEntity)
+
+		if key, ok := relationKey(val.Elem().Field(relatedKeyIndex)); ok {
+			byKey[fmt.Sprint(key)] = append(byKey[fmt.Sprint(key)], val)
+		}
+	}
+
+	for _, entity := range entities {
+		val := reflect.ValueOf(entity).Elem()
+
+		key, ok := relationKey(val.Field(keyIndex))
+		if !ok {
+			continue
+		}
+
+		setRelated(val.Field(rel.index), rel.kind, byKey[fmt.Sprint(key)])
+	}
+
+	return rows.Err()
+}
+
+// setRelated sets the field of a relation to the related entities: all of them for has_many relations, and the
+// only one for belongs_to relations.
+func setRelated(field reflect.Value, kind string, related []reflect.Value) {
+	if kind == relationBelongsTo {
+		if len(related) > 0 {
+			field.Set(related[0])
+		}
+
+		return
+	}
+
+	for _, r := range related {
+		field.Set(reflect.Append(field, r.Elem()))
+	}
+}
+
+// relationKey returns the value of a key field, unless it is a nil pointer or a null value.
+func relationKey(field reflect.Value) (any, bool) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, false
+		}
+
+		field = field.Elem()
+	}
+
+	key := field.Interface()
+
+	if valuer, ok := key.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil || value == nil {
+			return nil, false
+		}
+
+		return value, true
+	}
+
+	return key, true
+}
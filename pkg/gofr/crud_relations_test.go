@@ -0,0 +1,190 @@
+package gofr
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+	gofrSql "gofr.dev/pkg/gofr/datasource/sql"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+type customer struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Orders []customerOrder `json:"orders,omitempty" sql:"has_many"`
+}
+
+type customerOrder struct {
+	ID         int       `json:"id"`
+	CustomerID *int      `json:"customerId"`
+	Customer   *customer `json:"customer,omitempty" sql:"belongs_to"`
+}
+
+func createRelationTestContext(target, id string, cont *container.Container) *Context {
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+
+	return newContext(gofrHTTP.NewResponder(httptest.NewRecorder(), http.MethodGet), gofrHTTP.NewRequest(req), cont)
+}
+
+func scanRelatedEntity(t *testing.T, object any) *entity {
+	t.Helper()
+
+	e, err := scanEntity(object)
+	require.NoError(t, err)
+	require.NoError(t, e.resolveRelations())
+
+	return e
+}
+
+func Test_scanEntity_Relations(t *testing.T) {
+	e := scanRelatedEntity(t, &customer{})
+
+	require.Len(t, e.relations, 1)
+	assert.Equal(t, "orders", e.relations[0].name)
+	assert.Equal(t, relationHasMany, e.relations[0].kind)
+	assert.Equal(t, "customer_id", e.relations[0].foreignKey)
+	assert.Equal(t, 1, e.relations[0].keyIndex)
+	assert.Equal(t, "customer_order", e.relations[0].related.tableName)
+
+	fieldNames, _ := e.extractFields(&customer{ID: 1, Name: "gofr"})
+	assert.Equal(t, []string{"id", "name"}, fieldNames)
+
+	e = scanRelatedEntity(t, &customerOrder{})
+
+	assert.Equal(t, relationBelongsTo, e.relations[0].kind)
+	assert.Equal(t, "customer_id", e.relations[0].foreignKey)
+	assert.Equal(t, 1, e.relations[0].keyIndex)
+}
+
+func Test_scanEntity_InvalidRelations(t *testing.T) {
+	type notSlice struct {
+		ID     int
+		Orders customerOrder `sql:"has_many"`
+	}
+
+	type notPointer struct {
+		ID       int
+		Customer customer `sql:"belongs_to"`
+	}
+
+	type twoRelations struct {
+		ID       int
+		Customer *customer `sql:"belongs_to,has_many"`
+	}
+
+	testCases := []struct {
+		desc   string
+		object any
+		err    error
+	}{
+		{"has_many not held by a slice", &notSlice{}, errInvalidRelation},
+		{"belongs_to not held by a pointer", &notPointer{}, errInvalidRelation},
+		{"two relations", &twoRelations{}, errInvalidSQLTag},
+	}
+
+	for i, tc := range testCases {
+		_, err := scanEntity(tc.object)
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	type missingForeignKey struct {
+		ID       int
+		Customer *customer `sql:"belongs_to=owner_id"`
+	}
+
+	e, err := scanEntity(&missingForeignKey{})
+	require.NoError(t, err)
+	require.ErrorIs(t, e.resolveRelations(), errInvalidRelation)
+}
+
+func Test_RelationHandlers(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	customers := scanRelatedEntity(t, &customer{})
+	orders := scanRelatedEntity(t, &customerOrder{})
+
+	db, mock, _ := gofrSql.NewSQLMocksWithConfig(t, &gofrSql.DBConfig{Dialect: "mysql"})
+	c.SQL = db
+
+	t.Cleanup(func() { db.Close() })
+
+	one, two := 1, 2
+
+	t.Run("get all includes has_many relations", func(t *testing.T) {
+		ctx := createRelationTestContext("/customer?include=orders", "", c)
+
+		mock.ExpectQuery("SELECT * FROM `customer`").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "gofr").AddRow(2, "go").AddRow(3, "zop"))
+		mock.ExpectQuery("SELECT * FROM `customer_order` WHERE `customer_id` IN (?, ?, ?)").WithArgs(1, 2, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id"}).AddRow(10, 1).AddRow(11, 2).AddRow(12, 1))
+
+		resp, err := customers.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []any{
+			&customer{ID: 1, Name: "gofr", Orders: []customerOrder{{ID: 10, CustomerID: &one}, {ID: 12, CustomerID: &one}}},
+			&customer{ID: 2, Name: "go", Orders: []customerOrder{{ID: 11, CustomerID: &two}}},
+			&customer{ID: 3, Name: "zop"},
+		}, resp)
+	})
+
+	t.Run("get includes belongs_to relations", func(t *testing.T) {
+		ctx := createRelationTestContext("/customerorder/10?include=customer", "10", c)
+
+		mock.ExpectQuery("SELECT * FROM `customer_order` WHERE `id`=?").WithArgs("10").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id"}).AddRow(10, 1))
+		mock.ExpectQuery("SELECT * FROM `customer` WHERE `id` IN (?)").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "gofr"))
+
+		resp, err := orders.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &customerOrder{ID: 10, CustomerID: &one, Customer: &customer{ID: 1, Name: "gofr"}}, resp)
+	})
+
+	t.Run("unknown relation", func(t *testing.T) {
+		_, err := customers.GetAll(createRelationTestContext("/customer?include=invoices", "", c))
+		require.Equal(t, gofrHTTP.ErrorInvalidParam{Params: []string{"include"}}, err)
+	})
+
+	t.Run("nested route of has_many relation", func(t *testing.T) {
+		ctx := createRelationTestContext("/customer/1/orders", "1", c)
+
+		mock.ExpectQuery("SELECT * FROM `customer` WHERE `id`=?").WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "gofr"))
+		mock.ExpectQuery("SELECT * FROM `customer_order` WHERE `customer_id` IN (?)").WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id"}).AddRow(10, 1))
+
+		resp, err := customers.relationHandler(customers.relations[0])(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []customerOrder{{ID: 10, CustomerID: &one}}, resp)
+	})
+
+	t.Run("nested route of missing entity", func(t *testing.T) {
+		ctx := createRelationTestContext("/customer/4/orders", "4", c)
+
+		mock.ExpectQuery("SELECT * FROM `customer` WHERE `id`=?").WithArgs("4").WillReturnError(sql.ErrNoRows)
+
+		_, err := customers.relationHandler(customers.relations[0])(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "4"}, err)
+	})
+
+	t.Run("nested route of belongs_to relation without foreign key", func(t *testing.T) {
+		ctx := createRelationTestContext("/customerorder/11/customer", "11", c)
+
+		mock.ExpectQuery("SELECT * FROM `customer_order` WHERE `id`=?").WithArgs("11").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id"}).AddRow(11, nil))
+
+		_, err := orders.relationHandler(orders.relations[0])(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "customer_id", Value: "null"}, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
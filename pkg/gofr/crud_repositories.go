@@ -0,0 +1,456 @@
+package gofr
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gofr.dev/pkg/gofr/container"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+// MongoRepository stores the entity in the Mongo collection named by EntityInfo.Table. The documents are encoded
+// by the Mongo driver, so their fields are named by the bson tags, or are the lowercased field names.
+func MongoRepository(info EntityInfo) Repository {
+	return &mongoRepository{info: info, primaryKey: storedFieldName(info.Type.Field(0), "bson", strings.ToLower)}
+}
+
+// CouchbaseRepository stores the entity as JSON documents keyed by their ID, in the N1QL keyspace named by
+// EntityInfo.Table, e.g. "users" or "app.inventory.users". Listing the entities requires a primary index on it.
+func CouchbaseRepository(info EntityInfo) Repository {
+	return &couchbaseRepository{info: info, keyspace: quoteKeyspace(info.Table)}
+}
+
+// CassandraRepository stores the entity in the Cassandra table named by EntityInfo.Table, whose columns are named
+// by the db tags of the fields, or are the field names in snake case.
+func CassandraRepository(info EntityInfo) Repository {
+	return &cassandraRepository{info: info, primaryKey: storedFieldName(info.Type.Field(0), "db", toSnakeCase)}
+}
+
+type mongoRepository struct {
+	info       EntityInfo
+	primaryKey string
+}
+
+func (r *mongoRepository) Insert(c *Context, entity any) error {
+	_, err := c.Mongo.InsertOne(c, r.info.Table, entity)
+
+	return err
+}
+
+func (r *mongoRepository) FindAll(c *Context) ([]any, error) {
+	return r.find(c, r.filter(nil))
+}
+
+func (r *mongoRepository) Find(c *Context, id any) (any, error) {
+	entities, err := r.find(c, r.filter(map[string]any{r.primaryKey: id}))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, notFound(r.info, id)
+	}
+
+	return entities[0], nil
+}
+
+func (r *mongoRepository) find(c *Context, filter map[string]any) ([]any, error) {
+	results := reflect.New(reflect.SliceOf(r.info.Type))
+
+	if err := c.Mongo.Find(c, r.info.Table, filter, results.Interface()); err != nil {
+		return nil, err
+	}
+
+	return entityPointers(results.Elem()), nil
+}
+
+// filter restricts a filter to the entities which are not soft deleted, whose deleted_at field is null or missing.
+func (r *mongoRepository) filter(filter map[string]any) map[string]any {
+	if filter == nil {
+		filter = map[string]any{}
+	}
+
+	if r.info.SoftDeleteField != "" {
+		field, _ := r.info.Type.FieldByName(r.info.SoftDeleteField)
+		filter[storedFieldName(field, "bson", strings.ToLower)] = nil
+	}
+
+	return filter
+}
+
+// versionFilter restricts a filter to the stored version of a versioned entity being the one of the given entity.
+func (r *mongoRepository) versionFilter(filter map[string]any, entity any) map[string]any {
+	if r.info.VersionField != "" {
+		field, _ := r.info.Type.FieldByName(r.info.VersionField)
+		filter[storedFieldName(field, "bson", strings.ToLower)] = r.info.version(entity)
+	}
+
+	return filter
+}
+
+// Update sets the updatable fields of the entity. The drivers implementing container.MongoUpdateMatcher tell in
+// the same request whether the entity exists; with the other drivers, it is counted before being updated.
+func (r *mongoRepository) Update(c *Context, id, entity any) error {
+	filter := r.versionFilter(r.filter(map[string]any{r.primaryKey: id}), entity)
+	fields := make(map[string]any, len(r.info.UpdatableFields))
+
+	names, values := r.info.updatedFields(entity)
+	for i, field := range names {
+		fields[storedFieldName(field, "bson", strings.ToLower)] = values[i]
+	}
+
+	update := map[string]any{"$set": fields}
+
+	if matcher, ok := c.Mongo.(container.MongoUpdateMatcher); ok {
+		matched, err := matcher.UpdateOneMatched(c, r.info.Table, filter, update)
+		if err != nil {
+			return err
+		}
+
+		if matched == 0 {
+			return notFound(r.info, id)
+		}
+
+		return nil
+	}
+
+	count, err := c.Mongo.CountDocuments(c, r.info.Table, filter)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return notFound(r.info, id)
+	}
+
+	return c.Mongo.UpdateOne(c, r.info.Table, filter, update)
+}
+
+func (r *mongoRepository) Delete(c *Context, id, entity any) error {
+	deleted, err := c.Mongo.DeleteOne(c, r.info.Table, r.versionFilter(map[string]any{r.primaryKey: id}, entity))
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return notFound(r.info, id)
+	}
+
+	return nil
+}
+
+type couchbaseRepository struct {
+	info     EntityInfo
+	keyspace string
+}
+
+func (r *couchbaseRepository) Insert(c *Context, entity any) error {
+	id := reflect.ValueOf(entity).Elem().Field(0).Interface()
+
+	return c.Couchbase.Query(c, fmt.Sprintf("INSERT INTO %s (KEY, VALUE) VALUES ($id, $entity)", r.keyspace),
+		map[string]any{"id": fmt.Sprint(id), "entity": entity}, nil)
+}
+
+func (r *couchbaseRepository) FindAll(c *Context) ([]any, error) {
+	return r.find(c, fmt.Sprintf("SELECT d.* FROM %s AS d%s", r.keyspace, r.where(nil, nil)), nil)
+}
+
+func (r *couchbaseRepository) Find(c *Context, id any) (any, error) {
+	entities, err := r.find(c, fmt.Sprintf("SELECT d.* FROM %s AS d USE KEYS $id%s", r.keyspace, r.where(nil, nil)),
+		map[string]any{"id": fmt.Sprint(id)})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, notFound(r.info, id)
+	}
+
+	return entities[0], nil
+}
+
+func (r *couchbaseRepository) find(c *Context, statement string, params map[string]any) ([]any, error) {
+	results := reflect.New(reflect.SliceOf(r.info.Type))
+
+	if err := c.Couchbase.Query(c, statement, params, results.Interface()); err != nil {
+		return nil, err
+	}
+
+	return entityPointers(results.Elem()), nil
+}
+
+func (r *couchbaseRepository) Update(c *Context, id, entity any) error {
+	params := map[string]any{"id": fmt.Sprint(id)}
+
+	names, values := r.info.updatedFields(entity)
+	assignments := make([]string, 0, len(names))
+
+	for i, field := range names {
+		param := fmt.Sprintf("f%d", i)
+
+		params[param] = values[i]
+		assignments = append(assignments, fmt.Sprintf("d.`%s` = $%s", storedFieldName(field, "json", nil), param))
+	}
+
+	statement := fmt.Sprintf("UPDATE %s AS d USE KEYS $id SET %s%s RETURNING META(d).id", r.keyspace,
+		strings.Join(assignments, ", "), r.where(entity, params))
+
+	return r.mutate(c, statement, params, id)
+}
+
+func (r *couchbaseRepository) Delete(c *Context, id, entity any) error {
+	params := map[string]any{"id": fmt.Sprint(id)}
+
+	return r.mutate(c, fmt.Sprintf("DELETE FROM %s AS d USE KEYS $id%s RETURNING META(d).id", r.keyspace,
+		r.where(entity, params)), params, id)
+}
+
+// where returns the WHERE clause restricting a statement to the documents which are not soft deleted, whose
+// deleted_at field is null or missing, and, for a versioned entity, whose version is the one of the given entity,
+// which is added to the params. The entity is nil for the statements reading documents.
+func (r *couchbaseRepository) where(entity any, params map[string]any) string {
+	var conditions []string
+
+	if field, ok := r.info.Type.FieldByName(r.info.SoftDeleteField); ok {
+		conditions = append(conditions, fmt.Sprintf("d.`%s` IS NOT VALUED", storedFieldName(field, "json", nil)))
+	}
+
+	if field, ok := r.info.Type.FieldByName(r.info.VersionField); ok && entity != nil {
+		params["version"] = r.info.version(entity)
+		conditions = append(conditions, fmt.Sprintf("d.`%s` = $version", storedFieldName(field, "json", nil)))
+	}
+
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// mutate runs a statement returning the IDs of the documents it changed, which is none if the entity doesn't exist.
+func (r *couchbaseRepository) mutate(c *Context, statement string, params map[string]any, id any) error {
+	var changed []map[string]any
+
+	if err := c.Couchbase.Query(c, statement, params, &changed); err != nil {
+		return err
+	}
+
+	if len(changed) == 0 {
+		return notFound(r.info, id)
+	}
+
+	return nil
+}
+
+type cassandraRepository struct {
+	info       EntityInfo
+	primaryKey string
+}
+
+func (r *cassandraRepository) Insert(c *Context, entity any) error {
+	val := reflect.ValueOf(entity).Elem()
+	columns := make([]string, 0, val.NumField())
+	values := make([]any, 0, val.NumField())
+
+	for i := 0; i < val.NumField(); i++ {
+		columns = append(columns, storedFieldName(r.info.Type.Field(i), "db", toSnakeCase))
+		values = append(values, val.Field(i).Interface())
+	}
+
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (?%s) IF NOT EXISTS", r.info.Table, strings.Join(columns, ", "),
+		strings.Repeat(", ?", len(columns)-1))
+
+	applied, err := c.Cassandra.ExecCASWithCtx(c, reflect.New(r.info.Type).Interface(), stmt, values...)
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		return gofrHTTP.ErrorEntityAlreadyExist{}
+	}
+
+	return nil
+}
+
+func (r *cassandraRepository) FindAll(c *Context) ([]any, error) {
+	return r.find(c, fmt.Sprintf("SELECT * FROM %s", r.info.Table))
+}
+
+func (r *cassandraRepository) Find(c *Context, id any) (any, error) {
+	entities, err := r.find(c, fmt.Sprintf("SELECT * FROM %s WHERE %s = ?", r.info.Table, r.primaryKey), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, notFound(r.info, id)
+	}
+
+	return entities[0], nil
+}
+
+// find returns the entities found by a query, but the soft deleted ones, which Cassandra cannot filter on their
+// deleted_at column.
+func (r *cassandraRepository) find(c *Context, stmt string, values ...any) ([]any, error) {
+	results := reflect.New(reflect.SliceOf(r.info.Type))
+
+	if err := c.Cassandra.QueryWithCtx(c, results.Interface(), stmt, values...); err != nil {
+		return nil, err
+	}
+
+	entities := entityPointers(results.Elem())
+
+	if r.info.SoftDeleteField != "" {
+		entities = slices.DeleteFunc(entities, func(entity any) bool {
+			return !reflect.ValueOf(entity).Elem().FieldByName(r.info.SoftDeleteField).IsNil()
+		})
+	}
+
+	return entities, nil
+}
+
+func (r *cassandraRepository) Update(c *Context, id, entity any) error {
+	names, fieldValues := r.info.updatedFields(entity)
+	assignments := make([]string, 0, len(names))
+
+	for _, field := range names {
+		assignments = append(assignments, storedFieldName(field, "db", toSnakeCase)+" = ?")
+	}
+
+	conditions, conditionValues := r.conditions(entity)
+
+	stmt := fmt.Sprintf("UPDATE %s SET %s WHERE %s = ? %s", r.info.Table, strings.Join(assignments, ", "),
+		r.primaryKey, conditions)
+
+	return r.exec(c, stmt, id, append(append(fieldValues, id), conditionValues...)...)
+}
+
+func (r *cassandraRepository) Delete(c *Context, id, entity any) error {
+	conditions, conditionValues := r.conditions(entity)
+
+	return r.exec(c, fmt.Sprintf("DELETE FROM %s WHERE %s = ? %s", r.info.Table, r.primaryKey, conditions), id,
+		append([]any{id}, conditionValues...)...)
+}
+
+// conditions returns the condition of the lightweight transactions changing an entity, which is its existence, or
+// its deleted_at column being null and its version being the one of the given entity for the soft deleted and
+// versioned entities, along with the values of the condition.
+func (r *cassandraRepository) conditions(entity any) (string, []any) {
+	var (
+		conditions []string
+		values     []any
+	)
+
+	if field, ok := r.info.Type.FieldByName(r.info.SoftDeleteField); ok {
+		conditions = append(conditions, storedFieldName(field, "db", toSnakeCase)+" = null")
+	}
+
+	if field, ok := r.info.Type.FieldByName(r.info.VersionField); ok {
+		conditions = append(conditions, storedFieldName(field, "db", toSnakeCase)+" = ?")
+		values = append(values, r.info.version(entity))
+	}
+
+	if len(conditions) == 0 {
+		return "IF EXISTS", nil
+	}
+
+	return "IF " + strings.Join(conditions, " AND "), values
+}
+
+// exec runs a lightweight transaction conditioned on the existence of the entity.
+func (r *cassandraRepository) exec(c *Context, stmt string, id any, values ...any) error {
+	applied, err := c.Cassandra.ExecCASWithCtx(c, reflect.New(r.info.Type).Interface(), stmt, values...)
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		return notFound(r.info, id)
+	}
+
+	return nil
+}
+
+// updatedFields returns the struct fields set by Update, with their values in the entity: the UpdatableFields and
+// the SoftDeleteField, along with the VersionField, whose value is incremented.
+func (info EntityInfo) updatedFields(entity any) ([]reflect.StructField, []any) {
+	names := info.UpdatableFields
+	if info.SoftDeleteField != "" {
+		names = append(slices.Clip(names), info.SoftDeleteField)
+	}
+
+	fields := make([]reflect.StructField, 0, len(names)+1)
+	values := make([]any, 0, len(names)+1)
+	val := reflect.ValueOf(entity).Elem()
+
+	for _, name := range names {
+		field, _ := info.Type.FieldByName(name)
+
+		fields = append(fields, field)
+		values = append(values, val.FieldByName(name).Interface())
+	}
+
+	if info.VersionField != "" {
+		field, _ := info.Type.FieldByName(info.VersionField)
+		version := reflect.New(field.Type).Elem()
+
+		if current := val.FieldByName(info.VersionField); current.CanInt() {
+			version.SetInt(current.Int() + 1)
+		} else {
+			version.SetUint(current.Uint() + 1)
+		}
+
+		fields = append(fields, field)
+		values = append(values, version.Interface())
+	}
+
+	return fields, values
+}
+
+// version returns the value of the VersionField of the entity.
+func (info EntityInfo) version(entity any) any {
+	return reflect.ValueOf(entity).Elem().FieldByName(info.VersionField).Interface()
+}
+
+// storedFieldName returns the name of a field in a store, given by the tag with the given key, or by converting
+// the name of the field with convert when the tag is missing. A nil convert keeps the field name.
+func storedFieldName(field reflect.StructField, key string, convert func(string) string) string {
+	if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" && name != "-" {
+		return name
+	}
+
+	if convert == nil {
+		return field.Name
+	}
+
+	return convert(field.Name)
+}
+
+// quoteKeyspace escapes the parts of a N1QL keyspace path, which may already be escaped.
+func quoteKeyspace(keyspace string) string {
+	parts := strings.Split(keyspace, ".")
+
+	for i, part := range parts {
+		parts[i] = "`" + strings.Trim(part, "`") + "`"
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// entityPointers returns pointers to the entities of a slice, which is nil when it is empty like for the SQL
+// handlers.
+func entityPointers(slice reflect.Value) []any {
+	var entities []any
+
+	for i := 0; i < slice.Len(); i++ {
+		entities = append(entities, slice.Index(i).Addr().Interface())
+	}
+
+	return entities
+}
+
+func notFound(info EntityInfo, id any) error {
+	return gofrHTTP.ErrorEntityNotFound{Name: info.PrimaryKey, Value: fmt.Sprint(id)}
+}
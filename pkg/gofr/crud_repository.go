@@ -0,0 +1,395 @@
+package gofr
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+var (
+	errSQLOnlyConstraint = errors.New("auto_increment fields, relations and sql.NullTime deleted_at fields are only " +
+		"supported with SQL")
+	errUnsupportedID = errors.New("unsupported primary key type")
+)
+
+// Repository stores the entities of the handlers registered by AddRESTHandlers with WithRepository. The handlers
+// bind and validate the requests, and set the audit fields, so that the REST API of an entity is the same whichever
+// store backs it. The entities are pointers to the struct, and the IDs are of the type of its primary key field.
+//
+// The handlers check the versions of the entities having an EntityInfo.VersionField, and soft delete the entities
+// having an EntityInfo.SoftDeleteField by updating them, the repositories keeping the stored entities consistent
+// with them: the entities which are soft deleted, or whose version changed, are not found.
+type Repository interface {
+	// Insert stores a new entity.
+	Insert(c *Context, entity any) error
+	// FindAll returns all the entities which are not soft deleted.
+	FindAll(c *Context) ([]any, error)
+	// Find returns the entity with the given ID, or an http.ErrorEntityNotFound if it is missing or soft deleted.
+	Find(c *Context, id any) (any, error)
+	// Update sets the UpdatableFields and the SoftDeleteField of the entity with the given ID, or returns an
+	// http.ErrorEntityNotFound if it is missing or soft deleted. The entities having a VersionField are updated only
+	// if their stored version is the one of the given entity, their version being incremented.
+	Update(c *Context, id, entity any) error
+	// Delete deletes the entity with the given ID, or returns an http.ErrorEntityNotFound. entity is the current
+	// entity, found by the handler for the entities having a VersionField or delete hooks, and nil otherwise. The
+	// entities having a VersionField are deleted only if their stored version is the one of entity.
+	Delete(c *Context, id, entity any) error
+}
+
+// EntityInfo describes an entity to the Repository storing it.
+type EntityInfo struct {
+	// Name is the name of the struct.
+	Name string
+	// Table is the table, collection or keyspace of the entity, given by its TableName method or the struct name in
+	// snake case.
+	Table string
+	// PrimaryKey is the name of the first field, in snake case, which is the primary key.
+	PrimaryKey string
+	// Type is the type of the struct.
+	Type reflect.Type
+	// UpdatableFields are the names of the struct fields set by Update, i.e. all of them but the primary key, the
+	// version and the created_at, created_by and deleted_at audit fields.
+	UpdatableFields []string
+	// VersionField is the name of the integer struct field tagged `sql:"version"`, or empty for the entities without
+	// a version.
+	VersionField string
+	// SoftDeleteField is the name of the *time.Time struct field tagged `sql:"deleted_at"`, which is set instead of
+	// deleting the entities, or empty for the entities which are deleted.
+	SoftDeleteField string
+}
+
+// RepositoryFactory creates the Repository of an entity.
+type RepositoryFactory func(info EntityInfo) Repository
+
+// RESTOption configures the handlers registered by AddRESTHandlers.
+type RESTOption func(*restOptions)
+
+type restOptions struct {
+	repository RepositoryFactory
+}
+
+// WithRepository stores the entity with the Repository created by the given factory, such as MongoRepository,
+// CouchbaseRepository or CassandraRepository, instead of SQL.
+func WithRepository(factory RepositoryFactory) RESTOption {
+	return func(o *restOptions) {
+		o.repository = factory
+	}
+}
+
+// repositoryHandlers are the default CRUD handlers of an entity stored by a Repository.
+type repositoryHandlers struct {
+	*entity
+	repository Repository
+}
+
+func newRepositoryHandlers(e *entity, factory RepositoryFactory) (*repositoryHandlers, error) {
+	info := EntityInfo{
+		Name:       e.name,
+		Table:      e.tableName,
+		PrimaryKey: e.primaryKey,
+		Type:       e.entityType,
+	}
+
+	// the stores tell the soft deleted entities by their deleted_at field being null or missing.
+	if i, ok := e.auditFields[auditDeletedAt]; ok {
+		if e.entityType.Field(i).Type != reflect.TypeFor[*time.Time]() {
+			return nil, fmt.Errorf("%w: %s", errSQLOnlyConstraint, e.name)
+		}
+
+		info.SoftDeleteField = e.entityType.Field(i).Name
+	}
+
+	if len(e.relations) > 0 || hasAutoIncrementID(e.constraints) {
+		return nil, fmt.Errorf("%w: %s", errSQLOnlyConstraint, e.name)
+	}
+
+	if e.versionColumn != "" {
+		info.VersionField = e.entityType.Field(e.versionIndex).Name
+	}
+
+	for i := 0; i < e.entityType.NumField(); i++ {
+		if e.isUpdatable(i) {
+			info.UpdatableFields = append(info.UpdatableFields, e.entityType.Field(i).Name)
+		}
+	}
+
+	return &repositoryHandlers{entity: e, repository: factory(info)}, nil
+}
+
+// Create inserts the entity, calling its create hooks with a nil transaction.
+func (h *repositoryHandlers) Create(c *Context) (any, error) {
+	newEntity, err := h.bindAndValidateEntity(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.versionColumn != "" {
+		h.setVersion(newEntity, 1)
+		c.SetVersion("1")
+	}
+
+	h.clearDeleted(newEntity)
+	h.setAudit(c, newEntity, auditCreatedAt, auditUpdatedAt, auditCreatedBy, auditUpdatedBy)
+
+	if err := callHook(newEntity, func(hook BeforeCreate) error { return hook.BeforeCreate(c, nil) }); err != nil {
+		return nil, err
+	}
+
+	if err := h.repository.Insert(c, newEntity); err != nil {
+		return nil, err
+	}
+
+	if err := callHook(newEntity, func(hook AfterCreate) error { return hook.AfterCreate(c, nil) }); err != nil {
+		return nil, err
+	}
+
+	id := reflect.ValueOf(newEntity).Elem().Field(0).Interface()
+
+	return fmt.Sprintf("%s successfully created with id: %v", h.name, id), nil
+}
+
+func (h *repositoryHandlers) GetAll(c *Context) (any, error) {
+	return h.repository.FindAll(c)
+}
+
+func (h *repositoryHandlers) Get(c *Context) (any, error) {
+	id, err := h.pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := h.repository.Find(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.versionColumn != "" {
+		c.SetVersion(strconv.FormatInt(h.version(entity), 10))
+	}
+
+	return entity, nil
+}
+
+// Update updates the entity, provided the version the client based its changes on is the current one for versioned
+// entities, calling its update hooks with a nil transaction.
+func (h *repositoryHandlers) Update(c *Context) (any, error) {
+	id, err := h.pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	newEntity := reflect.New(h.entityType).Interface()
+
+	if err := c.Bind(newEntity); err != nil {
+		return nil, err
+	}
+
+	// the entity is identified by the path, whatever the body says.
+	reflect.ValueOf(newEntity).Elem().Field(0).Set(reflect.ValueOf(id))
+
+	if h.versionColumn != "" {
+		current, err := h.repository.Find(c, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := h.checkVersion(c, newEntity, h.version(current)); err != nil {
+			return nil, err
+		}
+
+		h.setVersion(newEntity, h.version(current))
+	}
+
+	if err := h.update(c, id, newEntity); err != nil {
+		return nil, err
+	}
+
+	return fmt.Sprintf("%s successfully updated with id: %v", h.name, id), nil
+}
+
+// Patch updates the entity with the JSON Merge Patch or the JSON Patch in the body of the request.
+func (h *repositoryHandlers) Patch(c *Context) (any, error) {
+	id, err := h.pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := h.repository.Find(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, supplied, err := h.applyPatch(c, current)
+	if err != nil {
+		return nil, err
+	}
+
+	reflect.ValueOf(patched).Elem().Field(0).Set(reflect.ValueOf(id))
+
+	// the version of the body is the one supplied by the patch, as for the SQL handlers.
+	if h.versionColumn != "" {
+		body := reflect.New(h.entityType).Interface()
+		if slices.Contains(supplied, h.versionIndex) {
+			body = patched
+		}
+
+		if err := h.checkVersion(c, body, h.version(current)); err != nil {
+			return nil, err
+		}
+
+		h.setVersion(patched, h.version(current))
+	}
+
+	if err := h.update(c, id, patched); err != nil {
+		return nil, err
+	}
+
+	return fmt.Sprintf("%s successfully updated with id: %v", h.name, id), nil
+}
+
+// update stores the updated entity, whose version is the current one for versioned entities, between its update
+// hooks.
+func (h *repositoryHandlers) update(c *Context, id, entity any) error {
+	// the deleted_at field is only set by Delete.
+	h.clearDeleted(entity)
+	h.setAudit(c, entity, auditUpdatedAt, auditUpdatedBy)
+
+	if err := callHook(entity, func(hook BeforeUpdate) error { return hook.BeforeUpdate(c, nil) }); err != nil {
+		return err
+	}
+
+	if err := h.versionConflict(h.repository.Update(c, id, entity)); err != nil {
+		return err
+	}
+
+	if h.versionColumn != "" {
+		c.SetVersion(strconv.FormatInt(h.version(entity)+1, 10))
+	}
+
+	return callHook(entity, func(hook AfterUpdate) error { return hook.AfterUpdate(c, nil) })
+}
+
+// Delete deletes the entity, or soft deletes it if it has a deleted_at field, calling its delete hooks with a nil
+// transaction. Versioned entities are deleted provided the If-Match header of the request has their current version.
+func (h *repositoryHandlers) Delete(c *Context) (any, error) {
+	id, err := h.pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var current any
+
+	if h.versionColumn != "" || h.softDeleteColumn != "" || h.hasDeleteHooks() {
+		if current, err = h.repository.Find(c, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if h.versionColumn != "" {
+		version := strconv.FormatInt(h.version(current), 10)
+
+		if c.header("If-Match") == "" {
+			c.SetVersion(version)
+
+			return nil, gofrHTTP.ErrorPreconditionRequired{}
+		}
+
+		if err := c.CheckVersion(version); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := callHook(current, func(hook BeforeDelete) error { return hook.BeforeDelete(c, nil) }); err != nil {
+		return nil, err
+	}
+
+	if h.softDeleteColumn != "" {
+		h.setAudit(c, current, auditDeletedAt, auditUpdatedAt, auditUpdatedBy)
+
+		err = h.repository.Update(c, id, current)
+	} else {
+		err = h.repository.Delete(c, id, current)
+	}
+
+	if err := h.versionConflict(err); err != nil {
+		return nil, err
+	}
+
+	if err := callHook(current, func(hook AfterDelete) error { return hook.AfterDelete(c, nil) }); err != nil {
+		return nil, err
+	}
+
+	return fmt.Sprintf("%s successfully deleted with id: %v", h.name, id), nil
+}
+
+// versionConflict returns an http.ErrorPreconditionFailed for versioned entities the repository didn't find: they
+// were found by the handler, but modified or deleted since.
+func (h *repositoryHandlers) versionConflict(err error) error {
+	if h.versionColumn != "" && errors.As(err, &gofrHTTP.ErrorEntityNotFound{}) {
+		return gofrHTTP.ErrorPreconditionFailed{}
+	}
+
+	return err
+}
+
+// clearDeleted clears the deleted_at field of the entity, if any.
+func (h *repositoryHandlers) clearDeleted(entity any) {
+	if i, ok := h.auditFields[auditDeletedAt]; ok {
+		reflect.ValueOf(entity).Elem().Field(i).SetZero()
+	}
+}
+
+// pathID returns the ID of the path, converted to the type of the primary key field.
+func (h *repositoryHandlers) pathID(c *Context) (any, error) {
+	id, err := parseID(c.PathParam(h.primaryKey), h.entityType.Field(0).Type)
+	if err != nil {
+		return nil, gofrHTTP.ErrorInvalidParam{Params: []string{h.primaryKey}}
+	}
+
+	return id, nil
+}
+
+// parseID converts an ID to the given type, which is a string, an integer or implements encoding.TextUnmarshaler,
+// like uuid.UUID.
+func parseID(value string, typ reflect.Type) (any, error) {
+	id := reflect.New(typ)
+
+	if u, ok := id.Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(value)); err != nil {
+			return nil, err
+		}
+
+		return id.Elem().Interface(), nil
+	}
+
+	elem := id.Elem()
+
+	switch {
+	case typ.Kind() == reflect.String:
+		elem.SetString(value)
+	case elem.CanInt():
+		i, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+
+		elem.SetInt(i)
+	case elem.CanUint():
+		u, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+
+		elem.SetUint(u)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedID, typ)
+	}
+
+	return elem.Interface(), nil
+}
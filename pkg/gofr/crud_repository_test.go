@@ -0,0 +1,632 @@
+package gofr
+
+import (
+	gosql "database/sql"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+	gofrSql "gofr.dev/pkg/gofr/datasource/sql"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+type product struct {
+	ID        int       `json:"id" bson:"_id" db:"product_id"`
+	Name      string    `json:"name"`
+	Price     float64   `json:"price" bson:"price,omitempty"`
+	CreatedAt time.Time `json:"createdAt" sql:"created_at"`
+	UpdatedBy string    `json:"updatedBy" sql:"updated_by"`
+}
+
+// note is a versioned entity, which is soft deleted.
+type note struct {
+	ID        int        `json:"id" bson:"_id"`
+	Text      string     `json:"text"`
+	Version   int        `json:"version" sql:"version"`
+	DeletedAt *time.Time `json:"deletedAt" sql:"deleted_at"`
+}
+
+func newNoteHandlers(t *testing.T, factory RepositoryFactory) *repositoryHandlers {
+	t.Helper()
+
+	e, err := scanEntity(&note{})
+	require.NoError(t, err)
+
+	h, err := newRepositoryHandlers(e, factory)
+	require.NoError(t, err)
+
+	return h
+}
+
+func newProductHandlers(t *testing.T, factory RepositoryFactory) *repositoryHandlers {
+	t.Helper()
+
+	e, err := scanEntity(&product{})
+	require.NoError(t, err)
+
+	h, err := newRepositoryHandlers(e, factory)
+	require.NoError(t, err)
+
+	return h
+}
+
+func Test_newRepositoryHandlers(t *testing.T) {
+	type nullTimeDeletedAt struct {
+		ID        int
+		DeletedAt gosql.NullTime `sql:"deleted_at"`
+	}
+
+	type autoIncremented struct {
+		ID int `sql:"auto_increment"`
+	}
+
+	for i, object := range []any{&nullTimeDeletedAt{}, &autoIncremented{}} {
+		e, err := scanEntity(object)
+		require.NoError(t, err)
+
+		_, err = newRepositoryHandlers(e, MongoRepository)
+		require.ErrorIs(t, err, errSQLOnlyConstraint, "TEST[%d], Failed.\n", i)
+	}
+
+	e, err := scanEntity(&note{})
+	require.NoError(t, err)
+
+	_, err = newRepositoryHandlers(e, func(info EntityInfo) Repository {
+		assert.Equal(t, []string{"Text"}, info.UpdatableFields)
+		assert.Equal(t, "Version", info.VersionField)
+		assert.Equal(t, "DeletedAt", info.SoftDeleteField)
+
+		return MongoRepository(info)
+	})
+	require.NoError(t, err)
+
+	var info EntityInfo
+
+	newProductHandlers(t, func(i EntityInfo) Repository {
+		info = i
+		return MongoRepository(i)
+	})
+
+	assert.Equal(t, "product", info.Name)
+	assert.Equal(t, "product", info.Table)
+	assert.Equal(t, "id", info.PrimaryKey)
+	assert.Equal(t, []string{"Name", "Price", "UpdatedBy"}, info.UpdatableFields)
+}
+
+func Test_parseID(t *testing.T) {
+	id := uuid.New()
+
+	testCases := []struct {
+		desc     string
+		value    string
+		idType   any
+		expected any
+		hasError bool
+	}{
+		{"int", "42", 0, 42, false},
+		{"int8 out of range", "300", int8(0), nil, true},
+		{"uint", "7", uint64(0), uint64(7), false},
+		{"not an int", "abc", 0, nil, true},
+		{"string", "abc", "", "abc", false},
+		{"uuid", id.String(), uuid.UUID{}, id, false},
+		{"invalid uuid", "abc", uuid.UUID{}, nil, true},
+		{"unsupported type", "1.5", 0.0, nil, true},
+	}
+
+	for i, tc := range testCases {
+		parsed, err := parseID(tc.value, reflect.TypeOf(tc.idType))
+
+		assert.Equal(t, tc.hasError, err != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expected, parsed, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+// matchingMongo is a Mongo driver telling the documents matched by updates.
+type matchingMongo struct {
+	*container.MockMongo
+	*container.MockMongoUpdateMatcher
+}
+
+func Test_MongoRepository(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	h := newProductHandlers(t, MongoRepository)
+
+	t.Run("create sets the audit fields", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPost, "", `{"id":1,"name":"pen","price":2.5}`, c)
+
+		mocks.Mongo.EXPECT().InsertOne(ctx, "product", gomock.Any()).DoAndReturn(
+			func(_ *Context, _ string, document any) (any, error) {
+				p := document.(*product)
+
+				assert.Equal(t, "pen", p.Name)
+				assert.Equal(t, "alice", p.UpdatedBy)
+				assert.False(t, p.CreatedAt.IsZero())
+
+				return 1, nil
+			})
+
+		resp, err := h.Create(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "product successfully created with id: 1", resp)
+	})
+
+	t.Run("get all", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "", "", c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "product", map[string]any{}, gomock.Any()).DoAndReturn(
+			func(_ *Context, _ string, _, results any) error {
+				*results.(*[]product) = []product{{ID: 1, Name: "pen"}, {ID: 2, Name: "ink"}}
+
+				return nil
+			})
+
+		resp, err := h.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []any{&product{ID: 1, Name: "pen"}, &product{ID: 2, Name: "ink"}}, resp)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "1", "", c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "product", map[string]any{"_id": 1}, gomock.Any()).DoAndReturn(
+			func(_ *Context, _ string, _, results any) error {
+				*results.(*[]product) = []product{{ID: 1, Name: "pen"}}
+
+				return nil
+			})
+
+		resp, err := h.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &product{ID: 1, Name: "pen"}, resp)
+	})
+
+	t.Run("get missing entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "2", "", c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "product", map[string]any{"_id": 2}, gomock.Any()).Return(nil)
+
+		_, err := h.Get(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+
+	t.Run("get with invalid id", func(t *testing.T) {
+		_, err := h.Get(createAuthenticatedTestContext(http.MethodGet, "abc", "", c))
+		require.Equal(t, gofrHTTP.ErrorInvalidParam{Params: []string{"id"}}, err)
+	})
+
+	t.Run("update sets the updatable fields", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPut, "1", `{"id":5,"name":"pen","price":3,`+
+			`"createdAt":"2025-01-01T00:00:00Z"}`, c)
+
+		mocks.Mongo.EXPECT().CountDocuments(ctx, "product", map[string]any{"_id": 1}).Return(int64(1), nil)
+		mocks.Mongo.EXPECT().UpdateOne(ctx, "product", map[string]any{"_id": 1},
+			map[string]any{"$set": map[string]any{"name": "pen", "price": 3.0, "updatedby": "alice"}}).Return(nil)
+
+		resp, err := h.Update(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "product successfully updated with id: 1", resp)
+	})
+
+	t.Run("update missing entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPut, "2", `{"name":"pen"}`, c)
+
+		mocks.Mongo.EXPECT().CountDocuments(ctx, "product", map[string]any{"_id": 2}).Return(int64(0), nil)
+
+		_, err := h.Update(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+
+	t.Run("update with the matched count of the driver", func(t *testing.T) {
+		matcher := container.NewMockMongoUpdateMatcher(gomock.NewController(t))
+		c.Mongo = matchingMongo{MockMongo: mocks.Mongo, MockMongoUpdateMatcher: matcher}
+
+		t.Cleanup(func() { c.Mongo = mocks.Mongo })
+
+		ctx := createAuthenticatedTestContext(http.MethodPut, "1", `{"name":"pen"}`, c)
+
+		matcher.EXPECT().UpdateOneMatched(ctx, "product", map[string]any{"_id": 1}, gomock.Any()).Return(int64(1), nil)
+
+		_, err := h.Update(ctx)
+		require.NoError(t, err)
+
+		ctx = createAuthenticatedTestContext(http.MethodPut, "2", `{"name":"pen"}`, c)
+
+		matcher.EXPECT().UpdateOneMatched(ctx, "product", map[string]any{"_id": 2}, gomock.Any()).Return(int64(0), nil)
+
+		_, err = h.Update(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodDelete, "1", "", c)
+
+		mocks.Mongo.EXPECT().DeleteOne(ctx, "product", map[string]any{"_id": 1}).Return(int64(1), nil)
+
+		resp, err := h.Delete(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "product successfully deleted with id: 1", resp)
+	})
+
+	t.Run("delete missing entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodDelete, "2", "", c)
+
+		mocks.Mongo.EXPECT().DeleteOne(ctx, "product", map[string]any{"_id": 2}).Return(int64(0), nil)
+
+		_, err := h.Delete(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodDelete, "1", "", c)
+
+		mocks.Mongo.EXPECT().DeleteOne(ctx, "product", map[string]any{"_id": 1}).Return(int64(0), errMock)
+
+		_, err := h.Delete(ctx)
+		require.ErrorIs(t, err, errMock)
+	})
+}
+
+func Test_CouchbaseRepository(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	h := newProductHandlers(t, CouchbaseRepository)
+
+	t.Run("create", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPost, "", `{"id":1,"name":"pen"}`, c)
+
+		mocks.Couchbase.EXPECT().Query(ctx, "INSERT INTO `product` (KEY, VALUE) VALUES ($id, $entity)",
+			gomock.Any(), nil).DoAndReturn(func(_ *Context, _ string, params map[string]any, _ any) error {
+			assert.Equal(t, "1", params["id"])
+			assert.Equal(t, "pen", params["entity"].(*product).Name)
+
+			return nil
+		})
+
+		_, err := h.Create(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("get all", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "", "", c)
+
+		mocks.Couchbase.EXPECT().Query(ctx, "SELECT d.* FROM `product` AS d", nil, gomock.Any()).Return(nil)
+
+		resp, err := h.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "1", "", c)
+
+		mocks.Couchbase.EXPECT().Query(ctx, "SELECT d.* FROM `product` AS d USE KEYS $id", map[string]any{"id": "1"},
+			gomock.Any()).DoAndReturn(func(_ *Context, _ string, _ map[string]any, result any) error {
+			*result.(*[]product) = []product{{ID: 1, Name: "pen"}}
+
+			return nil
+		})
+
+		resp, err := h.Get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &product{ID: 1, Name: "pen"}, resp)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPut, "1", `{"name":"pen","price":3}`, c)
+
+		mocks.Couchbase.EXPECT().Query(ctx, "UPDATE `product` AS d USE KEYS $id SET d.`name` = $f0, d.`price` = $f1, "+
+			"d.`updatedBy` = $f2 RETURNING META(d).id", map[string]any{"id": "1", "f0": "pen", "f1": 3.0, "f2": "alice"},
+			gomock.Any()).DoAndReturn(func(_ *Context, _ string, _ map[string]any, result any) error {
+			*result.(*[]map[string]any) = []map[string]any{{"id": "1"}}
+
+			return nil
+		})
+
+		_, err := h.Update(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete missing entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodDelete, "2", "", c)
+
+		mocks.Couchbase.EXPECT().Query(ctx, "DELETE FROM `product` AS d USE KEYS $id RETURNING META(d).id",
+			map[string]any{"id": "2"}, gomock.Any()).Return(nil)
+
+		_, err := h.Delete(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+}
+
+func Test_CassandraRepository(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	h := newProductHandlers(t, CassandraRepository)
+
+	t.Run("create", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPost, "", `{"id":1,"name":"pen"}`, c)
+
+		mocks.Cassandra.EXPECT().ExecCASWithCtx(ctx, gomock.Any(), "INSERT INTO product (product_id, name, price, "+
+			"created_at, updated_by) VALUES (?, ?, ?, ?, ?) IF NOT EXISTS", 1, "pen", 0.0, gomock.Any(), "alice").
+			Return(true, nil)
+
+		_, err := h.Create(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("create existing entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPost, "", `{"id":1,"name":"pen"}`, c)
+
+		mocks.Cassandra.EXPECT().ExecCASWithCtx(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		_, err := h.Create(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityAlreadyExist{}, err)
+	})
+
+	t.Run("get all", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "", "", c)
+
+		mocks.Cassandra.EXPECT().QueryWithCtx(ctx, gomock.Any(), "SELECT * FROM product").DoAndReturn(
+			func(_ *Context, dest any, _ string, _ ...any) error {
+				*dest.(*[]product) = []product{{ID: 1, Name: "pen"}}
+
+				return nil
+			})
+
+		resp, err := h.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []any{&product{ID: 1, Name: "pen"}}, resp)
+	})
+
+	t.Run("get missing entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodGet, "2", "", c)
+
+		mocks.Cassandra.EXPECT().QueryWithCtx(ctx, gomock.Any(), "SELECT * FROM product WHERE product_id = ?", 2).
+			Return(nil)
+
+		_, err := h.Get(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodPut, "1", `{"name":"pen","price":3}`, c)
+
+		mocks.Cassandra.EXPECT().ExecCASWithCtx(ctx, gomock.Any(), "UPDATE product SET name = ?, price = ?, "+
+			"updated_by = ? WHERE product_id = ? IF EXISTS", "pen", 3.0, "alice", 1).Return(true, nil)
+
+		_, err := h.Update(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete missing entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodDelete, "2", "", c)
+
+		mocks.Cassandra.EXPECT().ExecCASWithCtx(ctx, gomock.Any(), "DELETE FROM product WHERE product_id = ? IF EXISTS",
+			2).Return(false, nil)
+
+		_, err := h.Delete(ctx)
+		require.Equal(t, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}, err)
+	})
+}
+
+func Test_RepositoryVersionAndSoftDelete(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	h := newNoteHandlers(t, MongoRepository)
+
+	current := func(_ *Context, _ string, _, results any) error {
+		*results.(*[]note) = []note{{ID: 1, Text: "a", Version: 3}}
+
+		return nil
+	}
+
+	t.Run("create starts at version 1", func(t *testing.T) {
+		ctx, w := createVersionedTestContext(http.MethodPost, "", "", []byte(`{"id":1,"text":"a","version":7,`+
+			`"deletedAt":"2025-01-01T00:00:00Z"}`), c)
+
+		mocks.Mongo.EXPECT().InsertOne(ctx, "note", &note{ID: 1, Text: "a", Version: 1}).Return(1, nil)
+
+		_, err := h.Create(ctx)
+		require.NoError(t, err)
+
+		ctx.responder.Respond(nil, nil)
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("get skips the deleted entities", func(t *testing.T) {
+		ctx, w := createVersionedTestContext(http.MethodGet, "1", "", nil, c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "note", map[string]any{"_id": 1, "deletedat": nil}, gomock.Any()).
+			DoAndReturn(current)
+
+		resp, err := h.Get(ctx)
+		require.NoError(t, err)
+
+		ctx.responder.Respond(resp, err)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("update increments the current version", func(t *testing.T) {
+		ctx, w := createVersionedTestContext(http.MethodPut, "1", `"3"`, []byte(`{"text":"b"}`), c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "note", gomock.Any(), gomock.Any()).DoAndReturn(current)
+		mocks.Mongo.EXPECT().CountDocuments(ctx, "note", map[string]any{"_id": 1, "deletedat": nil, "version": 3}).
+			Return(int64(1), nil)
+		mocks.Mongo.EXPECT().UpdateOne(ctx, "note", gomock.Any(), map[string]any{"$set": map[string]any{
+			"text": "b", "deletedat": (*time.Time)(nil), "version": 4}}).Return(nil)
+
+		_, err := h.Update(ctx)
+		require.NoError(t, err)
+
+		ctx.responder.Respond(nil, nil)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("update of a stale version", func(t *testing.T) {
+		ctx, _ := createVersionedTestContext(http.MethodPut, "1", `"2"`, []byte(`{"text":"b"}`), c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "note", gomock.Any(), gomock.Any()).DoAndReturn(current)
+
+		_, err := h.Update(ctx)
+		require.Equal(t, gofrHTTP.ErrorPreconditionFailed{}, err)
+	})
+
+	t.Run("update of an entity modified since it was found", func(t *testing.T) {
+		ctx, _ := createVersionedTestContext(http.MethodPut, "1", `"3"`, []byte(`{"text":"b"}`), c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "note", gomock.Any(), gomock.Any()).DoAndReturn(current)
+		mocks.Mongo.EXPECT().CountDocuments(ctx, "note", gomock.Any()).Return(int64(0), nil)
+
+		_, err := h.Update(ctx)
+		require.Equal(t, gofrHTTP.ErrorPreconditionFailed{}, err)
+	})
+
+	t.Run("delete without if-match", func(t *testing.T) {
+		ctx, w := createVersionedTestContext(http.MethodDelete, "1", "", nil, c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "note", gomock.Any(), gomock.Any()).DoAndReturn(current)
+
+		_, err := h.Delete(ctx)
+		require.Equal(t, gofrHTTP.ErrorPreconditionRequired{}, err)
+
+		ctx.responder.Respond(nil, err)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("delete sets deleted_at", func(t *testing.T) {
+		ctx, _ := createVersionedTestContext(http.MethodDelete, "1", `"3"`, nil, c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "note", gomock.Any(), gomock.Any()).DoAndReturn(current)
+		mocks.Mongo.EXPECT().CountDocuments(ctx, "note", map[string]any{"_id": 1, "deletedat": nil, "version": 3}).
+			Return(int64(1), nil)
+		mocks.Mongo.EXPECT().UpdateOne(ctx, "note", gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ *Context, _ string, _, update any) error {
+				fields := update.(map[string]any)["$set"].(map[string]any)
+
+				assert.NotNil(t, fields["deletedat"])
+				assert.Equal(t, 4, fields["version"])
+
+				return nil
+			})
+
+		_, err := h.Delete(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("couchbase statements", func(t *testing.T) {
+		h := newNoteHandlers(t, CouchbaseRepository)
+		ctx, _ := createVersionedTestContext(http.MethodPut, "1", `"3"`, []byte(`{"text":"b"}`), c)
+
+		mocks.Couchbase.EXPECT().Query(ctx, "SELECT d.* FROM `note` AS d USE KEYS $id WHERE d.`deletedAt` IS NOT VALUED",
+			map[string]any{"id": "1"}, gomock.Any()).DoAndReturn(func(_ *Context, _ string, _ map[string]any, result any) error {
+			*result.(*[]note) = []note{{ID: 1, Text: "a", Version: 3}}
+
+			return nil
+		})
+		mocks.Couchbase.EXPECT().Query(ctx, "UPDATE `note` AS d USE KEYS $id SET d.`text` = $f0, d.`deletedAt` = $f1, "+
+			"d.`version` = $f2 WHERE d.`deletedAt` IS NOT VALUED AND d.`version` = $version RETURNING META(d).id",
+			map[string]any{"id": "1", "f0": "b", "f1": (*time.Time)(nil), "f2": 4, "version": 3}, gomock.Any()).Return(nil)
+
+		_, err := h.Update(ctx)
+		require.Equal(t, gofrHTTP.ErrorPreconditionFailed{}, err)
+	})
+
+	t.Run("cassandra statements", func(t *testing.T) {
+		h := newNoteHandlers(t, CassandraRepository)
+		ctx, _ := createVersionedTestContext(http.MethodGet, "", "", nil, c)
+		deletedAt := time.Now()
+
+		mocks.Cassandra.EXPECT().QueryWithCtx(ctx, gomock.Any(), "SELECT * FROM note").DoAndReturn(
+			func(_ *Context, dest any, _ string, _ ...any) error {
+				*dest.(*[]note) = []note{{ID: 1, Text: "a"}, {ID: 2, Text: "b", DeletedAt: &deletedAt}}
+
+				return nil
+			})
+
+		resp, err := h.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []any{&note{ID: 1, Text: "a"}}, resp)
+
+		ctx, _ = createVersionedTestContext(http.MethodPut, "1", `"3"`, []byte(`{"text":"b"}`), c)
+
+		mocks.Cassandra.EXPECT().QueryWithCtx(ctx, gomock.Any(), "SELECT * FROM note WHERE id = ?", 1).DoAndReturn(
+			func(_ *Context, dest any, _ string, _ ...any) error {
+				*dest.(*[]note) = []note{{ID: 1, Text: "a", Version: 3}}
+
+				return nil
+			})
+		mocks.Cassandra.EXPECT().ExecCASWithCtx(ctx, gomock.Any(), "UPDATE note SET text = ?, deleted_at = ?, "+
+			"version = ? WHERE id = ? IF deleted_at = null AND version = ?", "b", (*time.Time)(nil), 4, 1, 3).
+			Return(true, nil)
+
+		_, err = h.Update(ctx)
+		require.NoError(t, err)
+	})
+}
+
+// hookedNote is an entity with lifecycle hooks, stored by a Repository.
+type hookedNote struct {
+	ID   int    `json:"id" bson:"_id"`
+	Text string `json:"text"`
+}
+
+var errHookedNoteTx = errors.New("unexpected transaction")
+
+func (n *hookedNote) BeforeCreate(_ *Context, tx *gofrSql.Tx) error {
+	if tx != nil {
+		return errHookedNoteTx
+	}
+
+	if n.Text == "" {
+		return gofrHTTP.ErrorMissingParam{Params: []string{"text"}}
+	}
+
+	return nil
+}
+
+func (n *hookedNote) BeforeDelete(*Context, *gofrSql.Tx) error {
+	if n.Text == "pinned" {
+		return gofrHTTP.ErrorInvalidParam{Params: []string{"id"}}
+	}
+
+	return nil
+}
+
+func Test_RepositoryHooks(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	e, err := scanEntity(&hookedNote{})
+	require.NoError(t, err)
+
+	h, err := newRepositoryHandlers(e, MongoRepository)
+	require.NoError(t, err)
+
+	t.Run("create rejected by a hook", func(t *testing.T) {
+		_, err := h.Create(createAuthenticatedTestContext(http.MethodPost, "", `{"id":1}`, c))
+		require.Equal(t, gofrHTTP.ErrorMissingParam{Params: []string{"text"}}, err)
+	})
+
+	t.Run("delete calls the hooks on the current entity", func(t *testing.T) {
+		ctx := createAuthenticatedTestContext(http.MethodDelete, "1", "", c)
+
+		mocks.Mongo.EXPECT().Find(ctx, "hooked_note", map[string]any{"_id": 1}, gomock.Any()).DoAndReturn(
+			func(_ *Context, _ string, _, results any) error {
+				*results.(*[]hookedNote) = []hookedNote{{ID: 1, Text: "pinned"}}
+
+				return nil
+			})
+
+		_, err := h.Delete(ctx)
+		require.Equal(t, gofrHTTP.ErrorInvalidParam{Params: []string{"id"}}, err)
+	})
+}
+
+func Test_quoteKeyspace(t *testing.T) {
+	assert.Equal(t, "`users`", quoteKeyspace("users"))
+	assert.Equal(t, "`app`.`inventory`.`users`", quoteKeyspace("app.`inventory`.users"))
+}
@@ -0,0 +1,225 @@
+// Package cdc reads the changes of datasources, such as the change streams of MongoDB, the logical replication of
+// Postgres and the watches of NATS key-value buckets, as messages for the subscribers of GoFr. The position of the
+// last change handled is checkpointed in the key-value store of the container, for the changes to be read again
+// from it when the application restarts.
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+// Operations of the changes.
+const (
+	OperationInsert = "insert"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// checkpointPrefix prefixes the keys of the checkpoints in the key-value store, with characters valid in the keys
+// of all the stores.
+const checkpointPrefix = "gofr_cdc."
+
+var (
+	errNoCheckpointStore = errors.New("a key-value store is required to checkpoint the changes")
+	errFeedEnded         = errors.New("change feed ended")
+)
+
+// Change is a change of a datasource. The handlers subscribed to the changes bind it from their context:
+//
+//	app.SubscribeChanges(cdc.NewMongoChangeStream("orders", "orders"), func(ctx *gofr.Context) error {
+//		var change cdc.Change
+//
+//		if err := ctx.Bind(&change); err != nil {
+//			return err
+//		}
+//		...
+//	})
+//
+// Before and After hold the changed document, row or value as JSON, which can be bound in turn, or by binding a
+// struct whose Before and After fields have the type of the data.
+type Change struct {
+	// Operation is one of OperationInsert, OperationUpdate and OperationDelete.
+	Operation string `json:"operation"`
+	// Namespace is the collection of a document or the schema qualified table of a row.
+	Namespace string `json:"namespace,omitempty"`
+	// Key identifies the changed document, row or value, such as the _id of a document or the key of a value.
+	Key json.RawMessage `json:"key,omitempty"`
+	// Before is the data before an update or a delete, when the datasource provides it.
+	Before json.RawMessage `json:"before,omitempty"`
+	// After is the data after an insert or an update.
+	After json.RawMessage `json:"after,omitempty"`
+	// Time is the time of the change, when the datasource provides it.
+	Time time.Time `json:"time,omitzero"`
+}
+
+// Source reads the changes of a datasource of the container.
+type Source interface {
+	// Name is the topic of the messages of the changes, and identifies their checkpoint.
+	Name() string
+
+	// Read calls emit with the changes following checkpoint, along with the checkpoint following each of them,
+	// until ctx is done or emit returns an error. An empty checkpoint reads the changes from the current time,
+	// or from where the datasource keeps them from.
+	Read(ctx context.Context, c *container.Container, checkpoint string,
+		emit func(change *Change, checkpoint string) error) error
+}
+
+// acknowledger is implemented by the sources telling the datasource about the changes handled, for it to release
+// them.
+type acknowledger interface {
+	acknowledge(ctx context.Context, c *container.Container, checkpoint string) error
+}
+
+// Subscriber subscribes to the changes read by a source, as the subscriber of the message broker of the container
+// subscribes to its topics. The changes are read in the background from the last checkpoint, and each message
+// committed once handled saves the checkpoint following its change.
+type Subscriber struct {
+	source    Source
+	container *container.Container
+	messages  chan *pubsub.Message
+
+	mu sync.Mutex
+	// done is closed once the changes stop being read, and is nil while they are not.
+	done chan struct{}
+	// err is the error which stopped reading the changes.
+	err error
+}
+
+// NewSubscriber returns the subscriber to the changes of source, checkpointed in the key-value store of c.
+func NewSubscriber(c *container.Container, source Source) (*Subscriber, error) {
+	if c.KVStore == nil {
+		return nil, errNoCheckpointStore
+	}
+
+	return &Subscriber{
+		source:    source,
+		container: c,
+		messages:  make(chan *pubsub.Message),
+	}, nil
+}
+
+// Subscribe returns the next change of the source, the topic being the name of the source. It returns the error
+// which stopped reading the changes, which are read again from the last checkpoint on the next call.
+func (s *Subscriber) Subscribe(ctx context.Context, _ string) (*pubsub.Message, error) {
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+
+	done := s.startReading(ctx)
+
+	select {
+	case <-ctx.Done():
+		return nil, nil
+	case msg := <-s.messages:
+		return msg, nil
+	case <-done:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.done = nil
+
+		return nil, s.err
+	}
+}
+
+// startReading reads the changes in the background unless they are being read, and returns the channel closed
+// once they stop being read.
+func (s *Subscriber) startReading(ctx context.Context) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done != nil {
+		return s.done
+	}
+
+	done := make(chan struct{})
+	s.done = done
+
+	go func() {
+		err := s.read(ctx)
+		if err == nil {
+			err = errFeedEnded
+		}
+
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+
+		close(done)
+	}()
+
+	return done
+}
+
+func (s *Subscriber) read(ctx context.Context) error {
+	checkpoint, err := s.loadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.container.Debugf("reading the changes of %s from checkpoint %q", s.source.Name(), checkpoint)
+
+	return s.source.Read(ctx, s.container, checkpoint, func(change *Change, checkpoint string) error {
+		value, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+
+		msg := pubsub.NewMessage(ctx)
+		msg.Topic = s.source.Name()
+		msg.Value = value
+		msg.MetaData = change
+		msg.Committer = &committer{subscriber: s, checkpoint: checkpoint}
+
+		select {
+		case s.messages <- msg:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+func (s *Subscriber) loadCheckpoint(ctx context.Context) (string, error) {
+	checkpoint, err := s.container.KVStore.Get(ctx, checkpointPrefix+s.source.Name())
+	if errors.Is(err, container.ErrKeyNotFound) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("error loading checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// committer saves the checkpoint following the change of a message, once handled without error.
+type committer struct {
+	subscriber *Subscriber
+	checkpoint string
+}
+
+func (c *committer) Commit() {
+	s := c.subscriber
+	ctx := context.Background()
+
+	if err := s.container.KVStore.Set(ctx, checkpointPrefix+s.source.Name(), c.checkpoint); err != nil {
+		s.container.Errorf("error saving checkpoint %q of %s: %v", c.checkpoint, s.source.Name(), err)
+
+		return
+	}
+
+	if a, ok := s.source.(acknowledger); ok {
+		if err := a.acknowledge(ctx, s.container, c.checkpoint); err != nil {
+			s.container.Errorf("error acknowledging checkpoint %q of %s: %v", c.checkpoint, s.source.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+var errSourceFails = errors.New("source fails")
+
+// fakeSource emits its changes, numbering their checkpoints from the one it is read from, then fails.
+type fakeSource struct {
+	changes      []*Change
+	checkpoints  chan string
+	acknowledged chan string
+}
+
+func (*fakeSource) Name() string { return "orders" }
+
+func (f *fakeSource) Read(_ context.Context, _ *container.Container, checkpoint string,
+	emit func(change *Change, checkpoint string) error) error {
+	f.checkpoints <- checkpoint
+
+	for i, change := range f.changes {
+		if err := emit(change, fmt.Sprint(i+1)); err != nil {
+			return err
+		}
+	}
+
+	return errSourceFails
+}
+
+func (f *fakeSource) acknowledge(_ context.Context, _ *container.Container, checkpoint string) error {
+	f.acknowledged <- checkpoint
+
+	return nil
+}
+
+func TestSubscriber(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	source := &fakeSource{
+		changes: []*Change{
+			{Operation: OperationInsert, Namespace: "orders", Key: []byte(`"1"`), After: []byte(`{"total":10}`)},
+		},
+		checkpoints:  make(chan string, 2),
+		acknowledged: make(chan string, 1),
+	}
+
+	subscriber, err := NewSubscriber(c, source)
+	require.NoError(t, err)
+
+	mocks.KVStore.EXPECT().Get(gomock.Any(), "gofr_cdc.orders").Return("", fmt.Errorf("%w: gofr_cdc.orders", container.ErrKeyNotFound))
+
+	msg, err := subscriber.Subscribe(t.Context(), "orders")
+	require.NoError(t, err)
+
+	assert.Equal(t, "", <-source.checkpoints)
+	assert.Equal(t, "orders", msg.Topic)
+	assert.Equal(t, source.changes[0], msg.MetaData)
+
+	var change struct {
+		Operation string `json:"operation"`
+		After     struct {
+			Total int `json:"total"`
+		} `json:"after"`
+	}
+
+	require.NoError(t, msg.Bind(&change))
+	assert.Equal(t, OperationInsert, change.Operation)
+	assert.Equal(t, 10, change.After.Total)
+
+	mocks.KVStore.EXPECT().Set(gomock.Any(), "gofr_cdc.orders", "1").Return(nil)
+
+	msg.Commit()
+
+	assert.Equal(t, "1", <-source.acknowledged)
+
+	// the source failing is reported, and it is read again from the last checkpoint.
+	_, err = subscriber.Subscribe(t.Context(), "orders")
+	require.ErrorIs(t, err, errSourceFails)
+
+	mocks.KVStore.EXPECT().Get(gomock.Any(), "gofr_cdc.orders").Return("1", nil)
+
+	_, err = subscriber.Subscribe(t.Context(), "orders")
+	require.NoError(t, err)
+	assert.Equal(t, "1", <-source.checkpoints)
+}
+
+func TestSubscriber_Errors(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	source := &fakeSource{checkpoints: make(chan string, 1)}
+
+	mocks.KVStore.EXPECT().Get(gomock.Any(), "gofr_cdc.orders").Return("", errSourceFails)
+
+	subscriber, err := NewSubscriber(c, source)
+	require.NoError(t, err)
+
+	_, err = subscriber.Subscribe(t.Context(), "orders")
+	require.ErrorIs(t, err, errSourceFails)
+	assert.Empty(t, source.checkpoints, "the source is not read without its checkpoint")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	msg, err := subscriber.Subscribe(ctx, "orders")
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+
+	c.KVStore = nil
+
+	_, err = NewSubscriber(c, source)
+	require.ErrorIs(t, err, errNoCheckpointStore)
+}
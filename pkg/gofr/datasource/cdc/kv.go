@@ -0,0 +1,83 @@
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+type kvWatch struct {
+	name   string
+	prefix string
+}
+
+// NewKVWatch returns the source of the changes of the keys starting with prefix in the key-value store, such as
+// a NATS key-value bucket, read by watching the store. The revisions of the keys are the checkpoints, and the
+// changes start from the history kept by the store when none is saved.
+//
+// Setting a key is an update, and the values holding a JSON object or array are set as After as they are, the
+// others as JSON strings.
+func NewKVWatch(name, prefix string) Source {
+	return kvWatch{name: name, prefix: prefix}
+}
+
+func (w kvWatch) Name() string {
+	return w.name
+}
+
+func (w kvWatch) Read(ctx context.Context, c *container.Container, checkpoint string,
+	emit func(change *Change, checkpoint string) error) error {
+	watcher, ok := c.KVStore.(container.KVStoreRevisionWatcher)
+	if !ok {
+		return fmt.Errorf("%w: %T", errWatchNotSupported, c.KVStore)
+	}
+
+	var revision uint64
+
+	if checkpoint != "" {
+		var err error
+
+		if revision, err = strconv.ParseUint(checkpoint, 10, 64); err != nil {
+			return fmt.Errorf("invalid checkpoint %q: %w", checkpoint, err)
+		}
+	}
+
+	return watcher.WatchFrom(ctx, w.prefix, revision, func(key, value string, revision uint64, deleted bool) error {
+		// the checkpoints saved in the same store are not changes of the data.
+		if strings.HasPrefix(key, checkpointPrefix) {
+			return nil
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+
+		change := &Change{Operation: OperationUpdate, Key: k}
+
+		if deleted {
+			change.Operation = OperationDelete
+		} else {
+			change.After = jsonValue(value)
+		}
+
+		return emit(change, strconv.FormatUint(revision, 10))
+	})
+}
+
+func jsonValue(value string) json.RawMessage {
+	trimmed := strings.TrimSpace(value)
+
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+
+	// strings are always encoded.
+	raw, _ := json.Marshal(value)
+
+	return raw
+}
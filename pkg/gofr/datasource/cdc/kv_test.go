@@ -0,0 +1,88 @@
+package cdc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+type kvChange struct {
+	key, value string
+	revision   uint64
+	deleted    bool
+}
+
+// watchingKVStore streams its changes made after the revision it is watched from.
+type watchingKVStore struct {
+	*container.MockKVStore
+
+	changes []kvChange
+}
+
+func (s *watchingKVStore) WatchFrom(_ context.Context, _ string, revision uint64,
+	handler func(key, value string, revision uint64, deleted bool) error) error {
+	for _, change := range s.changes {
+		if change.revision <= revision {
+			continue
+		}
+
+		if err := handler(change.key, change.value, change.revision, change.deleted); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestKVWatch_Read(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	c.KVStore = &watchingKVStore{MockKVStore: mocks.KVStore, changes: []kvChange{
+		{key: "session.1", value: "token", revision: 3},
+		{key: "session.2", value: `{"user": "john"}`, revision: 4},
+		{key: checkpointPrefix + "sessions", value: "4", revision: 5},
+		{key: "session.1", revision: 6, deleted: true},
+	}}
+
+	var (
+		changes     []*Change
+		checkpoints []string
+	)
+
+	err := NewKVWatch("sessions", "session.").Read(t.Context(), c, "3", func(change *Change, checkpoint string) error {
+		changes = append(changes, change)
+		checkpoints = append(checkpoints, checkpoint)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4", "6"}, checkpoints)
+	assert.Equal(t, []*Change{
+		{Operation: OperationUpdate, Key: []byte(`"session.2"`), After: []byte(`{"user": "john"}`)},
+		{Operation: OperationDelete, Key: []byte(`"session.1"`)},
+	}, changes)
+}
+
+func TestKVWatch_Errors(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	err := NewKVWatch("sessions", "session.").Read(t.Context(), c, "", func(*Change, string) error { return nil })
+	require.ErrorIs(t, err, errWatchNotSupported)
+
+	c.KVStore = &watchingKVStore{MockKVStore: mocks.KVStore}
+
+	err = NewKVWatch("sessions", "session.").Read(t.Context(), c, "revision", func(*Change, string) error { return nil })
+	require.ErrorContains(t, err, "invalid checkpoint")
+}
+
+func TestJSONValue(t *testing.T) {
+	assert.JSONEq(t, `{"a":1}`, string(jsonValue(` {"a":1}`)))
+	assert.JSONEq(t, `[1,2]`, string(jsonValue(`[1,2]`)))
+	assert.JSONEq(t, `"42"`, string(jsonValue(`42`)))
+	assert.JSONEq(t, `"{broken"`, string(jsonValue(`{broken`)))
+}
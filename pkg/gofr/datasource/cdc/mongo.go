@@ -0,0 +1,110 @@
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+var errWatchNotSupported = errors.New("datasource does not support watching changes")
+
+type mongoChangeStream struct {
+	name       string
+	collection string
+}
+
+// NewMongoChangeStream returns the source of the changes of a collection of the Mongo datasource, read from its
+// change stream, which requires MongoDB to run as a replica set. The resume tokens of the stream are the
+// checkpoints, and the changes start from the time of the subscription when none is saved.
+//
+// The changes of updates hold the whole updated document as After.
+func NewMongoChangeStream(name, collection string) Source {
+	return mongoChangeStream{name: name, collection: collection}
+}
+
+func (m mongoChangeStream) Name() string {
+	return m.name
+}
+
+func (m mongoChangeStream) Read(ctx context.Context, c *container.Container, checkpoint string,
+	emit func(change *Change, checkpoint string) error) error {
+	watcher, ok := c.Mongo.(container.MongoWatcher)
+	if !ok {
+		return fmt.Errorf("%w: %T", errWatchNotSupported, c.Mongo)
+	}
+
+	return watcher.Watch(ctx, m.collection, checkpoint, func(event []byte, resumeToken string) error {
+		change, err := mongoChange(event)
+		if err != nil {
+			return err
+		}
+
+		// the events which do not change documents, such as the drop of the collection, are not emitted.
+		if change == nil {
+			return nil
+		}
+
+		return emit(change, resumeToken)
+	})
+}
+
+// mongoEvent is a change event of a change stream, as extended JSON.
+type mongoEvent struct {
+	OperationType string `json:"operationType"`
+	Namespace     struct {
+		Collection string `json:"coll"`
+	} `json:"ns"`
+	DocumentKey              map[string]json.RawMessage `json:"documentKey"`
+	FullDocument             json.RawMessage            `json:"fullDocument"`
+	FullDocumentBeforeChange json.RawMessage            `json:"fullDocumentBeforeChange"`
+	ClusterTime              struct {
+		Timestamp struct {
+			Seconds int64 `json:"t"`
+		} `json:"$timestamp"`
+	} `json:"clusterTime"`
+}
+
+func mongoChange(event []byte) (*Change, error) {
+	var e mongoEvent
+
+	if err := json.Unmarshal(event, &e); err != nil {
+		return nil, fmt.Errorf("error decoding change event: %w", err)
+	}
+
+	change := &Change{
+		Operation: e.OperationType,
+		Namespace: e.Namespace.Collection,
+		Key:       e.DocumentKey["_id"],
+		Before:    nullToEmpty(e.FullDocumentBeforeChange),
+		After:     nullToEmpty(e.FullDocument),
+	}
+
+	// the operations of MongoDB are named as the ones of the changes, replacing a document being an update.
+	switch e.OperationType {
+	case "insert", "update", "delete":
+	case "replace":
+		change.Operation = OperationUpdate
+	default:
+		return nil, nil
+	}
+
+	if e.ClusterTime.Timestamp.Seconds > 0 {
+		change.Time = time.Unix(e.ClusterTime.Timestamp.Seconds, 0).UTC()
+	}
+
+	return change, nil
+}
+
+// nullToEmpty leaves out the JSON null, for documents missing from an event not to be bound as null.
+func nullToEmpty(raw json.RawMessage) json.RawMessage {
+	if bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	return raw
+}
@@ -0,0 +1,87 @@
+package cdc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+// watchingMongo streams its events with the resume tokens numbering them.
+type watchingMongo struct {
+	*container.MockMongo
+
+	events      []string
+	resumeToken string
+}
+
+func (m *watchingMongo) Watch(_ context.Context, _, resumeToken string, handler func(event []byte, resumeToken string) error) error {
+	m.resumeToken = resumeToken
+
+	for i, event := range m.events {
+		if err := handler([]byte(event), fmt.Sprintf(`{"_data":"%c"}`, 'a'+i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestMongoChangeStream_Read(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	mongo := &watchingMongo{MockMongo: mocks.Mongo, events: []string{
+		`{"operationType":"insert","ns":{"db":"shop","coll":"orders"},"documentKey":{"_id":{"$oid":"6650"}},` +
+			`"fullDocument":{"_id":{"$oid":"6650"},"total":10},"clusterTime":{"$timestamp":{"t":1714557600,"i":1}}}`,
+		`{"operationType":"drop","ns":{"db":"shop","coll":"orders"}}`,
+		`{"operationType":"replace","ns":{"db":"shop","coll":"orders"},"documentKey":{"_id":1},"fullDocument":{"_id":1}}`,
+		`{"operationType":"delete","ns":{"db":"shop","coll":"orders"},"documentKey":{"_id":1},"fullDocument":null}`,
+	}}
+	c.Mongo = mongo
+
+	var (
+		changes     []*Change
+		checkpoints []string
+	)
+
+	err := NewMongoChangeStream("orders", "orders").Read(t.Context(), c, `{"_data":"z"}`,
+		func(change *Change, checkpoint string) error {
+			changes = append(changes, change)
+			checkpoints = append(checkpoints, checkpoint)
+
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"_data":"z"}`, mongo.resumeToken)
+	assert.Equal(t, []string{`{"_data":"a"}`, `{"_data":"c"}`, `{"_data":"d"}`}, checkpoints)
+
+	require.Len(t, changes, 3)
+	assert.Equal(t, &Change{
+		Operation: OperationInsert,
+		Namespace: "orders",
+		Key:       []byte(`{"$oid":"6650"}`),
+		After:     []byte(`{"_id":{"$oid":"6650"},"total":10}`),
+		Time:      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}, changes[0])
+	assert.Equal(t, OperationUpdate, changes[1].Operation)
+	assert.Equal(t, OperationDelete, changes[2].Operation)
+	assert.Nil(t, changes[2].After)
+}
+
+func TestMongoChangeStream_Errors(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	err := NewMongoChangeStream("orders", "orders").Read(t.Context(), c, "", func(*Change, string) error { return nil })
+	require.ErrorIs(t, err, errWatchNotSupported)
+
+	c.Mongo = &watchingMongo{MockMongo: mocks.Mongo, events: []string{`{"operationType":`}}
+
+	err = NewMongoChangeStream("orders", "orders").Read(t.Context(), c, "", func(*Change, string) error { return nil })
+	require.ErrorContains(t, err, "error decoding change event")
+}
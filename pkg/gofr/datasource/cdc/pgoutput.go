@@ -0,0 +1,368 @@
+package cdc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The type OIDs of Postgres whose text is valid JSON.
+const (
+	oidBool    = 16
+	oidInt8    = 20
+	oidInt2    = 21
+	oidInt4    = 23
+	oidOID     = 26
+	oidJSON    = 114
+	oidFloat4  = 700
+	oidFloat8  = 701
+	oidNumeric = 1700
+	oidJSONB   = 3802
+)
+
+// postgresEpoch is the origin of the timestamps of the replication protocol, in seconds since the Unix epoch.
+const postgresEpoch = 946684800
+
+var (
+	errTruncatedMessage = errors.New("truncated pgoutput message")
+	errUnknownRelation  = errors.New("change of unknown relation")
+	errUnexpectedTuple  = errors.New("unexpected tuple")
+)
+
+// pgPosition is the position of a change in the replication stream: the LSN of the commit of its transaction and
+// its index in the transaction, as the transactions are streamed in the order of their commits.
+type pgPosition struct {
+	lsn   uint64
+	index int
+}
+
+func (p pgPosition) after(o pgPosition) bool {
+	return p.lsn > o.lsn || (p.lsn == o.lsn && p.index > o.index)
+}
+
+func (p pgPosition) String() string {
+	return fmt.Sprintf("%s:%d", formatLSN(p.lsn), p.index)
+}
+
+func parsePosition(checkpoint string) (pgPosition, error) {
+	var (
+		p      pgPosition
+		hi, lo uint32
+	)
+
+	if _, err := fmt.Sscanf(checkpoint, "%X/%X:%d", &hi, &lo, &p.index); err != nil {
+		return pgPosition{}, fmt.Errorf("invalid checkpoint %q: %w", checkpoint, err)
+	}
+
+	p.lsn = uint64(hi)<<32 | uint64(lo)
+
+	return p, nil
+}
+
+// formatLSN formats lsn as Postgres does, for it to be cast to pg_lsn.
+func formatLSN(lsn uint64) string {
+	return fmt.Sprintf("%X/%X", uint32(lsn>>32), uint32(lsn)) //nolint:gosec // the halves of lsn fit in 32 bits.
+}
+
+type pgRelation struct {
+	namespace string
+	columns   []pgColumn
+}
+
+type pgColumn struct {
+	name    string
+	typeOID uint32
+	key     bool
+}
+
+// pgoutputDecoder decodes the messages of version 1 of the pgoutput logical replication protocol into changes.
+// The relations are described by messages preceding their first change in each decoding session.
+type pgoutputDecoder struct {
+	relations map[uint32]pgRelation
+	position  pgPosition
+	time      time.Time
+}
+
+func newPgoutputDecoder() *pgoutputDecoder {
+	return &pgoutputDecoder{relations: make(map[uint32]pgRelation)}
+}
+
+// decode decodes a message, returning the change of the inserts, updates and deletes, nil for the other messages.
+func (d *pgoutputDecoder) decode(data []byte) (*Change, error) {
+	if len(data) == 0 {
+		return nil, errTruncatedMessage
+	}
+
+	r := &pgReader{data: data[1:]}
+
+	var (
+		change *Change
+		err    error
+	)
+
+	switch data[0] {
+	case 'B':
+		d.position = pgPosition{lsn: r.uint64()}
+		d.time = time.Unix(postgresEpoch, 0).Add(time.Duration(r.int64()) * time.Microsecond).UTC()
+	case 'R':
+		d.decodeRelation(r)
+	case 'I', 'U', 'D':
+		change, err = d.decodeChange(data[0], r)
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return change, err
+}
+
+func (d *pgoutputDecoder) decodeRelation(r *pgReader) {
+	id := r.uint32()
+	relation := pgRelation{namespace: r.string() + "." + r.string()}
+
+	r.byte() // replica identity
+
+	relation.columns = make([]pgColumn, r.uint16())
+
+	for i := range relation.columns {
+		flags := r.byte()
+
+		relation.columns[i] = pgColumn{name: r.string(), typeOID: r.uint32(), key: flags&1 == 1}
+
+		r.uint32() // type modifier
+	}
+
+	d.relations[id] = relation
+}
+
+func (d *pgoutputDecoder) decodeChange(kind byte, r *pgReader) (*Change, error) {
+	relation, ok := d.relations[r.uint32()]
+	if !ok {
+		return nil, errUnknownRelation
+	}
+
+	d.position.index++
+
+	change := &Change{Namespace: relation.namespace, Time: d.time}
+
+	var keyTuple json.RawMessage
+
+	switch kind {
+	case 'I':
+		change.Operation = OperationInsert
+		change.After, keyTuple = decodeNewTuple(r, relation)
+	case 'U':
+		change.Operation = OperationUpdate
+
+		// the old row is sent when the key changed, or when the replica identity of the table is full.
+		if tag := r.peek(); tag == 'K' || tag == 'O' {
+			r.byte()
+
+			change.Before, _ = decodeTuple(r, relation, tag == 'K')
+		}
+
+		change.After, keyTuple = decodeNewTuple(r, relation)
+	case 'D':
+		change.Operation = OperationDelete
+
+		tag := r.byte()
+		if tag != 'K' && tag != 'O' {
+			return nil, fmt.Errorf("%w %q", errUnexpectedTuple, tag)
+		}
+
+		change.Before, keyTuple = decodeTuple(r, relation, tag == 'K')
+	}
+
+	change.Key = keyTuple
+
+	return change, nil
+}
+
+func decodeNewTuple(r *pgReader, relation pgRelation) (row, key json.RawMessage) {
+	if tag := r.byte(); tag != 'N' && r.err == nil {
+		r.err = fmt.Errorf("%w %q", errUnexpectedTuple, tag)
+
+		return nil, nil
+	}
+
+	return decodeTuple(r, relation, false)
+}
+
+// decodeTuple decodes a row as a JSON object, along with the JSON object of its key columns. The unchanged
+// values which Postgres does not send, such as the large values stored out of the row, are left out, as are the
+// columns other than the key ones of the rows holding only their key.
+func decodeTuple(r *pgReader, relation pgRelation, keyOnly bool) (row, key json.RawMessage) {
+	var rowBuf, keyBuf bytes.Buffer
+
+	n := int(r.uint16())
+
+	for i := 0; i < n && r.err == nil; i++ {
+		var value json.RawMessage
+
+		switch kind := r.byte(); kind {
+		case 'n':
+			value = json.RawMessage("null")
+		case 'u':
+			continue
+		case 't':
+			value = textToJSON(relation.column(i).typeOID, string(r.bytes(int(r.uint32()))))
+		default:
+			r.err = fmt.Errorf("%w value kind %q", errUnexpectedTuple, kind)
+
+			return nil, nil
+		}
+
+		column := relation.column(i)
+		if keyOnly && !column.key {
+			continue
+		}
+
+		appendMember(&rowBuf, column.name, value)
+
+		if column.key {
+			appendMember(&keyBuf, column.name, value)
+		}
+	}
+
+	return closeObject(&rowBuf), closeObject(&keyBuf)
+}
+
+func (p pgRelation) column(i int) pgColumn {
+	if i < len(p.columns) {
+		return p.columns[i]
+	}
+
+	return pgColumn{name: fmt.Sprint("column", i)}
+}
+
+func appendMember(buf *bytes.Buffer, name string, value json.RawMessage) {
+	if buf.Len() == 0 {
+		buf.WriteByte('{')
+	} else {
+		buf.WriteByte(',')
+	}
+
+	n, _ := json.Marshal(name)
+
+	buf.Write(n)
+	buf.WriteByte(':')
+	buf.Write(value)
+}
+
+func closeObject(buf *bytes.Buffer) json.RawMessage {
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes()
+}
+
+// textToJSON converts the text of a value of Postgres to JSON, as a number, a boolean or a JSON document for the
+// types holding them, and as a string otherwise.
+func textToJSON(typeOID uint32, text string) json.RawMessage {
+	switch typeOID {
+	case oidBool:
+		return json.RawMessage(fmt.Sprint(text == "t"))
+	case oidInt2, oidInt4, oidInt8, oidOID, oidFloat4, oidFloat8, oidNumeric, oidJSON, oidJSONB:
+		// NaN and Infinity, which are not JSON numbers, are kept as strings.
+		if json.Valid([]byte(text)) {
+			return json.RawMessage(text)
+		}
+	}
+
+	s, _ := json.Marshal(text)
+
+	return s
+}
+
+// pgReader reads the fields of a message of the replication protocol, recording the first read past its end.
+type pgReader struct {
+	data []byte
+	err  error
+}
+
+func (r *pgReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if n < 0 || n > len(r.data) {
+		r.err = errTruncatedMessage
+
+		return nil
+	}
+
+	b := r.data[:n]
+	r.data = r.data[n:]
+
+	return b
+}
+
+func (r *pgReader) peek() byte {
+	if r.err != nil || len(r.data) == 0 {
+		return 0
+	}
+
+	return r.data[0]
+}
+
+func (r *pgReader) byte() byte {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+
+	return 0
+}
+
+func (r *pgReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+
+	return 0
+}
+
+func (r *pgReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+
+	return 0
+}
+
+func (r *pgReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+
+	return 0
+}
+
+func (r *pgReader) int64() int64 {
+	return int64(r.uint64()) //nolint:gosec // the timestamps are signed.
+}
+
+// string reads a null-terminated string.
+func (r *pgReader) string() string {
+	if r.err != nil {
+		return ""
+	}
+
+	i := bytes.IndexByte(r.data, 0)
+	if i < 0 {
+		r.err = errTruncatedMessage
+
+		return ""
+	}
+
+	s := string(r.data[:i])
+	r.data = r.data[i+1:]
+
+	return s
+}
@@ -0,0 +1,159 @@
+package cdc
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pgMessage builds the messages of the pgoutput protocol.
+type pgMessage []byte
+
+func newPgMessage(kind byte) pgMessage { return pgMessage{kind} }
+
+func (m pgMessage) u8(v byte) pgMessage { return append(m, v) }
+
+func (m pgMessage) u16(v uint16) pgMessage { return binary.BigEndian.AppendUint16(m, v) }
+
+func (m pgMessage) u32(v uint32) pgMessage { return binary.BigEndian.AppendUint32(m, v) }
+
+func (m pgMessage) u64(v uint64) pgMessage { return binary.BigEndian.AppendUint64(m, v) }
+
+func (m pgMessage) str(v string) pgMessage { return append(append(m, v...), 0) }
+
+// tuple appends a tuple of text values, nil values being NULL.
+func (m pgMessage) tuple(values ...*string) pgMessage {
+	m = m.u16(uint16(len(values))) //nolint:gosec // the tuples of the tests are small.
+
+	for _, v := range values {
+		if v == nil {
+			m = m.u8('n')
+
+			continue
+		}
+
+		m = m.u8('t').u32(uint32(len(*v))) //nolint:gosec // the values of the tests are small.
+		m = append(m, *v...)
+	}
+
+	return m
+}
+
+func text(v string) *string { return &v }
+
+func pgBegin(lsn uint64, commitTime time.Time) []byte {
+	return newPgMessage('B').u64(lsn).u64(uint64(commitTime.Sub(time.Unix(postgresEpoch, 0)).Microseconds())).u32(1)
+}
+
+func pgUsersRelation() []byte {
+	return newPgMessage('R').u32(16384).str("public").str("users").u8('d').u16(4).
+		u8(1).str("id").u32(oidInt4).u32(0).
+		u8(0).str("name").u32(25).u32(0).
+		u8(0).str("active").u32(oidBool).u32(0).
+		u8(0).str("profile").u32(oidJSONB).u32(0)
+}
+
+func TestPgoutputDecoder(t *testing.T) {
+	commitTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	insert := newPgMessage('I').u32(16384).u8('N').tuple(text("1"), text("john"), text("t"), text(`{"age": 30}`))
+	update := newPgMessage('U').u32(16384).u8('O').tuple(text("1"), text("john"), text("t"), nil).
+		u8('N').tuple(text("1"), text("jane"), text("f"), nil)
+	deleteKey := newPgMessage('D').u32(16384).u8('K').tuple(text("1"), nil, nil, nil)
+
+	d := newPgoutputDecoder()
+
+	for _, msg := range [][]byte{pgBegin(0x16B374D848, commitTime), pgUsersRelation()} {
+		change, err := d.decode(msg)
+
+		require.NoError(t, err)
+		assert.Nil(t, change)
+	}
+
+	testCases := []struct {
+		desc     string
+		msg      []byte
+		expected string
+	}{
+		{desc: "insert", msg: insert, expected: `{"operation":"insert","namespace":"public.users","key":{"id":1},` +
+			`"after":{"id":1,"name":"john","active":true,"profile":{"age": 30}},"time":"2024-05-01T10:00:00Z"}`},
+		{desc: "update", msg: update, expected: `{"operation":"update","namespace":"public.users","key":{"id":1},` +
+			`"before":{"id":1,"name":"john","active":true,"profile":null},` +
+			`"after":{"id":1,"name":"jane","active":false,"profile":null},"time":"2024-05-01T10:00:00Z"}`},
+		{desc: "delete", msg: deleteKey, expected: `{"operation":"delete","namespace":"public.users","key":{"id":1},` +
+			`"before":{"id":1},"time":"2024-05-01T10:00:00Z"}`},
+	}
+
+	for i, tc := range testCases {
+		change, err := d.decode(tc.msg)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		encoded, err := json.Marshal(change)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, tc.expected, string(encoded), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, fmt.Sprintf("16/B374D848:%d", i+1), d.position.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestPgoutputDecoder_Errors(t *testing.T) {
+	d := newPgoutputDecoder()
+
+	_, err := d.decode(newPgMessage('I').u32(1).u8('N').tuple(text("1")))
+	require.ErrorIs(t, err, errUnknownRelation)
+
+	_, err = d.decode(pgUsersRelation()[:12])
+	require.ErrorIs(t, err, errTruncatedMessage)
+
+	_, err = d.decode(pgUsersRelation())
+	require.NoError(t, err)
+
+	_, err = d.decode(newPgMessage('I').u32(16384).u8('N').tuple(text("1"))[:10])
+	require.ErrorIs(t, err, errTruncatedMessage)
+
+	_, err = d.decode(newPgMessage('D').u32(16384).u8('N').tuple(text("1")))
+	require.ErrorIs(t, err, errUnexpectedTuple)
+
+	_, err = d.decode(nil)
+	require.ErrorIs(t, err, errTruncatedMessage)
+}
+
+func TestPgPosition(t *testing.T) {
+	p, err := parsePosition("16/B374D848:3")
+
+	require.NoError(t, err)
+	assert.Equal(t, pgPosition{lsn: 0x16B374D848, index: 3}, p)
+	assert.Equal(t, "16/B374D848:3", p.String())
+
+	assert.True(t, pgPosition{lsn: 2, index: 1}.after(pgPosition{lsn: 1, index: 5}))
+	assert.True(t, pgPosition{lsn: 2, index: 2}.after(pgPosition{lsn: 2, index: 1}))
+	assert.False(t, pgPosition{lsn: 2, index: 1}.after(pgPosition{lsn: 2, index: 1}))
+
+	_, err = parsePosition("16/B374D848")
+	require.Error(t, err)
+}
+
+func TestTextToJSON(t *testing.T) {
+	testCases := []struct {
+		typeOID  uint32
+		text     string
+		expected string
+	}{
+		{typeOID: oidInt8, text: "42", expected: `42`},
+		{typeOID: oidNumeric, text: "3.14", expected: `3.14`},
+		{typeOID: oidNumeric, text: "NaN", expected: `"NaN"`},
+		{typeOID: oidBool, text: "f", expected: `false`},
+		{typeOID: oidJSON, text: `[1, 2]`, expected: `[1, 2]`},
+		{typeOID: 25, text: `say "hi"`, expected: `"say \"hi\""`},
+		{typeOID: 1184, text: "2024-05-01 10:00:00+00", expected: `"2024-05-01 10:00:00+00"`},
+	}
+
+	for i, tc := range testCases {
+		assert.JSONEq(t, tc.expected, string(textToJSON(tc.typeOID, tc.text)), "TEST[%d], Failed.\n%s", i, tc.text)
+	}
+}
@@ -0,0 +1,194 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+const (
+	// pollInterval is the wait between the reads of the replication slot which returned no change.
+	pollInterval = time.Second
+	// maxChangesPerRead bounds the changes read from the replication slot at once, the transactions being read
+	// whole.
+	maxChangesPerRead = 1000
+
+	peekChangesQuery = "SELECT data FROM pg_logical_slot_peek_binary_changes($1, NULL, $2, " +
+		"'proto_version', '1', 'publication_names', $3)"
+	advanceSlotQuery = "SELECT pg_replication_slot_advance(slot_name, GREATEST($2::pg_lsn, confirmed_flush_lsn)) " +
+		"FROM pg_replication_slots WHERE slot_name = $1"
+)
+
+var errReplicationNotSupported = errors.New("logical replication requires a postgres or supabase SQL datasource")
+
+type postgresReplication struct {
+	name        string
+	slot        string
+	publication string
+
+	// mu keeps the slot from being advanced while read, which Postgres refuses.
+	mu *sync.Mutex
+}
+
+// NewPostgresReplication returns the source of the changes of the tables of a publication of the SQL datasource,
+// read by logical replication with the pgoutput plugin. The publication is created beforehand, in a migration for
+// instance, and the replication slot is created when missing, which requires wal_level to be logical:
+//
+//	CREATE PUBLICATION orders_publication FOR TABLE orders
+//
+// The slot is read over the connections of the SQL datasource, and advanced as the changes are handled, for
+// Postgres to release the WAL they were read from. The checkpoints are the LSNs of the commits of the changes.
+func NewPostgresReplication(name, slot, publication string) Source {
+	return postgresReplication{name: name, slot: slot, publication: publication, mu: &sync.Mutex{}}
+}
+
+func (p postgresReplication) Name() string {
+	return p.name
+}
+
+func (p postgresReplication) Read(ctx context.Context, c *container.Container, checkpoint string,
+	emit func(change *Change, checkpoint string) error) error {
+	if c.SQL == nil {
+		return errReplicationNotSupported
+	}
+
+	if dialect := c.SQL.Dialect(); dialect != "postgres" && dialect != "supabase" {
+		return fmt.Errorf("%w, not %s", errReplicationNotSupported, dialect)
+	}
+
+	if err := p.createSlot(ctx, c); err != nil {
+		return err
+	}
+
+	var last pgPosition
+
+	if checkpoint != "" {
+		var err error
+
+		if last, err = parsePosition(checkpoint); err != nil {
+			return err
+		}
+
+		// the slot may not have been advanced to the checkpoint, when the application stopped in between.
+		if err = p.acknowledge(ctx, c, checkpoint); err != nil {
+			return err
+		}
+	}
+
+	decoder := newPgoutputDecoder()
+
+	for {
+		emitted, err := p.readChanges(ctx, c, decoder, &last, emit)
+		if err != nil {
+			return err
+		}
+
+		if emitted > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (p postgresReplication) createSlot(ctx context.Context, c *container.Container) error {
+	var exists bool
+
+	err := c.SQL.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = $1)",
+		p.slot).Scan(&exists)
+	if err != nil || exists {
+		return err
+	}
+
+	c.Infof("creating replication slot %s for the changes of %s", p.slot, p.name)
+
+	_, err = c.SQL.ExecContext(ctx, "SELECT pg_create_logical_replication_slot($1, 'pgoutput')", p.slot)
+
+	return err
+}
+
+// readChanges emits the changes following last in the slot, which are read whole before being emitted, for the
+// slot not to be in use when advanced as the changes are handled.
+func (p postgresReplication) readChanges(ctx context.Context, c *container.Container, decoder *pgoutputDecoder,
+	last *pgPosition, emit func(change *Change, checkpoint string) error) (int, error) {
+	messages, err := p.peekChanges(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+
+	emitted := 0
+
+	for _, data := range messages {
+		change, err := decoder.decode(data)
+		if err != nil {
+			return emitted, err
+		}
+
+		// the changes of the transactions read again, until the slot is advanced past them, were already emitted.
+		if change == nil || !decoder.position.after(*last) {
+			continue
+		}
+
+		if err := emit(change, decoder.position.String()); err != nil {
+			return emitted, err
+		}
+
+		*last = decoder.position
+		emitted++
+	}
+
+	return emitted, nil
+}
+
+func (p postgresReplication) peekChanges(ctx context.Context, c *container.Container) ([][]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rows, err := c.SQL.QueryContext(ctx, peekChangesQuery, p.slot, maxChangesPerRead, p.publication)
+	if err != nil {
+		return nil, fmt.Errorf("error reading replication slot %s: %w", p.slot, err)
+	}
+	defer rows.Close()
+
+	messages := make([][]byte, 0)
+
+	for rows.Next() {
+		var data []byte
+
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, data)
+	}
+
+	return messages, rows.Err()
+}
+
+// acknowledge advances the slot to the commit of the transaction of the checkpoint, which is read again from the
+// slot until all its changes are handled.
+func (p postgresReplication) acknowledge(ctx context.Context, c *container.Container, checkpoint string) error {
+	position, err := parsePosition(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// the slot is never moved back, which Postgres refuses.
+	_, err = c.SQL.ExecContext(ctx, advanceSlotQuery, p.slot, formatLSN(position.lsn))
+	if err != nil {
+		return fmt.Errorf("error advancing replication slot %s: %w", p.slot, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package cdc
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+var errStopReading = errors.New("stop reading")
+
+func TestPostgresReplication_Read(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	commitTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	insert := func(id string) []byte {
+		return newPgMessage('I').u32(16384).u8('N').tuple(text(id), text("john"), text("t"), nil)
+	}
+
+	mocks.SQL.ExpectDialect().WillReturnString("postgres")
+	mocks.SQL.ExpectQuery("SELECT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = $1)").
+		WithArgs("users_slot").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mocks.SQL.ExpectExec("SELECT pg_create_logical_replication_slot($1, 'pgoutput')").
+		WithArgs("users_slot").WillReturnResult(sqlmock.NewResult(0, 1))
+	mocks.SQL.ExpectExec(advanceSlotQuery).WithArgs("users_slot", "0/100").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mocks.SQL.ExpectQuery(peekChangesQuery).WithArgs("users_slot", maxChangesPerRead, "users_publication").
+		WillReturnRows(sqlmock.NewRows([]string{"data"}).
+			AddRow(pgBegin(0x100, commitTime)).AddRow(pgUsersRelation()).AddRow(insert("1")).AddRow(insert("2")).
+			AddRow(pgBegin(0x200, commitTime)).AddRow(pgUsersRelation()).AddRow(insert("3")).
+			AddRow([]byte{'C'}))
+
+	source := NewPostgresReplication("users", "users_slot", "users_publication")
+
+	var (
+		keys        []string
+		checkpoints []string
+	)
+
+	// the first change of the transaction at 0/100 was handled before the checkpoint was saved.
+	err := source.Read(t.Context(), c, "0/100:1", func(change *Change, checkpoint string) error {
+		keys = append(keys, string(change.Key))
+		checkpoints = append(checkpoints, checkpoint)
+
+		if len(keys) == 2 {
+			return errStopReading
+		}
+
+		return nil
+	})
+
+	require.ErrorIs(t, err, errStopReading)
+	assert.Equal(t, []string{`{"id":2}`, `{"id":3}`}, keys)
+	assert.Equal(t, []string{"0/100:2", "0/200:1"}, checkpoints)
+}
+
+func TestPostgresReplication_Acknowledge(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	mocks.SQL.ExpectExec(advanceSlotQuery).WithArgs("users_slot", "16/B374D848").
+		WillReturnResult(driver.ResultNoRows)
+
+	source := NewPostgresReplication("users", "users_slot", "users_publication").(postgresReplication)
+
+	require.NoError(t, source.acknowledge(t.Context(), c, "16/B374D848:7"))
+	require.Error(t, source.acknowledge(t.Context(), c, "invalid"))
+}
+
+func TestPostgresReplication_Unsupported(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+
+	mocks.SQL.ExpectDialect().WillReturnString("mysql")
+
+	err := NewPostgresReplication("users", "users_slot", "users_publication").Read(t.Context(), c, "",
+		func(*Change, string) error { return nil })
+
+	require.ErrorIs(t, err, errReplicationNotSupported)
+
+	c.SQL = nil
+
+	err = NewPostgresReplication("users", "users_slot", "users_publication").Read(t.Context(), c, "",
+		func(*Change, string) error { return nil })
+
+	require.ErrorIs(t, err, errReplicationNotSupported)
+}
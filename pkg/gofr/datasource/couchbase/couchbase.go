@@ -398,6 +398,11 @@ func executeQuery(queryFn func() (resultProvider, error), queryType string, resu
 		return fmt.Errorf("%s query iteration error: %w", queryType, err)
 	}
 
+	// the statements run for their effect, such as the ones creating indexes, need no result.
+	if result == nil {
+		return nil
+	}
+
 	data, err := json.Marshal(tempResults)
 	if err != nil {
 		return fmt.Errorf("failed to marshal %s results: %w", queryType, err)
@@ -587,6 +587,23 @@ func TestClient_Query(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:      "success: N1QL statement without result",
+			statement: "CREATE INDEX idx_status ON `bucket`(status)",
+			params:    nil,
+			result:    nil,
+			setup: func(mocks *testMocks) *Client {
+				gomock.InOrder(mocks.cluster.EXPECT().Query(gomock.Any(), gomock.Any()).Return(mocks.queryResult, nil),
+					mocks.queryResult.EXPECT().Next().Return(false),
+					mocks.queryResult.EXPECT().Err().Return(nil),
+					mocks.queryResult.EXPECT().Close().Return(nil))
+				mocks.metrics.EXPECT().RecordHistogram(gomock.Any(), "app_couchbase_stats", gomock.Any(), gomock.Any()).AnyTimes()
+				mocks.logger.EXPECT().Debug(gomock.Any())
+				return &Client{
+					cluster: mocks.cluster, config: &Config{}, logger: mocks.logger, metrics: mocks.metrics,
+				}
+			},
+		},
 		{
 			name:      "error: from cluster.Query",
 			statement: "SELECT * FROM `bucket`",
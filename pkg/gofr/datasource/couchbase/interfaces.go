@@ -16,6 +16,13 @@ type Couchbase interface {
 	AnalyticsQuery(ctx context.Context, statement string, params map[string]any, result any) error
 	RunTransaction(ctx context.Context, logic func(attempt *gocb.TransactionAttemptContext) error) (*gocb.TransactionResult, error)
 	Close(opts any) error
+
+	CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error
+	DropBucket(ctx context.Context, bucket string) error
+	CreateScope(ctx context.Context, scope string) error
+	DropScope(ctx context.Context, scope string) error
+	CreateCollection(ctx context.Context, scope, collection string) error
+	DropCollection(ctx context.Context, scope, collection string) error
 }
 
 // clusterProvider is an interface that abstracts the gocb.Cluster for easier testing.
@@ -27,6 +34,7 @@ type clusterProvider interface {
 	Ping(opts *gocb.PingOptions) (*gocb.PingResult, error)
 	Close(opts *gocb.ClusterCloseOptions) error
 	Transactions() transactionsProvider
+	Buckets() bucketManagerProvider
 }
 
 // resultProvider is an interface that abstracts gocb.QueryResult and gocb.AnalyticsResult for easier testing.
@@ -43,6 +51,7 @@ type bucketProvider interface {
 	DefaultCollection() collectionProvider
 	WaitUntilReady(timeout time.Duration, opts *gocb.WaitUntilReadyOptions) error
 	Scope(name string) scopeProvider
+	CollectionsV2() collectionManagerProvider
 }
 
 // collectionProvider is an interface that abstracts the gocb.Collection for easier testing.
@@ -66,3 +75,18 @@ type scopeProvider interface {
 type transactionsProvider interface {
 	Run(logic func(*gocb.TransactionAttemptContext) error, opts *gocb.TransactionOptions) (*gocb.TransactionResult, error)
 }
+
+// bucketManagerProvider is an interface that abstracts the gocb.BucketManager for easier testing.
+type bucketManagerProvider interface {
+	CreateBucket(settings gocb.CreateBucketSettings, opts *gocb.CreateBucketOptions) error
+	DropBucket(name string, opts *gocb.DropBucketOptions) error
+}
+
+// collectionManagerProvider is an interface that abstracts the gocb.CollectionManagerV2 for easier testing.
+type collectionManagerProvider interface {
+	CreateScope(scopeName string, opts *gocb.CreateScopeOptions) error
+	DropScope(scopeName string, opts *gocb.DropScopeOptions) error
+	CreateCollection(scopeName, collectionName string, settings *gocb.CreateCollectionSettings,
+		opts *gocb.CreateCollectionOptions) error
+	DropCollection(scopeName, collectionName string, opts *gocb.DropCollectionOptions) error
+}
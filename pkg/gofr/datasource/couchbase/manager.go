@@ -0,0 +1,100 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+)
+
+// CreateBucket creates a bucket in the cluster with a RAM quota of ramQuotaMB megabytes.
+func (c *Client) CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error {
+	if c.cluster == nil {
+		return errClustertNotInitialized
+	}
+
+	return c.manage(ctx, "CreateBucket", bucket, func(tracerCtx context.Context) error {
+		settings := gocb.CreateBucketSettings{BucketSettings: gocb.BucketSettings{
+			Name:       bucket,
+			RAMQuotaMB: ramQuotaMB,
+			BucketType: gocb.CouchbaseBucketType,
+		}}
+
+		return c.cluster.Buckets().CreateBucket(settings, &gocb.CreateBucketOptions{Context: tracerCtx})
+	})
+}
+
+// DropBucket deletes a bucket of the cluster with all its documents.
+func (c *Client) DropBucket(ctx context.Context, bucket string) error {
+	if c.cluster == nil {
+		return errClustertNotInitialized
+	}
+
+	return c.manage(ctx, "DropBucket", bucket, func(tracerCtx context.Context) error {
+		return c.cluster.Buckets().DropBucket(bucket, &gocb.DropBucketOptions{Context: tracerCtx})
+	})
+}
+
+// CreateScope creates a scope in the bucket of the client.
+func (c *Client) CreateScope(ctx context.Context, scope string) error {
+	if c.bucket == nil {
+		return errBucketNotInitialized
+	}
+
+	return c.manage(ctx, "CreateScope", scope, func(tracerCtx context.Context) error {
+		return c.bucket.CollectionsV2().CreateScope(scope, &gocb.CreateScopeOptions{Context: tracerCtx})
+	})
+}
+
+// DropScope deletes a scope of the bucket of the client with all its collections.
+func (c *Client) DropScope(ctx context.Context, scope string) error {
+	if c.bucket == nil {
+		return errBucketNotInitialized
+	}
+
+	return c.manage(ctx, "DropScope", scope, func(tracerCtx context.Context) error {
+		return c.bucket.CollectionsV2().DropScope(scope, &gocb.DropScopeOptions{Context: tracerCtx})
+	})
+}
+
+// CreateCollection creates a collection in a scope of the bucket of the client.
+func (c *Client) CreateCollection(ctx context.Context, scope, collection string) error {
+	if c.bucket == nil {
+		return errBucketNotInitialized
+	}
+
+	return c.manage(ctx, "CreateCollection", scope+"."+collection, func(tracerCtx context.Context) error {
+		return c.bucket.CollectionsV2().CreateCollection(scope, collection, nil,
+			&gocb.CreateCollectionOptions{Context: tracerCtx})
+	})
+}
+
+// DropCollection deletes a collection of a scope of the bucket of the client with all its documents.
+func (c *Client) DropCollection(ctx context.Context, scope, collection string) error {
+	if c.bucket == nil {
+		return errBucketNotInitialized
+	}
+
+	return c.manage(ctx, "DropCollection", scope+"."+collection, func(tracerCtx context.Context) error {
+		return c.bucket.CollectionsV2().DropCollection(scope, collection, &gocb.DropCollectionOptions{Context: tracerCtx})
+	})
+}
+
+// manage runs an operation managing the buckets, scopes or collections, traced and recorded as the queries are.
+func (c *Client) manage(ctx context.Context, operation, name string, op func(tracerCtx context.Context) error) error {
+	tracerCtx, span := c.addTrace(ctx, operation, name)
+	startTime := time.Now()
+
+	err := op(tracerCtx)
+
+	c.finishSpan(span, err)
+
+	defer c.sendOperationStats(&QueryLog{Query: operation, Statement: name}, startTime, operation)
+
+	if err != nil {
+		return fmt.Errorf("failed to %s %s: %w", operation, name, err)
+	}
+
+	return nil
+}
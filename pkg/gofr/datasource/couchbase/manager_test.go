@@ -0,0 +1,87 @@
+package couchbase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClient_ManageBucketsScopesAndCollections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mocks := newTestMocks(t)
+	buckets := NewMockbucketManagerProvider(ctrl)
+	collections := NewMockcollectionManagerProvider(ctrl)
+
+	mocks.cluster.EXPECT().Buckets().Return(buckets).AnyTimes()
+	mocks.bucket.EXPECT().CollectionsV2().Return(collections).AnyTimes()
+	mocks.logger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mocks.metrics.EXPECT().RecordHistogram(gomock.Any(), "app_couchbase_stats", gomock.Any(), gomock.Any()).AnyTimes()
+
+	client := &Client{cluster: mocks.cluster, bucket: mocks.bucket, config: &Config{}, logger: mocks.logger, metrics: mocks.metrics}
+
+	tests := []struct {
+		desc   string
+		expect func()
+		run    func(ctx context.Context) error
+		err    error
+	}{
+		{
+			desc: "create bucket",
+			expect: func() {
+				buckets.EXPECT().CreateBucket(gocb.CreateBucketSettings{BucketSettings: gocb.BucketSettings{
+					Name: "orders", RAMQuotaMB: 100, BucketType: gocb.CouchbaseBucketType,
+				}}, gomock.Any()).Return(nil)
+			},
+			run: func(ctx context.Context) error { return client.CreateBucket(ctx, "orders", 100) },
+		},
+		{
+			desc:   "drop bucket",
+			expect: func() { buckets.EXPECT().DropBucket("orders", gomock.Any()).Return(gocb.ErrBucketNotFound) },
+			run:    func(ctx context.Context) error { return client.DropBucket(ctx, "orders") },
+			err:    gocb.ErrBucketNotFound,
+		},
+		{
+			desc:   "create scope",
+			expect: func() { collections.EXPECT().CreateScope("sales", gomock.Any()).Return(nil) },
+			run:    func(ctx context.Context) error { return client.CreateScope(ctx, "sales") },
+		},
+		{
+			desc:   "drop scope",
+			expect: func() { collections.EXPECT().DropScope("sales", gomock.Any()).Return(gocb.ErrScopeNotFound) },
+			run:    func(ctx context.Context) error { return client.DropScope(ctx, "sales") },
+			err:    gocb.ErrScopeNotFound,
+		},
+		{
+			desc:   "create collection",
+			expect: func() { collections.EXPECT().CreateCollection("sales", "orders", nil, gomock.Any()).Return(nil) },
+			run:    func(ctx context.Context) error { return client.CreateCollection(ctx, "sales", "orders") },
+		},
+		{
+			desc:   "drop collection",
+			expect: func() { collections.EXPECT().DropCollection("sales", "orders", gomock.Any()).Return(nil) },
+			run:    func(ctx context.Context) error { return client.DropCollection(ctx, "sales", "orders") },
+		},
+	}
+
+	for i, tc := range tests {
+		tc.expect()
+
+		err := tc.run(t.Context())
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestClient_ManageNotConnected(t *testing.T) {
+	client := &Client{config: &Config{}}
+
+	require.ErrorIs(t, client.CreateBucket(t.Context(), "orders", 100), errClustertNotInitialized)
+	require.ErrorIs(t, client.DropBucket(t.Context(), "orders"), errClustertNotInitialized)
+	require.ErrorIs(t, client.CreateScope(t.Context(), "sales"), errBucketNotInitialized)
+	require.ErrorIs(t, client.DropScope(t.Context(), "sales"), errBucketNotInitialized)
+	require.ErrorIs(t, client.CreateCollection(t.Context(), "sales", "orders"), errBucketNotInitialized)
+	require.ErrorIs(t, client.DropCollection(t.Context(), "sales", "orders"), errBucketNotInitialized)
+}
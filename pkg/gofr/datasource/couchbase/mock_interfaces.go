@@ -70,6 +70,90 @@ func (mr *MockCouchbaseMockRecorder) Close(opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCouchbase)(nil).Close), opts)
 }
 
+// CreateBucket mocks base method.
+func (m *MockCouchbase) CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", ctx, bucket, ramQuotaMB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBucket indicates an expected call of CreateBucket.
+func (mr *MockCouchbaseMockRecorder) CreateBucket(ctx, bucket, ramQuotaMB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockCouchbase)(nil).CreateBucket), ctx, bucket, ramQuotaMB)
+}
+
+// CreateCollection mocks base method.
+func (m *MockCouchbase) CreateCollection(ctx context.Context, scope, collection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, scope, collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockCouchbaseMockRecorder) CreateCollection(ctx, scope, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockCouchbase)(nil).CreateCollection), ctx, scope, collection)
+}
+
+// CreateScope mocks base method.
+func (m *MockCouchbase) CreateScope(ctx context.Context, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScope", ctx, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateScope indicates an expected call of CreateScope.
+func (mr *MockCouchbaseMockRecorder) CreateScope(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScope", reflect.TypeOf((*MockCouchbase)(nil).CreateScope), ctx, scope)
+}
+
+// DropBucket mocks base method.
+func (m *MockCouchbase) DropBucket(ctx context.Context, bucket string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropBucket", ctx, bucket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropBucket indicates an expected call of DropBucket.
+func (mr *MockCouchbaseMockRecorder) DropBucket(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropBucket", reflect.TypeOf((*MockCouchbase)(nil).DropBucket), ctx, bucket)
+}
+
+// DropCollection mocks base method.
+func (m *MockCouchbase) DropCollection(ctx context.Context, scope, collection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropCollection", ctx, scope, collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropCollection indicates an expected call of DropCollection.
+func (mr *MockCouchbaseMockRecorder) DropCollection(ctx, scope, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropCollection", reflect.TypeOf((*MockCouchbase)(nil).DropCollection), ctx, scope, collection)
+}
+
+// DropScope mocks base method.
+func (m *MockCouchbase) DropScope(ctx context.Context, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropScope", ctx, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropScope indicates an expected call of DropScope.
+func (mr *MockCouchbaseMockRecorder) DropScope(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropScope", reflect.TypeOf((*MockCouchbase)(nil).DropScope), ctx, scope)
+}
+
 // Get mocks base method.
 func (m *MockCouchbase) Get(ctx context.Context, key string, result any) error {
 	m.ctrl.T.Helper()
@@ -208,6 +292,20 @@ func (mr *MockclusterProviderMockRecorder) Bucket(bucketName any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bucket", reflect.TypeOf((*MockclusterProvider)(nil).Bucket), bucketName)
 }
 
+// Buckets mocks base method.
+func (m *MockclusterProvider) Buckets() bucketManagerProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Buckets")
+	ret0, _ := ret[0].(bucketManagerProvider)
+	return ret0
+}
+
+// Buckets indicates an expected call of Buckets.
+func (mr *MockclusterProviderMockRecorder) Buckets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Buckets", reflect.TypeOf((*MockclusterProvider)(nil).Buckets))
+}
+
 // Close mocks base method.
 func (m *MockclusterProvider) Close(opts *gocb.ClusterCloseOptions) error {
 	m.ctrl.T.Helper()
@@ -398,6 +496,20 @@ func (mr *MockbucketProviderMockRecorder) Collection(collectionName any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collection", reflect.TypeOf((*MockbucketProvider)(nil).Collection), collectionName)
 }
 
+// CollectionsV2 mocks base method.
+func (m *MockbucketProvider) CollectionsV2() collectionManagerProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectionsV2")
+	ret0, _ := ret[0].(collectionManagerProvider)
+	return ret0
+}
+
+// CollectionsV2 indicates an expected call of CollectionsV2.
+func (mr *MockbucketProviderMockRecorder) CollectionsV2() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectionsV2", reflect.TypeOf((*MockbucketProvider)(nil).CollectionsV2))
+}
+
 // DefaultCollection mocks base method.
 func (m *MockbucketProvider) DefaultCollection() collectionProvider {
 	m.ctrl.T.Helper()
@@ -638,3 +750,135 @@ func (mr *MocktransactionsProviderMockRecorder) Run(logic, opts any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MocktransactionsProvider)(nil).Run), logic, opts)
 }
+
+// MockbucketManagerProvider is a mock of bucketManagerProvider interface.
+type MockbucketManagerProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockbucketManagerProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockbucketManagerProviderMockRecorder is the mock recorder for MockbucketManagerProvider.
+type MockbucketManagerProviderMockRecorder struct {
+	mock *MockbucketManagerProvider
+}
+
+// NewMockbucketManagerProvider creates a new mock instance.
+func NewMockbucketManagerProvider(ctrl *gomock.Controller) *MockbucketManagerProvider {
+	mock := &MockbucketManagerProvider{ctrl: ctrl}
+	mock.recorder = &MockbucketManagerProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbucketManagerProvider) EXPECT() *MockbucketManagerProviderMockRecorder {
+	return m.recorder
+}
+
+// CreateBucket mocks base method.
+func (m *MockbucketManagerProvider) CreateBucket(settings gocb.CreateBucketSettings, opts *gocb.CreateBucketOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", settings, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBucket indicates an expected call of CreateBucket.
+func (mr *MockbucketManagerProviderMockRecorder) CreateBucket(settings, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockbucketManagerProvider)(nil).CreateBucket), settings, opts)
+}
+
+// DropBucket mocks base method.
+func (m *MockbucketManagerProvider) DropBucket(name string, opts *gocb.DropBucketOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropBucket", name, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropBucket indicates an expected call of DropBucket.
+func (mr *MockbucketManagerProviderMockRecorder) DropBucket(name, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropBucket", reflect.TypeOf((*MockbucketManagerProvider)(nil).DropBucket), name, opts)
+}
+
+// MockcollectionManagerProvider is a mock of collectionManagerProvider interface.
+type MockcollectionManagerProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockcollectionManagerProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockcollectionManagerProviderMockRecorder is the mock recorder for MockcollectionManagerProvider.
+type MockcollectionManagerProviderMockRecorder struct {
+	mock *MockcollectionManagerProvider
+}
+
+// NewMockcollectionManagerProvider creates a new mock instance.
+func NewMockcollectionManagerProvider(ctrl *gomock.Controller) *MockcollectionManagerProvider {
+	mock := &MockcollectionManagerProvider{ctrl: ctrl}
+	mock.recorder = &MockcollectionManagerProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcollectionManagerProvider) EXPECT() *MockcollectionManagerProviderMockRecorder {
+	return m.recorder
+}
+
+// CreateCollection mocks base method.
+func (m *MockcollectionManagerProvider) CreateCollection(scopeName, collectionName string, settings *gocb.CreateCollectionSettings, opts *gocb.CreateCollectionOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", scopeName, collectionName, settings, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockcollectionManagerProviderMockRecorder) CreateCollection(scopeName, collectionName, settings, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockcollectionManagerProvider)(nil).CreateCollection), scopeName, collectionName, settings, opts)
+}
+
+// CreateScope mocks base method.
+func (m *MockcollectionManagerProvider) CreateScope(scopeName string, opts *gocb.CreateScopeOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScope", scopeName, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateScope indicates an expected call of CreateScope.
+func (mr *MockcollectionManagerProviderMockRecorder) CreateScope(scopeName, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScope", reflect.TypeOf((*MockcollectionManagerProvider)(nil).CreateScope), scopeName, opts)
+}
+
+// DropCollection mocks base method.
+func (m *MockcollectionManagerProvider) DropCollection(scopeName, collectionName string, opts *gocb.DropCollectionOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropCollection", scopeName, collectionName, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropCollection indicates an expected call of DropCollection.
+func (mr *MockcollectionManagerProviderMockRecorder) DropCollection(scopeName, collectionName, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropCollection", reflect.TypeOf((*MockcollectionManagerProvider)(nil).DropCollection), scopeName, collectionName, opts)
+}
+
+// DropScope mocks base method.
+func (m *MockcollectionManagerProvider) DropScope(scopeName string, opts *gocb.DropScopeOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropScope", scopeName, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropScope indicates an expected call of DropScope.
+func (mr *MockcollectionManagerProviderMockRecorder) DropScope(scopeName, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropScope", reflect.TypeOf((*MockcollectionManagerProvider)(nil).DropScope), scopeName, opts)
+}
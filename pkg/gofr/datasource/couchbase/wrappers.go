@@ -87,6 +87,11 @@ func (cw *clusterWrapper) Transactions() transactionsProvider {
 	return &transactionsWrapper{cw.Cluster.Transactions()}
 }
 
+// Buckets returns the manager of the buckets of the cluster.
+func (cw *clusterWrapper) Buckets() bucketManagerProvider {
+	return cw.Cluster.Buckets()
+}
+
 // Collection returns a collectionProvider for the specified collection name.
 func (bw *bucketWrapper) Collection(name string) collectionProvider {
 	return &collectionWrapper{bw.Bucket.Collection(name)}
@@ -101,6 +106,11 @@ func (bw *bucketWrapper) Scope(name string) scopeProvider {
 	return &scopeWrapper{bw.Bucket.Scope(name)}
 }
 
+// CollectionsV2 returns the manager of the scopes and collections of the bucket.
+func (bw *bucketWrapper) CollectionsV2() collectionManagerProvider {
+	return bw.Bucket.CollectionsV2()
+}
+
 func (bw *bucketWrapper) WaitUntilReady(timeout time.Duration, opts *gocb.WaitUntilReadyOptions) error {
 	return bw.Bucket.WaitUntilReady(timeout, opts)
 }
@@ -146,6 +146,21 @@ func (mr *MockDBMockRecorder) Begin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockDB)(nil).Begin))
 }
 
+// BeginTx mocks base method.
+func (m *MockDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql0.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx, opts)
+	ret0, _ := ret[0].(*sql0.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockDBMockRecorder) BeginTx(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockDB)(nil).BeginTx), ctx, opts)
+}
+
 // Close mocks base method.
 func (m *MockDB) Close() error {
 	m.ctrl.T.Helper()
@@ -337,3 +352,36 @@ func (mr *MockDBMockRecorder) Select(ctx, data, query any, args ...any) *gomock.
 	varargs := append([]any{ctx, data, query}, args...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockDB)(nil).Select), varargs...)
 }
+
+// SelectWithError mocks base method.
+func (m *MockDB) SelectWithError(ctx context.Context, data any, query string, args ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, data, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SelectWithError", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SelectWithError indicates an expected call of SelectWithError.
+func (mr *MockDBMockRecorder) SelectWithError(ctx, data, query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, data, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWithError", reflect.TypeOf((*MockDB)(nil).SelectWithError), varargs...)
+}
+
+// WithTx mocks base method.
+func (m *MockDB) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(*sql0.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockDBMockRecorder) WithTx(ctx, opts, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockDB)(nil).WithTx), ctx, opts, fn)
+}
@@ -30,7 +30,9 @@ const (
 	defaultTimeoutSec  = 30
 )
 
-var errReplicaFailedNoFallback = errors.New("replica query failed and fallback disabled")
+var (
+	errReplicaFailedNoFallback = errors.New("replica query failed and fallback disabled")
+)
 
 type contextKey string
 
@@ -462,6 +464,51 @@ func (r *Resolver) Select(ctx context.Context, data any, query string, args ...a
 	r.recordStats(start, "select", "primary", span, false, nil)
 }
 
+// SelectWithError routes to replica for reads, primary for writes, as Select does, and returns the error of the query.
+func (r *Resolver) SelectWithError(ctx context.Context, data any, query string, args ...any) error {
+	start := time.Now()
+
+	r.stats.totalQueries.Add(1)
+
+	tracedCtx, span := r.addTrace(ctx, "select", query)
+
+	if r.shouldUseReplica(ctx) && len(r.replicas) > 0 {
+		if wrapper := r.selectHealthyReplica(); wrapper != nil {
+			r.stats.replicaReads.Add(1)
+			wrapper.breaker.recordSuccess()
+
+			err := selectWithError(tracedCtx, wrapper.db, data, query, args...)
+
+			r.recordStats(start, "select", "replica", span, true, &wrapper.index)
+
+			return err
+		}
+
+		r.stats.replicaFailures.Add(1)
+	}
+
+	r.stats.primaryWrites.Add(1)
+
+	err := selectWithError(tracedCtx, r.primary, data, query, args...)
+
+	r.recordStats(start, "select", "primary", span, false, nil)
+
+	return err
+}
+
+// selectWithError runs SelectWithError on the databases providing it, and Select on the other ones.
+func selectWithError(ctx context.Context, db container.DB, data any, query string, args ...any) error {
+	if s, ok := db.(interface {
+		SelectWithError(ctx context.Context, data any, query string, args ...any) error
+	}); ok {
+		return s.SelectWithError(ctx, data, query, args...)
+	}
+
+	db.Select(ctx, data, query, args...)
+
+	return nil
+}
+
 // Prepare always routes to primary (consistency).
 func (r *Resolver) Prepare(query string) (*sql.Stmt, error) {
 	r.stats.totalQueries.Add(1)
@@ -476,6 +523,20 @@ func (r *Resolver) Begin() (*gofrSQL.Tx, error) {
 	return r.primary.Begin()
 }
 
+// BeginTx always routes to primary (transactions).
+func (r *Resolver) BeginTx(ctx context.Context, opts *sql.TxOptions) (*gofrSQL.Tx, error) {
+	r.stats.totalQueries.Add(1)
+
+	return r.primary.BeginTx(ctx, opts)
+}
+
+// WithTx always routes to primary (transactions), including the retries of the transaction.
+func (r *Resolver) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *gofrSQL.Tx) error) error {
+	r.stats.totalQueries.Add(1)
+
+	return r.primary.WithTx(ctx, opts, fn)
+}
+
 // Dialect returns the database dialect.
 func (r *Resolver) Dialect() string {
 	return r.primary.Dialect()
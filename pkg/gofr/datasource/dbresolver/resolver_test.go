@@ -364,6 +364,45 @@ func TestResolver_Select_WriteGoesToPrimary(t *testing.T) {
 	mocks.Resolver.Select(t.Context(), data, writeQuery, args[0])
 }
 
+func TestResolver_SelectWithError(t *testing.T) {
+	mocks := setupMocks(t)
+	defer mocks.Ctrl.Finish()
+
+	data := &struct{ Name string }{}
+	readQuery := "SELECT name FROM users WHERE id = ?"
+
+	mocks.Strategy.EXPECT().Next(2).Return(1)
+	mocks.MockReplicas[1].EXPECT().SelectWithError(gomock.Any(), data, readQuery, 1).Return(sql.ErrNoRows)
+	mocks.Primary.EXPECT().SelectWithError(gomock.Any(), data, readQuery, 2).Return(nil)
+
+	err := mocks.Resolver.SelectWithError(WithHTTPMethod(t.Context(), "GET"), data, readQuery, 1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = mocks.Resolver.SelectWithError(t.Context(), data, readQuery, 2)
+	require.NoError(t, err)
+}
+
+func TestResolver_Transactions_GoToPrimary(t *testing.T) {
+	mocks := setupMocks(t)
+	defer mocks.Ctrl.Finish()
+
+	ctx := WithHTTPMethod(t.Context(), "GET")
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+	expectedTx := &gofrSQL.Tx{}
+
+	mocks.Primary.EXPECT().BeginTx(ctx, opts).Return(expectedTx, nil)
+	mocks.Primary.EXPECT().WithTx(ctx, opts, gomock.Any()).Return(errTestReplicaFailed)
+
+	tx, err := mocks.Resolver.BeginTx(ctx, opts)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedTx, tx)
+
+	err = mocks.Resolver.WithTx(ctx, opts, func(*gofrSQL.Tx) error { return nil })
+
+	require.ErrorIs(t, err, errTestReplicaFailed)
+}
+
 func TestResolver_Prepare_GoesToPrimary(t *testing.T) {
 	mocks := setupMocks(t)
 	defer mocks.Ctrl.Finish()
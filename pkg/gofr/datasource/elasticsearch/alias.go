@@ -0,0 +1,136 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+var (
+	errEmptyAlias   = errors.New("alias name cannot be empty")
+	errEmptyActions = errors.New("actions cannot be empty")
+)
+
+// UpdateAliases applies alias actions such as {"add": {"index": "products-v2", "alias": "products"}} or
+// {"remove": {"index": "products-v1", "alias": "products"}} in one atomic operation.
+func (c *Client) UpdateAliases(ctx context.Context, actions []map[string]any) error {
+	if len(actions) == 0 {
+		return errEmptyActions
+	}
+
+	start := time.Now()
+
+	tracedCtx, span := c.addTrace(ctx, "update-aliases", nil, "")
+
+	request := map[string]any{"actions": actions}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("%w: actions: %w", errMarshaling, err)
+	}
+
+	req := esapi.IndicesUpdateAliasesRequest{
+		Body: bytes.NewReader(body),
+	}
+
+	res, err := req.Do(tracedCtx, c.client)
+	if err != nil {
+		return fmt.Errorf("%w: updating aliases: %w", errOperation, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("%w: %s", errResponse, res.String())
+	}
+
+	c.sendOperationStats(start, "UPDATE ALIASES", nil, "", request, span)
+
+	return nil
+}
+
+// GetAliasIndices returns the indices the alias points to, which are none when the alias doesn't exist.
+func (c *Client) GetAliasIndices(ctx context.Context, alias string) ([]string, error) {
+	if strings.TrimSpace(alias) == "" {
+		return nil, errEmptyAlias
+	}
+
+	start := time.Now()
+
+	tracedCtx, span := c.addTrace(ctx, "get-alias", nil, "")
+
+	req := esapi.IndicesGetAliasRequest{
+		Name: []string{alias},
+	}
+
+	res, err := req.Do(tracedCtx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("%w: getting alias: %w", errOperation, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		c.sendOperationStats(start, fmt.Sprintf("GET ALIAS %s", alias), nil, "", nil, span)
+
+		return nil, nil
+	}
+
+	if res.IsError() {
+		return nil, fmt.Errorf("%w: %s", errResponse, res.String())
+	}
+
+	var result map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: %w", errParsingResponse, err)
+	}
+
+	indices := make([]string, 0, len(result))
+	for index := range result {
+		indices = append(indices, index)
+	}
+
+	slices.Sort(indices)
+
+	c.sendOperationStats(start, fmt.Sprintf("GET ALIAS %s", alias), indices, "", nil, span)
+
+	return indices, nil
+}
+
+// SwapAlias points the alias to the index only, in one atomic operation, so that the readers of the alias switch
+// from the previous indices to the new one without downtime, e.g. at the end of a reindex.
+// It returns the indices the alias pointed to before, which can then be deleted.
+func (c *Client) SwapAlias(ctx context.Context, alias, index string) ([]string, error) {
+	if strings.TrimSpace(index) == "" {
+		return nil, errEmptyIndex
+	}
+
+	previous, err := c.GetAliasIndices(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]map[string]any, 0, len(previous)+1)
+
+	for _, p := range previous {
+		if p != index {
+			actions = append(actions, map[string]any{"remove": map[string]any{"index": p, "alias": alias}})
+		}
+	}
+
+	actions = append(actions, map[string]any{"add": map[string]any{"index": index, "alias": alias}})
+
+	if err := c.UpdateAliases(ctx, actions); err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(previous, func(p string) bool { return p == index }), nil
+}
@@ -0,0 +1,94 @@
+package elasticsearch
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateAliases(t *testing.T) {
+	client, transport := setupSequenceTest(t,
+		createMockResponse(200, `{"acknowledged": true}`),
+		createMockResponse(400, `{"error": "index_not_found_exception"}`))
+
+	actions := []map[string]any{{"add": map[string]any{"index": "products-v2", "alias": "products"}}}
+
+	require.NoError(t, client.UpdateAliases(t.Context(), actions))
+	assert.Equal(t, "/_aliases", transport.requests[0].URL.Path)
+	assert.JSONEq(t, `{"actions": [{"add": {"index": "products-v2", "alias": "products"}}]}`, transport.bodies[0])
+
+	require.ErrorIs(t, client.UpdateAliases(t.Context(), actions), errResponse)
+	require.ErrorIs(t, client.UpdateAliases(t.Context(), nil), errEmptyActions)
+}
+
+func TestClient_GetAliasIndices(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		resp    *http.Response
+		indices []string
+		err     error
+	}{
+		{"alias of two indices", createMockResponse(200,
+			`{"products-v2": {"aliases": {"products": {}}}, "products-v1": {"aliases": {"products": {}}}}`),
+			[]string{"products-v1", "products-v2"}, nil},
+		{"missing alias", createMockResponse(404, `{"error": "alias [products] missing", "status": 404}`), nil, nil},
+		{"failure", createMockResponse(500, `{"error": "internal"}`), nil, errResponse},
+	}
+
+	for i, tc := range testCases {
+		client, transport := setupSequenceTest(t, tc.resp)
+
+		indices, err := client.GetAliasIndices(t.Context(), "products")
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.indices, indices, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "/_alias/products", transport.requests[0].URL.Path, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	client, _ := setupSequenceTest(t)
+
+	_, err := client.GetAliasIndices(t.Context(), " ")
+	require.ErrorIs(t, err, errEmptyAlias)
+}
+
+func TestClient_SwapAlias(t *testing.T) {
+	client, transport := setupSequenceTest(t,
+		createMockResponse(200, `{"products-v1": {"aliases": {"products": {}}}}`),
+		createMockResponse(200, `{"acknowledged": true}`))
+
+	previous, err := client.SwapAlias(t.Context(), "products", "products-v2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"products-v1"}, previous)
+	assert.JSONEq(t, `{"actions": [
+		{"remove": {"index": "products-v1", "alias": "products"}},
+		{"add": {"index": "products-v2", "alias": "products"}}
+	]}`, transport.bodies[1])
+}
+
+func TestClient_SwapAlias_NewAlias(t *testing.T) {
+	client, transport := setupSequenceTest(t,
+		createMockResponse(404, `{"error": "alias [products] missing", "status": 404}`),
+		createMockResponse(200, `{"acknowledged": true}`))
+
+	previous, err := client.SwapAlias(t.Context(), "products", "products-v1")
+	require.NoError(t, err)
+	assert.Empty(t, previous)
+	assert.JSONEq(t, `{"actions": [{"add": {"index": "products-v1", "alias": "products"}}]}`, transport.bodies[1])
+}
+
+func TestClient_SwapAlias_Errors(t *testing.T) {
+	client, _ := setupSequenceTest(t,
+		createMockResponse(200, `{"products-v1": {"aliases": {"products": {}}}}`),
+		createMockResponse(404, `{"error": "index_not_found_exception"}`))
+
+	_, err := client.SwapAlias(t.Context(), "products", "")
+	require.ErrorIs(t, err, errEmptyIndex)
+
+	_, err = client.SwapAlias(t.Context(), "", "products-v2")
+	require.ErrorIs(t, err, errEmptyAlias)
+
+	_, err = client.SwapAlias(t.Context(), "products", "products-v2")
+	require.ErrorIs(t, err, errResponse)
+}
@@ -0,0 +1,340 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFlushActions  = 1000
+	defaultFlushBytes    = 5 << 20
+	defaultFlushInterval = 5 * time.Second
+)
+
+var (
+	errInvalidBulkAction = errors.New("invalid bulk action")
+	errBulkIndexerClosed = errors.New("bulk indexer is closed")
+)
+
+// Bulker executes bulk requests, as the Client does.
+type Bulker interface {
+	Bulk(ctx context.Context, operations []map[string]any) (map[string]any, error)
+}
+
+// BulkItem is an operation of a BulkIndexer. Action is "index", "create", "update" or "delete", and Document is the
+// document to index or create, or the partial document of an update. It is ignored by deletes.
+type BulkItem struct {
+	Action   string
+	Index    string
+	ID       string
+	Document any
+}
+
+// BulkItemFailure is an operation of a BulkIndexer which Elasticsearch rejected.
+type BulkItemFailure struct {
+	Item   BulkItem
+	Status int
+	Type   string
+	Reason string
+}
+
+// BulkIndexerConfig holds the configuration of a BulkIndexer. The zero values of the thresholds are replaced by
+// their defaults: 1000 operations, 5 MB and 5 seconds.
+type BulkIndexerConfig struct {
+	// FlushActions is the number of operations which triggers a flush.
+	FlushActions int
+	// FlushBytes is the size of the encoded documents which triggers a flush.
+	FlushBytes int
+	// FlushInterval is the maximum time an operation waits before being flushed.
+	FlushInterval time.Duration
+	// OnFailure is called for each operation which failed, including those of a bulk request which failed as a whole.
+	OnFailure func(ctx context.Context, failure BulkItemFailure)
+}
+
+// BulkIndexerStats holds the counts of the operations of a BulkIndexer.
+type BulkIndexerStats struct {
+	Added     int64
+	Flushed   int64
+	Succeeded int64
+	Failed    int64
+	Requests  int64
+}
+
+// BulkIndexer batches operations into bulk requests, sent when they reach a number of operations or a size, and at
+// a fixed interval. It is safe for concurrent use.
+type BulkIndexer struct {
+	bulker Bulker
+	config BulkIndexerConfig
+
+	mu         sync.Mutex
+	items      []BulkItem
+	operations []map[string]any
+	size       int
+	stats      BulkIndexerStats
+	closed     bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewBulkIndexer returns a BulkIndexer sending its bulk requests with the bulker, e.g. ctx.Elasticsearch.
+// It must be closed to flush its last operations.
+func NewBulkIndexer(bulker Bulker, config BulkIndexerConfig) *BulkIndexer {
+	if config.FlushActions <= 0 {
+		config.FlushActions = defaultFlushActions
+	}
+
+	if config.FlushBytes <= 0 {
+		config.FlushBytes = defaultFlushBytes
+	}
+
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaultFlushInterval
+	}
+
+	bi := &BulkIndexer{
+		bulker: bulker,
+		config: config,
+		stop:   make(chan struct{}),
+	}
+
+	bi.wg.Add(1)
+
+	go bi.flushPeriodically()
+
+	return bi
+}
+
+func (bi *BulkIndexer) flushPeriodically() {
+	defer bi.wg.Done()
+
+	ticker := time.NewTicker(bi.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bi.stop:
+			return
+		case <-ticker.C:
+			// the failures are reported to OnFailure.
+			_ = bi.Flush(context.Background())
+		}
+	}
+}
+
+// Add queues an operation, and flushes the queued ones if they reach the number of operations or the size of the
+// configuration.
+func (bi *BulkIndexer) Add(ctx context.Context, item BulkItem) error {
+	operations, size, err := bulkOperations(item)
+	if err != nil {
+		return err
+	}
+
+	bi.mu.Lock()
+
+	if bi.closed {
+		bi.mu.Unlock()
+
+		return errBulkIndexerClosed
+	}
+
+	bi.items = append(bi.items, item)
+	bi.operations = append(bi.operations, operations...)
+	bi.size += size
+	bi.stats.Added++
+
+	var items []BulkItem
+
+	if len(bi.items) >= bi.config.FlushActions || bi.size >= bi.config.FlushBytes {
+		items, operations = bi.take()
+	}
+
+	bi.mu.Unlock()
+
+	return bi.send(ctx, items, operations)
+}
+
+// Flush sends the queued operations. Only the failure of the whole bulk request is returned, the failures of the
+// operations are reported to OnFailure.
+func (bi *BulkIndexer) Flush(ctx context.Context) error {
+	bi.mu.Lock()
+	items, operations := bi.take()
+	bi.mu.Unlock()
+
+	return bi.send(ctx, items, operations)
+}
+
+// Close stops the periodic flushes and flushes the queued operations.
+func (bi *BulkIndexer) Close(ctx context.Context) error {
+	bi.mu.Lock()
+
+	if bi.closed {
+		bi.mu.Unlock()
+
+		return nil
+	}
+
+	bi.closed = true
+
+	bi.mu.Unlock()
+
+	close(bi.stop)
+	bi.wg.Wait()
+
+	return bi.Flush(ctx)
+}
+
+// Stats returns the counts of the operations of the indexer.
+func (bi *BulkIndexer) Stats() BulkIndexerStats {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	return bi.stats
+}
+
+// take empties the queue, and must be called with the lock held.
+func (bi *BulkIndexer) take() ([]BulkItem, []map[string]any) {
+	items, operations := bi.items, bi.operations
+
+	bi.items, bi.operations, bi.size = nil, nil, 0
+
+	return items, operations
+}
+
+func (bi *BulkIndexer) send(ctx context.Context, items []BulkItem, operations []map[string]any) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	result, err := bi.bulker.Bulk(ctx, operations)
+	if err != nil {
+		for _, item := range items {
+			bi.fail(ctx, BulkItemFailure{Item: item, Reason: err.Error()})
+		}
+
+		bi.count(len(items), 0, len(items))
+
+		return err
+	}
+
+	failures := bulkFailures(result, items)
+
+	for _, failure := range failures {
+		bi.fail(ctx, failure)
+	}
+
+	bi.count(len(items), len(items)-len(failures), len(failures))
+
+	return nil
+}
+
+func (bi *BulkIndexer) fail(ctx context.Context, failure BulkItemFailure) {
+	if bi.config.OnFailure != nil {
+		bi.config.OnFailure(ctx, failure)
+	}
+}
+
+func (bi *BulkIndexer) count(flushed, succeeded, failed int) {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	bi.stats.Requests++
+	bi.stats.Flushed += int64(flushed)
+	bi.stats.Succeeded += int64(succeeded)
+	bi.stats.Failed += int64(failed)
+}
+
+// bulkOperations returns the lines of the bulk request of the item, and the size of its document.
+func bulkOperations(item BulkItem) ([]map[string]any, int, error) {
+	if item.Index == "" {
+		return nil, 0, errEmptyIndex
+	}
+
+	meta := map[string]any{"_index": item.Index}
+	if item.ID != "" {
+		meta["_id"] = item.ID
+	}
+
+	action := map[string]any{item.Action: meta}
+
+	switch item.Action {
+	case "delete":
+		if item.ID == "" {
+			return nil, 0, errEmptyDocumentID
+		}
+
+		return []map[string]any{action}, 0, nil
+	case "update":
+		if item.ID == "" {
+			return nil, 0, errEmptyDocumentID
+		}
+
+		document, size, err := documentMap(item.Document)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return []map[string]any{action, {"doc": document}}, size, nil
+	case "index", "create":
+		document, size, err := documentMap(item.Document)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return []map[string]any{action, document}, size, nil
+	default:
+		return nil, 0, fmt.Errorf("%w: %q", errInvalidBulkAction, item.Action)
+	}
+}
+
+// documentMap converts a document into the map of its JSON object, and returns the size of its encoding.
+func documentMap(document any) (map[string]any, int, error) {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: document: %w", errMarshaling, err)
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, 0, fmt.Errorf("%w: document: %w", errMarshaling, err)
+	}
+
+	return m, len(body), nil
+}
+
+// bulkFailures returns the failed items of a bulk response, whose items are in the order of the request.
+func bulkFailures(result map[string]any, items []BulkItem) []BulkItemFailure {
+	if failed, _ := result["errors"].(bool); !failed {
+		return nil
+	}
+
+	responses, _ := result["items"].([]any)
+
+	var failures []BulkItemFailure
+
+	for i, response := range responses {
+		if i >= len(items) {
+			break
+		}
+
+		r, _ := response.(map[string]any)
+		status, _ := r[items[i].Action].(map[string]any)
+
+		cause, ok := status["error"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		code, _ := status["status"].(float64)
+		errorType, _ := cause["type"].(string)
+		reason, _ := cause["reason"].(string)
+
+		failures = append(failures, BulkItemFailure{Item: items[i], Status: int(code), Type: errorType, Reason: reason})
+	}
+
+	return failures
+}
@@ -0,0 +1,156 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBulker records the bulk requests, and returns its response or error.
+type recordingBulker struct {
+	mu       sync.Mutex
+	requests [][]map[string]any
+	response string
+	err      error
+}
+
+func (b *recordingBulker) Bulk(_ context.Context, operations []map[string]any) (map[string]any, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.requests = append(b.requests, operations)
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	var result map[string]any
+
+	err := json.Unmarshal([]byte(b.response), &result)
+
+	return result, err
+}
+
+func (b *recordingBulker) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.requests)
+}
+
+func TestBulkIndexer_FlushActions(t *testing.T) {
+	bulker := &recordingBulker{response: `{"errors": false, "items": []}`}
+	indexer := NewBulkIndexer(bulker, BulkIndexerConfig{FlushActions: 3, FlushInterval: time.Hour})
+
+	ctx := t.Context()
+
+	require.NoError(t, indexer.Add(ctx, BulkItem{Action: "index", Index: "products", ID: "1", Document: product{Name: "pen"}}))
+	require.NoError(t, indexer.Add(ctx, BulkItem{Action: "update", Index: "products", ID: "2",
+		Document: map[string]any{"price": 3}}))
+	assert.Equal(t, 0, bulker.count())
+
+	require.NoError(t, indexer.Add(ctx, BulkItem{Action: "delete", Index: "products", ID: "3"}))
+	require.Equal(t, 1, bulker.count())
+
+	assert.Equal(t, []map[string]any{
+		{"index": map[string]any{"_index": "products", "_id": "1"}},
+		{"name": "pen", "price": float64(0)},
+		{"update": map[string]any{"_index": "products", "_id": "2"}},
+		{"doc": map[string]any{"price": float64(3)}},
+		{"delete": map[string]any{"_index": "products", "_id": "3"}},
+	}, bulker.requests[0])
+
+	require.NoError(t, indexer.Add(ctx, BulkItem{Action: "create", Index: "products", Document: product{Name: "ink"}}))
+	require.NoError(t, indexer.Close(ctx))
+	require.Equal(t, 2, bulker.count())
+
+	assert.Equal(t, BulkIndexerStats{Added: 4, Flushed: 4, Succeeded: 4, Requests: 2}, indexer.Stats())
+	require.ErrorIs(t, indexer.Add(ctx, BulkItem{Action: "delete", Index: "products", ID: "1"}), errBulkIndexerClosed)
+	require.NoError(t, indexer.Close(ctx))
+}
+
+func TestBulkIndexer_FlushBytes(t *testing.T) {
+	bulker := &recordingBulker{response: `{"errors": false}`}
+	indexer := NewBulkIndexer(bulker, BulkIndexerConfig{FlushBytes: 20, FlushInterval: time.Hour})
+
+	require.NoError(t, indexer.Add(t.Context(), BulkItem{Action: "index", Index: "products", Document: product{Name: "pen"}}))
+	assert.Equal(t, 1, bulker.count())
+
+	require.NoError(t, indexer.Close(t.Context()))
+	assert.Equal(t, 1, bulker.count())
+}
+
+func TestBulkIndexer_FlushInterval(t *testing.T) {
+	bulker := &recordingBulker{response: `{"errors": false}`}
+	indexer := NewBulkIndexer(bulker, BulkIndexerConfig{FlushInterval: 10 * time.Millisecond})
+
+	defer indexer.Close(t.Context())
+
+	require.NoError(t, indexer.Add(t.Context(), BulkItem{Action: "delete", Index: "products", ID: "1"}))
+
+	assert.Eventually(t, func() bool { return bulker.count() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestBulkIndexer_Failures(t *testing.T) {
+	bulker := &recordingBulker{response: `{"errors": true, "items": [
+		{"index": {"_id": "1", "status": 201}},
+		{"index": {"_id": "2", "status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse"}}}
+	]}`}
+
+	var failures []BulkItemFailure
+
+	indexer := NewBulkIndexer(bulker, BulkIndexerConfig{
+		FlushInterval: time.Hour,
+		OnFailure: func(_ context.Context, failure BulkItemFailure) {
+			failures = append(failures, failure)
+		},
+	})
+
+	second := BulkItem{Action: "index", Index: "products", ID: "2", Document: map[string]any{"price": "free"}}
+
+	require.NoError(t, indexer.Add(t.Context(), BulkItem{Action: "index", Index: "products", ID: "1", Document: product{}}))
+	require.NoError(t, indexer.Add(t.Context(), second))
+	require.NoError(t, indexer.Flush(t.Context()))
+
+	assert.Equal(t, []BulkItemFailure{{Item: second, Status: 400, Type: "mapper_parsing_exception",
+		Reason: "failed to parse"}}, failures)
+
+	bulker.err = errTestFailed
+
+	require.NoError(t, indexer.Add(t.Context(), BulkItem{Action: "delete", Index: "products", ID: "1"}))
+	require.ErrorIs(t, indexer.Close(t.Context()), errTestFailed)
+
+	assert.Len(t, failures, 2)
+	assert.Equal(t, errTestFailed.Error(), failures[1].Reason)
+	assert.Equal(t, BulkIndexerStats{Added: 3, Flushed: 3, Succeeded: 1, Failed: 2, Requests: 2}, indexer.Stats())
+}
+
+func TestBulkIndexer_InvalidItems(t *testing.T) {
+	indexer := NewBulkIndexer(&recordingBulker{}, BulkIndexerConfig{})
+
+	defer indexer.Close(t.Context())
+
+	testCases := []struct {
+		desc string
+		item BulkItem
+		err  error
+	}{
+		{"missing index", BulkItem{Action: "index", Document: product{}}, errEmptyIndex},
+		{"delete without ID", BulkItem{Action: "delete", Index: "products"}, errEmptyDocumentID},
+		{"update without ID", BulkItem{Action: "update", Index: "products", Document: product{}}, errEmptyDocumentID},
+		{"unknown action", BulkItem{Action: "upsert", Index: "products"}, errInvalidBulkAction},
+		{"document not an object", BulkItem{Action: "index", Index: "products", Document: []int{1}}, errMarshaling},
+		{"document not encodable", BulkItem{Action: "create", Index: "products", Document: make(chan int)}, errMarshaling},
+	}
+
+	for i, tc := range testCases {
+		require.ErrorIs(t, indexer.Add(t.Context(), tc.item), tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	assert.Equal(t, BulkIndexerStats{}, indexer.Stats())
+}
@@ -175,8 +175,9 @@ func (c *Client) DeleteIndex(ctx context.Context, index string) error {
 
 // Search executes a query against one or more indices.
 // Returns the entire response JSON as a map.
+// The indices must be empty when the query searches a point in time opened by OpenPointInTime.
 func (c *Client) Search(ctx context.Context, indices []string, query map[string]any) (map[string]any, error) {
-	if len(indices) == 0 {
+	if _, ok := query["pit"]; len(indices) == 0 && !ok {
 		return nil, errEmptyIndex
 	}
 
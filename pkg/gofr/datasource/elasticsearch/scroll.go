@@ -0,0 +1,218 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+var (
+	errEmptyPointInTime = errors.New("point in time ID cannot be empty")
+	errEmptyScrollID    = errors.New("scroll ID cannot be empty")
+)
+
+// OpenPointInTime opens a point in time on the indices, which keeps a consistent view of their documents for
+// keepAlive. The returned ID is passed in the "pit" section of the queries of Search, which must not name indices.
+func (c *Client) OpenPointInTime(ctx context.Context, indices []string, keepAlive time.Duration) (string, error) {
+	if len(indices) == 0 {
+		return "", errEmptyIndex
+	}
+
+	start := time.Now()
+
+	tracedCtx, span := c.addTrace(ctx, "open-point-in-time", indices, "")
+
+	req := esapi.OpenPointInTimeRequest{
+		Index:     indices,
+		KeepAlive: formatKeepAlive(keepAlive),
+	}
+
+	res, err := req.Do(tracedCtx, c.client)
+	if err != nil {
+		return "", fmt.Errorf("%w: opening point in time: %w", errOperation, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("%w: %s", errResponse, res.String())
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("%w: %w", errParsingResponse, err)
+	}
+
+	c.sendOperationStats(start, "OPEN POINT IN TIME", indices, "", nil, span)
+
+	return result.ID, nil
+}
+
+// ClosePointInTime releases a point in time opened by OpenPointInTime.
+func (c *Client) ClosePointInTime(ctx context.Context, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return errEmptyPointInTime
+	}
+
+	start := time.Now()
+
+	tracedCtx, span := c.addTrace(ctx, "close-point-in-time", nil, "")
+
+	body, err := json.Marshal(map[string]any{"id": id})
+	if err != nil {
+		return fmt.Errorf("%w: point in time: %w", errMarshaling, err)
+	}
+
+	req := esapi.ClosePointInTimeRequest{
+		Body: bytes.NewReader(body),
+	}
+
+	res, err := req.Do(tracedCtx, c.client)
+	if err != nil {
+		return fmt.Errorf("%w: closing point in time: %w", errOperation, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("%w: %s", errResponse, res.String())
+	}
+
+	c.sendOperationStats(start, "CLOSE POINT IN TIME", nil, "", nil, span)
+
+	return nil
+}
+
+// SearchScroll executes a query against one or more indices and keeps its results for keepAlive, to be read
+// page by page with ScrollNext. The scroll ID is in the "_scroll_id" field of the returned response.
+func (c *Client) SearchScroll(ctx context.Context, indices []string, query map[string]any,
+	keepAlive time.Duration) (map[string]any, error) {
+	if len(indices) == 0 {
+		return nil, errEmptyIndex
+	}
+
+	if len(query) == 0 {
+		return nil, errEmptyQuery
+	}
+
+	start := time.Now()
+
+	tracedCtx, span := c.addTrace(ctx, "search-scroll", indices, "")
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: query: %w", errMarshaling, err)
+	}
+
+	req := esapi.SearchRequest{
+		Index:  indices,
+		Body:   bytes.NewReader(body),
+		Scroll: keepAlive,
+	}
+
+	res, err := req.Do(tracedCtx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("%w: executing search: %w", errOperation, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("%w: %s", errResponse, res.String())
+	}
+
+	var result map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: %w", errParsingResponse, err)
+	}
+
+	c.sendOperationStats(start, "SEARCH SCROLL", indices, "", query, span)
+
+	return result, nil
+}
+
+// ScrollNext returns the next page of the results of a search started by SearchScroll, and keeps the remaining
+// ones for keepAlive. The page has no hits once all the results have been read.
+func (c *Client) ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (map[string]any, error) {
+	if strings.TrimSpace(scrollID) == "" {
+		return nil, errEmptyScrollID
+	}
+
+	start := time.Now()
+
+	tracedCtx, span := c.addTrace(ctx, "scroll", nil, "")
+
+	body, err := json.Marshal(map[string]any{"scroll_id": scrollID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: scroll: %w", errMarshaling, err)
+	}
+
+	req := esapi.ScrollRequest{
+		Body:   bytes.NewReader(body),
+		Scroll: keepAlive,
+	}
+
+	res, err := req.Do(tracedCtx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("%w: scrolling: %w", errOperation, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("%w: %s", errResponse, res.String())
+	}
+
+	var result map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: %w", errParsingResponse, err)
+	}
+
+	c.sendOperationStats(start, "SCROLL", nil, "", nil, span)
+
+	return result, nil
+}
+
+// ClearScroll releases the results of a search started by SearchScroll before they expire.
+func (c *Client) ClearScroll(ctx context.Context, scrollID string) error {
+	if strings.TrimSpace(scrollID) == "" {
+		return errEmptyScrollID
+	}
+
+	start := time.Now()
+
+	tracedCtx, span := c.addTrace(ctx, "clear-scroll", nil, "")
+
+	req := esapi.ClearScrollRequest{
+		ScrollID: []string{scrollID},
+	}
+
+	res, err := req.Do(tracedCtx, c.client)
+	if err != nil {
+		return fmt.Errorf("%w: clearing scroll: %w", errOperation, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("%w: %s", errResponse, res.String())
+	}
+
+	c.sendOperationStats(start, "CLEAR SCROLL", nil, "", nil, span)
+
+	return nil
+}
+
+// formatKeepAlive formats a duration in the time units of Elasticsearch.
+func formatKeepAlive(keepAlive time.Duration) string {
+	return fmt.Sprintf("%dms", keepAlive.Milliseconds())
+}
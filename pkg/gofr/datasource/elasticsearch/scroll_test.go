@@ -0,0 +1,152 @@
+package elasticsearch
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceTransport returns its responses in order, and records the requests with their bodies.
+type sequenceTransport struct {
+	responses []*http.Response
+	requests  []*http.Request
+	bodies    []string
+}
+
+func (t *sequenceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+
+	var body []byte
+
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+
+	t.bodies = append(t.bodies, string(body))
+
+	resp := t.responses[0]
+	if len(t.responses) > 1 {
+		t.responses = t.responses[1:]
+	}
+
+	return resp, nil
+}
+
+func setupSequenceTest(t *testing.T, responses ...*http.Response) (*Client, *sequenceTransport) {
+	t.Helper()
+
+	client, _ := setupTest(t)
+	transport := &sequenceTransport{responses: responses}
+
+	es, err := elasticsearch.NewClient(elasticsearch.Config{
+		Transport:               transport,
+		EnableCompatibilityMode: true,
+	})
+	require.NoError(t, err)
+
+	client.client = es
+
+	return client, transport
+}
+
+func TestClient_PointInTime(t *testing.T) {
+	client, transport := setupSequenceTest(t,
+		createMockResponse(200, `{"id": "pit-1"}`),
+		createMockResponse(200, `{"succeeded": true}`))
+
+	id, err := client.OpenPointInTime(t.Context(), []string{"products"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "pit-1", id)
+	assert.Equal(t, "/products/_pit", transport.requests[0].URL.Path)
+	assert.Equal(t, "60000ms", transport.requests[0].URL.Query().Get("keep_alive"))
+
+	require.NoError(t, client.ClosePointInTime(t.Context(), id))
+	assert.Equal(t, http.MethodDelete, transport.requests[1].Method)
+	assert.JSONEq(t, `{"id": "pit-1"}`, transport.bodies[1])
+}
+
+func TestClient_PointInTime_Errors(t *testing.T) {
+	client, _ := setupSequenceTest(t, createMockResponse(404, `{"error": "index_not_found_exception"}`))
+
+	_, err := client.OpenPointInTime(t.Context(), nil, time.Minute)
+	require.ErrorIs(t, err, errEmptyIndex)
+
+	_, err = client.OpenPointInTime(t.Context(), []string{"missing"}, time.Minute)
+	require.ErrorIs(t, err, errResponse)
+
+	require.ErrorIs(t, client.ClosePointInTime(t.Context(), ""), errEmptyPointInTime)
+	require.ErrorIs(t, client.ClosePointInTime(t.Context(), "pit-1"), errResponse)
+}
+
+func TestClient_SearchPointInTime(t *testing.T) {
+	client, transport := setupSequenceTest(t, createMockResponse(200, `{"pit_id": "pit-1", "hits": {"hits": []}}`))
+
+	_, err := client.Search(t.Context(), nil, map[string]any{"pit": map[string]any{"id": "pit-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "/_search", transport.requests[0].URL.Path)
+
+	_, err = client.Search(t.Context(), nil, map[string]any{"query": map[string]any{}})
+	require.ErrorIs(t, err, errEmptyIndex)
+}
+
+func TestClient_Scroll(t *testing.T) {
+	client, transport := setupSequenceTest(t,
+		createMockResponse(200, `{"_scroll_id": "scroll-1", "hits": {"hits": [{"_id": "1"}]}}`),
+		createMockResponse(200, `{"_scroll_id": "scroll-1", "hits": {"hits": []}}`),
+		createMockResponse(200, `{"succeeded": true}`))
+
+	result, err := client.SearchScroll(t.Context(), []string{"products"}, map[string]any{"size": 1}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "scroll-1", result["_scroll_id"])
+	assert.Equal(t, "60000ms", transport.requests[0].URL.Query().Get("scroll"))
+
+	_, err = client.ScrollNext(t.Context(), "scroll-1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "/_search/scroll", transport.requests[1].URL.Path)
+	assert.JSONEq(t, `{"scroll_id": "scroll-1"}`, transport.bodies[1])
+
+	require.NoError(t, client.ClearScroll(t.Context(), "scroll-1"))
+	assert.Equal(t, "/_search/scroll/scroll-1", transport.requests[2].URL.Path)
+}
+
+func TestClient_Scroll_Errors(t *testing.T) {
+	client, _ := setupSequenceTest(t, createMockResponse(500, `{"error": "search_phase_execution_exception"}`))
+
+	testCases := []struct {
+		desc string
+		call func() error
+		err  error
+	}{
+		{"search without indices", func() error {
+			_, err := client.SearchScroll(t.Context(), nil, map[string]any{"size": 1}, time.Minute)
+			return err
+		}, errEmptyIndex},
+		{"search without query", func() error {
+			_, err := client.SearchScroll(t.Context(), []string{"products"}, nil, time.Minute)
+			return err
+		}, errEmptyQuery},
+		{"search failure", func() error {
+			_, err := client.SearchScroll(t.Context(), []string{"products"}, map[string]any{"size": 1}, time.Minute)
+			return err
+		}, errResponse},
+		{"next without scroll ID", func() error {
+			_, err := client.ScrollNext(t.Context(), "", time.Minute)
+			return err
+		}, errEmptyScrollID},
+		{"next failure", func() error {
+			_, err := client.ScrollNext(t.Context(), "scroll-1", time.Minute)
+			return err
+		}, errResponse},
+		{"clear without scroll ID", func() error { return client.ClearScroll(t.Context(), "") }, errEmptyScrollID},
+		{"clear failure", func() error { return client.ClearScroll(t.Context(), "scroll-1") }, errResponse},
+	}
+
+	for i, tc := range testCases {
+		require.ErrorIs(t, tc.call(), tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
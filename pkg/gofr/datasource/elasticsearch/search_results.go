@@ -0,0 +1,287 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// defaultPageSize is the number of hits of the pages read by the iterators when the query doesn't set a size.
+const defaultPageSize = 1000
+
+// Searcher executes queries, as the Client does.
+type Searcher interface {
+	Search(ctx context.Context, indices []string, query map[string]any) (map[string]any, error)
+}
+
+// PointInTimeSearcher executes queries against points in time, as the Client does.
+type PointInTimeSearcher interface {
+	Searcher
+	OpenPointInTime(ctx context.Context, indices []string, keepAlive time.Duration) (string, error)
+	ClosePointInTime(ctx context.Context, id string) error
+}
+
+// ScrollSearcher executes scrolled queries, as the Client does.
+type ScrollSearcher interface {
+	SearchScroll(ctx context.Context, indices []string, query map[string]any, keepAlive time.Duration) (map[string]any, error)
+	ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (map[string]any, error)
+	ClearScroll(ctx context.Context, scrollID string) error
+}
+
+// Hit is a document found by a search, with its source decoded into T.
+type Hit[T any] struct {
+	Index     string              `json:"_index"`
+	ID        string              `json:"_id"`
+	Score     float64             `json:"_score"`
+	Source    T                   `json:"_source"`
+	Highlight map[string][]string `json:"highlight,omitempty"`
+	Sort      []any               `json:"sort,omitempty"`
+}
+
+// SearchResult is a page of the results of a search, with the hits decoded into T.
+type SearchResult[T any] struct {
+	Total         int64
+	MaxScore      float64
+	Hits          []Hit[T]
+	Aggregations  map[string]any
+	ScrollID      string
+	PointInTimeID string
+}
+
+type searchResponse[T any] struct {
+	ScrollID      string `json:"_scroll_id"`
+	PointInTimeID string `json:"pit_id"`
+	Hits          struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		MaxScore float64  `json:"max_score"`
+		Hits     []Hit[T] `json:"hits"`
+	} `json:"hits"`
+	Aggregations map[string]any `json:"aggregations"`
+}
+
+// DecodeSearchResult decodes a response of Search, SearchScroll or ScrollNext, decoding the source of the hits into T.
+func DecodeSearchResult[T any](response map[string]any) (*SearchResult[T], error) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errParsingResponse, err)
+	}
+
+	var r searchResponse[T]
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("%w: %w", errParsingResponse, err)
+	}
+
+	return &SearchResult[T]{
+		Total:         r.Hits.Total.Value,
+		MaxScore:      r.Hits.MaxScore,
+		Hits:          r.Hits.Hits,
+		Aggregations:  r.Aggregations,
+		ScrollID:      r.ScrollID,
+		PointInTimeID: r.PointInTimeID,
+	}, nil
+}
+
+// SearchAs executes a query against one or more indices and decodes the source of the hits into T.
+//
+//	result, err := elasticsearch.SearchAs[Product](ctx, ctx.Elasticsearch, []string{"products"}, query)
+func SearchAs[T any](ctx context.Context, s Searcher, indices []string, query map[string]any) (*SearchResult[T], error) {
+	response, err := s.Search(ctx, indices, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeSearchResult[T](response)
+}
+
+// Iterator iterates over all the hits of a search, reading them page by page:
+//
+//	it := elasticsearch.NewPointInTimeIterator[Product](ctx.Elasticsearch, []string{"products"}, query, time.Minute)
+//	defer it.Close(ctx)
+//
+//	for it.Next(ctx) {
+//		product := it.Hit().Source
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	next    func(ctx context.Context) (*SearchResult[T], error)
+	release func(ctx context.Context) error
+
+	page []Hit[T]
+	pos  int
+	hit  Hit[T]
+	err  error
+	done bool
+}
+
+// Next advances to the next hit, reading the next page when the current one has been read.
+// It returns false when all the hits have been read or when reading a page failed, which Err reports.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	if it.pos >= len(it.page) {
+		result, err := it.next(ctx)
+		if err != nil {
+			it.err = err
+
+			return false
+		}
+
+		it.page, it.pos = result.Hits, 0
+
+		if len(it.page) == 0 {
+			it.done = true
+
+			return false
+		}
+	}
+
+	it.hit = it.page[it.pos]
+	it.pos++
+
+	return true
+}
+
+// Hit returns the current hit.
+func (it *Iterator[T]) Hit() Hit[T] {
+	return it.hit
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close releases the point in time or the scroll of the search, and stops the iteration.
+func (it *Iterator[T]) Close(ctx context.Context) error {
+	it.done = true
+
+	return it.release(ctx)
+}
+
+// NewPointInTimeIterator returns an Iterator over all the hits of the query against the indices, read from a point in
+// time kept open for keepAlive between pages, with search_after. The query is sorted by _shard_doc when it sets no
+// sort, and it is the sort of the query which orders the hits.
+func NewPointInTimeIterator[T any](s PointInTimeSearcher, indices []string, query map[string]any,
+	keepAlive time.Duration) *Iterator[T] {
+	query = pageQuery(query)
+
+	if _, ok := query["sort"]; !ok {
+		query["sort"] = []any{map[string]any{"_shard_doc": "asc"}}
+	}
+
+	var pitID string
+
+	next := func(ctx context.Context) (*SearchResult[T], error) {
+		if pitID == "" {
+			id, err := s.OpenPointInTime(ctx, indices, keepAlive)
+			if err != nil {
+				return nil, err
+			}
+
+			pitID = id
+		}
+
+		query["pit"] = map[string]any{"id": pitID, "keep_alive": formatKeepAlive(keepAlive)}
+
+		result, err := SearchAs[T](ctx, s, nil, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.PointInTimeID != "" {
+			pitID = result.PointInTimeID
+		}
+
+		if len(result.Hits) > 0 {
+			query["search_after"] = result.Hits[len(result.Hits)-1].Sort
+		}
+
+		return result, nil
+	}
+
+	release := func(ctx context.Context) error {
+		if pitID == "" {
+			return nil
+		}
+
+		id := pitID
+		pitID = ""
+
+		return s.ClosePointInTime(ctx, id)
+	}
+
+	return &Iterator[T]{next: next, release: release}
+}
+
+// NewScrollIterator returns an Iterator over all the hits of the query against the indices, read with a scroll kept
+// for keepAlive between pages.
+func NewScrollIterator[T any](s ScrollSearcher, indices []string, query map[string]any,
+	keepAlive time.Duration) *Iterator[T] {
+	query = pageQuery(query)
+
+	var scrollID string
+
+	next := func(ctx context.Context) (*SearchResult[T], error) {
+		var (
+			response map[string]any
+			err      error
+		)
+
+		if scrollID == "" {
+			response, err = s.SearchScroll(ctx, indices, query, keepAlive)
+		} else {
+			response, err = s.ScrollNext(ctx, scrollID, keepAlive)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := DecodeSearchResult[T](response)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.ScrollID != "" {
+			scrollID = result.ScrollID
+		}
+
+		return result, nil
+	}
+
+	release := func(ctx context.Context) error {
+		if scrollID == "" {
+			return nil
+		}
+
+		id := scrollID
+		scrollID = ""
+
+		return s.ClearScroll(ctx, id)
+	}
+
+	return &Iterator[T]{next: next, release: release}
+}
+
+// pageQuery returns a copy of the query, which the iterators modify, with the default page size if it sets none.
+func pageQuery(query map[string]any) map[string]any {
+	query = maps.Clone(query)
+	if query == nil {
+		query = make(map[string]any)
+	}
+
+	if _, ok := query["size"]; !ok {
+		query["size"] = defaultPageSize
+	}
+
+	return query
+}
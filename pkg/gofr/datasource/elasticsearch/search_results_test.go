@@ -0,0 +1,185 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type product struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// pagedSearcher returns its pages in order to the searches of points in time and scrolls, and records the queries.
+type pagedSearcher struct {
+	pages   []string
+	queries []map[string]any
+	closed  []string
+	err     error
+}
+
+func (s *pagedSearcher) page() (map[string]any, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var page map[string]any
+
+	if err := json.Unmarshal([]byte(s.pages[0]), &page); err != nil {
+		return nil, err
+	}
+
+	s.pages = s.pages[1:]
+
+	return page, nil
+}
+
+func (s *pagedSearcher) Search(_ context.Context, _ []string, query map[string]any) (map[string]any, error) {
+	// the query is modified by the iterator between pages.
+	body, _ := json.Marshal(query)
+
+	var q map[string]any
+	_ = json.Unmarshal(body, &q)
+
+	s.queries = append(s.queries, q)
+
+	return s.page()
+}
+
+func (s *pagedSearcher) OpenPointInTime(context.Context, []string, time.Duration) (string, error) {
+	return "pit-1", s.err
+}
+
+func (s *pagedSearcher) ClosePointInTime(_ context.Context, id string) error {
+	s.closed = append(s.closed, id)
+
+	return nil
+}
+
+func (s *pagedSearcher) SearchScroll(_ context.Context, _ []string, query map[string]any, _ time.Duration) (map[string]any, error) {
+	s.queries = append(s.queries, query)
+
+	return s.page()
+}
+
+func (s *pagedSearcher) ScrollNext(context.Context, string, time.Duration) (map[string]any, error) {
+	return s.page()
+}
+
+func (s *pagedSearcher) ClearScroll(_ context.Context, scrollID string) error {
+	s.closed = append(s.closed, scrollID)
+
+	return nil
+}
+
+func TestDecodeSearchResult(t *testing.T) {
+	var response map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"hits": {
+			"total": {"value": 2, "relation": "eq"},
+			"max_score": 1.5,
+			"hits": [
+				{"_index": "products", "_id": "1", "_score": 1.5, "_source": {"name": "pen", "price": 2.5},
+					"highlight": {"name": ["<em>pen</em>"]}},
+				{"_index": "products", "_id": "2", "_score": null, "_source": {"name": "ink", "price": 4}, "sort": [4, "2"]}
+			]
+		},
+		"aggregations": {"avg_price": {"value": 3.25}}
+	}`), &response))
+
+	result, err := DecodeSearchResult[product](response)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), result.Total)
+	assert.InDelta(t, 1.5, result.MaxScore, 0)
+	assert.Equal(t, Hit[product]{Index: "products", ID: "1", Score: 1.5, Source: product{Name: "pen", Price: 2.5},
+		Highlight: map[string][]string{"name": {"<em>pen</em>"}}}, result.Hits[0])
+	assert.Equal(t, []any{float64(4), "2"}, result.Hits[1].Sort)
+	assert.Equal(t, map[string]any{"avg_price": map[string]any{"value": 3.25}}, result.Aggregations)
+
+	_, err = DecodeSearchResult[product](map[string]any{"hits": map[string]any{"hits": "invalid"}})
+	require.ErrorIs(t, err, errParsingResponse)
+}
+
+func TestSearchAs(t *testing.T) {
+	s := &pagedSearcher{pages: []string{`{"hits": {"hits": [{"_id": "1", "_source": {"name": "pen"}}]}}`}}
+
+	result, err := SearchAs[product](t.Context(), s, []string{"products"}, map[string]any{"size": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "pen", result.Hits[0].Source.Name)
+
+	s.err = errTestFailed
+
+	_, err = SearchAs[product](t.Context(), s, []string{"products"}, map[string]any{"size": 1})
+	require.ErrorIs(t, err, errTestFailed)
+}
+
+func TestPointInTimeIterator(t *testing.T) {
+	s := &pagedSearcher{pages: []string{
+		`{"pit_id": "pit-2", "hits": {"hits": [{"_id": "1", "sort": [1]}, {"_id": "2", "sort": [2]}]}}`,
+		`{"pit_id": "pit-2", "hits": {"hits": [{"_id": "3", "sort": [3]}]}}`,
+		`{"pit_id": "pit-2", "hits": {"hits": []}}`,
+	}}
+
+	it := NewPointInTimeIterator[product](s, []string{"products"}, map[string]any{"size": 2}, time.Minute)
+
+	var ids []string
+
+	for it.Next(t.Context()) {
+		ids = append(ids, it.Hit().ID)
+	}
+
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close(t.Context()))
+
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.False(t, it.Next(t.Context()))
+
+	assert.Equal(t, map[string]any{"id": "pit-1", "keep_alive": "60000ms"}, s.queries[0]["pit"])
+	assert.Equal(t, []any{map[string]any{"_shard_doc": "asc"}}, s.queries[0]["sort"])
+	assert.NotContains(t, s.queries[0], "search_after")
+	assert.Equal(t, map[string]any{"id": "pit-2", "keep_alive": "60000ms"}, s.queries[1]["pit"])
+	assert.Equal(t, []any{float64(2)}, s.queries[1]["search_after"])
+	assert.Equal(t, []any{float64(3)}, s.queries[2]["search_after"])
+	assert.Equal(t, []string{"pit-2"}, s.closed)
+}
+
+func TestPointInTimeIterator_Error(t *testing.T) {
+	s := &pagedSearcher{err: errTestFailed}
+
+	it := NewPointInTimeIterator[product](s, []string{"products"}, nil, time.Minute)
+
+	assert.False(t, it.Next(t.Context()))
+	require.ErrorIs(t, it.Err(), errTestFailed)
+	require.NoError(t, it.Close(t.Context()))
+	assert.Empty(t, s.closed)
+}
+
+func TestScrollIterator(t *testing.T) {
+	s := &pagedSearcher{pages: []string{
+		`{"_scroll_id": "scroll-1", "hits": {"hits": [{"_id": "1", "_source": {"name": "pen"}}]}}`,
+		`{"_scroll_id": "scroll-1", "hits": {"hits": [{"_id": "2", "_source": {"name": "ink"}}]}}`,
+		`{"_scroll_id": "scroll-1", "hits": {"hits": []}}`,
+	}}
+
+	it := NewScrollIterator[product](s, []string{"products"}, map[string]any{"query": map[string]any{}}, time.Minute)
+
+	var names []string
+
+	for it.Next(t.Context()) {
+		names = append(names, it.Hit().Source.Name)
+	}
+
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close(t.Context()))
+
+	assert.Equal(t, []string{"pen", "ink"}, names)
+	assert.Equal(t, defaultPageSize, s.queries[0]["size"])
+	assert.Equal(t, []string{"scroll-1"}, s.closed)
+}
@@ -23,6 +23,8 @@ var (
 	errUnsupportedFlags = errors.New("unsupported flag combination for OpenFile")
 
 	errProviderNil = errors.New("storage provider is not configured")
+
+	errNotConnected = errors.New("file system is not connected")
 )
 
 // CommonFileSystem provides shared implementations of FileSystem operations.
@@ -646,3 +648,32 @@ func (c *CommonFileSystem) IsRetryDisabled() bool {
 func (c *CommonFileSystem) SetConnected(connected bool) {
 	c.connected = connected
 }
+
+// StorageProvider returns the storage the file system is built on, which Copy streams files from and to.
+func (c *CommonFileSystem) StorageProvider() StorageProvider {
+	return c.Provider
+}
+
+// HealthCheck reports whether the storage is reachable, asking the providers checking their health, and otherwise
+// whether the file system is connected.
+func (c *CommonFileSystem) HealthCheck(ctx context.Context) (any, error) {
+	h := datasource.Health{
+		Status:  datasource.StatusUp,
+		Details: map[string]any{"provider": c.getProviderName(), "location": c.Location},
+	}
+
+	var err error
+
+	if p, ok := c.Provider.(interface{ Health(context.Context) error }); ok {
+		err = p.Health(ctx)
+	} else if !c.connected {
+		err = errNotConnected
+	}
+
+	if err != nil {
+		h.Status = datasource.StatusDown
+		h.Details["error"] = err.Error()
+	}
+
+	return &h, err
+}
@@ -0,0 +1,160 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// ErrChecksumMismatch is returned by Copy when the copied file differs from the source once read back.
+var ErrChecksumMismatch = errors.New("checksum of the copied file does not match the source")
+
+const copyBufferSize = 1 << 20
+
+// CopyOption configures Copy.
+type CopyOption func(*copyOptions)
+
+type copyOptions struct {
+	progress func(copied, total int64)
+	verify   bool
+}
+
+// WithProgress calls progress as the file is copied, with the bytes copied so far and the size of the file,
+// which is -1 when the source does not report it.
+func WithProgress(progress func(copied, total int64)) CopyOption {
+	return func(o *copyOptions) { o.progress = progress }
+}
+
+// WithChecksum reads the copied file back once written, and fails the copy with ErrChecksumMismatch unless its
+// SHA-256 checksum is the one of the source.
+func WithChecksum() CopyOption {
+	return func(o *copyOptions) { o.verify = true }
+}
+
+// providerExposer is implemented by the file systems built on a StorageProvider, such as the ones embedding
+// CommonFileSystem.
+type providerExposer interface {
+	StorageProvider() StorageProvider
+}
+
+// Copy streams the file at srcPath of src to dstPath of dst, which may be different stores, without holding the
+// file in memory. It returns the number of bytes copied. When the copy fails midway, the destination is removed
+// rather than left truncated.
+func Copy(ctx context.Context, src FileSystem, srcPath string, dst FileSystem, dstPath string,
+	opts ...CopyOption) (int64, error) {
+	var o copyOptions
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	total := int64(-1)
+	if info, err := src.Stat(srcPath); err == nil && !info.IsDir() {
+		total = info.Size()
+	}
+
+	reader, err := openReader(ctx, src, srcPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s for copy: %w", srcPath, err)
+	}
+	defer reader.Close()
+
+	// cancelling the context of the writer aborts the upload of the stores which commit the content on Close.
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer, err := openWriter(writeCtx, dst, dstPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s for copy: %w", dstPath, err)
+	}
+
+	sum := sha256.New()
+	out := io.MultiWriter(writer, sum, &progressWriter{total: total, progress: o.progress})
+
+	// the reader is wrapped for its WriteTo method, if any, not to bypass the buffer.
+	copied, err := io.CopyBuffer(out, struct{ io.Reader }{reader}, make([]byte, copyBufferSize))
+	if err != nil {
+		cancel()
+
+		// the stores writing the content as it comes, rather than on Close, hold a partial file to remove.
+		_ = writer.Close()
+		_ = dst.Remove(dstPath)
+
+		return copied, fmt.Errorf("failed to copy %s to %s: %w", srcPath, dstPath, err)
+	}
+
+	// the content of remote stores is only committed once the writer is closed.
+	if err = writer.Close(); err != nil {
+		return copied, fmt.Errorf("failed to copy %s to %s: %w", srcPath, dstPath, err)
+	}
+
+	if o.verify {
+		if err := verifyChecksum(ctx, dst, dstPath, sum); err != nil {
+			return copied, err
+		}
+	}
+
+	return copied, nil
+}
+
+func openReader(ctx context.Context, fs FileSystem, name string) (io.ReadCloser, error) {
+	if p, ok := fs.(providerExposer); ok && p.StorageProvider() != nil {
+		return p.StorageProvider().NewReader(ctx, name)
+	}
+
+	return fs.Open(name)
+}
+
+func openWriter(ctx context.Context, fs FileSystem, name string) (io.WriteCloser, error) {
+	if p, ok := fs.(providerExposer); ok && p.StorageProvider() != nil {
+		writer := p.StorageProvider().NewWriter(ctx, name)
+		if writer == nil {
+			return nil, errWriterNil
+		}
+
+		return writer, nil
+	}
+
+	return fs.Create(name)
+}
+
+func verifyChecksum(ctx context.Context, fs FileSystem, name string, expected hash.Hash) error {
+	reader, err := openReader(ctx, fs, name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s back for checksum: %w", name, err)
+	}
+	defer reader.Close()
+
+	sum := sha256.New()
+
+	if _, err := io.Copy(sum, reader); err != nil {
+		return fmt.Errorf("failed to read %s back for checksum: %w", name, err)
+	}
+
+	if !bytes.Equal(sum.Sum(nil), expected.Sum(nil)) {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, name)
+	}
+
+	return nil
+}
+
+// progressWriter reports the bytes written through it.
+type progressWriter struct {
+	copied   int64
+	total    int64
+	progress func(copied, total int64)
+}
+
+func (p *progressWriter) Write(data []byte) (int, error) {
+	p.copied += int64(len(data))
+
+	if p.progress != nil {
+		p.progress(p.copied, p.total)
+	}
+
+	return len(data), nil
+}
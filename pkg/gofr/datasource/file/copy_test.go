@@ -0,0 +1,140 @@
+package file
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// plainFileSystem hides the storage provider of a file system, which Copy then reads and writes through its files.
+type plainFileSystem struct {
+	FileSystem
+}
+
+// corruptingFileSystem reads back different content than what was written.
+type corruptingFileSystem struct {
+	FileSystem
+}
+
+func (corruptingFileSystem) Open(string) (File, error) {
+	return nil, errCorrupted
+}
+
+var errCorrupted = io.ErrUnexpectedEOF
+
+// failingFileSystem opens files failing once their first bytes are read.
+type failingFileSystem struct {
+	FileSystem
+}
+
+func (f failingFileSystem) Open(name string) (File, error) {
+	file, err := f.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &failingFile{File: file}, nil
+}
+
+type failingFile struct {
+	File
+	read bool
+}
+
+func (f *failingFile) Read(p []byte) (int, error) {
+	if f.read {
+		return 0, errCorrupted
+	}
+
+	f.read = true
+
+	return f.File.Read(p[:4])
+}
+
+func TestCopy(t *testing.T) {
+	dir := t.TempDir()
+	content := bytes.Repeat([]byte("gofr"), copyBufferSize/2)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.bin"), content, 0o600))
+
+	local := NewLocalFileSystem(nil)
+
+	testCases := []struct {
+		desc string
+		src  FileSystem
+		dst  FileSystem
+	}{
+		{desc: "through storage providers", src: local, dst: local},
+		{desc: "through files", src: plainFileSystem{local}, dst: plainFileSystem{local}},
+	}
+
+	for i, tc := range testCases {
+		dst := filepath.Join(dir, "out", tc.desc, "dst.bin")
+
+		var progress []int64
+
+		copied, err := Copy(t.Context(), tc.src, filepath.Join(dir, "src.bin"), tc.dst, dst,
+			WithChecksum(), WithProgress(func(copied, total int64) {
+				assert.Equal(t, int64(len(content)), total, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+				progress = append(progress, copied)
+			}))
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, int64(len(content)), copied, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.IsIncreasing(t, progress, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, int64(len(content)), progress[len(progress)-1], "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		written, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		assert.Equal(t, content, written, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCopy_Errors(t *testing.T) {
+	dir := t.TempDir()
+	local := NewLocalFileSystem(nil)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("data"), 0o600))
+
+	_, err := Copy(t.Context(), local, filepath.Join(dir, "missing.txt"), local, filepath.Join(dir, "dst.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Copy(t.Context(), local, filepath.Join(dir, "src.txt"), corruptingFileSystem{plainFileSystem{local}},
+		filepath.Join(dir, "dst.txt"), WithChecksum())
+	require.ErrorIs(t, err, errCorrupted, "the copied file is read back to verify its checksum")
+	assert.True(t, strings.Contains(err.Error(), "checksum"))
+}
+
+func TestCopy_FailureRemovesDestination(t *testing.T) {
+	dir := t.TempDir()
+	local := NewLocalFileSystem(nil)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("partial content"), 0o600))
+
+	testCases := []struct {
+		desc string
+		dst  FileSystem
+	}{
+		{desc: "through storage providers", dst: local},
+		{desc: "through files", dst: plainFileSystem{local}},
+	}
+
+	for i, tc := range testCases {
+		dst := filepath.Join(dir, "dst.txt")
+
+		copied, err := Copy(t.Context(), failingFileSystem{plainFileSystem{local}}, filepath.Join(dir, "src.txt"), tc.dst, dst)
+
+		require.ErrorIs(t, err, errCorrupted, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, int64(4), copied, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		_, err = os.Stat(dst)
+		require.ErrorIs(t, err, os.ErrNotExist, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
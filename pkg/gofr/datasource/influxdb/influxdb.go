@@ -7,6 +7,7 @@ import (
 	"time"
 
 	influxdb "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
 	"go.opencensus.io/trace"
 )
 
@@ -16,6 +17,8 @@ type Config struct {
 	Token    string
 	Username string
 	Password string
+	// Org is the organization of the application, in which the migrations are recorded.
+	Org string
 }
 
 type influx struct {
@@ -202,6 +205,37 @@ func (c *Client) DeleteBucket(ctx context.Context, bucketID string) error {
 	return nil
 }
 
+// UpdateBucketRetention sets how long the data of a bucket is kept before expiring.
+// Parameters:
+// - ctx: Context for request cancellation and timeouts.
+// - bucketID: The ID of the bucket to be updated. Must not be empty.
+// - retention: The duration the data is kept for, 0 keeping it forever.
+//
+// Returns:
+// - err: Error if the bucket cannot be found or updated, or if bucketID is empty.
+func (c *Client) UpdateBucketRetention(ctx context.Context, bucketID string, retention time.Duration) error {
+	if bucketID == "" {
+		return errEmptyBucketID
+	}
+
+	tracedCtx, span := c.addTrace(ctx, "update-bucket-retention", "")
+
+	start := time.Now()
+	defer c.sendOperationStats(start, "UpdateBucketRetention", "update-bucket-retention", span, bucketID, retention)
+
+	b, err := c.influx.bucket.FindBucketByID(tracedCtx, bucketID)
+	if err != nil {
+		return err
+	}
+
+	expire := domain.RetentionRuleTypeExpire
+	b.RetentionRules = domain.RetentionRules{{EverySeconds: int64(retention.Seconds()), Type: &expire}}
+
+	_, err = c.influx.bucket.UpdateBucket(tracedCtx, b)
+
+	return err
+}
+
 type Health struct {
 	Status  string         `json:"status"`            // "UP" or "DOWN"
 	Details map[string]any `json:"details,omitempty"` // extra metadata
@@ -396,6 +430,11 @@ func New(config Config) *Client {
 	}
 }
 
+// Org returns the organization of the application, in which the migrations are recorded.
+func (c *Client) Org() string {
+	return c.config.Org
+}
+
 // Connect initializes a new InfluxDB client using the configured URL and authentication token.
 // It logs the connection status and performs a health check to verify connectivity.
 //
@@ -3,6 +3,7 @@ package influxdb
 import (
 	"errors"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/influxdata/influxdb-client-go/v2/domain"
@@ -15,6 +16,7 @@ var (
 	errFailedCreatingOrg = errors.New("failed to create new organization")
 	errPingFailed        = errors.New("failed to ping")
 	errFailedQuery       = errors.New("error failed query")
+	errBucketNotFound    = errors.New("bucket not found")
 )
 
 func setupDB(t *testing.T, ctrl *gomock.Controller) *Client {
@@ -443,6 +445,32 @@ func Test_DeleteBucket(t *testing.T) {
 	}
 }
 
+func Test_UpdateBucketRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dummyID := "id1"
+	expire := domain.RetentionRuleTypeExpire
+
+	client := *setupDB(t, ctrl)
+	mockBucket := NewMockbucket(ctrl)
+	client.influx.bucket = mockBucket
+
+	require.Equal(t, errEmptyBucketID, client.UpdateBucketRetention(t.Context(), "", time.Hour))
+
+	mockBucket.EXPECT().FindBucketByID(gomock.Any(), dummyID).Return(&domain.Bucket{Id: &dummyID}, nil)
+	mockBucket.EXPECT().UpdateBucket(gomock.Any(), &domain.Bucket{
+		Id:             &dummyID,
+		RetentionRules: domain.RetentionRules{{EverySeconds: 3600, Type: &expire}},
+	}).Return(&domain.Bucket{Id: &dummyID}, nil)
+
+	require.NoError(t, client.UpdateBucketRetention(t.Context(), dummyID, time.Hour))
+
+	mockBucket.EXPECT().FindBucketByID(gomock.Any(), dummyID).Return(nil, errBucketNotFound)
+
+	require.Equal(t, errBucketNotFound, client.UpdateBucketRetention(t.Context(), dummyID, time.Hour))
+}
+
 func Test_ListBucket(t *testing.T) {
 	t.Helper()
 
@@ -4,15 +4,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/pb"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
-var errStatusDown = errors.New("status down")
+var (
+	errStatusDown       = errors.New("status down")
+	errRevisionMismatch = errors.New("revision mismatch")
+	// errKeyNotFound matches container.ErrKeyNotFound of gofr with errors.Is.
+	errKeyNotFound error = keyNotFoundError{}
+)
+
+// keyNotFoundError is returned, wrapped, for missing keys.
+type keyNotFoundError struct{}
+
+func (keyNotFoundError) Error() string {
+	return "key not found"
+}
+
+// Is matches the errors of missing keys reporting KeyNotFound, such as container.ErrKeyNotFound of gofr.
+func (keyNotFoundError) Is(target error) bool {
+	nf, ok := target.(interface{ KeyNotFound() bool })
+
+	return ok && nf.KeyNotFound()
+}
 
 type Configs struct {
 	DirPath string
@@ -84,7 +105,7 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	if err != nil {
 		c.logger.Debugf("error while fetching data for key: %v, error: %v", key, err)
 
-		return "", err
+		return "", keyError(key, err)
 	}
 
 	value, err = item.ValueCopy(nil)
@@ -120,7 +141,11 @@ func (c *Client) Delete(ctx context.Context, key string) error {
 	defer c.sendOperationStats(time.Now(), "DELETE", "delete", span, key, "")
 
 	return c.useTransaction(func(txn *badger.Txn) error {
-		return txn.Delete([]byte(key))
+		if err := txn.Delete([]byte(key)); err != nil {
+			return err
+		}
+
+		return txn.Delete([]byte(revisionPrefix + key))
 	})
 }
 
@@ -202,3 +227,250 @@ func (c *Client) addTrace(ctx context.Context, method, key string) trace.Span {
 
 	return nil
 }
+
+// SetWithTTL sets the value of key, which expires once ttl elapses.
+func (c *Client) SetWithTTL(ctx context.Context, key, value string, ttl time.Duration) error {
+	span := c.addTrace(ctx, "set-ttl", key)
+
+	defer c.sendOperationStats(time.Now(), "SETTTL", "set-ttl", span, key, value)
+
+	return c.useTransaction(func(txn *badger.Txn) error {
+		return txn.SetEntry(badger.NewEntry([]byte(key), []byte(value)).WithTTL(ttl))
+	})
+}
+
+// MGet returns the values of the keys which exist, read in a single transaction.
+func (c *Client) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	span := c.addTrace(ctx, "mget", strings.Join(keys, ","))
+
+	defer c.sendOperationStats(time.Now(), "MGET", "mget", span, keys...)
+
+	values := make(map[string]string, len(keys))
+
+	err := c.db.View(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			item, err := txn.Get([]byte(key))
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			values[key] = string(value)
+		}
+
+		return nil
+	})
+	if err != nil {
+		c.logger.Debugf("error while fetching data for keys: %v, error: %v", keys, err)
+
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// MSet sets the values of keys in a single transaction.
+func (c *Client) MSet(ctx context.Context, values map[string]string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	span := c.addTrace(ctx, "mset", strings.Join(keys, ","))
+
+	defer c.sendOperationStats(time.Now(), "MSET", "mset", span, keys...)
+
+	return c.useTransaction(func(txn *badger.Txn) error {
+		for key, value := range values {
+			if err := txn.Set([]byte(key), []byte(value)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Scan returns up to limit keys starting with prefix in ascending order, after the key given as cursor, or all of
+// them when limit is not positive. The cursor of the next page is the last key returned.
+func (c *Client) Scan(ctx context.Context, prefix, cursor string, limit int) (values map[string]string, next string, err error) {
+	span := c.addTrace(ctx, "scan", prefix)
+
+	defer c.sendOperationStats(time.Now(), "SCAN", "scan", span, prefix, cursor)
+
+	values = make(map[string]string)
+
+	err = c.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		it.Seek([]byte(max(prefix, cursor)))
+
+		for ; it.Valid(); it.Next() {
+			key := string(it.Item().Key())
+			if key == cursor || strings.HasPrefix(key, revisionPrefix) {
+				continue
+			}
+
+			if limit > 0 && len(values) == limit {
+				next = cursor
+				break
+			}
+
+			value, err := it.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			values[key], cursor = string(value), key
+		}
+
+		return nil
+	})
+	if err != nil {
+		c.logger.Debugf("error while scanning keys with prefix: %v, error: %v", prefix, err)
+
+		return nil, "", err
+	}
+
+	return values, next, nil
+}
+
+// revisionPrefix prefixes the keys holding the revisions of the keys written by CompareAndSwap.
+const revisionPrefix = "\x00gofr:revision:"
+
+// GetWithRevision returns the value of key with its revision, which increases with every write of the key.
+func (c *Client) GetWithRevision(ctx context.Context, key string) (value string, revision uint64, err error) {
+	span := c.addTrace(ctx, "get-revision", key)
+
+	defer c.sendOperationStats(time.Now(), "GETREV", "get-revision", span, key)
+
+	err = c.db.View(func(txn *badger.Txn) error {
+		item, rev, err := revisionOf(txn, key)
+		if err != nil {
+			return err
+		}
+
+		data, err := item.ValueCopy(nil)
+		value, revision = string(data), rev
+
+		return err
+	})
+	if err != nil {
+		c.logger.Debugf("error while fetching data for key: %v, error: %v", key, err)
+
+		return "", 0, keyError(key, err)
+	}
+
+	return value, revision, nil
+}
+
+// CompareAndSwap sets the value of key in a transaction, which conflicts with the transactions writing the key
+// concurrently. As the version BadgerDB gives to the key is only known once the transaction is committed, the new
+// revision is kept under a key of its own, written in the same transaction.
+func (c *Client) CompareAndSwap(ctx context.Context, key, value string, revision uint64) (newRevision uint64,
+	swapped bool, err error) {
+	span := c.addTrace(ctx, "cas", key)
+
+	defer c.sendOperationStats(time.Now(), "CAS", "cas", span, key, value)
+
+	err = c.useTransaction(func(txn *badger.Txn) error {
+		_, current, err := revisionOf(txn, key)
+		if err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+			return err
+		}
+
+		if current != revision {
+			return errRevisionMismatch
+		}
+
+		// the versions of the keys written before are at most the read timestamp of the transaction, and the
+		// version of this write is greater, so the revisions of a key keep increasing whatever wrote it.
+		newRevision = txn.ReadTs() + 1
+
+		if err := txn.Set([]byte(key), []byte(value)); err != nil {
+			return err
+		}
+
+		return txn.Set([]byte(revisionPrefix+key), []byte(strconv.FormatUint(newRevision, 10)))
+	})
+
+	switch {
+	case errors.Is(err, errRevisionMismatch), errors.Is(err, badger.ErrConflict):
+		return revision, false, nil
+	case err != nil:
+		return 0, false, err
+	}
+
+	return newRevision, true, nil
+}
+
+// keyError wraps errKeyNotFound into the error of a missing key.
+func keyError(key string, err error) error {
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return fmt.Errorf("%w: %s", errKeyNotFound, key)
+	}
+
+	return err
+}
+
+// revisionOf returns the item of key with its revision: the one written by CompareAndSwap when it wrote the current
+// value, the version of the item otherwise.
+func revisionOf(txn *badger.Txn, key string) (*badger.Item, uint64, error) {
+	item, err := txn.Get([]byte(key))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stored, err := txn.Get([]byte(revisionPrefix + key))
+
+	switch {
+	case errors.Is(err, badger.ErrKeyNotFound):
+		return item, item.Version(), nil
+	case err != nil:
+		return nil, 0, err
+	case stored.Version() != item.Version(): // the key was written since, by another method.
+		return item, item.Version(), nil
+	}
+
+	var revision uint64
+
+	err = stored.Value(func(val []byte) error {
+		revision, err = strconv.ParseUint(string(val), 10, 64)
+
+		return err
+	})
+
+	return item, revision, err
+}
+
+// Watch calls handler with the changes of the keys starting with prefix, until ctx is done. As BadgerDB
+// stores deletions as empty values, keys set to an empty value are reported as deleted.
+func (c *Client) Watch(ctx context.Context, prefix string, handler func(key, value string, deleted bool)) error {
+	err := c.db.Subscribe(ctx, func(kvs *badger.KVList) error {
+		for _, kv := range kvs.GetKv() {
+			if !strings.HasPrefix(string(kv.GetKey()), revisionPrefix) {
+				handler(string(kv.GetKey()), string(kv.GetValue()), len(kv.GetValue()) == 0)
+			}
+		}
+
+		return nil
+	}, []pb.Match{{Prefix: []byte(prefix)}})
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+
+	return err
+}
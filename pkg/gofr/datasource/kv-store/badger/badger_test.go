@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,7 +60,7 @@ func Test_ClientGetError(t *testing.T) {
 
 	val, err := cl.Get(context.Background(), "lkey")
 
-	require.EqualError(t, err, "Key not found")
+	require.EqualError(t, err, "key not found: lkey")
 	assert.Empty(t, val)
 }
 
@@ -79,3 +80,138 @@ func Test_ClientHealthCheck(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, fmt.Sprint(val), "UP")
 }
+
+func Test_ClientSetWithTTL(t *testing.T) {
+	cl := setupDB(t)
+
+	require.NoError(t, cl.SetWithTTL(t.Context(), "session", "token", time.Second))
+
+	val, err := cl.Get(t.Context(), "session")
+	require.NoError(t, err)
+	assert.Equal(t, "token", val)
+
+	time.Sleep(1100 * time.Millisecond)
+
+	_, err = cl.Get(t.Context(), "session")
+	require.ErrorIs(t, err, errKeyNotFound)
+	require.ErrorIs(t, err, containerKeyNotFound{})
+}
+
+func Test_ClientMGetMSet(t *testing.T) {
+	cl := setupDB(t)
+
+	require.NoError(t, cl.MSet(t.Context(), map[string]string{"a": "1", "b": "2"}))
+
+	values, err := cl.MGet(t.Context(), "a", "b", "missing")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, values)
+}
+
+func Test_ClientScan(t *testing.T) {
+	cl := setupDB(t)
+
+	require.NoError(t, cl.MSet(t.Context(), map[string]string{
+		"user:1": "a", "user:2": "b", "user:3": "c", "order:1": "d",
+	}))
+
+	testCases := []struct {
+		cursor string
+		values map[string]string
+		next   string
+	}{
+		{cursor: "", values: map[string]string{"user:1": "a", "user:2": "b"}, next: "user:2"},
+		{cursor: "user:2", values: map[string]string{"user:3": "c"}, next: ""},
+	}
+
+	for i, tc := range testCases {
+		values, next, err := cl.Scan(t.Context(), "user:", tc.cursor, 2)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.values, values, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.next, next, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func Test_ClientCompareAndSwap(t *testing.T) {
+	cl := setupDB(t)
+
+	revision, swapped, err := cl.CompareAndSwap(t.Context(), "counter", "1", 0)
+	require.NoError(t, err)
+	assert.True(t, swapped, "keys which do not exist are created with revision 0")
+
+	_, swapped, err = cl.CompareAndSwap(t.Context(), "counter", "1", 0)
+	require.NoError(t, err)
+	assert.False(t, swapped, "existing keys are not overwritten with revision 0")
+
+	value, current, err := cl.GetWithRevision(t.Context(), "counter")
+	require.NoError(t, err)
+	assert.Equal(t, "1", value)
+	assert.Equal(t, revision, current)
+
+	newRevision, swapped, err := cl.CompareAndSwap(t.Context(), "counter", "2", revision)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+	assert.Greater(t, newRevision, revision)
+
+	_, swapped, err = cl.CompareAndSwap(t.Context(), "counter", "3", revision)
+	require.NoError(t, err)
+	assert.False(t, swapped, "stale revisions are rejected")
+
+	_, current, err = cl.GetWithRevision(t.Context(), "counter")
+	require.NoError(t, err)
+	assert.Equal(t, newRevision, current, "the revision returned is the one of the write")
+
+	require.NoError(t, cl.Set(t.Context(), "counter", "4"))
+
+	_, current, err = cl.GetWithRevision(t.Context(), "counter")
+	require.NoError(t, err)
+	assert.Greater(t, current, newRevision, "the writes of other methods increase the revision")
+
+	_, swapped, err = cl.CompareAndSwap(t.Context(), "counter", "5", newRevision)
+	require.NoError(t, err)
+	assert.False(t, swapped, "the revision read before a Set is stale")
+
+	values, _, err := cl.Scan(t.Context(), "", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"counter": "4"}, values, "the revisions are not returned as keys")
+}
+
+func Test_ClientWatch(t *testing.T) {
+	cl := setupDB(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	changes := make(chan string, 10)
+	done := make(chan error)
+
+	go func() {
+		done <- cl.Watch(ctx, "user:", func(key, value string, deleted bool) {
+			changes <- fmt.Sprint(key, "=", value, " ", deleted)
+		})
+	}()
+
+	// the subscription is registered asynchronously, so keys are written until the first change is received.
+	require.Eventually(t, func() bool {
+		return cl.Set(t.Context(), "user:1", "a") == nil && len(changes) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, cl.Set(t.Context(), "order:1", "b"))
+	require.NoError(t, cl.Delete(t.Context(), "user:1"))
+
+	for change := range changes {
+		if change != "user:1=a false" {
+			assert.Equal(t, "user:1= true", change)
+			break
+		}
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// containerKeyNotFound stands for container.ErrKeyNotFound of gofr, which reports KeyNotFound.
+type containerKeyNotFound struct{}
+
+func (containerKeyNotFound) Error() string { return "key not found" }
+
+func (containerKeyNotFound) KeyNotFound() bool { return true }
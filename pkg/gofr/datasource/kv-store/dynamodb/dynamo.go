@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -17,15 +21,33 @@ import (
 
 var (
 	errClientNotConnected = errors.New("client not connected, call Connect() first")
-	errKeyNotFound        = errors.New("key not found")
 	errStatusDown         = errors.New("status down")
+	// errKeyNotFound matches container.ErrKeyNotFound of gofr with errors.Is.
+	errKeyNotFound error = keyNotFoundError{}
 )
 
+// keyNotFoundError is returned, wrapped, for missing keys.
+type keyNotFoundError struct{}
+
+func (keyNotFoundError) Error() string {
+	return "key not found"
+}
+
+// Is matches the errors of missing keys reporting KeyNotFound, such as container.ErrKeyNotFound of gofr.
+func (keyNotFoundError) Is(target error) bool {
+	nf, ok := target.(interface{ KeyNotFound() bool })
+
+	return ok && nf.KeyNotFound()
+}
+
 type Configs struct {
 	Table            string
 	Region           string
 	Endpoint         string
 	PartitionKeyName string
+	// TTLAttributeName is the attribute holding the expiry of the keys set with SetWithTTL, "expires_at" by default.
+	// It must be the TTL attribute of the table for DynamoDB to delete the expired keys.
+	TTLAttributeName string
 }
 type dynamoDBInterface interface {
 	PutItem(
@@ -48,6 +70,26 @@ type dynamoDBInterface interface {
 		params *dynamodb.DescribeTableInput,
 		optFns ...func(*dynamodb.Options),
 	) (*dynamodb.DescribeTableOutput, error)
+	BatchGetItem(
+		ctx context.Context,
+		params *dynamodb.BatchGetItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.BatchGetItemOutput, error)
+	UpdateItem(
+		ctx context.Context,
+		params *dynamodb.UpdateItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.UpdateItemOutput, error)
+	TransactWriteItems(
+		ctx context.Context,
+		params *dynamodb.TransactWriteItemsInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.TransactWriteItemsOutput, error)
+	Scan(
+		ctx context.Context,
+		params *dynamodb.ScanInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.ScanOutput, error)
 }
 
 type Client struct {
@@ -64,6 +106,10 @@ func New(configs Configs) *Client {
 		configs.PartitionKeyName = "pk"
 	}
 
+	if configs.TTLAttributeName == "" {
+		configs.TTLAttributeName = "expires_at"
+	}
+
 	return &Client{configs: &configs, connected: false}
 }
 
@@ -156,8 +202,8 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 		return "", err
 	}
 
-	if out.Item == nil {
-		return "", errKeyNotFound
+	if out.Item == nil || c.expired(out.Item) {
+		return "", fmt.Errorf("%w: %s", errKeyNotFound, key)
 	}
 
 	// Look for a "value" field that contains the JSON string
@@ -180,17 +226,7 @@ func (c *Client) Set(ctx context.Context, key, value string) error {
 	defer c.sendOperationsStats(time.Now(), "SET", "set", span, key)
 
 	// Store the value as a string in the "value" field
-	item := map[string]types.AttributeValue{
-		c.configs.PartitionKeyName: &types.AttributeValueMemberS{Value: key},
-		"value":                    &types.AttributeValueMemberS{Value: value},
-	}
-
-	input := &dynamodb.PutItemInput{
-		TableName: aws.String(c.configs.Table),
-		Item:      item,
-	}
-
-	_, err := c.db.PutItem(ctx, input)
+	_, err := c.db.UpdateItem(ctx, c.update(key, value, time.Time{}))
 	if err != nil {
 		c.logger.Errorf("error while setting data for key: %v, error: %v", key, err)
 		return err
@@ -294,3 +330,300 @@ func (c *Client) addTrace(ctx context.Context, method, key string) trace.Span {
 
 	return nil
 }
+
+const (
+	versionAttribute = "version"
+	// batchGetSize and transactWriteSize are the most items DynamoDB reads in a batch and writes in a transaction.
+	batchGetSize      = 100
+	transactWriteSize = 100
+)
+
+// SetWithTTL sets the value of key with its expiry in the TTL attribute. As DynamoDB deletes the expired items
+// with a delay, they are ignored once expired by the reads of the client.
+func (c *Client) SetWithTTL(ctx context.Context, key, value string, ttl time.Duration) error {
+	if !c.connected {
+		return errClientNotConnected
+	}
+
+	span := c.addTrace(ctx, "set-ttl", key)
+	defer c.sendOperationsStats(time.Now(), "SETTTL", "set-ttl", span, key)
+
+	_, err := c.db.UpdateItem(ctx, c.update(key, value, time.Now().Add(ttl)))
+	if err != nil {
+		c.logger.Errorf("error while setting data for key: %v, error: %v", key, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// MGet returns the values of the keys which exist, read in batches of 100 keys.
+func (c *Client) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	if !c.connected {
+		return nil, errClientNotConnected
+	}
+
+	span := c.addTrace(ctx, "mget", strings.Join(keys, ","))
+	defer c.sendOperationsStats(time.Now(), "MGET", "mget", span, keys...)
+
+	values := make(map[string]string, len(keys))
+
+	for batch := range slices.Chunk(keys, batchGetSize) {
+		requested := make([]map[string]types.AttributeValue, len(batch))
+
+		for i, key := range batch {
+			requested[i] = c.key(key)
+		}
+
+		request := map[string]types.KeysAndAttributes{c.configs.Table: {Keys: requested}}
+
+		// the keys DynamoDB did not read, when throttled, are requested again.
+		for len(request) > 0 {
+			out, err := c.db.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: request})
+			if err != nil {
+				c.logger.Errorf("error while fetching data for keys: %v, error: %v", batch, err)
+
+				return nil, err
+			}
+
+			c.collect(values, out.Responses[c.configs.Table])
+
+			request = out.UnprocessedKeys
+		}
+	}
+
+	return values, nil
+}
+
+// MSet sets the values of keys in transactions of 100 keys. The transactions written before a failure are kept.
+func (c *Client) MSet(ctx context.Context, values map[string]string) error {
+	if !c.connected {
+		return errClientNotConnected
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	span := c.addTrace(ctx, "mset", strings.Join(keys, ","))
+	defer c.sendOperationsStats(time.Now(), "MSET", "mset", span, keys...)
+
+	// the values are written with updates, which a batch write does not support, to bump the versions of the keys.
+	for batch := range slices.Chunk(keys, transactWriteSize) {
+		writes := make([]types.TransactWriteItem, len(batch))
+
+		for i, key := range batch {
+			update := c.update(key, values[key], time.Time{})
+
+			writes[i] = types.TransactWriteItem{Update: &types.Update{
+				TableName:                 update.TableName,
+				Key:                       update.Key,
+				UpdateExpression:          update.UpdateExpression,
+				ExpressionAttributeNames:  update.ExpressionAttributeNames,
+				ExpressionAttributeValues: update.ExpressionAttributeValues,
+			}}
+		}
+
+		_, err := c.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: writes})
+		if err != nil {
+			c.logger.Errorf("error while setting data for keys: %v, error: %v", batch, err)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Scan returns up to limit keys starting with prefix, or all of them when limit is not positive. DynamoDB scans
+// the table in an order of its own, reading limit items per request and leaving out the ones without the prefix,
+// so a page may hold fewer keys than limit while the cursor of the next page is not empty.
+func (c *Client) Scan(ctx context.Context, prefix, cursor string, limit int) (values map[string]string, next string, err error) {
+	if !c.connected {
+		return nil, "", errClientNotConnected
+	}
+
+	span := c.addTrace(ctx, "scan", prefix)
+	defer c.sendOperationsStats(time.Now(), "SCAN", "scan", span, prefix)
+
+	input := &dynamodb.ScanInput{
+		TableName:                 aws.String(c.configs.Table),
+		FilterExpression:          aws.String("begins_with(#pk, :prefix)"),
+		ExpressionAttributeNames:  map[string]string{"#pk": c.configs.PartitionKeyName},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":prefix": &types.AttributeValueMemberS{Value: prefix}},
+	}
+
+	if limit > 0 {
+		input.Limit = aws.Int32(int32(min(limit, math.MaxInt32))) //nolint:gosec // the limit is bounded above.
+	}
+
+	if cursor != "" {
+		input.ExclusiveStartKey = c.key(cursor)
+	}
+
+	values = make(map[string]string)
+
+	for {
+		out, err := c.db.Scan(ctx, input)
+		if err != nil {
+			c.logger.Errorf("error while scanning keys with prefix: %v, error: %v", prefix, err)
+
+			return nil, "", err
+		}
+
+		c.collect(values, out.Items)
+
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+
+		if limit > 0 || out.LastEvaluatedKey == nil {
+			break
+		}
+	}
+
+	if last, ok := input.ExclusiveStartKey[c.configs.PartitionKeyName].(*types.AttributeValueMemberS); ok {
+		next = last.Value
+	}
+
+	return values, next, nil
+}
+
+// GetWithRevision returns the value of key with the revision kept in its version attribute, which every write of
+// the key increments. The items written by other clients without a version have revision 0.
+func (c *Client) GetWithRevision(ctx context.Context, key string) (value string, revision uint64, err error) {
+	if !c.connected {
+		return "", 0, errClientNotConnected
+	}
+
+	span := c.addTrace(ctx, "get-revision", key)
+	defer c.sendOperationsStats(time.Now(), "GETREV", "get-revision", span, key)
+
+	out, err := c.db.GetItem(ctx, &dynamodb.GetItemInput{TableName: aws.String(c.configs.Table), Key: c.key(key)})
+	if err != nil {
+		c.logger.Errorf("error while fetching data for key: %v, error: %v", key, err)
+
+		return "", 0, err
+	}
+
+	if out.Item == nil || c.expired(out.Item) {
+		return "", 0, fmt.Errorf("%w: %s", errKeyNotFound, key)
+	}
+
+	if v, ok := out.Item["value"].(*types.AttributeValueMemberS); ok {
+		value = v.Value
+	}
+
+	if v, ok := out.Item[versionAttribute].(*types.AttributeValueMemberN); ok {
+		revision, _ = strconv.ParseUint(v.Value, 10, 64)
+	}
+
+	return value, revision, nil
+}
+
+// CompareAndSwap sets the value of key with a conditional write on its version attribute. With revision 0, the key
+// is only created when it does not exist.
+func (c *Client) CompareAndSwap(ctx context.Context, key, value string, revision uint64) (newRevision uint64,
+	swapped bool, err error) {
+	if !c.connected {
+		return 0, false, errClientNotConnected
+	}
+
+	span := c.addTrace(ctx, "cas", key)
+	defer c.sendOperationsStats(time.Now(), "CAS", "cas", span, key)
+
+	item := c.item(key, value)
+	item[versionAttribute] = &types.AttributeValueMemberN{Value: strconv.FormatUint(revision+1, 10)}
+
+	input := &dynamodb.PutItemInput{
+		TableName:                aws.String(c.configs.Table),
+		Item:                     item,
+		ConditionExpression:      aws.String("attribute_not_exists(#pk)"),
+		ExpressionAttributeNames: map[string]string{"#pk": c.configs.PartitionKeyName},
+	}
+
+	if revision > 0 {
+		input.ConditionExpression = aws.String("#version = :revision")
+		input.ExpressionAttributeNames = map[string]string{"#version": versionAttribute}
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":revision": &types.AttributeValueMemberN{Value: strconv.FormatUint(revision, 10)},
+		}
+	}
+
+	_, err = c.db.PutItem(ctx, input)
+
+	var conditionFailed *types.ConditionalCheckFailedException
+
+	switch {
+	case errors.As(err, &conditionFailed):
+		return revision, false, nil
+	case err != nil:
+		c.logger.Errorf("error while setting data for key: %v, error: %v", key, err)
+
+		return 0, false, err
+	}
+
+	return revision + 1, true, nil
+}
+
+// update sets the value of key and increments its version, so that a compare-and-swap based on the previous revision
+// fails. The expiry of the key is set to expiry, or removed when expiry is zero.
+func (c *Client) update(key, value string, expiry time.Time) *dynamodb.UpdateItemInput {
+	input := &dynamodb.UpdateItemInput{
+		TableName:        aws.String(c.configs.Table),
+		Key:              c.key(key),
+		UpdateExpression: aws.String("SET #value = :value, #version = if_not_exists(#version, :zero) + :one REMOVE #ttl"),
+		ExpressionAttributeNames: map[string]string{
+			"#value": "value", "#version": versionAttribute, "#ttl": c.configs.TTLAttributeName,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":value": &types.AttributeValueMemberS{Value: value},
+			":zero":  &types.AttributeValueMemberN{Value: "0"},
+			":one":   &types.AttributeValueMemberN{Value: "1"},
+		},
+	}
+
+	if !expiry.IsZero() {
+		input.UpdateExpression = aws.String("SET #value = :value, #version = if_not_exists(#version, :zero) + :one, #ttl = :expiry")
+		input.ExpressionAttributeValues[":expiry"] = &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(expiry.Unix(), 10),
+		}
+	}
+
+	return input
+}
+
+func (c *Client) key(key string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{c.configs.PartitionKeyName: &types.AttributeValueMemberS{Value: key}}
+}
+
+func (c *Client) item(key, value string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		c.configs.PartitionKeyName: &types.AttributeValueMemberS{Value: key},
+		"value":                    &types.AttributeValueMemberS{Value: value},
+	}
+}
+
+// collect adds the values of the items which have not expired to values.
+func (c *Client) collect(values map[string]string, items []map[string]types.AttributeValue) {
+	for _, item := range items {
+		key, okKey := item[c.configs.PartitionKeyName].(*types.AttributeValueMemberS)
+		value, okValue := item["value"].(*types.AttributeValueMemberS)
+
+		if okKey && okValue && !c.expired(item) {
+			values[key.Value] = value.Value
+		}
+	}
+}
+
+// expired reports whether an item set with SetWithTTL has expired, DynamoDB not having deleted it yet.
+func (c *Client) expired(item map[string]types.AttributeValue) bool {
+	expiry, ok := item[c.configs.TTLAttributeName].(*types.AttributeValueMemberN)
+	if !ok {
+		return false
+	}
+
+	seconds, err := strconv.ParseInt(expiry.Value, 10, 64)
+
+	return err == nil && time.Now().Unix() >= seconds
+}
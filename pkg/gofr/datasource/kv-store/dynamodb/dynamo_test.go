@@ -3,7 +3,10 @@ package dynamodb
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -35,7 +38,7 @@ func setupTest(t *testing.T) testDeps {
 
 	client := &Client{
 		db:        mockDB,
-		configs:   &Configs{Table: "test-table", Region: "us-east-1", PartitionKeyName: "pk"},
+		configs:   &Configs{Table: "test-table", Region: "us-east-1", PartitionKeyName: "pk", TTLAttributeName: "expires_at"},
 		logger:    mockLogger,
 		metrics:   mockMetrics,
 		connected: true, // Set as connected for tests
@@ -65,15 +68,21 @@ func Test_ClientSet(t *testing.T) {
 	key := "test-key"
 	value := "test-value"
 
-	expectedInput := &dynamodb.PutItemInput{
-		TableName: aws.String("test-table"),
-		Item: map[string]types.AttributeValue{
-			"pk":    &types.AttributeValueMemberS{Value: key},
-			"value": &types.AttributeValueMemberS{Value: value},
+	expectedInput := &dynamodb.UpdateItemInput{
+		TableName:        aws.String("test-table"),
+		Key:              map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: key}},
+		UpdateExpression: aws.String("SET #value = :value, #version = if_not_exists(#version, :zero) + :one REMOVE #ttl"),
+		ExpressionAttributeNames: map[string]string{
+			"#value": "value", "#version": "version", "#ttl": "expires_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":value": &types.AttributeValueMemberS{Value: value},
+			":zero":  &types.AttributeValueMemberN{Value: "0"},
+			":one":   &types.AttributeValueMemberN{Value: "1"},
 		},
 	}
 
-	mockDB.EXPECT().PutItem(ctx, expectedInput, gomock.Any()).Return(&dynamodb.PutItemOutput{}, nil)
+	mockDB.EXPECT().UpdateItem(ctx, expectedInput, gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, nil)
 	mockLogger.EXPECT().Debug(gomock.Any())
 	mockMetrics.EXPECT().RecordHistogram(
 		gomock.Any(),
@@ -101,7 +110,7 @@ func Test_ClientSetError(t *testing.T) {
 	value := "test-value"
 	expectedErr := errDynamoFailure
 
-	mockDB.EXPECT().PutItem(ctx, gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockDB.EXPECT().UpdateItem(ctx, gomock.Any(), gomock.Any()).Return(nil, expectedErr)
 	mockLogger.EXPECT().Errorf("error while setting data for key: %v, error: %v", key, expectedErr)
 	mockLogger.EXPECT().Debug(gomock.Any())
 	mockMetrics.EXPECT().RecordHistogram(
@@ -451,6 +460,7 @@ func Test_ClientGetItemNotFound(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "key not found")
+	require.ErrorIs(t, err, containerKeyNotFound{})
 	assert.Empty(t, result)
 }
 
@@ -560,15 +570,21 @@ func Test_ClientSetWithTracer(t *testing.T) {
 	key := "test-key"
 	value := "test-value"
 
-	expectedInput := &dynamodb.PutItemInput{
-		TableName: aws.String("test-table"),
-		Item: map[string]types.AttributeValue{
-			"pk":    &types.AttributeValueMemberS{Value: key},
-			"value": &types.AttributeValueMemberS{Value: value},
+	expectedInput := &dynamodb.UpdateItemInput{
+		TableName:        aws.String("test-table"),
+		Key:              map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: key}},
+		UpdateExpression: aws.String("SET #value = :value, #version = if_not_exists(#version, :zero) + :one REMOVE #ttl"),
+		ExpressionAttributeNames: map[string]string{
+			"#value": "value", "#version": "version", "#ttl": "expires_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":value": &types.AttributeValueMemberS{Value: value},
+			":zero":  &types.AttributeValueMemberN{Value: "0"},
+			":one":   &types.AttributeValueMemberN{Value: "1"},
 		},
 	}
 
-	mockDB.EXPECT().PutItem(ctx, expectedInput, gomock.Any()).Return(&dynamodb.PutItemOutput{}, nil)
+	mockDB.EXPECT().UpdateItem(ctx, expectedInput, gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, nil)
 	mockLogger.EXPECT().Debug(gomock.Any())
 	mockMetrics.EXPECT().RecordHistogram(
 		gomock.Any(),
@@ -628,3 +644,166 @@ func Test_ClientGetWithTracer(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expectedValue, result)
 }
+
+// setupExtendedTest returns a client whose logs and metrics are not checked.
+func setupExtendedTest(t *testing.T) testDeps {
+	t.Helper()
+
+	deps := setupTest(t)
+
+	deps.mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	deps.mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+	deps.mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_dynamodb_duration_ms", gomock.Any(),
+		"table", "test-table", "operation", gomock.Any()).AnyTimes()
+
+	return deps
+}
+
+func item(key, value string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"pk":    &types.AttributeValueMemberS{Value: key},
+		"value": &types.AttributeValueMemberS{Value: value},
+	}
+}
+
+func Test_ClientSetWithTTL(t *testing.T) {
+	deps := setupExtendedTest(t)
+
+	deps.mockDB.EXPECT().UpdateItem(deps.ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, input *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+			expiry, err := strconv.ParseInt(input.ExpressionAttributeValues[":expiry"].(*types.AttributeValueMemberN).Value, 10, 64)
+
+			require.NoError(t, err)
+			assert.InDelta(t, time.Now().Add(time.Hour).Unix(), expiry, 1)
+			assert.Equal(t, "SET #value = :value, #version = if_not_exists(#version, :zero) + :one, #ttl = :expiry",
+				*input.UpdateExpression)
+
+			return &dynamodb.UpdateItemOutput{}, nil
+		})
+
+	require.NoError(t, deps.client.SetWithTTL(deps.ctx, "session", "token", time.Hour))
+
+	expired := item("session", "token")
+	expired["expires_at"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix()-1, 10)}
+
+	deps.mockDB.EXPECT().GetItem(deps.ctx, gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{Item: expired}, nil)
+
+	_, err := deps.client.Get(deps.ctx, "session")
+	require.ErrorIs(t, err, errKeyNotFound, "expired items not deleted yet are not returned")
+}
+
+func Test_ClientMGet(t *testing.T) {
+	deps := setupExtendedTest(t)
+
+	unprocessed := map[string]types.KeysAndAttributes{"test-table": {Keys: []map[string]types.AttributeValue{
+		{"pk": &types.AttributeValueMemberS{Value: "b"}},
+	}}}
+
+	gomock.InOrder(
+		deps.mockDB.EXPECT().BatchGetItem(deps.ctx, gomock.Any(), gomock.Any()).Return(&dynamodb.BatchGetItemOutput{
+			Responses:       map[string][]map[string]types.AttributeValue{"test-table": {item("a", "1")}},
+			UnprocessedKeys: unprocessed,
+		}, nil),
+		deps.mockDB.EXPECT().BatchGetItem(deps.ctx, &dynamodb.BatchGetItemInput{RequestItems: unprocessed},
+			gomock.Any()).Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]types.AttributeValue{"test-table": {item("b", "2")}},
+		}, nil),
+	)
+
+	values, err := deps.client.MGet(deps.ctx, "a", "b", "missing")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, values)
+}
+
+func Test_ClientMSet(t *testing.T) {
+	deps := setupExtendedTest(t)
+
+	values := make(map[string]string)
+	for i := range 130 {
+		values[fmt.Sprint("key", i)] = "value"
+	}
+
+	written := 0
+
+	deps.mockDB.EXPECT().TransactWriteItems(deps.ctx, gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, input *dynamodb.TransactWriteItemsInput,
+			_ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			assert.LessOrEqual(t, len(input.TransactItems), transactWriteSize)
+			assert.Contains(t, *input.TransactItems[0].Update.UpdateExpression, "#version = if_not_exists(#version, :zero) + :one",
+				"the writes must bump the versions of the keys")
+
+			written += len(input.TransactItems)
+
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		})
+
+	require.NoError(t, deps.client.MSet(deps.ctx, values))
+	assert.Equal(t, 130, written)
+}
+
+func Test_ClientScan(t *testing.T) {
+	deps := setupExtendedTest(t)
+
+	deps.mockDB.EXPECT().Scan(deps.ctx, &dynamodb.ScanInput{
+		TableName:                 aws.String("test-table"),
+		FilterExpression:          aws.String("begins_with(#pk, :prefix)"),
+		ExpressionAttributeNames:  map[string]string{"#pk": "pk"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":prefix": &types.AttributeValueMemberS{Value: "user:"}},
+		Limit:                     aws.Int32(2),
+		ExclusiveStartKey:         map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: "user:1"}},
+	}, gomock.Any()).Return(&dynamodb.ScanOutput{
+		Items:            []map[string]types.AttributeValue{item("user:2", "b")},
+		LastEvaluatedKey: map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: "order:1"}},
+	}, nil)
+
+	values, next, err := deps.client.Scan(deps.ctx, "user:", "user:1", 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user:2": "b"}, values)
+	assert.Equal(t, "order:1", next)
+}
+
+func Test_ClientCompareAndSwap(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		revision  uint64
+		condition string
+		err       error
+		expected  uint64
+		swapped   bool
+	}{
+		{desc: "create", revision: 0, condition: "attribute_not_exists(#pk)", expected: 1, swapped: true},
+		{desc: "existing key", revision: 0, condition: "attribute_not_exists(#pk)",
+			err: &types.ConditionalCheckFailedException{}, expected: 0},
+		{desc: "update", revision: 4, condition: "#version = :revision", expected: 5, swapped: true},
+		{desc: "stale revision", revision: 4, condition: "#version = :revision",
+			err: &types.ConditionalCheckFailedException{}, expected: 4},
+	}
+
+	for i, tc := range testCases {
+		deps := setupExtendedTest(t)
+
+		deps.mockDB.EXPECT().PutItem(deps.ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+				assert.Equal(t, tc.condition, *input.ConditionExpression, "TEST[%d], Failed.\n%s", i, tc.desc)
+				assert.Equal(t, &types.AttributeValueMemberN{Value: strconv.FormatUint(tc.revision+1, 10)},
+					input.Item["version"], "TEST[%d], Failed.\n%s", i, tc.desc)
+
+				return &dynamodb.PutItemOutput{}, tc.err
+			})
+
+		revision, swapped, err := deps.client.CompareAndSwap(deps.ctx, "key", "value", tc.revision)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expected, revision, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.swapped, swapped, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+// containerKeyNotFound stands for container.ErrKeyNotFound of gofr, which reports KeyNotFound.
+type containerKeyNotFound struct{}
+
+func (containerKeyNotFound) Error() string { return "key not found" }
+
+func (containerKeyNotFound) KeyNotFound() bool { return true }
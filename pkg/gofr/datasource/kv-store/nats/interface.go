@@ -32,13 +32,16 @@ type JetStream interface {
 
 // MockKeyValueEntry for testing.
 type MockKeyValueEntry struct {
-	value []byte
+	key       string
+	value     []byte
+	revision  uint64
+	operation nats.KeyValueOp
 }
 
-func (*MockKeyValueEntry) Bucket() string             { return "" }
-func (*MockKeyValueEntry) Key() string                { return "" }
-func (m *MockKeyValueEntry) Value() []byte            { return m.value }
-func (*MockKeyValueEntry) Revision() uint64           { return 0 }
-func (*MockKeyValueEntry) Created() time.Time         { return time.Time{} }
-func (*MockKeyValueEntry) Delta() uint64              { return 0 }
-func (*MockKeyValueEntry) Operation() nats.KeyValueOp { return 0 }
+func (*MockKeyValueEntry) Bucket() string               { return "" }
+func (m *MockKeyValueEntry) Key() string                { return m.key }
+func (m *MockKeyValueEntry) Value() []byte              { return m.value }
+func (m *MockKeyValueEntry) Revision() uint64           { return m.revision }
+func (*MockKeyValueEntry) Created() time.Time           { return time.Time{} }
+func (*MockKeyValueEntry) Delta() uint64                { return 0 }
+func (m *MockKeyValueEntry) Operation() nats.KeyValueOp { return m.operation }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/nats-io/nats.go"
@@ -12,10 +14,25 @@ import (
 )
 
 var (
-	errStatusDown  = errors.New("status down")
-	errKeyNotFound = errors.New("key not found")
+	errStatusDown = errors.New("status down")
+	// errKeyNotFound matches container.ErrKeyNotFound of gofr with errors.Is.
+	errKeyNotFound error = keyNotFoundError{}
 )
 
+// keyNotFoundError is returned, wrapped, for missing keys.
+type keyNotFoundError struct{}
+
+func (keyNotFoundError) Error() string {
+	return "key not found"
+}
+
+// Is matches the errors of missing keys reporting KeyNotFound, such as container.ErrKeyNotFound of gofr.
+func (keyNotFoundError) Is(target error) bool {
+	nf, ok := target.(interface{ KeyNotFound() bool })
+
+	return ok && nf.KeyNotFound()
+}
+
 type Configs struct {
 	Server string
 	Bucket string
@@ -234,3 +251,187 @@ func (c *Client) addTrace(ctx context.Context, method, key string) trace.Span {
 
 	return nil
 }
+
+// MGet returns the values of the keys which exist. NATS KV reads each key separately.
+func (c *Client) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	span := c.addTrace(ctx, "mget", strings.Join(keys, ","))
+	defer c.sendOperationStats(time.Now(), "MGET", "mget", span, keys...)
+
+	values := make(map[string]string, len(keys))
+
+	for _, key := range keys {
+		entry, err := c.kv.Get(key)
+		if errors.Is(err, nats.ErrKeyNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key %s: %w", key, err)
+		}
+
+		values[key] = string(entry.Value())
+	}
+
+	return values, nil
+}
+
+// MSet sets the values of keys one by one, stopping at the first failure. The keys set before it are kept.
+func (c *Client) MSet(ctx context.Context, values map[string]string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	span := c.addTrace(ctx, "mset", strings.Join(keys, ","))
+	defer c.sendOperationStats(time.Now(), "MSET", "mset", span, keys...)
+
+	for _, key := range keys {
+		if _, err := c.kv.Put(key, []byte(values[key])); err != nil {
+			return fmt.Errorf("failed to set key %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// Scan returns up to limit keys starting with prefix in ascending order, after the key given as cursor, or all of
+// them when limit is not positive. The cursor of the next page is the last key returned. As NATS KV lists all keys
+// of the bucket, the cost of a page grows with the size of the bucket.
+func (c *Client) Scan(ctx context.Context, prefix, cursor string, limit int) (values map[string]string, next string, err error) {
+	span := c.addTrace(ctx, "scan", prefix)
+	defer c.sendOperationStats(time.Now(), "SCAN", "scan", span, prefix)
+
+	lister, err := c.kv.ListKeys(nats.Context(ctx))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	var keys []string
+
+	for key := range lister.Keys() {
+		if strings.HasPrefix(key, prefix) && key > cursor {
+			keys = append(keys, key)
+		}
+	}
+
+	_ = lister.Stop()
+
+	slices.Sort(keys)
+
+	if limit > 0 && len(keys) > limit {
+		keys, next = keys[:limit], keys[limit-1]
+	}
+
+	values, err = c.MGet(ctx, keys...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return values, next, nil
+}
+
+// GetWithRevision returns the value of key with its revision in the bucket.
+func (c *Client) GetWithRevision(ctx context.Context, key string) (value string, revision uint64, err error) {
+	span := c.addTrace(ctx, "get-revision", key)
+	defer c.sendOperationStats(time.Now(), "GETREV", "get-revision", span, key)
+
+	entry, err := c.kv.Get(key)
+	if err != nil {
+		if errors.Is(err, nats.ErrKeyNotFound) {
+			return "", 0, fmt.Errorf("%w: %s", errKeyNotFound, key)
+		}
+
+		return "", 0, fmt.Errorf("failed to get key: %w", err)
+	}
+
+	return string(entry.Value()), entry.Revision(), nil
+}
+
+// CompareAndSwap sets the value of key if its latest revision in the bucket is still revision.
+func (c *Client) CompareAndSwap(ctx context.Context, key, value string, revision uint64) (newRevision uint64,
+	swapped bool, err error) {
+	span := c.addTrace(ctx, "cas", key)
+	defer c.sendOperationStats(time.Now(), "CAS", "cas", span, key)
+
+	if revision == 0 {
+		newRevision, err = c.kv.Create(key, []byte(value))
+	} else {
+		newRevision, err = c.kv.Update(key, []byte(value), revision)
+	}
+
+	switch {
+	case errors.Is(err, nats.ErrKeyExists):
+		return revision, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("failed to set key: %w", err)
+	}
+
+	return newRevision, true, nil
+}
+
+// Watch calls handler with the changes of the keys starting with prefix, until ctx is done. The changes made
+// before Watch is called are not replayed.
+func (c *Client) Watch(ctx context.Context, prefix string, handler func(key, value string, deleted bool)) error {
+	watcher, err := c.kv.WatchAll(nats.UpdatesOnly(), nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to watch keys: %w", err)
+	}
+
+	defer func() { _ = watcher.Stop() }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entry, ok := <-watcher.Updates():
+			if !ok {
+				return nil
+			}
+
+			if entry == nil || !strings.HasPrefix(entry.Key(), prefix) {
+				continue
+			}
+
+			deleted := entry.Operation() != nats.KeyValuePut
+
+			handler(entry.Key(), string(entry.Value()), deleted)
+		}
+	}
+}
+
+// WatchFrom calls handler with the changes of the keys starting with prefix made after revision, with their
+// revision, until ctx is done or handler returns an error. The changes are replayed from the history kept by the
+// bucket, so that a watch resumed from the revision of the last change handled misses none of those still kept.
+func (c *Client) WatchFrom(ctx context.Context, prefix string, revision uint64,
+	handler func(key, value string, revision uint64, deleted bool) error) error {
+	watcher, err := c.kv.WatchAll(nats.IncludeHistory(), nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to watch keys: %w", err)
+	}
+
+	defer func() { _ = watcher.Stop() }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entry, ok := <-watcher.Updates():
+			if !ok {
+				return nil
+			}
+
+			// a nil entry marks the end of the replayed history.
+			if entry == nil || entry.Revision() <= revision || !strings.HasPrefix(entry.Key(), prefix) {
+				continue
+			}
+
+			deleted := entry.Operation() != nats.KeyValuePut
+
+			if err := handler(entry.Key(), string(entry.Value()), entry.Revision(), deleted); err != nil {
+				return err
+			}
+		}
+	}
+}
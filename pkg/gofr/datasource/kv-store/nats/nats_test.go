@@ -15,6 +15,7 @@ import (
 var (
 	errFailedToSet      = errors.New("failed to set")
 	errConnectionFailed = errors.New("connection failed")
+	errWatchStopped     = errors.New("watch stopped")
 )
 
 func Test_ClientSet(t *testing.T) {
@@ -171,6 +172,7 @@ func Test_ClientGetError(t *testing.T) {
 	require.Error(t, err)
 	assert.Empty(t, val)
 	assert.Contains(t, err.Error(), "key not found")
+	assert.ErrorIs(t, err, containerKeyNotFound{})
 }
 
 func Test_ClientDelete(t *testing.T) {
@@ -333,3 +335,160 @@ func Test_ClientHealthCheckFailure(t *testing.T) {
 	assert.Equal(t, configs.Server, health.Details["url"])
 	assert.Equal(t, configs.Bucket, health.Details["bucket"])
 }
+
+func newTestClient(t *testing.T) (*Client, *MockKeyValue) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mockKV := NewMockKeyValue(ctrl)
+	mockLogger := NewMockLogger(ctrl)
+	mockMetrics := NewMockMetrics(ctrl)
+
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_nats_kv_stats", gomock.Any(), "bucket", "test_bucket",
+		"operation", gomock.Any()).AnyTimes()
+
+	return &Client{kv: mockKV, logger: mockLogger, metrics: mockMetrics, configs: &Configs{Bucket: "test_bucket"}}, mockKV
+}
+
+// keyChannel lists or watches keys by sending them on a channel.
+type keyChannel[T any] struct {
+	ch chan T
+}
+
+func newKeyChannel[T any](values ...T) *keyChannel[T] {
+	ch := make(chan T, len(values))
+
+	for _, v := range values {
+		ch <- v
+	}
+
+	return &keyChannel[T]{ch: ch}
+}
+
+func (k *keyChannel[T]) Keys() <-chan T         { return k.ch }
+func (k *keyChannel[T]) Updates() <-chan T      { return k.ch }
+func (*keyChannel[T]) Context() context.Context { return context.Background() }
+func (*keyChannel[T]) Stop() error              { return nil }
+
+func Test_ClientMGet(t *testing.T) {
+	cl, mockKV := newTestClient(t)
+
+	mockKV.EXPECT().Get("a").Return(&MockKeyValueEntry{value: []byte("1")}, nil)
+	mockKV.EXPECT().Get("missing").Return(nil, nats.ErrKeyNotFound)
+
+	values, err := cl.MGet(t.Context(), "a", "missing")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1"}, values)
+}
+
+func Test_ClientScan(t *testing.T) {
+	cl, mockKV := newTestClient(t)
+
+	lister := newKeyChannel("user:3", "order:1", "user:1", "user:2")
+	close(lister.ch)
+
+	mockKV.EXPECT().ListKeys(gomock.Any()).Return(lister, nil)
+	mockKV.EXPECT().Get("user:2").Return(&MockKeyValueEntry{value: []byte("b")}, nil)
+
+	values, next, err := cl.Scan(t.Context(), "user:", "user:1", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user:2": "b"}, values)
+	assert.Equal(t, "user:2", next)
+}
+
+func Test_ClientCompareAndSwap(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		revision uint64
+		mock     func(kv *MockKeyValue)
+		expected uint64
+		swapped  bool
+	}{
+		{desc: "create", revision: 0, expected: 1, swapped: true, mock: func(kv *MockKeyValue) {
+			kv.EXPECT().Create("key", []byte("value")).Return(uint64(1), nil)
+		}},
+		{desc: "update", revision: 4, expected: 5, swapped: true, mock: func(kv *MockKeyValue) {
+			kv.EXPECT().Update("key", []byte("value"), uint64(4)).Return(uint64(5), nil)
+		}},
+		{desc: "stale revision", revision: 4, expected: 4, mock: func(kv *MockKeyValue) {
+			kv.EXPECT().Update("key", []byte("value"), uint64(4)).Return(uint64(0), &nats.APIError{
+				ErrorCode: nats.JSErrCodeStreamWrongLastSequence,
+			})
+		}},
+	}
+
+	for i, tc := range testCases {
+		cl, mockKV := newTestClient(t)
+		tc.mock(mockKV)
+
+		revision, swapped, err := cl.CompareAndSwap(t.Context(), "key", "value", tc.revision)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expected, revision, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.swapped, swapped, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func Test_ClientWatch(t *testing.T) {
+	cl, mockKV := newTestClient(t)
+
+	watcher := newKeyChannel[nats.KeyValueEntry](
+		&MockKeyValueEntry{key: "user:1", value: []byte("a"), operation: nats.KeyValuePut},
+		&MockKeyValueEntry{key: "order:1", value: []byte("b"), operation: nats.KeyValuePut},
+		&MockKeyValueEntry{key: "user:1", operation: nats.KeyValueDelete},
+	)
+	close(watcher.ch)
+
+	mockKV.EXPECT().WatchAll(gomock.Any(), gomock.Any()).Return(watcher, nil)
+
+	var changes []string
+
+	err := cl.Watch(t.Context(), "user:", func(key, value string, deleted bool) {
+		changes = append(changes, fmt.Sprint(key, "=", value, " ", deleted))
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1=a false", "user:1= true"}, changes)
+}
+
+func Test_ClientWatchFrom(t *testing.T) {
+	cl, mockKV := newTestClient(t)
+
+	watcher := newKeyChannel[nats.KeyValueEntry](
+		&MockKeyValueEntry{key: "user:1", value: []byte("a"), revision: 3, operation: nats.KeyValuePut},
+		&MockKeyValueEntry{key: "user:2", value: []byte("b"), revision: 4, operation: nats.KeyValuePut},
+		&MockKeyValueEntry{key: "order:1", value: []byte("c"), revision: 5, operation: nats.KeyValuePut},
+		nil,
+		&MockKeyValueEntry{key: "user:1", revision: 6, operation: nats.KeyValueDelete},
+		&MockKeyValueEntry{key: "user:3", value: []byte("d"), revision: 7, operation: nats.KeyValuePut},
+	)
+	close(watcher.ch)
+
+	mockKV.EXPECT().WatchAll(gomock.Any(), gomock.Any()).Return(watcher, nil)
+
+	var changes []string
+
+	err := cl.WatchFrom(t.Context(), "user:", 3, func(key, value string, revision uint64, deleted bool) error {
+		changes = append(changes, fmt.Sprint(key, "=", value, " ", revision, " ", deleted))
+
+		if len(changes) == 2 {
+			return errWatchStopped
+		}
+
+		return nil
+	})
+
+	require.ErrorIs(t, err, errWatchStopped)
+	assert.Equal(t, []string{"user:2=b 4 false", "user:1= 6 true"}, changes)
+}
+
+// containerKeyNotFound stands for container.ErrKeyNotFound of gofr, which reports KeyNotFound.
+type containerKeyNotFound struct{}
+
+func (containerKeyNotFound) Error() string { return "key not found" }
+
+func (containerKeyNotFound) KeyNotFound() bool { return true }
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+// CreateIndex creates an index on the keys of the specified collection, such as bson.D{{Key: "email", Value: 1}},
+// and returns its name. opts makes the index unique or expires the documents after a duration:
+//
+//	name, err := ctx.Mongo.CreateIndex(ctx, "users", bson.D{{Key: "email", Value: 1}}, container.IndexOptions{Unique: true})
+//	name, err := ctx.Mongo.CreateIndex(ctx, "sessions", bson.D{{Key: "created_at", Value: 1}},
+//		container.IndexOptions{ExpireAfter: time.Hour})
+//
+// Creating an index identical to an existing one succeeds without changing it.
+func (c *Client) CreateIndex(ctx context.Context, collection string, keys any, opts container.IndexOptions) (string,
+	error) {
+	tracerCtx, span := c.addTrace(ctx, "createIndex", collection)
+
+	defer c.sendOperationStats(&QueryLog{Query: "createIndex", Collection: collection, Filter: keys}, time.Now(),
+		"createIndex", span)
+
+	return c.Database.Collection(collection).Indexes().CreateOne(tracerCtx,
+		mongo.IndexModel{Keys: keys, Options: indexOptions(opts)})
+}
+
+// indexOptions converts the options of CreateIndex to the options of the driver, leaving unset the zero ones.
+func indexOptions(opts container.IndexOptions) *options.IndexOptions {
+	indexOptions := options.Index()
+
+	if opts.Name != "" {
+		indexOptions.SetName(opts.Name)
+	}
+
+	if opts.Unique {
+		indexOptions.SetUnique(true)
+	}
+
+	if opts.ExpireAfter > 0 {
+		indexOptions.SetExpireAfterSeconds(int32(opts.ExpireAfter / time.Second))
+	}
+
+	return indexOptions
+}
+
+// ListIndexes returns the specifications of the indexes of the specified collection, with their name, key,
+// and their options such as unique and expireAfterSeconds.
+func (c *Client) ListIndexes(ctx context.Context, collection string) ([]map[string]any, error) {
+	tracerCtx, span := c.addTrace(ctx, "listIndexes", collection)
+
+	defer c.sendOperationStats(&QueryLog{Query: "listIndexes", Collection: collection}, time.Now(), "listIndexes", span)
+
+	cur, err := c.Database.Collection(collection).Indexes().List(tracerCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(ctx)
+
+	indexes := make([]map[string]any, 0)
+
+	if err := cur.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// DropIndex drops the index with the given name from the specified collection.
+func (c *Client) DropIndex(ctx context.Context, collection, name string) error {
+	tracerCtx, span := c.addTrace(ctx, "dropIndex", collection)
+
+	defer c.sendOperationStats(&QueryLog{Query: "dropIndex", Collection: collection, ID: name}, time.Now(), "dropIndex",
+		span)
+
+	_, err := c.Database.Collection(collection).Indexes().DropOne(tracerCtx, name)
+
+	return err
+}
@@ -0,0 +1,71 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+func Test_IndexCommands(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	ctrl := gomock.NewController(t)
+
+	metrics := NewMockMetrics(ctrl)
+	logger := NewMockLogger(ctrl)
+
+	cl := Client{metrics: metrics, logger: logger, tracer: otel.GetTracerProvider().Tracer("gofr-mongo")}
+
+	metrics.EXPECT().RecordHistogram(context.Background(), "app_mongo_stats", gomock.Any(), "hostname",
+		gomock.Any(), "database", gomock.Any(), "type", gomock.Any()).Times(3)
+	logger.EXPECT().Debug(gomock.Any()).Times(3)
+
+	mt.Run("createIndex", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		name, err := cl.CreateIndex(context.Background(), mt.Coll.Name(), bson.D{{Key: "created_at", Value: 1}},
+			container.IndexOptions{Unique: true, ExpireAfter: time.Hour})
+
+		require.NoError(t, err)
+		assert.Equal(t, "created_at_1", name)
+
+		index := mt.GetStartedEvent().Command.Lookup("indexes").Array().Index(0).Value().Document()
+		assert.Equal(t, int32(3600), index.Lookup("expireAfterSeconds").Int32())
+		assert.True(t, index.Lookup("unique").Boolean())
+	})
+
+	mt.Run("listIndexes", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch,
+			bson.D{{Key: "name", Value: "_id_"}, {Key: "key", Value: bson.D{{Key: "_id", Value: 1}}}},
+			bson.D{{Key: "name", Value: "email_1"}, {Key: "unique", Value: true}}))
+
+		indexes, err := cl.ListIndexes(context.Background(), mt.Coll.Name())
+
+		require.NoError(t, err)
+		require.Len(t, indexes, 2)
+		assert.Equal(t, "email_1", indexes[1]["name"])
+		assert.Equal(t, true, indexes[1]["unique"])
+	})
+
+	mt.Run("dropIndex", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := cl.DropIndex(context.Background(), mt.Coll.Name(), "email_1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "email_1", mt.GetStartedEvent().Command.Lookup("index").StringValue())
+	})
+
+}
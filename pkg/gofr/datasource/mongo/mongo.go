@@ -15,6 +15,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+
+	"gofr.dev/pkg/gofr/container"
 )
 
 type Client struct {
@@ -46,6 +48,7 @@ var (
 	errMissingField = errors.New("missing required field in config")
 	errIncorrectURI = errors.New("incorrect URI for MongoDB")
 	errParseHost    = errors.New("failed to parse host from MongoDB URI")
+	errWriteModel   = errors.New("unexpected type of write model")
 )
 
 /*
@@ -242,6 +245,125 @@ func (c *Client) FindOne(ctx context.Context, collection string, filter, result
 	return bson.Unmarshal(b, result)
 }
 
+// FindWithOptions retrieves documents from the specified collection based on the provided filter and binds response
+// to results. opts sets the sort, projection, limit and skip of the query:
+//
+//	opts := container.FindOptions{Sort: bson.D{{Key: "created_at", Value: -1}}, Limit: 20, Skip: 40}
+//	err := ctx.Mongo.FindWithOptions(ctx, "orders", bson.M{"status": "paid"}, &orders, opts)
+func (c *Client) FindWithOptions(ctx context.Context, collection string, filter, results any,
+	opts container.FindOptions) error {
+	tracerCtx, span := c.addTrace(ctx, "findWithOptions", collection)
+
+	defer c.sendOperationStats(&QueryLog{Query: "findWithOptions", Collection: collection, Filter: filter}, time.Now(),
+		"findWithOptions", span)
+
+	cur, err := c.Database.Collection(collection).Find(tracerCtx, filter, findOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+
+	return cur.All(ctx, results)
+}
+
+// Aggregate runs the aggregation pipeline, such as a bson.A or a mongo.Pipeline of stages, on the specified collection
+// and binds the resulting documents to results.
+func (c *Client) Aggregate(ctx context.Context, collection string, pipeline, results any) error {
+	tracerCtx, span := c.addTrace(ctx, "aggregate", collection)
+
+	defer c.sendOperationStats(&QueryLog{Query: "aggregate", Collection: collection, Filter: pipeline}, time.Now(),
+		"aggregate", span)
+
+	cur, err := c.Database.Collection(collection).Aggregate(tracerCtx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+
+	return cur.All(ctx, results)
+}
+
+// BulkWrite runs the write models, such as container.InsertOneModel, container.UpdateOneModel or
+// container.DeleteManyModel, in a single request on the specified collection. Ordered writes stop at the first failing
+// model, while unordered writes run all of them. It returns the numbers of documents written.
+func (c *Client) BulkWrite(ctx context.Context, collection string, models []container.WriteModel,
+	ordered bool) (*container.BulkWriteResult, error) {
+	writeModels := make([]mongo.WriteModel, 0, len(models))
+
+	for _, m := range models {
+		model, err := writeModel(m)
+		if err != nil {
+			return nil, err
+		}
+
+		writeModels = append(writeModels, model)
+	}
+
+	tracerCtx, span := c.addTrace(ctx, "bulkWrite", collection)
+
+	defer c.sendOperationStats(&QueryLog{Query: "bulkWrite", Collection: collection}, time.Now(), "bulkWrite", span)
+
+	res, err := c.Database.Collection(collection).BulkWrite(tracerCtx, writeModels,
+		options.BulkWrite().SetOrdered(ordered))
+	if res == nil {
+		return nil, err
+	}
+
+	return &container.BulkWriteResult{
+		InsertedCount: res.InsertedCount,
+		MatchedCount:  res.MatchedCount,
+		ModifiedCount: res.ModifiedCount,
+		DeletedCount:  res.DeletedCount,
+		UpsertedCount: res.UpsertedCount,
+		UpsertedIDs:   res.UpsertedIDs,
+	}, err
+}
+
+// findOptions converts the options of FindWithOptions to the options of the driver, leaving unset the zero ones.
+func findOptions(opts container.FindOptions) *options.FindOptions {
+	findOptions := options.Find()
+
+	if opts.Sort != nil {
+		findOptions.SetSort(opts.Sort)
+	}
+
+	if opts.Projection != nil {
+		findOptions.SetProjection(opts.Projection)
+	}
+
+	if opts.Limit > 0 {
+		findOptions.SetLimit(opts.Limit)
+	}
+
+	if opts.Skip > 0 {
+		findOptions.SetSkip(opts.Skip)
+	}
+
+	return findOptions
+}
+
+// writeModel converts a write model of BulkWrite to the write model of the driver.
+func writeModel(m container.WriteModel) (mongo.WriteModel, error) {
+	switch m := m.(type) {
+	case container.InsertOneModel:
+		return mongo.NewInsertOneModel().SetDocument(m.Document), nil
+	case container.UpdateOneModel:
+		return mongo.NewUpdateOneModel().SetFilter(m.Filter).SetUpdate(m.Update).SetUpsert(m.Upsert), nil
+	case container.UpdateManyModel:
+		return mongo.NewUpdateManyModel().SetFilter(m.Filter).SetUpdate(m.Update).SetUpsert(m.Upsert), nil
+	case container.ReplaceOneModel:
+		return mongo.NewReplaceOneModel().SetFilter(m.Filter).SetReplacement(m.Replacement).SetUpsert(m.Upsert), nil
+	case container.DeleteOneModel:
+		return mongo.NewDeleteOneModel().SetFilter(m.Filter), nil
+	case container.DeleteManyModel:
+		return mongo.NewDeleteManyModel().SetFilter(m.Filter), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errWriteModel, m)
+	}
+}
+
 // UpdateByID updates a document in the specified collection by its ID.
 func (c *Client) UpdateByID(ctx context.Context, collection string, id, update any) (int64, error) {
 	tracerCtx, span := c.addTrace(ctx, "updateByID", collection)
@@ -266,6 +388,23 @@ func (c *Client) UpdateOne(ctx context.Context, collection string, filter, updat
 	return err
 }
 
+// UpdateOneMatched updates a single document in the specified collection based on the provided filter, and returns
+// the number of documents matched by the filter, 0 when no document was updated.
+func (c *Client) UpdateOneMatched(ctx context.Context, collection string, filter, update any) (int64, error) {
+	tracerCtx, span := c.addTrace(ctx, "updateOne", collection)
+
+	res, err := c.Database.Collection(collection).UpdateOne(tracerCtx, filter, update)
+
+	defer c.sendOperationStats(&QueryLog{Query: "updateOne", Collection: collection, Filter: filter, Update: update},
+		time.Now(), "updateOne", span)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return res.MatchedCount, nil
+}
+
 // UpdateMany updates multiple documents in the specified collection based on the provided filter.
 func (c *Client) UpdateMany(ctx context.Context, collection string, filter, update any) (int64, error) {
 	tracerCtx, span := c.addTrace(ctx, "updateMany", collection)
@@ -395,6 +534,40 @@ func (c *Client) StartSession() (any, error) {
 	return ses, err
 }
 
+// WithTransaction runs fn in a transaction, committed when fn returns nil and aborted when it returns an error.
+// The operations run by fn join the transaction when they are passed the context given to fn:
+//
+//	err := ctx.Mongo.WithTransaction(ctx, func(tx context.Context) error {
+//		if _, err := ctx.Mongo.InsertOne(tx, "orders", order); err != nil {
+//			return err
+//		}
+//
+//		_, err := ctx.Mongo.UpdateByID(tx, "stock", order.ItemID, bson.M{"$inc": bson.M{"count": -1}})
+//
+//		return err
+//	})
+//
+// Transactions failing on a transient error, such as a write conflict, are retried by the driver, so fn must not
+// have effects outside of the transaction.
+func (c *Client) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tracerCtx, span := c.addTrace(ctx, "withTransaction", "")
+
+	defer c.sendOperationStats(&QueryLog{Query: "withTransaction"}, time.Now(), "withTransaction", span)
+
+	s, err := c.Client().StartSession()
+	if err != nil {
+		return err
+	}
+
+	defer s.EndSession(tracerCtx)
+
+	_, err = s.WithTransaction(tracerCtx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+
+	return err
+}
+
 type session struct {
 	mongo.Session
 }
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -14,8 +15,12 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
 )
 
+var errTest = errors.New("mocked error")
+
 func Test_NewMongoClient(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -461,9 +466,9 @@ func Test_UpdateCommands(t *testing.T) {
 	cl := Client{metrics: metrics, tracer: otel.GetTracerProvider().Tracer("gofr-mongo")}
 
 	metrics.EXPECT().RecordHistogram(context.Background(), "app_mongo_stats", gomock.Any(), "hostname",
-		gomock.Any(), "database", gomock.Any(), "type", gomock.Any()).Times(3)
+		gomock.Any(), "database", gomock.Any(), "type", gomock.Any()).Times(4)
 
-	logger.EXPECT().Debug(gomock.Any()).Times(3)
+	logger.EXPECT().Debug(gomock.Any()).Times(4)
 
 	cl.logger = logger
 
@@ -488,6 +493,17 @@ func Test_UpdateCommands(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	mt.Run("updateOneMatched", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 0}})
+
+		matched, err := cl.UpdateOneMatched(context.Background(), mt.Coll.Name(), bson.D{{Key: "name", Value: "test"}},
+			bson.M{"$set": bson.M{"name": "test"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), matched, "the documents left unchanged are matched")
+	})
+
 	mt.Run("updateMany", func(mt *mtest.T) {
 		cl.Database = mt.DB
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
@@ -733,3 +749,120 @@ func Test_HealthCheck(t *testing.T) {
 		assert.Contains(t, fmt.Sprint(resp), "DOWN")
 	})
 }
+
+func Test_FindWithOptionsAndAggregate(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	ctrl := gomock.NewController(t)
+
+	metrics := NewMockMetrics(ctrl)
+	logger := NewMockLogger(ctrl)
+
+	cl := Client{metrics: metrics, logger: logger, tracer: otel.GetTracerProvider().Tracer("gofr-mongo")}
+
+	metrics.EXPECT().RecordHistogram(context.Background(), "app_mongo_stats", gomock.Any(), "hostname",
+		gomock.Any(), "database", gomock.Any(), "type", gomock.Any()).Times(3)
+	logger.EXPECT().Debug(gomock.Any()).Times(3)
+
+	mt.Run("findWithOptions", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch,
+			bson.D{{Key: "name", Value: "john"}}, bson.D{{Key: "name", Value: "jane"}}))
+
+		var users []struct {
+			Name string `bson:"name"`
+		}
+
+		err := cl.FindWithOptions(context.Background(), mt.Coll.Name(), bson.D{},
+			&users, container.FindOptions{Sort: bson.D{{Key: "name", Value: 1}}, Limit: 2, Skip: 1})
+
+		require.NoError(t, err)
+		assert.Len(t, users, 2)
+
+		cmd := mt.GetStartedEvent().Command
+		assert.Equal(t, int64(2), cmd.Lookup("limit").Int64())
+		assert.Equal(t, int64(1), cmd.Lookup("skip").Int64())
+	})
+
+	mt.Run("aggregate", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "foo.bar", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: "paid"}, {Key: "total", Value: 42}}))
+
+		var totals []bson.M
+
+		err := cl.Aggregate(context.Background(), mt.Coll.Name(), mongo.Pipeline{
+			{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$status"}, {Key: "total", Value: bson.M{"$sum": 1}}}}},
+		}, &totals)
+
+		require.NoError(t, err)
+		assert.Equal(t, []bson.M{{"_id": "paid", "total": int32(42)}}, totals)
+	})
+
+	mt.Run("bulkWrite", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
+
+		res, err := cl.BulkWrite(context.Background(), mt.Coll.Name(), []container.WriteModel{
+			container.InsertOneModel{Document: bson.M{"name": "john"}},
+			container.UpdateOneModel{Filter: bson.M{"name": "jane"}, Update: bson.M{"$set": bson.M{"age": 30}}},
+		}, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.InsertedCount)
+		assert.Equal(t, int64(1), res.ModifiedCount)
+	})
+
+	mt.Run("invalid write model", func(mt *mtest.T) {
+		cl.Database = mt.DB
+
+		_, err := cl.BulkWrite(context.Background(), mt.Coll.Name(), []container.WriteModel{nil}, false)
+		require.ErrorIs(t, err, errWriteModel)
+	})
+}
+
+func TestClient_WithTransaction(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	ctrl := gomock.NewController(t)
+
+	metrics := NewMockMetrics(ctrl)
+	logger := NewMockLogger(ctrl)
+
+	cl := Client{metrics: metrics, logger: logger, tracer: otel.GetTracerProvider().Tracer("gofr-mongo")}
+
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_mongo_stats", gomock.Any(), "hostname",
+		gomock.Any(), "database", gomock.Any(), "type", gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+	mt.Run("committed", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := cl.WithTransaction(context.Background(), func(tx context.Context) error {
+			_, err := cl.InsertOne(tx, mt.Coll.Name(), bson.M{"name": "john"})
+
+			return err
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "commitTransaction", mt.GetAllStartedEvents()[1].CommandName)
+	})
+
+	mt.Run("aborted", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := cl.WithTransaction(context.Background(), func(tx context.Context) error {
+			if _, err := cl.InsertOne(tx, mt.Coll.Name(), bson.M{"name": "john"}); err != nil {
+				return err
+			}
+
+			return errTest
+		})
+
+		require.ErrorIs(t, err, errTest)
+		assert.Equal(t, "abortTransaction", mt.GetAllStartedEvents()[1].CommandName)
+	})
+}
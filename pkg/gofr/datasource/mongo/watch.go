@@ -0,0 +1,68 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Watch opens a change stream on the specified collection and calls handler with each change event, encoded as
+// relaxed extended JSON, and the resume token following it. The stream resumes after resumeToken, as returned
+// with a previous event, or starts from the current time when it is empty.
+//
+// The events of updates hold the updated document as fullDocument. Watch returns when ctx is done, or with the
+// error of the stream or of handler.
+func (c *Client) Watch(ctx context.Context, collection, resumeToken string,
+	handler func(event []byte, resumeToken string) error) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+
+	if resumeToken != "" {
+		var token bson.Raw
+
+		if err := bson.UnmarshalExtJSON([]byte(resumeToken), false, &token); err != nil {
+			return fmt.Errorf("invalid resume token: %w", err)
+		}
+
+		opts.SetResumeAfter(token)
+	}
+
+	tracerCtx, span := c.addTrace(ctx, "watch", collection)
+	start := time.Now()
+
+	// the stats are of opening the stream, which then remains open until ctx is done.
+	stream, err := c.Database.Collection(collection).Watch(tracerCtx, mongo.Pipeline{}, opts)
+
+	c.sendOperationStats(&QueryLog{Query: "watch", Collection: collection}, start, "watch", span)
+
+	if err != nil {
+		return err
+	}
+
+	defer stream.Close(context.WithoutCancel(ctx))
+
+	for stream.Next(ctx) {
+		event, err := bson.MarshalExtJSON(stream.Current, false, false)
+		if err != nil {
+			return err
+		}
+
+		token, err := bson.MarshalExtJSON(stream.ResumeToken(), false, false)
+		if err != nil {
+			return err
+		}
+
+		if err := handler(event, string(token)); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return stream.Err()
+}
@@ -0,0 +1,90 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/mock/gomock"
+)
+
+var errStopWatch = errors.New("stop watching")
+
+func Test_Watch(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	ctrl := gomock.NewController(t)
+
+	metrics := NewMockMetrics(ctrl)
+	logger := NewMockLogger(ctrl)
+
+	cl := Client{metrics: metrics, logger: logger, tracer: otel.GetTracerProvider().Tracer("gofr-mongo")}
+
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_mongo_stats", gomock.Any(), "hostname",
+		gomock.Any(), "database", gomock.Any(), "type", gomock.Any()).Times(2)
+	logger.EXPECT().Debug(gomock.Any()).Times(2)
+
+	mt.Run("changes", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "foo.bar", mtest.FirstBatch,
+			bson.D{
+				{Key: "_id", Value: bson.D{{Key: "_data", Value: "8263"}}},
+				{Key: "operationType", Value: "insert"},
+				{Key: "fullDocument", Value: bson.D{{Key: "name", Value: "john"}}},
+			},
+			bson.D{
+				{Key: "_id", Value: bson.D{{Key: "_data", Value: "8264"}}},
+				{Key: "operationType", Value: "delete"},
+			}))
+
+		var events, tokens []string
+
+		err := cl.Watch(context.Background(), mt.Coll.Name(), "", func(event []byte, resumeToken string) error {
+			events = append(events, string(event))
+			tokens = append(tokens, resumeToken)
+
+			if len(events) == 2 {
+				return errStopWatch
+			}
+
+			return nil
+		})
+
+		require.ErrorIs(t, err, errStopWatch)
+		assert.Equal(t, []string{`{"_data":"8263"}`, `{"_data":"8264"}`}, tokens)
+		assert.JSONEq(t, `{"_id":{"_data":"8263"},"operationType":"insert","fullDocument":{"name":"john"}}`, events[0])
+
+		cmd := mt.GetStartedEvent().Command
+		stage := cmd.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$changeStream").Document()
+		assert.Equal(t, "updateLookup", stage.Lookup("fullDocument").StringValue())
+	})
+
+	mt.Run("resume", func(mt *mtest.T) {
+		cl.Database = mt.DB
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "foo.bar", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: bson.D{{Key: "_data", Value: "8265"}}}, {Key: "operationType", Value: "insert"}}))
+
+		err := cl.Watch(context.Background(), mt.Coll.Name(), `{"_data":"8264"}`, func([]byte, string) error {
+			return errStopWatch
+		})
+
+		require.ErrorIs(t, err, errStopWatch)
+
+		cmd := mt.GetStartedEvent().Command
+		stage := cmd.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$changeStream").Document()
+		assert.Equal(t, "8264", stage.Lookup("resumeAfter", "_data").StringValue())
+	})
+
+	mt.Run("invalid resume token", func(mt *mtest.T) {
+		cl.Database = mt.DB
+
+		err := cl.Watch(context.Background(), mt.Coll.Name(), "8264", func([]byte, string) error { return nil })
+
+		require.ErrorContains(t, err, "invalid resume token")
+	})
+}
@@ -3,6 +3,8 @@ package pubsub
 import (
 	"fmt"
 	"io"
+
+	"gofr.dev/pkg/gofr/logging"
 )
 
 type Log struct {
@@ -15,6 +17,14 @@ type Log struct {
 	Time          int64  `json:"time"`
 }
 
+// Redact returns a copy of the log with the sensitive values of the message redacted.
+func (l *Log) Redact(r *logging.Redactor) any {
+	redacted := *l
+	redacted.MessageValue = r.JSON(l.MessageValue)
+
+	return &redacted
+}
+
 func (l *Log) PrettyPrint(writer io.Writer) {
 	fmt.Fprintf(writer, "\u001B[38;5;8m%-32s \u001B[38;5;24m%-6s\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m %-4s %s \u001b[38;5;101m%s\u001b[0m\n",
 		l.CorrelationID, l.PubSubBackend, l.Time, l.Mode, l.Topic, l.MessageValue)
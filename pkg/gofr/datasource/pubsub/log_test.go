@@ -0,0 +1,20 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func TestLog_Redact(t *testing.T) {
+	l := &Log{Mode: "PUB", Topic: "users", MessageValue: `{"email":"bob@example.com","password":"p@ss"}`}
+
+	redacted, ok := l.Redact(logging.DefaultRedactor()).(*Log)
+	require.True(t, ok)
+
+	assert.JSONEq(t, `{"email":"bob@example.com","password":"[REDACTED]"}`, redacted.MessageValue)
+	assert.Equal(t, "users", redacted.Topic)
+}
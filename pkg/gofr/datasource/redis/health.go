@@ -5,8 +5,12 @@ package redis
 import (
 	"context"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+
 	"gofr.dev/pkg/gofr/datasource"
 )
 
@@ -15,11 +19,23 @@ func (r *Redis) HealthCheck() datasource.Health {
 		Details: make(map[string]any),
 	}
 
-	h.Details["host"] = r.config.HostName + ":" + strconv.Itoa(r.config.Port)
+	switch {
+	case len(r.config.ClusterAddrs) > 0:
+		h.Details["cluster"] = strings.Join(r.config.ClusterAddrs, ",")
+	case r.config.SentinelMasterName != "":
+		h.Details["master"] = r.config.SentinelMasterName
+		h.Details["sentinels"] = strings.Join(r.config.SentinelAddrs, ",")
+	default:
+		h.Details["host"] = r.config.HostName + ":" + strconv.Itoa(r.config.Port)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
+	if r.cluster != nil {
+		return clusterHealth(ctx, r.cluster, h)
+	}
+
 	if r.Client == nil {
 		h.Status = datasource.StatusDown
 		h.Details["error"] = "redis not connected"
@@ -40,3 +56,37 @@ func (r *Redis) HealthCheck() datasource.Health {
 
 	return h
 }
+
+// clusterHealth reports the stats of every master of a cluster, which is down when any of them is.
+func clusterHealth(ctx context.Context, cc *redis.ClusterClient, h datasource.Health) datasource.Health {
+	var mu sync.Mutex
+
+	nodes := make(map[string]any)
+
+	err := cc.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		info, err := node.InfoMap(ctx, "Stats").Result()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			nodes[node.Options().Addr] = map[string]any{"status": datasource.StatusDown, "error": err.Error()}
+
+			return err
+		}
+
+		nodes[node.Options().Addr] = map[string]any{"status": datasource.StatusUp, "stats": info["Stats"]}
+
+		return nil
+	})
+
+	h.Status = datasource.StatusUp
+	h.Details["nodes"] = nodes
+
+	if err != nil {
+		h.Status = datasource.StatusDown
+		h.Details["error"] = err.Error()
+	}
+
+	return h
+}
@@ -11,13 +11,15 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/logging"
 )
 
 // redisHook is a custom Redis hook for logging queries and their durations.
 type redisHook struct {
-	config  *Config
-	logger  datasource.Logger
-	metrics Metrics
+	config   *Config
+	hostname string // node the hook is added to, the configured host when empty
+	logger   datasource.Logger
+	metrics  Metrics
 }
 
 // QueryLog represents a logged Redis query.
@@ -64,17 +66,22 @@ func (ql *QueryLog) String() string {
 }
 
 // logQuery logs the Redis query information.
-func (r *redisHook) sendOperationStats(start time.Time, query string, args ...any) {
+func (r *redisHook) sendOperationStats(ctx context.Context, start time.Time, query string, args ...any) {
 	duration := time.Since(start).Microseconds()
 
-	r.logger.Debug(&QueryLog{
+	logging.LoggerWithFields(ctx, r.logger).Debug(&QueryLog{
 		Query:    query,
 		Duration: duration,
 		Args:     args,
 	})
 
+	hostname := r.hostname
+	if hostname == "" {
+		hostname = r.config.HostName
+	}
+
 	r.metrics.RecordHistogram(context.Background(), "app_redis_stats",
-		float64(duration), "hostname", r.config.HostName, "type", query)
+		float64(duration), "hostname", hostname, "type", query)
 }
 
 // DialHook implements the redis.DialHook interface.
@@ -87,7 +94,7 @@ func (r *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
 	return func(ctx context.Context, cmd redis.Cmder) error {
 		start := time.Now()
 		err := next(ctx, cmd)
-		r.sendOperationStats(start, cmd.Name(), cmd.Args()...)
+		r.sendOperationStats(ctx, start, cmd.Name(), cmd.Args()...)
 
 		return err
 	}
@@ -98,7 +105,7 @@ func (r *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.Pr
 	return func(ctx context.Context, cmds []redis.Cmder) error {
 		start := time.Now()
 		err := next(ctx, cmds)
-		r.sendOperationStats(start, "pipeline", cmds[:len(cmds)-1])
+		r.sendOperationStats(ctx, start, "pipeline", cmds[:len(cmds)-1])
 
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	otel "github.com/redis/go-redis/extra/redisotel/v9"
@@ -29,29 +30,46 @@ type Config struct {
 	DB       int
 	Options  *redis.Options
 	TLS      *tls.Config
+
+	// SentinelMasterName is the name of the master monitored by the sentinels at SentinelAddrs.
+	SentinelMasterName string
+	SentinelAddrs      []string
+	FailoverOptions    *redis.FailoverOptions
+
+	// ClusterAddrs are the seed nodes of a cluster, the other nodes being discovered from them.
+	ClusterAddrs   []string
+	ClusterOptions *redis.ClusterOptions
 }
 
 type Redis struct {
+	// Client is the client of a single node or of a master managed by Sentinel. It is nil when connected to a cluster,
+	// [Redis.Universal] returning the client of any of them.
 	*redis.Client
-	logger datasource.Logger
-	config *Config
+	cluster *redis.ClusterClient
+	logger  datasource.Logger
+	config  *Config
 }
 
 // NewClient returns a [Redis] client if connection is successful based on [Config].
 // Supports both plain and TLS connections. TLS is configured via REDIS_TLS_ENABLED and related environment variables.
 // In case of error, it returns an error as second parameter.
+//
+// The client connects to a single node at REDIS_HOST, to the master named REDIS_SENTINEL_MASTER through the sentinels
+// at REDIS_SENTINEL_ADDRS, or to the cluster whose seed nodes are REDIS_CLUSTER_ADDRS.
 func NewClient(c config.Config, logger datasource.Logger, metrics Metrics) *Redis {
 	redisConfig := getRedisConfig(c, logger)
 
-	// if Hostname is not provided, we won't try to connect to Redis
-	if redisConfig.HostName == "" {
+	// if no address is provided, we won't try to connect to Redis
+	if redisConfig.HostName == "" && redisConfig.SentinelMasterName == "" && len(redisConfig.ClusterAddrs) == 0 {
 		return nil
 	}
 
-	logger.Debugf("connecting to redis at '%s:%d' on database %d", redisConfig.HostName, redisConfig.Port, redisConfig.DB)
+	logger.Debugf("connecting to redis at %s", redisConfig.address())
 
-	rc := redis.NewClient(redisConfig.Options)
-	rc.AddHook(&redisHook{config: redisConfig, logger: logger, metrics: metrics})
+	r := &Redis{config: redisConfig, logger: logger}
+	r.connect(metrics)
+
+	rc := r.Universal()
 
 	ctx, cancel := context.WithTimeout(context.TODO(), redisPingTimeout)
 	defer cancel()
@@ -61,23 +79,67 @@ func NewClient(c config.Config, logger datasource.Logger, metrics Metrics) *Redi
 			logger.Errorf("could not add tracing instrumentation, error: %s", err)
 		}
 
-		logger.Infof("connected to redis at %s:%d on database %d", redisConfig.HostName, redisConfig.Port, redisConfig.DB)
+		logger.Infof("connected to redis at %s", redisConfig.address())
 	} else {
-		logger.Errorf("could not connect to redis at '%s:%d' , error: %s", redisConfig.HostName, redisConfig.Port, err)
+		logger.Errorf("could not connect to redis at %s, error: %s", redisConfig.address(), err)
 	}
 
-	return &Redis{Client: rc, config: redisConfig, logger: logger}
+	return r
+}
+
+// connect creates the client of the topology configured. The commands sent to a cluster are logged and measured
+// per node, labelled with the address of the node.
+func (r *Redis) connect(metrics Metrics) {
+	switch {
+	case len(r.config.ClusterAddrs) > 0:
+		r.cluster = redis.NewClusterClient(r.config.ClusterOptions)
+		r.cluster.OnNewNode(func(node *redis.Client) {
+			node.AddHook(&redisHook{config: r.config, hostname: node.Options().Addr, logger: r.logger, metrics: metrics})
+		})
+	case r.config.SentinelMasterName != "":
+		r.Client = redis.NewFailoverClient(r.config.FailoverOptions)
+		r.Client.AddHook(&redisHook{config: r.config, hostname: r.config.SentinelMasterName, logger: r.logger, metrics: metrics})
+	default:
+		r.Client = redis.NewClient(r.config.Options)
+		r.Client.AddHook(&redisHook{config: r.config, logger: r.logger, metrics: metrics})
+	}
+}
+
+// Universal returns the client of the configured topology: the cluster client for a cluster, Client otherwise.
+// It returns nil when Redis is not connected.
+func (r *Redis) Universal() redis.UniversalClient {
+	switch {
+	case r.cluster != nil:
+		return r.cluster
+	case r.Client != nil:
+		return r.Client
+	default:
+		return nil
+	}
 }
 
 // Close shuts down the Redis client, ensuring the current dataset is saved before exiting.
 func (r *Redis) Close() error {
-	if r.Client != nil {
-		return r.Client.Close()
+	if rc := r.Universal(); rc != nil {
+		return rc.Close()
 	}
 
 	return nil
 }
 
+// address describes where the client connects to, for the logs.
+func (c *Config) address() string {
+	switch {
+	case len(c.ClusterAddrs) > 0:
+		return "cluster " + strings.Join(c.ClusterAddrs, ",")
+	case c.SentinelMasterName != "":
+		return fmt.Sprintf("master %s through sentinels %s on database %d", c.SentinelMasterName,
+			strings.Join(c.SentinelAddrs, ","), c.DB)
+	default:
+		return fmt.Sprintf("%s:%d on database %d", c.HostName, c.Port, c.DB)
+	}
+}
+
 // getRedisConfig builds the Redis Config struct from the provided [Config].
 // It supports TLS configuration using the following environment variables:
 //
@@ -108,17 +170,94 @@ func getRedisConfig(c config.Config, logger datasource.Logger) *Config {
 
 	redisConfig.DB = db
 
+	if c.Get("REDIS_TLS_ENABLED") == "true" {
+		redisConfig.TLS = getTLSConfig(c, logger)
+	}
+
 	options := new(redis.Options)
 	options.Addr = fmt.Sprintf("%s:%d", redisConfig.HostName, redisConfig.Port)
 	options.Username = redisConfig.Username
 	options.Password = redisConfig.Password
 	options.DB = redisConfig.DB
+	options.TLSConfig = redisConfig.TLS
+
+	redisConfig.Options = options
+
+	setSentinelConfig(c, redisConfig)
+	setClusterConfig(c, logger, redisConfig)
+
+	return redisConfig
+}
 
-	if c.Get("REDIS_TLS_ENABLED") != "true" {
-		redisConfig.Options = options
-		return redisConfig
+// setSentinelConfig configures the master named REDIS_SENTINEL_MASTER, monitored by the sentinels at the
+// comma-separated REDIS_SENTINEL_ADDRS and authenticated with REDIS_SENTINEL_PASSWORD.
+func setSentinelConfig(c config.Config, redisConfig *Config) {
+	redisConfig.SentinelMasterName = c.Get("REDIS_SENTINEL_MASTER")
+	if redisConfig.SentinelMasterName == "" {
+		return
 	}
 
+	redisConfig.SentinelAddrs = splitAddrs(c.Get("REDIS_SENTINEL_ADDRS"))
+	redisConfig.FailoverOptions = &redis.FailoverOptions{
+		MasterName:       redisConfig.SentinelMasterName,
+		SentinelAddrs:    redisConfig.SentinelAddrs,
+		SentinelPassword: c.Get("REDIS_SENTINEL_PASSWORD"),
+		Username:         redisConfig.Username,
+		Password:         redisConfig.Password,
+		DB:               redisConfig.DB,
+		TLSConfig:        redisConfig.TLS,
+	}
+}
+
+// setClusterConfig configures the cluster whose seed nodes are the comma-separated REDIS_CLUSTER_ADDRS.
+// REDIS_CLUSTER_READ_FROM sends the read-only commands to the replicas ("replica"), to the closest node ("latency")
+// or to a random node ("random"), instead of the masters.
+func setClusterConfig(c config.Config, logger datasource.Logger, redisConfig *Config) {
+	redisConfig.ClusterAddrs = splitAddrs(c.Get("REDIS_CLUSTER_ADDRS"))
+	if len(redisConfig.ClusterAddrs) == 0 {
+		return
+	}
+
+	if redisConfig.DB != 0 {
+		logger.Warnf("REDIS_DB is ignored, as a redis cluster only supports database 0")
+	}
+
+	options := &redis.ClusterOptions{
+		Addrs:     redisConfig.ClusterAddrs,
+		Username:  redisConfig.Username,
+		Password:  redisConfig.Password,
+		TLSConfig: redisConfig.TLS,
+	}
+
+	switch readFrom := c.Get("REDIS_CLUSTER_READ_FROM"); readFrom {
+	case "", "master":
+	case "replica":
+		options.ReadOnly = true
+	case "latency":
+		options.RouteByLatency = true
+	case "random":
+		options.RouteRandomly = true
+	default:
+		logger.Warnf("unsupported REDIS_CLUSTER_READ_FROM %q, reading from the masters", readFrom)
+	}
+
+	redisConfig.ClusterOptions = options
+}
+
+func splitAddrs(addrs string) []string {
+	var result []string
+
+	for _, addr := range strings.Split(addrs, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			result = append(result, addr)
+		}
+	}
+
+	return result
+}
+
+// getTLSConfig loads the certificates set by REDIS_TLS_CA_CERT, REDIS_TLS_CERT and REDIS_TLS_KEY.
+func getTLSConfig(c config.Config, logger datasource.Logger) *tls.Config {
 	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
 
 	if caCertPath := c.Get("REDIS_TLS_CA_CERT"); caCertPath != "" {
@@ -143,11 +282,7 @@ func getRedisConfig(c config.Config, logger datasource.Logger) *Config {
 		}
 	}
 
-	options.TLSConfig = tlsConfig
-	redisConfig.TLS = tlsConfig
-	redisConfig.Options = options
-
-	return redisConfig
+	return tlsConfig
 }
 
 func initializeCerts(logger datasource.Logger, caCert []byte, tlsConfig *tls.Config) {
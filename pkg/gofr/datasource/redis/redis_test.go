@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/datasource"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/testutil"
 )
@@ -201,3 +203,91 @@ func getMockKey() string {
 
 	return mockKey
 }
+
+func Test_SentinelConfig(t *testing.T) {
+	mockConfig := config.NewMockConfig(map[string]string{
+		"REDIS_SENTINEL_MASTER":   "mymaster",
+		"REDIS_SENTINEL_ADDRS":    "sentinel-1:26379, sentinel-2:26379",
+		"REDIS_SENTINEL_PASSWORD": "sentinel-secret",
+		"REDIS_PASSWORD":          "secret",
+		"REDIS_DB":                "2",
+	})
+
+	conf := getRedisConfig(mockConfig, logging.NewMockLogger(logging.ERROR))
+
+	require.NotNil(t, conf.FailoverOptions)
+	assert.Equal(t, "mymaster", conf.FailoverOptions.MasterName)
+	assert.Equal(t, []string{"sentinel-1:26379", "sentinel-2:26379"}, conf.FailoverOptions.SentinelAddrs)
+	assert.Equal(t, "sentinel-secret", conf.FailoverOptions.SentinelPassword)
+	assert.Equal(t, "secret", conf.FailoverOptions.Password)
+	assert.Equal(t, 2, conf.FailoverOptions.DB)
+	assert.Nil(t, conf.ClusterOptions)
+}
+
+func Test_ClusterConfig(t *testing.T) {
+	testCases := []struct {
+		readFrom string
+		expected redis.ClusterOptions
+	}{
+		{readFrom: "", expected: redis.ClusterOptions{}},
+		{readFrom: "replica", expected: redis.ClusterOptions{ReadOnly: true}},
+		{readFrom: "latency", expected: redis.ClusterOptions{RouteByLatency: true}},
+		{readFrom: "random", expected: redis.ClusterOptions{RouteRandomly: true}},
+		{readFrom: "unknown", expected: redis.ClusterOptions{}},
+	}
+
+	for i, tc := range testCases {
+		mockConfig := config.NewMockConfig(map[string]string{
+			"REDIS_CLUSTER_ADDRS":     "node-1:6379,node-2:6379",
+			"REDIS_CLUSTER_READ_FROM": tc.readFrom,
+			"REDIS_USER":              "user",
+		})
+
+		conf := getRedisConfig(mockConfig, logging.NewMockLogger(logging.ERROR))
+
+		tc.expected.Addrs = []string{"node-1:6379", "node-2:6379"}
+		tc.expected.Username = "user"
+
+		require.NotNil(t, conf.ClusterOptions, "TEST[%d], Failed.\n%s", i, tc.readFrom)
+		assert.Equal(t, tc.expected, *conf.ClusterOptions, "TEST[%d], Failed.\n%s", i, tc.readFrom)
+	}
+}
+
+func TestRedis_Cluster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// miniredis answers CLUSTER SLOTS as a cluster of a single node.
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	mockMetric := NewMockMetrics(ctrl)
+	mockMetric.EXPECT().RecordHistogram(gomock.Any(), "app_redis_stats", gomock.Any(),
+		"hostname", s.Addr(), "type", "set").Times(1)
+	mockMetric.EXPECT().RecordHistogram(gomock.Any(), "app_redis_stats", gomock.Any(),
+		"hostname", gomock.Any(), "type", gomock.Any()).AnyTimes()
+
+	client := NewClient(config.NewMockConfig(map[string]string{"REDIS_CLUSTER_ADDRS": s.Addr()}),
+		logging.NewMockLogger(logging.ERROR), mockMetric)
+	require.NotNil(t, client)
+
+	defer client.Close()
+
+	assert.Nil(t, client.Client, "the client of a single node is not set for a cluster")
+	require.IsType(t, &redis.ClusterClient{}, client.Universal())
+
+	require.NoError(t, client.Universal().Set(t.Context(), "key", "value", time.Minute).Err())
+
+	value, err := client.Universal().Get(t.Context(), "key").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	health := client.HealthCheck()
+
+	// miniredis does not support INFO sections, reporting the node as down.
+	assert.Equal(t, datasource.StatusDown, health.Status)
+	assert.Equal(t, s.Addr(), health.Details["cluster"])
+	assert.Contains(t, health.Details["nodes"], s.Addr())
+}
@@ -206,6 +206,48 @@ func (c *Client) DeleteField(ctx context.Context, collection string, document *b
 	return resp, err
 }
 
+// ListCollections retrieves the names of the collections of the SolrCloud cluster.
+func (c *Client) ListCollections(ctx context.Context) (any, error) {
+	url := c.url + "/admin/collections"
+	startTime := time.Now()
+
+	resp, span, err := c.call(ctx, http.MethodGet, url, map[string]any{"action": "LIST"}, nil)
+
+	c.sendOperationStats(ctx, &QueryLog{Type: "ListCollections", URL: url}, startTime, "list-collections", span)
+
+	return resp, err
+}
+
+// CreateCollection creates a collection in the SolrCloud cluster. params can be used to send parameters like
+// numShards, replicationFactor and collection.configName.
+func (c *Client) CreateCollection(ctx context.Context, collection string, params map[string]any) (any, error) {
+	url := c.url + "/admin/collections"
+	startTime := time.Now()
+
+	query := map[string]any{"action": "CREATE", "name": collection}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	resp, span, err := c.call(ctx, http.MethodGet, url, query, nil)
+
+	c.sendOperationStats(ctx, &QueryLog{Type: "CreateCollection", URL: url}, startTime, "create-collection", span)
+
+	return resp, err
+}
+
+// DeleteCollection deletes a collection of the SolrCloud cluster with all its documents.
+func (c *Client) DeleteCollection(ctx context.Context, collection string) (any, error) {
+	url := c.url + "/admin/collections"
+	startTime := time.Now()
+
+	resp, span, err := c.call(ctx, http.MethodGet, url, map[string]any{"action": "DELETE", "name": collection}, nil)
+
+	c.sendOperationStats(ctx, &QueryLog{Type: "DeleteCollection", URL: url}, startTime, "delete-collection", span)
+
+	return resp, err
+}
+
 // Response stores the response from Solr.
 type Response struct {
 	Code int
@@ -186,3 +186,46 @@ func Test_ClientDeleteField(t *testing.T) {
 	require.NoError(t, err, "TEST Failed.\n")
 	require.NotNil(t, resp, "TEST Failed.\n")
 }
+
+func Test_ClientCollections(t *testing.T) {
+	var queries []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+
+		_, _ = w.Write([]byte(`{"responseHeader": {"status": 0}, "collections": ["test"]}`))
+	}))
+	defer ts.Close()
+
+	addr := strings.Split(ts.Listener.Addr().String(), ":")
+
+	ctrl := gomock.NewController(t)
+	mockLogger := NewMockLogger(ctrl)
+	mockMetrics := NewMockMetrics(ctrl)
+
+	mockLogger.EXPECT().Debug(gomock.Any()).Times(3)
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_solr_stats", gomock.Any(), "type", gomock.Any()).Times(3)
+
+	s := New(Config{Host: addr[0], Port: addr[1]})
+	s.metrics = mockMetrics
+	s.logger = mockLogger
+
+	resp, err := s.ListCollections(context.Background())
+
+	require.NoError(t, err, "TEST Failed.\n")
+	require.Equal(t, Response{Code: http.StatusOK, Data: map[string]any{
+		"responseHeader": map[string]any{"status": float64(0)}, "collections": []any{"test"},
+	}}, resp, "TEST Failed.\n")
+
+	_, err = s.CreateCollection(context.Background(), "test", map[string]any{"numShards": 1})
+	require.NoError(t, err, "TEST Failed.\n")
+
+	_, err = s.DeleteCollection(context.Background(), "test")
+	require.NoError(t, err, "TEST Failed.\n")
+
+	require.Equal(t, []string{
+		"/solr/admin/collections?action=LIST",
+		"/solr/admin/collections?action=CREATE&name=test&numShards=1",
+		"/solr/admin/collections?action=DELETE&name=test",
+	}, queries, "TEST Failed.\n")
+}
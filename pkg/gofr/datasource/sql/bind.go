@@ -1,7 +1,17 @@
 package sql
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrMissingNamedArg is returned by BindNamed for a parameter of the query not found in its argument.
+	ErrMissingNamedArg = errors.New("missing named argument")
+
+	errNamedArgType = errors.New("named arguments must be a map[string]any or a struct")
 )
 
 const (
@@ -50,3 +60,135 @@ func quote(dialect string) string {
 func quotedString(q, s string) string {
 	return fmt.Sprintf("%s%s%s", q, s, q)
 }
+
+// BindNamed rewrites the :name parameters of query into the bindvars of the dialect, and returns the arguments
+// to run the rewritten query with. arg is a map[string]any, or a struct whose fields are named as the columns
+// read by Select are:
+//
+//	query, args, err := sql.BindNamed(ctx.SQL.Dialect(),
+//		"SELECT * FROM users WHERE name = :name AND created_at > :since", map[string]any{"name": name, "since": t})
+//
+// Parameters within quoted strings and identifiers, and the :: casts of postgres, are left as they are.
+// A parameter used several times takes the same bindvar with postgres, and is repeated in the arguments otherwise.
+func BindNamed(dialect, query string, arg any) (string, []any, error) {
+	lookup, err := namedArgs(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	n := namedBinder{dialect: dialect, lookup: lookup, positions: make(map[string]int)}
+
+	var quote byte
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case strings.HasPrefix(query[i:], "::"):
+			n.query.WriteByte(c)
+			i++
+		case c == ':':
+			name := paramName(query[i+1:])
+			if name == "" {
+				break
+			}
+
+			if err := n.bind(name); err != nil {
+				return "", nil, err
+			}
+
+			i += len(name)
+
+			continue
+		}
+
+		n.query.WriteByte(query[i])
+	}
+
+	return n.query.String(), n.args, nil
+}
+
+// namedBinder builds the query and the arguments of BindNamed.
+type namedBinder struct {
+	dialect   string
+	lookup    func(name string) (any, bool)
+	query     strings.Builder
+	args      []any
+	positions map[string]int
+}
+
+func (n *namedBinder) bind(name string) error {
+	value, ok := n.lookup(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingNamedArg, name)
+	}
+
+	position, seen := n.positions[name]
+	if !seen || bindType(n.dialect) != DOLLAR {
+		n.args = append(n.args, value)
+		position = len(n.args)
+		n.positions[name] = position
+	}
+
+	n.query.WriteString(bindVar(n.dialect, position))
+
+	return nil
+}
+
+// paramName returns the name of the parameter at the start of s, if any.
+func paramName(s string) string {
+	if s == "" || !isNameStart(s[0]) {
+		return ""
+	}
+
+	end := 1
+	for end < len(s) && isNamePart(s[end]) {
+		end++
+	}
+
+	return s[:end]
+}
+
+func namedArgs(arg any) (func(name string) (any, bool), error) {
+	if m, ok := arg.(map[string]any); ok {
+		return func(name string) (any, bool) {
+			value, ok := m[name]
+
+			return value, ok
+		}, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, errNamedArgType
+	}
+
+	indexes := fieldIndexes(v.Type())
+
+	return func(name string) (any, bool) {
+		index, ok := indexes[name]
+		if !ok {
+			return nil, false
+		}
+
+		return v.FieldByIndex(index).Interface(), true
+	}, nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
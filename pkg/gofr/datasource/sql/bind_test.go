@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_BindType(t *testing.T) {
@@ -126,3 +127,76 @@ func Test_QuotedString(t *testing.T) {
 		})
 	}
 }
+
+func Test_BindNamed(t *testing.T) {
+	type filter struct {
+		Name   string
+		MinAge int `db:"age"`
+	}
+
+	tests := []struct {
+		name          string
+		dialect       string
+		query         string
+		arg           any
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{
+			name:          "MySQL with a map",
+			dialect:       dialectMysql,
+			query:         "SELECT * FROM users WHERE name = :name AND age > :age",
+			arg:           map[string]any{"name": "gofr", "age": 18},
+			expectedQuery: "SELECT * FROM users WHERE name = ? AND age > ?",
+			expectedArgs:  []any{"gofr", 18},
+		},
+		{
+			name:          "Postgres with a struct",
+			dialect:       dialectPostgres,
+			query:         "SELECT * FROM users WHERE name = :name AND age > :age",
+			arg:           &filter{Name: "gofr", MinAge: 18},
+			expectedQuery: "SELECT * FROM users WHERE name = $1 AND age > $2",
+			expectedArgs:  []any{"gofr", 18},
+		},
+		{
+			name:          "Postgres with a repeated parameter",
+			dialect:       dialectPostgres,
+			query:         "SELECT * FROM users WHERE first_name = :name OR last_name = :name",
+			arg:           map[string]any{"name": "gofr"},
+			expectedQuery: "SELECT * FROM users WHERE first_name = $1 OR last_name = $1",
+			expectedArgs:  []any{"gofr"},
+		},
+		{
+			name:          "MySQL with a repeated parameter",
+			dialect:       dialectMysql,
+			query:         "SELECT * FROM users WHERE first_name = :name OR last_name = :name",
+			arg:           map[string]any{"name": "gofr"},
+			expectedQuery: "SELECT * FROM users WHERE first_name = ? OR last_name = ?",
+			expectedArgs:  []any{"gofr", "gofr"},
+		},
+		{
+			name:          "casts and quoted text left as they are",
+			dialect:       dialectPostgres,
+			query:         "SELECT id::text, ':skip' FROM users WHERE age > :age",
+			arg:           map[string]any{"age": 18},
+			expectedQuery: "SELECT id::text, ':skip' FROM users WHERE age > $1",
+			expectedArgs:  []any{18},
+		},
+	}
+
+	for i, tc := range tests {
+		query, args, err := BindNamed(tc.dialect, tc.query, tc.arg)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.name)
+		assert.Equal(t, tc.expectedQuery, query, "TEST[%d], Failed.\n%s", i, tc.name)
+		assert.Equal(t, tc.expectedArgs, args, "TEST[%d], Failed.\n%s", i, tc.name)
+	}
+}
+
+func Test_BindNamedErrors(t *testing.T) {
+	_, _, err := BindNamed(dialectMysql, "SELECT * FROM users WHERE name = :name", map[string]any{})
+	require.ErrorIs(t, err, ErrMissingNamedArg)
+
+	_, _, err = BindNamed(dialectMysql, "SELECT * FROM users WHERE name = :name", "gofr")
+	require.ErrorIs(t, err, errNamedArgType)
+}
@@ -6,14 +6,15 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
-	"reflect"
 	"regexp"
 	"strings"
 	"time"
 
 	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/logging"
 )
 
 // DB is a wrapper around sql.DB which provides some more features.
@@ -37,6 +38,16 @@ func (l *Log) PrettyPrint(writer io.Writer) {
 		l.Type, "SQL", l.Duration, clean(l.Query))
 }
 
+// Redact returns a copy of the log in which the arguments bound to sensitive columns, and literals
+// compared against them, are redacted.
+func (l *Log) Redact(r *logging.Redactor) any {
+	redacted := *l
+	redacted.Query = r.SQL(l.Query)
+	redacted.Args = r.SQLArgs(l.Query, l.Args)
+
+	return &redacted
+}
+
 func clean(query string) string {
 	query = regexp.MustCompile(`\s+`).ReplaceAllString(query, " ")
 	query = strings.TrimSpace(query)
@@ -44,18 +55,22 @@ func clean(query string) string {
 	return query
 }
 
-func (d *DB) sendOperationStats(start time.Time, queryType, query string, args ...any) {
-	duration := time.Since(start).Milliseconds()
+func (d *DB) sendOperationStats(ctx context.Context, start time.Time, queryType, query string, args ...any) {
+	elapsed := time.Since(start)
+	duration := elapsed.Milliseconds()
 
-	d.logger.Debug(&Log{
+	logger := logging.LoggerWithFields(ctx, d.logger)
+
+	logger.Debug(&Log{
 		Type:     queryType,
 		Query:    query,
 		Duration: duration,
 		Args:     args,
 	})
 
-	d.metrics.RecordHistogram(context.Background(), "app_sql_stats", float64(duration), "hostname", d.config.HostName,
-		"database", d.config.Database, "type", getOperationType(query))
+	logSlowQuery(ctx, logger, d.config, elapsed, queryType, query, args)
+
+	recordQueryStats(d.metrics, d.config, duration, query)
 }
 
 func getOperationType(query string) string {
@@ -66,13 +81,23 @@ func getOperationType(query string) string {
 }
 
 func (d *DB) Query(query string, args ...any) (*sql.Rows, error) {
-	defer d.sendOperationStats(time.Now(), "Query", query, args...)
-	return d.DB.QueryContext(context.Background(), query, args...)
+	defer d.sendOperationStats(context.Background(), time.Now(), "Query", query, args...)
+
+	ctx, cancel := statementContext(context.Background(), d.config)
+	rows, err := d.DB.QueryContext(ctx, query, args...)
+	cancelFailed(err, cancel)
+
+	return rows, err
 }
 
 func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
-	defer d.sendOperationStats(time.Now(), "QueryContext", query, args...)
-	return d.DB.QueryContext(ctx, query, args...)
+	defer d.sendOperationStats(ctx, time.Now(), "QueryContext", query, args...)
+
+	ctx, cancel := statementContext(ctx, d.config)
+	rows, err := d.DB.QueryContext(ctx, query, args...)
+	cancelFailed(err, cancel)
+
+	return rows, err
 }
 
 func (d *DB) Dialect() string {
@@ -80,37 +105,54 @@ func (d *DB) Dialect() string {
 }
 
 func (d *DB) QueryRow(query string, args ...any) *sql.Row {
-	defer d.sendOperationStats(time.Now(), "QueryRow", query, args...)
-	return d.DB.QueryRowContext(context.Background(), query, args...)
+	defer d.sendOperationStats(context.Background(), time.Now(), "QueryRow", query, args...)
+
+	ctx, cancel := statementContext(context.Background(), d.config)
+	row := d.DB.QueryRowContext(ctx, query, args...)
+	cancelFailed(row.Err(), cancel)
+
+	return row
 }
 
 func (d *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
-	defer d.sendOperationStats(time.Now(), "QueryRowContext", query, args...)
-	return d.DB.QueryRowContext(ctx, query, args...)
+	defer d.sendOperationStats(ctx, time.Now(), "QueryRowContext", query, args...)
+
+	ctx, cancel := statementContext(ctx, d.config)
+	row := d.DB.QueryRowContext(ctx, query, args...)
+	cancelFailed(row.Err(), cancel)
+
+	return row
 }
 
 func (d *DB) Exec(query string, args ...any) (sql.Result, error) {
-	defer d.sendOperationStats(time.Now(), "Exec", query, args...)
-	return d.DB.ExecContext(context.Background(), query, args...)
+	defer d.sendOperationStats(context.Background(), time.Now(), "Exec", query, args...)
+
+	ctx, cancel := statementContext(context.Background(), d.config)
+	defer cancel()
+
+	return d.DB.ExecContext(ctx, query, args...)
 }
 
 func (d *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
-	defer d.sendOperationStats(time.Now(), "ExecContext", query, args...)
+	defer d.sendOperationStats(ctx, time.Now(), "ExecContext", query, args...)
+
+	ctx, cancel := statementContext(ctx, d.config)
+	defer cancel()
+
 	return d.DB.ExecContext(ctx, query, args...)
 }
 
 func (d *DB) Prepare(query string) (*sql.Stmt, error) {
-	defer d.sendOperationStats(time.Now(), "Prepare", query)
-	return d.DB.PrepareContext(context.Background(), query)
+	defer d.sendOperationStats(context.Background(), time.Now(), "Prepare", query)
+
+	ctx, cancel := statementContext(context.Background(), d.config)
+	defer cancel()
+
+	return d.DB.PrepareContext(ctx, query)
 }
 
 func (d *DB) Begin() (*Tx, error) {
-	tx, err := d.DB.BeginTx(context.Background(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Tx{Tx: tx, config: d.config, logger: d.logger, metrics: d.metrics}, nil
+	return d.BeginTx(context.Background(), nil)
 }
 
 func (d *DB) Close() error {
@@ -128,57 +170,103 @@ type Tx struct {
 	metrics Metrics
 }
 
-func (t *Tx) sendOperationStats(start time.Time, queryType, query string, args ...any) {
-	duration := time.Since(start).Milliseconds()
+func (t *Tx) sendOperationStats(ctx context.Context, start time.Time, queryType, query string, args ...any) {
+	elapsed := time.Since(start)
+	duration := elapsed.Milliseconds()
+
+	logger := logging.LoggerWithFields(ctx, t.logger)
 
-	t.logger.Debug(&Log{
+	logger.Debug(&Log{
 		Type:     queryType,
 		Query:    query,
 		Duration: duration,
 		Args:     args,
 	})
 
-	t.metrics.RecordHistogram(context.Background(), "app_sql_stats", float64(duration), "hostname", t.config.HostName,
-		"database", t.config.Database, "type", getOperationType(query))
+	logSlowQuery(ctx, logger, t.config, elapsed, queryType, query, args)
+
+	recordQueryStats(t.metrics, t.config, duration, query)
 }
 
 func (t *Tx) Query(query string, args ...any) (*sql.Rows, error) {
-	defer t.sendOperationStats(time.Now(), "TxQuery", query, args...)
-	return t.Tx.QueryContext(context.Background(), query, args...)
+	defer t.sendOperationStats(context.Background(), time.Now(), "TxQuery", query, args...)
+
+	ctx, cancel := statementContext(context.Background(), t.config)
+	rows, err := t.Tx.QueryContext(ctx, query, args...)
+	cancelFailed(err, cancel)
+
+	return rows, err
+}
+
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	defer t.sendOperationStats(ctx, time.Now(), "TxQueryContext", query, args...)
+
+	ctx, cancel := statementContext(ctx, t.config)
+	rows, err := t.Tx.QueryContext(ctx, query, args...)
+	cancelFailed(err, cancel)
+
+	return rows, err
 }
 
 func (t *Tx) QueryRow(query string, args ...any) *sql.Row {
-	defer t.sendOperationStats(time.Now(), "TxQueryRow", query, args...)
-	return t.Tx.QueryRowContext(context.Background(), query, args...)
+	defer t.sendOperationStats(context.Background(), time.Now(), "TxQueryRow", query, args...)
+
+	ctx, cancel := statementContext(context.Background(), t.config)
+	row := t.Tx.QueryRowContext(ctx, query, args...)
+	cancelFailed(row.Err(), cancel)
+
+	return row
 }
 
 func (t *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
-	defer t.sendOperationStats(time.Now(), "TxQueryRowContext", query, args...)
-	return t.Tx.QueryRowContext(ctx, query, args...)
+	defer t.sendOperationStats(ctx, time.Now(), "TxQueryRowContext", query, args...)
+
+	ctx, cancel := statementContext(ctx, t.config)
+	row := t.Tx.QueryRowContext(ctx, query, args...)
+	cancelFailed(row.Err(), cancel)
+
+	return row
 }
 
 func (t *Tx) Exec(query string, args ...any) (sql.Result, error) {
-	defer t.sendOperationStats(time.Now(), "TxExec", query, args...)
-	return t.Tx.ExecContext(context.Background(), query, args...)
+	defer t.sendOperationStats(context.Background(), time.Now(), "TxExec", query, args...)
+
+	ctx, cancel := statementContext(context.Background(), t.config)
+	defer cancel()
+
+	return t.Tx.ExecContext(ctx, query, args...)
 }
 
 func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
-	defer t.sendOperationStats(time.Now(), "TxExecContext", query, args...)
+	defer t.sendOperationStats(ctx, time.Now(), "TxExecContext", query, args...)
+
+	ctx, cancel := statementContext(ctx, t.config)
+	defer cancel()
+
 	return t.Tx.ExecContext(ctx, query, args...)
 }
 
 func (t *Tx) Prepare(query string) (*sql.Stmt, error) {
-	defer t.sendOperationStats(time.Now(), "TxPrepare", query)
-	return t.Tx.PrepareContext(context.Background(), query)
+	defer t.sendOperationStats(context.Background(), time.Now(), "TxPrepare", query)
+
+	ctx, cancel := statementContext(context.Background(), t.config)
+	defer cancel()
+
+	return t.Tx.PrepareContext(ctx, query)
+}
+
+// SelectWithError binds the result of the query, run in the transaction, to data. It behaves as DB.SelectWithError.
+func (t *Tx) SelectWithError(ctx context.Context, data any, query string, args ...any) error {
+	return selectInto(ctx, t, data, query, args...)
 }
 
 func (t *Tx) Commit() error {
-	defer t.sendOperationStats(time.Now(), "TxCommit", "COMMIT")
+	defer t.sendOperationStats(context.Background(), time.Now(), "TxCommit", "COMMIT")
 	return t.Tx.Commit()
 }
 
 func (t *Tx) Rollback() error {
-	defer t.sendOperationStats(time.Now(), "TxRollback", "ROLLBACK")
+	defer t.sendOperationStats(context.Background(), time.Now(), "TxRollback", "ROLLBACK")
 	return t.Tx.Rollback()
 }
 
@@ -209,125 +297,37 @@ func (t *Tx) Rollback() error {
 //     }
 //     users := []user{}
 //     db.Select(ctx, &users, "select * from users")
-//
-//nolint:exhaustive // We just want to take care of slice and struct in this case.
 func (d *DB) Select(ctx context.Context, data any, query string, args ...any) {
 	// If context is done, it is not needed
 	if ctx.Err() != nil {
 		return
 	}
 
-	// First confirm that what we got in v is a pointer else it won't be settable
-	rvo := reflect.ValueOf(data)
-	if rvo.Kind() != reflect.Ptr {
-		d.logger.Error("we did not get a pointer. data is not settable.")
-		return
-	}
-
-	// Deference the pointer to the underlying element, if the underlying element is a slice, multiple rows are expected.
-	// If the underlying element is a struct, one row is expected.
-	rv := rvo.Elem()
-
-	switch rv.Kind() {
-	case reflect.Slice:
-		d.selectSlice(ctx, query, args, rvo, rv)
-
-	case reflect.Struct:
-		d.selectStruct(ctx, query, args, rv)
+	err := selectInto(ctx, d, data, query, args...)
 
+	switch {
+	case err == nil, errors.Is(err, sql.ErrNoRows):
+	case errors.Is(err, ErrNotPointer):
+		d.logger.Error("we did not get a pointer. data is not settable.")
+	case errors.Is(err, errUnexpectedKind):
+		d.logger.Debugf("%v.", err)
 	default:
-		d.logger.Debugf("a pointer to %v was not expected.", rv.Kind().String())
-	}
-}
-
-func (d *DB) selectSlice(ctx context.Context, query string, args []any, rvo, rv reflect.Value) {
-	rows, err := d.QueryContext(ctx, query, args...)
-	if err != nil {
-		d.logger.Errorf("error running query: %v", err)
-		return
-	}
-
-	for rows.Next() {
-		val := reflect.New(rv.Type().Elem())
-
-		if rv.Type().Elem().Kind() == reflect.Struct {
-			d.rowsToStruct(rows, val)
-		} else {
-			_ = rows.Scan(val.Interface())
-		}
-
-		rv = reflect.Append(rv, val.Elem())
-	}
-
-	if rows.Err() != nil {
-		d.logger.Errorf("error parsing rows : %v", err)
-		return
-	}
-
-	if rvo.Elem().CanSet() {
-		rvo.Elem().Set(rv)
-	}
-}
-
-func (d *DB) selectStruct(ctx context.Context, query string, args []any, rv reflect.Value) {
-	rows, err := d.QueryContext(ctx, query, args...)
-	if err != nil {
-		d.logger.Errorf("error running query: %v", err)
-		return
-	}
-
-	for rows.Next() {
-		d.rowsToStruct(rows, rv)
-	}
-
-	if rows.Err() != nil {
-		d.logger.Errorf("error parsing rows : %v", err)
-		return
+		d.logger.Errorf("%v", err)
 	}
 }
 
-func (*DB) rowsToStruct(rows *sql.Rows, vo reflect.Value) {
-	v := vo
-	if vo.Kind() == reflect.Ptr {
-		v = vo.Elem()
-	}
-
-	// Map fields and their indexes by normalized name
-	fieldNameIndex := map[string]int{}
-
-	for i := 0; i < v.Type().NumField(); i++ {
-		var name string
-
-		f := v.Type().Field(i)
-		tag := f.Tag.Get("db")
-
-		if tag != "" {
-			name = tag
-		} else {
-			name = ToSnakeCase(f.Name)
-		}
-
-		fieldNameIndex[name] = i
-	}
-
-	fields := []any{}
-	columns, _ := rows.Columns()
-
-	for _, c := range columns {
-		if i, ok := fieldNameIndex[c]; ok {
-			fields = append(fields, v.Field(i).Addr().Interface())
-		} else {
-			var i any
-
-			fields = append(fields, &i)
-		}
-	}
-
-	_ = rows.Scan(fields...)
-
-	if vo.CanSet() {
-		vo.Set(v)
-	}
+// SelectWithError binds the result of the query to data as Select does, but returns the errors of the query
+// and of the scanning of its rows, instead of logging them. It returns sql.ErrNoRows when data is a pointer to
+// a struct and the query returns no rows, so that a missing row can be told apart from a failing database:
+//
+//	var u user
+//
+//	err := db.SelectWithError(ctx, &u, "select * from users where id=?", 1)
+//	if errors.Is(err, sql.ErrNoRows) {
+//		return nil, http.ErrorEntityNotFound{Name: "id", Value: "1"}
+//	}
+func (d *DB) SelectWithError(ctx context.Context, data any, query string, args ...any) error {
+	return selectInto(ctx, d, data, query, args...)
 }
 
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
@@ -1136,6 +1136,20 @@ func TestPrettyPrint(t *testing.T) {
 		w.String())
 }
 
+func TestLog_Redact(t *testing.T) {
+	l := &Log{
+		Type:  "Exec",
+		Query: "UPDATE users SET password = ? WHERE email = ?",
+		Args:  []any{"p@ss", "bob@example.com"},
+	}
+
+	redacted, ok := l.Redact(logging.DefaultRedactor()).(*Log)
+	require.True(t, ok)
+
+	assert.Equal(t, []any{logging.RedactedValue, "bob@example.com"}, redacted.Args)
+	assert.Equal(t, []any{"p@ss", "bob@example.com"}, l.Args, "original log must not be modified")
+}
+
 func TestClean(t *testing.T) {
 	query := ""
 
@@ -1246,7 +1260,7 @@ func TestDB_sendOperationStats_RecordsMilliseconds(t *testing.T) {
 		"hostname", "host", "database", "db", "type", "SELECT",
 	)
 
-	db.sendOperationStats(start, "SELECT", "SELECT * FROM users")
+	db.sendOperationStats(t.Context(), start, "SELECT", "SELECT * FROM users")
 
 	duration := time.Since(start).Milliseconds()
 	assert.Equal(t, int64(1500), duration)
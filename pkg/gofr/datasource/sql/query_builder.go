@@ -16,6 +16,17 @@ var (
 type FieldConstraints struct {
 	AutoIncrement bool
 	NotNull       bool
+	// Version marks the column holding the version of a row, used for optimistic concurrency control.
+	Version bool
+	// Audit is the audit column the field maps to, created_at, updated_at, created_by, updated_by or deleted_at,
+	// whose values are set by the CRUD handlers rather than by the clients.
+	Audit string
+	// Relation is the relation the field holds the related entities of, has_many or belongs_to; such fields are not
+	// columns.
+	Relation string
+	// ForeignKey is the column referencing the entity in the related table for has_many relations, and the related
+	// entity in the table of the entity for belongs_to relations. It is empty when defaulted.
+	ForeignKey string
 }
 
 func InsertQuery(dialect, tableName string, fieldNames []string, values []any,
@@ -47,20 +58,34 @@ func InsertQuery(dialect, tableName string, fieldNames []string, values []any,
 	return stmt, nil
 }
 
-func SelectQuery(dialect, tableName string) string {
-	return fmt.Sprintf(`SELECT * FROM %s`, quotedString(quote(dialect), tableName))
+// SelectQuery returns a query selecting the rows of the table, restricted by the optional conditions, such as the
+// one of NotDeleted.
+func SelectQuery(dialect, tableName string, conditions ...string) string {
+	return fmt.Sprintf(`SELECT * FROM %s`, quotedString(quote(dialect), tableName)) + where(conditions...)
 }
 
-func SelectByQuery(dialect, tableName, field string) string {
+func SelectByQuery(dialect, tableName, field string, conditions ...string) string {
 	q := quote(dialect)
 
-	return fmt.Sprintf(`SELECT * FROM %s WHERE %s=%s`,
-		quotedString(q, tableName),
-		quotedString(q, field),
-		bindVar(dialect, 1))
+	return fmt.Sprintf(`SELECT * FROM %s`, quotedString(q, tableName)) +
+		where(append([]string{fmt.Sprintf(`%s=%s`, quotedString(q, field), bindVar(dialect, 1))}, conditions...)...)
+}
+
+// SelectByInQuery returns a query selecting the rows whose field is one of count values.
+func SelectByInQuery(dialect, tableName, field string, count int, conditions ...string) string {
+	q := quote(dialect)
+
+	bindVars := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		bindVars = append(bindVars, bindVar(dialect, i+1))
+	}
+
+	in := fmt.Sprintf(`%s IN (%s)`, quotedString(q, field), strings.Join(bindVars, ", "))
+
+	return fmt.Sprintf(`SELECT * FROM %s`, quotedString(q, tableName)) + where(append([]string{in}, conditions...)...)
 }
 
-func UpdateByQuery(dialect, tableName string, fieldNames []string, field string) string {
+func UpdateByQuery(dialect, tableName string, fieldNames []string, field string, conditions ...string) string {
 	q := quote(dialect)
 	fieldNamesLength := len(fieldNames)
 
@@ -69,14 +94,49 @@ func UpdateByQuery(dialect, tableName string, fieldNames []string, field string)
 		paramsList = append(paramsList, fmt.Sprintf(`%s=%s`, quotedString(q, fieldNames[i]), bindVar(dialect, i+1)))
 	}
 
-	stmt := fmt.Sprintf(`UPDATE %s SET %s WHERE %s=%s`,
+	stmt := fmt.Sprintf(`UPDATE %s SET %s`,
 		quotedString(q, tableName),
 		strings.Join(paramsList, ", "),
-		quotedString(q, field),
-		bindVar(dialect, fieldNamesLength+1),
 	)
 
-	return stmt
+	key := fmt.Sprintf(`%s=%s`, quotedString(q, field), bindVar(dialect, fieldNamesLength+1))
+
+	return stmt + where(append([]string{key}, conditions...)...)
+}
+
+// UpdateByVersionQuery returns a statement updating the given fields of the row matching both the key field and
+// the version field, and incrementing its version. It affects no row when the version was changed concurrently.
+func UpdateByVersionQuery(dialect, tableName string, fieldNames []string, field, versionField string,
+	conditions ...string) string {
+	q := quote(dialect)
+	fieldNamesLength := len(fieldNames)
+
+	paramsList := make([]string, 0, fieldNamesLength+1)
+	for i := 0; i < fieldNamesLength; i++ {
+		paramsList = append(paramsList, fmt.Sprintf(`%s=%s`, quotedString(q, fieldNames[i]), bindVar(dialect, i+1)))
+	}
+
+	version := quotedString(q, versionField)
+	paramsList = append(paramsList, fmt.Sprintf(`%s=%s+1`, version, version))
+
+	stmt := fmt.Sprintf(`UPDATE %s SET %s`, quotedString(q, tableName), strings.Join(paramsList, ", "))
+
+	return stmt + where(append([]string{
+		fmt.Sprintf(`%s=%s`, quotedString(q, field), bindVar(dialect, fieldNamesLength+1)),
+		fmt.Sprintf(`%s=%s`, version, bindVar(dialect, fieldNamesLength+2)),
+	}, conditions...)...)
+}
+
+// DeleteByVersionQuery returns a statement deleting the row matching both the key field and the version field.
+func DeleteByVersionQuery(dialect, tableName, field, versionField string) string {
+	q := quote(dialect)
+
+	return fmt.Sprintf(`DELETE FROM %s WHERE %s=%s AND %s=%s`,
+		quotedString(q, tableName),
+		quotedString(q, field),
+		bindVar(dialect, 1),
+		quotedString(q, versionField),
+		bindVar(dialect, 2))
 }
 
 func DeleteByQuery(dialect, tableName, field string) string {
@@ -88,6 +148,21 @@ func DeleteByQuery(dialect, tableName, field string) string {
 		bindVar(dialect, 1))
 }
 
+// NotDeleted returns the condition restricting the statements built by the functions of this file to the rows which
+// are not soft deleted, i.e. whose deletedField is null.
+func NotDeleted(dialect, deletedField string) string {
+	return fmt.Sprintf(`%s IS NULL`, quotedString(quote(dialect), deletedField))
+}
+
+// where returns the WHERE clause of the conditions, which is empty without condition.
+func where(conditions ...string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
 func validateNotNull(fieldName string, value any, isNotNull bool) error {
 	if !isNotNull {
 		return nil
@@ -206,6 +206,108 @@ func Test_DeleteByQuery(t *testing.T) {
 	}
 }
 
+func Test_UpdateByVersionQuery(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{
+			dialect:  "mysql",
+			expected: "UPDATE `user` SET `name`=?, `age`=?, `version`=`version`+1 WHERE `id`=? AND `version`=?",
+		},
+		{
+			dialect:  "postgres",
+			expected: `UPDATE "user" SET "name"=$1, "age"=$2, "version"="version"+1 WHERE "id"=$3 AND "version"=$4`,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.dialect, func(t *testing.T) {
+			actual := UpdateByVersionQuery(tc.dialect, "user", []string{"name", "age"}, "id", "version")
+			assert.Equal(t, tc.expected, actual, "TEST[%d], Failed.\n%s", i, tc.dialect)
+		})
+	}
+}
+
+func Test_DeleteByVersionQuery(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{
+			dialect:  "mysql",
+			expected: "DELETE FROM `user` WHERE `id`=? AND `version`=?",
+		},
+		{
+			dialect:  "postgres",
+			expected: `DELETE FROM "user" WHERE "id"=$1 AND "version"=$2`,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.dialect, func(t *testing.T) {
+			actual := DeleteByVersionQuery(tc.dialect, "user", "id", "version")
+			assert.Equal(t, tc.expected, actual, "TEST[%d], Failed.\n%s", i, tc.dialect)
+		})
+	}
+}
+
+func Test_NotDeleted(t *testing.T) {
+	tests := []struct {
+		desc     string
+		actual   string
+		expected string
+	}{
+		{
+			desc:     "select all",
+			actual:   SelectQuery("mysql", "user", NotDeleted("mysql", "deleted_at")),
+			expected: "SELECT * FROM `user` WHERE `deleted_at` IS NULL",
+		},
+		{
+			desc:     "select by id",
+			actual:   SelectByQuery("postgres", "user", "id", NotDeleted("postgres", "deleted_at")),
+			expected: `SELECT * FROM "user" WHERE "id"=$1 AND "deleted_at" IS NULL`,
+		},
+		{
+			desc:     "select by in",
+			actual:   SelectByInQuery("mysql", "order", "user_id", 2, NotDeleted("mysql", "deleted_at")),
+			expected: "SELECT * FROM `order` WHERE `user_id` IN (?, ?) AND `deleted_at` IS NULL",
+		},
+		{
+			desc:     "update by id",
+			actual:   UpdateByQuery("mysql", "user", []string{"name"}, "id", NotDeleted("mysql", "deleted_at")),
+			expected: "UPDATE `user` SET `name`=? WHERE `id`=? AND `deleted_at` IS NULL",
+		},
+		{
+			desc: "update by version",
+			actual: UpdateByVersionQuery("postgres", "user", []string{"deleted_at"}, "id", "version",
+				NotDeleted("postgres", "deleted_at")),
+			expected: `UPDATE "user" SET "deleted_at"=$1, "version"="version"+1 WHERE "id"=$2 AND "version"=$3 AND "deleted_at" IS NULL`,
+		},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, tc.actual, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func Test_SelectByInQuery(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dialect  string
+		count    int
+		expected string
+	}{
+		{"mysql", "mysql", 3, "SELECT * FROM `order` WHERE `user_id` IN (?, ?, ?)"},
+		{"postgres", "postgres", 2, `SELECT * FROM "order" WHERE "user_id" IN ($1, $2)`},
+	}
+
+	for i, tc := range tests {
+		actual := SelectByInQuery(tc.dialect, "order", "user_id", tc.count)
+		assert.Equal(t, tc.expected, actual, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
 func Test_validateNotNull_Error(t *testing.T) {
 	type customType struct{}
 
@@ -0,0 +1,162 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+const (
+	// maxFingerprints bounds the number of distinct fingerprints labelling the metrics, the queries of any other
+	// fingerprint being labelled otherFingerprint.
+	maxFingerprints = 500
+	// maxCachedQueries bounds the number of queries whose fingerprint is remembered, not to compute it on each run.
+	maxCachedQueries = 2000
+	// maxFingerprintLength truncates the fingerprints of long queries.
+	maxFingerprintLength = 256
+
+	otherFingerprint = "other"
+)
+
+var (
+	// literalPattern matches the string and numeric literals, and the positional bindvars of postgres.
+	literalPattern = regexp.MustCompile(`'(?:[^']|'')*'|\$\d+|\b\d+(?:\.\d+)?\b`)
+	// listPattern matches lists of values, such as the ones of IN clauses, whatever their length.
+	listPattern = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+
+	fingerprints = &fingerprintSet{byQuery: make(map[string]string), known: make(map[string]struct{})}
+)
+
+// SlowQueryLog is logged at WARN for the statements running longer than DB_SLOW_QUERY_THRESHOLD.
+type SlowQueryLog struct {
+	Type     string `json:"type"`
+	Query    string `json:"query"`
+	Duration int64  `json:"duration"`
+	Args     []any  `json:"args,omitempty"`
+	TraceID  string `json:"trace_id,omitempty"`
+}
+
+func (l *SlowQueryLog) PrettyPrint(writer io.Writer) {
+	fmt.Fprintf(writer, "\u001B[38;5;8m%-32s \u001B[38;5;220m%-6s\u001B[0m %8d\u001B[38;5;8mms\u001B[0m %s\n",
+		l.Type, "SLOW", l.Duration, l.Query)
+}
+
+// Redact returns a copy of the log in which the arguments bound to sensitive columns, and literals
+// compared against them, are redacted.
+func (l *SlowQueryLog) Redact(r *logging.Redactor) any {
+	redacted := *l
+	redacted.Query = r.SQL(l.Query)
+	redacted.Args = r.SQLArgs(l.Query, l.Args)
+
+	return &redacted
+}
+
+func logSlowQuery(ctx context.Context, logger datasource.Logger, config *DBConfig, elapsed time.Duration,
+	queryType, query string, args []any) {
+	if config == nil || config.SlowQueryThreshold <= 0 || elapsed < config.SlowQueryThreshold {
+		return
+	}
+
+	l := &SlowQueryLog{Type: queryType, Query: clean(query), Duration: elapsed.Milliseconds(), Args: args}
+
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		l.TraceID = span.TraceID().String()
+	}
+
+	logger.Warn(l)
+}
+
+// statementContext bounds ctx by the statement timeout of the config, DB_QUERY_TIMEOUT. cancel is called once
+// the statement is run.
+func statementContext(ctx context.Context, config *DBConfig) (context.Context, context.CancelFunc) {
+	if config == nil || config.QueryTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, config.QueryTimeout)
+}
+
+// cancelFailed calls cancel, releasing the statement timeout of a query returning rows, if the query failed.
+// database/sql closes the rows of a query once its context is done, so the timeout of a successful query bounds
+// reading its rows too, and is released when it expires.
+func cancelFailed(err error, cancel context.CancelFunc) {
+	if err != nil {
+		cancel()
+	}
+}
+
+// recordQueryStats records the duration of a statement in app_sql_stats and, if DB_QUERY_METRICS is enabled, in
+// app_sql_query_stats labelled by the fingerprint of the query.
+func recordQueryStats(metrics Metrics, config *DBConfig, duration int64, query string) {
+	metrics.RecordHistogram(context.Background(), "app_sql_stats", float64(duration), "hostname", config.HostName,
+		"database", config.Database, "type", getOperationType(query))
+
+	if !config.QueryMetrics {
+		return
+	}
+
+	metrics.RecordHistogram(context.Background(), "app_sql_query_stats", float64(duration), "hostname", config.HostName,
+		"database", config.Database, "query", fingerprint(query))
+}
+
+// fingerprint returns the query with its literals and bindvars replaced by ?, for the metrics of the queries
+// differing only by their values to share a label.
+func fingerprint(query string) string {
+	return fingerprints.get(query)
+}
+
+// fingerprintSet remembers the fingerprints of the queries run, up to a bound keeping the cardinality of the
+// metrics labelled by them bounded.
+type fingerprintSet struct {
+	mu      sync.RWMutex
+	byQuery map[string]string
+	known   map[string]struct{}
+}
+
+func (f *fingerprintSet) get(query string) string {
+	f.mu.RLock()
+	fp, ok := f.byQuery[query]
+	f.mu.RUnlock()
+
+	if ok {
+		return fp
+	}
+
+	fp = normalize(query)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.known[fp]; !ok {
+		if len(f.known) >= maxFingerprints {
+			fp = otherFingerprint
+		} else {
+			f.known[fp] = struct{}{}
+		}
+	}
+
+	if len(f.byQuery) < maxCachedQueries {
+		f.byQuery[query] = fp
+	}
+
+	return fp
+}
+
+func normalize(query string) string {
+	fp := literalPattern.ReplaceAllString(clean(query), "?")
+	fp = listPattern.ReplaceAllString(fp, "(?)")
+
+	if len(fp) > maxFingerprintLength {
+		fp = fp[:maxFingerprintLength]
+	}
+
+	return fp
+}
@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestFingerprint(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		query    string
+		expected string
+	}{
+		{desc: "numbers", query: "SELECT * FROM users WHERE id = 42 AND score > 1.5",
+			expected: "SELECT * FROM users WHERE id = ? AND score > ?"},
+		{desc: "strings", query: "SELECT * FROM users WHERE name = 'o''brien'",
+			expected: "SELECT * FROM users WHERE name = ?"},
+		{desc: "postgres bindvars", query: "UPDATE users SET name = $1 WHERE id = $2",
+			expected: "UPDATE users SET name = ? WHERE id = ?"},
+		{desc: "lists of any length", query: "SELECT * FROM users WHERE id IN (1, 2, 3)",
+			expected: "SELECT * FROM users WHERE id IN (?)"},
+		{desc: "whitespace and identifiers", query: "SELECT id\n\tFROM   table2",
+			expected: "SELECT id FROM table2"},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.expected, fingerprint(tc.query), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestFingerprint_Bounded(t *testing.T) {
+	set := &fingerprintSet{byQuery: make(map[string]string), known: make(map[string]struct{})}
+
+	for i := 0; i < maxFingerprints; i++ {
+		set.get(fmt.Sprintf("SELECT c%d FROM users WHERE id = 1", i))
+	}
+
+	assert.Equal(t, otherFingerprint, set.get("SELECT * FROM orders"))
+	assert.Equal(t, "SELECT c1 FROM users WHERE id = ?", set.get("SELECT c1 FROM users WHERE id = 2"),
+		"the queries of known fingerprints keep their label")
+}
+
+func TestDB_SlowQueryLog(t *testing.T) {
+	out := testutil.StdoutOutputForFunc(func() {
+		db, mock := getDBWithMetrics(t)
+		db.logger = logging.NewMockLogger(logging.WARN)
+		db.config.SlowQueryThreshold = 10 * time.Millisecond
+
+		mock.ExpectExec("UPDATE users SET active = ?").WithArgs(true).
+			WillDelayFor(20 * time.Millisecond).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE users SET active = ?").WithArgs(false).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := db.ExecContext(t.Context(), "UPDATE users SET active = ?", true)
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(t.Context(), "UPDATE users SET active = ?", false)
+		require.NoError(t, err)
+	})
+
+	assert.Equal(t, 1, strings.Count(out, "ExecContext UPDATE users SET active = ?"),
+		"only the statements slower than the threshold are logged")
+}
+
+func TestDB_QueryTimeout(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+	db.config.QueryTimeout = 10 * time.Millisecond
+
+	mock.ExpectExec("DELETE FROM sessions").WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	start := time.Now()
+
+	_, err := db.ExecContext(t.Context(), "DELETE FROM sessions")
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second, "the statement is canceled once the timeout is reached")
+}
+
+func TestGetDBConfig_QueryDurations(t *testing.T) {
+	configs := getDBConfig(config.NewMockConfig(map[string]string{
+		"DB_SLOW_QUERY_THRESHOLD": "500ms",
+		"DB_QUERY_TIMEOUT":        "invalid",
+		"DB_QUERY_METRICS":        "true",
+	}))
+
+	assert.Equal(t, 500*time.Millisecond, configs.SlowQueryThreshold)
+	assert.Zero(t, configs.QueryTimeout)
+	assert.True(t, configs.QueryMetrics)
+}
+
+func TestRecordQueryStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	metrics := NewMockMetrics(ctrl)
+	config := &DBConfig{HostName: "host", Database: "db"}
+
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", float64(5),
+		"hostname", "host", "database", "db", "type", "SELECT").Times(2)
+
+	recordQueryStats(metrics, config, 5, "SELECT * FROM users WHERE id = 1")
+
+	config.QueryMetrics = true
+
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_query_stats", float64(5),
+		"hostname", "host", "database", "db", "query", "SELECT * FROM users WHERE id = ?")
+
+	recordQueryStats(metrics, config, 5, "SELECT * FROM users WHERE id = 1")
+}
+
+func TestCancelFailed(t *testing.T) {
+	canceled := 0
+
+	cancelFailed(nil, func() { canceled++ })
+
+	assert.Equal(t, 0, canceled, "the timeout of a successful query bounds reading its rows")
+
+	cancelFailed(errors.New("query failed"), func() { canceled++ })
+
+	assert.Equal(t, 1, canceled, "the timeout of a failed query is released at once")
+}
@@ -0,0 +1,288 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	// ErrNotPointer is returned by SelectWithError when the data is not a pointer, and cannot be set.
+	ErrNotPointer = errors.New("we did not get a pointer. data is not settable")
+
+	errUnexpectedKind = errors.New("was not expected")
+
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// Querier runs queries returning rows. It is implemented by DB, Tx and the SQL datasource of the container.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// QueryAll runs a query with args and returns its rows as values of type T. T is a struct whose fields are
+// mapped to the columns by their db tags, or their snake cased names, or a type holding a single column:
+//
+//	users, err := sql.QueryAll[User](ctx, ctx.SQL, "SELECT id, name FROM users WHERE active = ?", true)
+//
+// Columns holding NULL leave the zero value in fields which are neither pointers nor sql.Scanner.
+func QueryAll[T any](ctx context.Context, q Querier, query string, args ...any) ([]T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]T, 0)
+
+	for rows.Next() {
+		var value T
+
+		if err := scanRow(rows, reflect.ValueOf(&value).Elem()); err != nil {
+			return nil, err
+		}
+
+		result = append(result, value)
+	}
+
+	return result, rows.Err()
+}
+
+// QueryOne runs a query with args and returns its first row as a value of type T, mapped as in QueryAll.
+// It returns sql.ErrNoRows when the query returns no rows.
+func QueryOne[T any](ctx context.Context, q Querier, query string, args ...any) (T, error) {
+	var value T
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return value, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return value, err
+		}
+
+		return value, sql.ErrNoRows
+	}
+
+	if err := scanRow(rows, reflect.ValueOf(&value).Elem()); err != nil {
+		return value, err
+	}
+
+	return value, rows.Err()
+}
+
+// selectInto binds the rows of the query to data, a pointer to a slice for all the rows, or to a struct for
+// a single row. It returns sql.ErrNoRows when a struct is expected and the query returns no rows.
+//
+//nolint:exhaustive // We just want to take care of slice and struct in this case.
+func selectInto(ctx context.Context, q Querier, data any, query string, args ...any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rvo := reflect.ValueOf(data)
+	if rvo.Kind() != reflect.Ptr || rvo.IsNil() {
+		return ErrNotPointer
+	}
+
+	rv := rvo.Elem()
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		return selectSlice(ctx, q, query, args, rv)
+	case reflect.Struct:
+		return selectStruct(ctx, q, query, args, rv)
+	default:
+		return fmt.Errorf("a pointer to %v %w", rv.Kind().String(), errUnexpectedKind)
+	}
+}
+
+func selectSlice(ctx context.Context, q Querier, query string, args []any, rv reflect.Value) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error running query: %w", err)
+	}
+	defer rows.Close()
+
+	result := rv
+
+	for rows.Next() {
+		val := reflect.New(rv.Type().Elem()).Elem()
+
+		if err := scanRow(rows, val); err != nil {
+			return err
+		}
+
+		result = reflect.Append(result, val)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error parsing rows: %w", err)
+	}
+
+	rv.Set(result)
+
+	return nil
+}
+
+func selectStruct(ctx context.Context, q Querier, query string, args []any, rv reflect.Value) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error running query: %w", err)
+	}
+	defer rows.Close()
+
+	found := false
+
+	for rows.Next() {
+		if err := scanRow(rows, rv); err != nil {
+			return err
+		}
+
+		found = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error parsing rows: %w", err)
+	}
+
+	if !found {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// scanRow scans the current row into v, mapping the columns to its fields when v is a struct holding several
+// columns, rather than a single column value such as time.Time or a sql.Scanner.
+func scanRow(rows *sql.Rows, v reflect.Value) error {
+	if !isRowStruct(v.Type()) {
+		dest, assign := scanTarget(v)
+
+		if err := rows.Scan(dest); err != nil {
+			return fmt.Errorf("error scanning row: %w", err)
+		}
+
+		assign()
+
+		return nil
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("error reading columns: %w", err)
+	}
+
+	indexes := fieldIndexes(v.Type())
+	dests := make([]any, len(columns))
+	assigns := make([]func(), 0, len(columns))
+
+	for i, column := range columns {
+		index, ok := indexes[column]
+		if !ok {
+			dests[i] = new(any)
+
+			continue
+		}
+
+		var assign func()
+
+		dests[i], assign = scanTarget(v.FieldByIndex(index))
+		assigns = append(assigns, assign)
+	}
+
+	if err := rows.Scan(dests...); err != nil {
+		return fmt.Errorf("error scanning row: %w", err)
+	}
+
+	for _, assign := range assigns {
+		assign()
+	}
+
+	return nil
+}
+
+func isRowStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(scannerType)
+}
+
+// scanTarget returns the destination to scan a column of v into, and the function setting v once scanned.
+// Values which cannot hold NULL are scanned through a pointer, NULL leaving them to their zero value.
+func scanTarget(v reflect.Value) (dest any, assign func()) {
+	if v.Kind() == reflect.Ptr || reflect.PointerTo(v.Type()).Implements(scannerType) {
+		return v.Addr().Interface(), func() {}
+	}
+
+	ptr := reflect.New(reflect.PointerTo(v.Type()))
+
+	return ptr.Interface(), func() {
+		if ptr.Elem().IsNil() {
+			v.SetZero()
+
+			return
+		}
+
+		v.Set(ptr.Elem().Elem())
+	}
+}
+
+// fieldIndexes maps the column names of the exported fields of a struct to their indexes. A field is named by its
+// db tag, or its snake cased name, and the fields of embedded structs are promoted unless the struct is tagged.
+// A db tag of "-" leaves the field out.
+func fieldIndexes(t reflect.Type) map[string][]int {
+	indexes := make(map[string][]int)
+
+	addFieldIndexes(t, nil, indexes)
+
+	return indexes
+}
+
+func addFieldIndexes(t reflect.Type, parent []int, indexes map[string][]int) {
+	embedded := make([]reflect.StructField, 0)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("db")
+
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, f)
+
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = ToSnakeCase(f.Name)
+		}
+
+		indexes[name] = append(append([]int{}, parent...), i)
+	}
+
+	// the fields of embedded structs are added last, for the fields of the outer struct to take precedence.
+	for _, f := range embedded {
+		promoted := make(map[string][]int)
+
+		addFieldIndexes(f.Type, append(append([]int{}, parent...), f.Index...), promoted)
+
+		for name, index := range promoted {
+			if _, ok := indexes[name]; !ok {
+				indexes[name] = index
+			}
+		}
+	}
+}
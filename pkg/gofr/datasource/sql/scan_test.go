@@ -0,0 +1,143 @@
+package sql
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+type auditFields struct {
+	CreatedAt time.Time
+	UpdatedBy string `db:"updated_by"`
+}
+
+type scannedUser struct {
+	auditFields
+
+	ID       int
+	Name     string
+	Nickname sql.NullString
+	Email    *string
+	Secret   string `db:"-"`
+}
+
+func getDBWithMetrics(t *testing.T) (*DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock := getDB(t, logging.INFO)
+
+	mockMetrics := NewMockMetrics(gomock.NewController(t))
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "hostname", gomock.Any(),
+		"database", gomock.Any(), "type", gomock.Any()).AnyTimes()
+
+	db.metrics = mockMetrics
+
+	t.Cleanup(func() { db.DB.Close() })
+
+	return db, mock
+}
+
+func TestQueryAll(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	email := "gofr@gofr.dev"
+
+	mock.ExpectQuery("SELECT * FROM users").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "nickname", "email", "created_at", "updated_by", "secret", "unknown"}).
+			AddRow(1, "gofr", "gf", email, createdAt, "admin", "hidden", "x").
+			AddRow(2, nil, nil, nil, createdAt, nil, nil, nil))
+
+	users, err := QueryAll[scannedUser](t.Context(), db, "SELECT * FROM users")
+
+	require.NoError(t, err)
+	assert.Equal(t, []scannedUser{
+		{
+			auditFields: auditFields{CreatedAt: createdAt, UpdatedBy: "admin"},
+			ID:          1, Name: "gofr", Nickname: sql.NullString{String: "gf", Valid: true}, Email: &email,
+		},
+		{auditFields: auditFields{CreatedAt: createdAt}, ID: 2},
+	}, users)
+}
+
+func TestQueryAll_SingleColumn(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+
+	mock.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	ids, err := QueryAll[int](t.Context(), db, "SELECT id FROM users")
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+func TestQueryAll_Error(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+
+	mock.ExpectQuery("SELECT id FROM users").WillReturnError(errDB)
+
+	ids, err := QueryAll[int](t.Context(), db, "SELECT id FROM users")
+
+	require.ErrorIs(t, err, errDB)
+	assert.Nil(t, ids)
+}
+
+func TestQueryOne(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+
+	mock.ExpectQuery("SELECT id, name FROM users WHERE id = ?").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "gofr"))
+	mock.ExpectQuery("SELECT id, name FROM users WHERE id = ?").WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	user, err := QueryOne[scannedUser](t.Context(), db, "SELECT id, name FROM users WHERE id = ?", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, scannedUser{ID: 1, Name: "gofr"}, user)
+
+	_, err = QueryOne[scannedUser](t.Context(), db, "SELECT id, name FROM users WHERE id = ?", 2)
+
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDB_SelectWithError(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+
+	mock.ExpectQuery("SELECT id, name FROM users WHERE id = ?").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	mock.ExpectQuery("SELECT id FROM users").WillReturnError(errDB)
+
+	var user scannedUser
+
+	err := db.SelectWithError(t.Context(), &user, "SELECT id, name FROM users WHERE id = ?", 1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	var ids []int
+
+	err = db.SelectWithError(t.Context(), &ids, "SELECT id FROM users")
+	require.ErrorIs(t, err, errDB)
+
+	err = db.SelectWithError(t.Context(), ids, "SELECT id FROM users")
+	require.ErrorIs(t, err, ErrNotPointer)
+}
+
+func TestTx_SelectWithError(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	var ids []int
+
+	require.NoError(t, tx.SelectWithError(t.Context(), &ids, "SELECT id FROM users"))
+	assert.Equal(t, []int{1}, ids)
+}
@@ -46,6 +46,12 @@ type DBConfig struct {
 	MaxIdleConn int
 	MaxOpenConn int
 	Charset     string
+	// SlowQueryThreshold is the duration from which statements are logged at WARN. Zero disables the log.
+	SlowQueryThreshold time.Duration
+	// QueryTimeout bounds the duration of each statement. Zero leaves statements bounded by their context only.
+	QueryTimeout time.Duration
+	// QueryMetrics enables app_sql_query_stats, labelled by the fingerprint of each query.
+	QueryMetrics bool
 }
 
 func setupSupabaseDefaults(dbConfig *DBConfig, configs config.Config, logger datasource.Logger) {
@@ -223,11 +229,24 @@ func getDBConfig(configs config.Config) *DBConfig {
 		MaxOpenConn: maxOpenConn,
 		MaxIdleConn: maxIdleConn,
 		// Supported for postgres, supabase, cockroachdb, and mysql
-		SSLMode: configs.GetOrDefault("DB_SSL_MODE", "disable"),
-		Charset: configs.Get("DB_CHARSET"),
+		SSLMode:            configs.GetOrDefault("DB_SSL_MODE", "disable"),
+		Charset:            configs.Get("DB_CHARSET"),
+		SlowQueryThreshold: getDuration(configs, "DB_SLOW_QUERY_THRESHOLD"),
+		QueryTimeout:       getDuration(configs, "DB_QUERY_TIMEOUT"),
+		QueryMetrics:       strings.EqualFold(configs.Get("DB_QUERY_METRICS"), "true"),
 	}
 }
 
+// getDuration reads a duration such as 500ms or 2s, an invalid or negative duration being read as zero.
+func getDuration(configs config.Config, key string) time.Duration {
+	d, err := time.ParseDuration(configs.Get(key))
+	if err != nil || d < 0 {
+		return 0
+	}
+
+	return d
+}
+
 func getDBConnectionString(dbConfig *DBConfig) (string, error) {
 	switch dbConfig.Dialect {
 	case "mysql":
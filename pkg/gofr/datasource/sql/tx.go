@@ -0,0 +1,119 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+const (
+	// maxTxAttempts is the number of times WithTx runs a transaction failing on a serialization error or a deadlock.
+	maxTxAttempts = 3
+
+	mysqlDeadlock          = 1213
+	postgresSerialization  = "40001"
+	postgresDeadlock       = "40P01"
+	defaultTxRetryInterval = 20 * time.Millisecond
+)
+
+// txRetryInterval is the wait before the first retry of a transaction, doubled on each further retry.
+var txRetryInterval = defaultTxRetryInterval
+
+// BeginTx starts a transaction with opts, which set its isolation level and whether it is read-only. The context
+// is used until the transaction is committed or rolled back, and the transaction is rolled back if it is canceled.
+func (d *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := d.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tx{Tx: tx, config: d.config, logger: d.logger, metrics: d.metrics}, nil
+}
+
+// WithTx runs fn in a transaction started with opts. The transaction is committed when fn returns nil, and rolled
+// back when it returns an error or panics, the panic being propagated once the transaction is rolled back.
+//
+// Transactions failing on a serialization error or a deadlock, which the database reports when concurrent
+// transactions conflict, are run again from the start with a backoff, so fn must not have effects outside of tx:
+//
+//	err := ctx.SQL.WithTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable}, func(tx *gofrSQL.Tx) error {
+//		_, err := tx.ExecContext(ctx, "UPDATE accounts SET balance = balance - ? WHERE id = ?", amount, from)
+//		if err != nil {
+//			return err
+//		}
+//
+//		_, err = tx.ExecContext(ctx, "UPDATE accounts SET balance = balance + ? WHERE id = ?", amount, to)
+//
+//		return err
+//	})
+func (d *DB) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *Tx) error) error {
+	interval := txRetryInterval
+
+	for attempt := 1; ; attempt++ {
+		err := d.runTx(ctx, opts, fn)
+		if err == nil || attempt == maxTxAttempts || !isRetryableTxError(d.config.Dialect, err) {
+			return err
+		}
+
+		d.logger.Debugf("retrying transaction, attempt %d failed: %v", attempt, err)
+
+		// jitter keeps the conflicting transactions from being retried at the same time.
+		wait := interval/2 + rand.N(interval) //nolint:gosec // jitter does not need a secure random number.
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+
+		interval *= 2
+	}
+}
+
+func (d *DB) runTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *Tx) error) error {
+	tx, err := d.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, fmt.Errorf("error rolling back transaction: %w", rbErr))
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isRetryableTxError tells whether the transaction failed on a conflict with a concurrent transaction, as reported
+// by the driver of the dialect.
+func isRetryableTxError(dialect string, err error) bool {
+	switch dialect {
+	case dialectMysql:
+		var mysqlErr *mysql.MySQLError
+
+		return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDeadlock
+	case dialectPostgres, supabaseDialect, cockroachDB:
+		var pqErr *pq.Error
+
+		return errors.As(err, &pqErr) && (pqErr.Code == postgresSerialization || pqErr.Code == postgresDeadlock)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errMySQLDeadlock = &mysql.MySQLError{Number: mysqlDeadlock, Message: "Deadlock found when trying to get lock"}
+	errPQSerialize   = &pq.Error{Code: postgresSerialization, Message: "could not serialize access"}
+)
+
+func TestDB_WithTx(t *testing.T) {
+	txRetryInterval = time.Millisecond
+
+	t.Cleanup(func() { txRetryInterval = defaultTxRetryInterval })
+
+	testCases := []struct {
+		desc        string
+		dialect     string
+		errs        []error
+		expectedErr error
+		commits     int
+	}{
+		{desc: "committed", dialect: dialectMysql, commits: 1},
+		{desc: "rolled back on error", dialect: dialectMysql, errs: []error{errDB}, expectedErr: errDB},
+		{desc: "retried on a mysql deadlock", dialect: dialectMysql, errs: []error{errMySQLDeadlock}, commits: 1},
+		{desc: "retried on a postgres serialization failure", dialect: dialectPostgres, errs: []error{errPQSerialize},
+			commits: 1},
+		{desc: "not retried on the error of another dialect", dialect: dialectPostgres, errs: []error{errMySQLDeadlock},
+			expectedErr: errMySQLDeadlock},
+		{desc: "retried a limited number of times", dialect: dialectMysql,
+			errs:        []error{errMySQLDeadlock, errMySQLDeadlock, errMySQLDeadlock},
+			expectedErr: errMySQLDeadlock},
+	}
+
+	for i, tc := range testCases {
+		db, mock := getDBWithMetrics(t)
+		db.config.Dialect = tc.dialect
+
+		for _, err := range tc.errs {
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE accounts SET balance = 0").WillReturnError(err)
+			mock.ExpectRollback()
+		}
+
+		if tc.commits > 0 {
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE accounts SET balance = 0").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+
+		attempts := 0
+
+		err := db.WithTx(t.Context(), &sql.TxOptions{Isolation: sql.LevelSerializable}, func(tx *Tx) error {
+			attempts++
+
+			_, err := tx.ExecContext(t.Context(), "UPDATE accounts SET balance = 0")
+
+			return err
+		})
+
+		require.ErrorIs(t, err, tc.expectedErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, len(tc.errs)+tc.commits, attempts, "TEST[%d], Failed.\n%s", i, tc.desc)
+		require.NoError(t, mock.ExpectationsWereMet(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestDB_WithTx_Panic(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	assert.PanicsWithValue(t, "handler panicked", func() {
+		_ = db.WithTx(t.Context(), nil, func(*Tx) error {
+			panic("handler panicked")
+		})
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet(), "the transaction is rolled back before the panic is propagated")
+}
+
+func TestDB_WithTx_BeginError(t *testing.T) {
+	db, mock := getDBWithMetrics(t)
+
+	mock.ExpectBegin().WillReturnError(errbegin)
+
+	err := db.WithTx(t.Context(), nil, func(*Tx) error { return nil })
+
+	require.ErrorIs(t, err, errbegin)
+}
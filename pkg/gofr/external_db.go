@@ -1,8 +1,11 @@
 package gofr
 
 import (
+	"strings"
+
 	"go.opentelemetry.io/otel"
 
+	"gofr.dev/pkg/gofr/cache"
 	"gofr.dev/pkg/gofr/container"
 	"gofr.dev/pkg/gofr/datasource/file"
 )
@@ -42,14 +45,29 @@ func (a *App) AddPubSub(pubsub container.PubSubProvider) {
 	a.container.PubSub = pubsub
 }
 
-// AddFileStore sets the FTP, SFTP, S3, GCS, or Azure File Storage datasource in the app's container.
-func (a *App) AddFileStore(fs file.FileSystemProvider) {
+// AddFileStore sets the FTP, SFTP, S3, GCS, or Azure File Storage datasource in the app's container. Without a name,
+// it replaces the default store ctx.File. With a name, it is added next to the other stores and read with
+// ctx.FileStore(name), so that an application can use several stores at once:
+//
+//	app.AddFileStore(sftp.New(inboxConfig), "inbox")
+//	app.AddFileStore(s3.New(archiveConfig), "archive")
+func (a *App) AddFileStore(fs file.FileSystemProvider, name ...string) {
 	fs.UseLogger(a.Logger())
 	fs.UseMetrics(a.Metrics())
 
 	fs.Connect()
 
-	a.container.File = fs
+	if len(name) == 0 || name[0] == "" {
+		a.container.File = fs
+
+		return
+	}
+
+	if a.container.FileStores == nil {
+		a.container.FileStores = make(map[string]file.FileSystem)
+	}
+
+	a.container.FileStores[name[0]] = fs
 }
 
 // AddClickhouse initializes the clickhouse client.
@@ -114,6 +132,10 @@ func (a *App) AddKVStore(db container.KVStoreProvider) {
 	db.Connect()
 
 	a.container.KVStore = db
+
+	if a.container.Cache != nil && strings.EqualFold(a.Config.Get("CACHE_STORE"), "kvstore") {
+		a.container.Cache.UseStore(cache.NewKVStoreCache(db))
+	}
 }
 
 // AddSolr sets the Solr datasource in the app's container.
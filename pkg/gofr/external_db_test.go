@@ -184,6 +184,27 @@ func TestApp_AddS3(t *testing.T) {
 	})
 }
 
+func TestApp_AddFileStore_Named(t *testing.T) {
+	testutil.NewServerConfigs(t)
+
+	app := New()
+
+	ctrl := gomock.NewController(t)
+
+	mock := file.NewMockFileSystemProvider(ctrl)
+
+	mock.EXPECT().UseLogger(app.Logger())
+	mock.EXPECT().UseMetrics(app.Metrics())
+	mock.EXPECT().Connect()
+
+	defaultStore := app.container.File
+
+	app.AddFileStore(mock, "archive")
+
+	assert.Equal(t, mock, app.container.FileStore("archive"))
+	assert.Equal(t, defaultStore, app.container.File, "the default store is left unchanged")
+}
+
 func TestApp_AddOpenTSDB(t *testing.T) {
 	t.Run("Adding OpenTSDB", func(t *testing.T) {
 		testutil.NewServerConfigs(t)
@@ -16,7 +16,9 @@ import (
 
 	"gofr.dev/pkg/gofr/config"
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/cdc"
 	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/middleware"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/metrics"
 	"gofr.dev/pkg/gofr/migration"
@@ -147,6 +149,7 @@ func (a *App) httpServerSetup() {
 	a.httpServer.router.PathPrefix("/").Handler(handler{
 		function:  catchAllHandler,
 		container: a.container,
+		encoders:  a.httpServer.encoders,
 	})
 
 	var registeredMethods []string
@@ -262,11 +265,65 @@ func (a *App) Subscribe(topic string, handler SubscribeFunc) {
 	a.subscriptionManager.subscriptions[topic] = handler
 }
 
+// SubscribeChanges registers a handler for the changes of a datasource read by source, such as the change stream of
+// a Mongo collection, the logical replication of Postgres tables or the watch of the keys of a NATS key-value store:
+//
+//	app.SubscribeChanges(cdc.NewPostgresReplication("orders", "orders_slot", "orders_publication"),
+//		func(ctx *gofr.Context) error {
+//			var change cdc.Change
+//
+//			if err := ctx.Bind(&change); err != nil {
+//				return err
+//			}
+//			...
+//		})
+//
+// The changes are handled as the messages of the topic named after the source. The checkpoint of each change
+// handled without error is saved in the key-value store of the container, which must be added beforehand, and
+// the changes are read from the last checkpoint when the application restarts.
+func (a *App) SubscribeChanges(source cdc.Source, handler SubscribeFunc) {
+	if source == nil || handler == nil {
+		a.container.Logger.Errorf("invalid subscription: source and handler must not be nil")
+
+		return
+	}
+
+	if _, ok := a.subscriptionManager.subscriptions[source.Name()]; ok {
+		a.container.Logger.Errorf("topic %s is already subscribed", source.Name())
+
+		return
+	}
+
+	subscriber, err := cdc.NewSubscriber(a.container, source)
+	if err != nil {
+		a.container.Logger.Errorf("error subscribing to the changes of %s: %v", source.Name(), err)
+
+		return
+	}
+
+	a.subscriptionManager.subscriptions[source.Name()] = handler
+	a.subscriptionManager.subscribers[source.Name()] = subscriber
+}
+
 // UseMiddleware is a setter method for adding user defined custom middleware to GoFr's router.
 func (a *App) UseMiddleware(middlewares ...gofrHTTP.Middleware) {
 	a.httpServer.router.UseMiddleware(middlewares...)
 }
 
+// AddResponseEncoders registers encoders rendering handler results in media types other than JSON, such as
+// gofrHTTP.XMLEncoder{} or gofrHTTP.CSVEncoder{}. The media type of each response is negotiated from the
+// Accept header of the request; JSON remains the default.
+func (a *App) AddResponseEncoders(encoders ...gofrHTTP.ResponseEncoder) {
+	a.httpServer.encoders.Register(encoders...)
+}
+
+// AddCompressor registers a compressor for a content coding not built into GoFr, such as "deflate", to be used
+// when response compression is enabled with HTTP_COMPRESSION_ENABLED. The coding must also be listed in
+// HTTP_COMPRESSION_ENCODINGS. Registering a compressor for "gzip", "zstd" or "br" replaces the built-in one.
+func (a *App) AddCompressor(compressor middleware.Compressor) {
+	a.httpServer.compressors.Register(compressor)
+}
+
 // UseMiddlewareWithContainer adds a middleware that has access to the container
 // and wraps the provided handler with the middleware logic.
 //
@@ -1,6 +1,7 @@
 package gofr
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -16,9 +17,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"gofr.dev/pkg/gofr/config"
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/cdc"
 	gofrHTTP "gofr.dev/pkg/gofr/http"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/migration"
@@ -1172,6 +1175,73 @@ func TestApp_Subscribe(t *testing.T) {
 	})
 }
 
+// changeSource emits a change, then waits for the subscription to end.
+type changeSource struct{}
+
+func (changeSource) Name() string { return "orders" }
+
+func (changeSource) Read(ctx context.Context, _ *container.Container, _ string,
+	emit func(change *cdc.Change, checkpoint string) error) error {
+	if err := emit(&cdc.Change{Operation: cdc.OperationInsert, After: []byte(`{"id":1}`)}, "1"); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func TestApp_SubscribeChanges(t *testing.T) {
+	testutil.NewServerConfigs(t)
+
+	app := New()
+
+	c, mocks := container.NewMockContainer(t)
+	app.container = c
+
+	var id int
+
+	app.SubscribeChanges(changeSource{}, func(ctx *Context) error {
+		var change struct {
+			After struct {
+				ID int `json:"id"`
+			} `json:"after"`
+		}
+
+		err := ctx.Bind(&change)
+		id = change.After.ID
+
+		return err
+	})
+
+	require.Contains(t, app.subscriptionManager.subscriptions, "orders")
+
+	mocks.KVStore.EXPECT().Get(gomock.Any(), "gofr_cdc.orders").Return("", nil)
+	mocks.KVStore.EXPECT().Set(gomock.Any(), "gofr_cdc.orders", "1").Return(nil)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	err := app.subscriptionManager.handleSubscription(ctx, "orders", app.subscriptionManager.subscriptions["orders"])
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	// the topic of a source cannot be subscribed to twice.
+	app.SubscribeChanges(changeSource{}, nil)
+	app.SubscribeChanges(changeSource{}, func(*Context) error { return nil })
+
+	assert.Len(t, app.subscriptionManager.subscriptions, 1)
+
+	// the checkpoints require a key-value store.
+	app = New()
+	app.container.KVStore = nil
+
+	app.SubscribeChanges(changeSource{}, func(*Context) error { return nil })
+
+	assert.NotContains(t, app.subscriptionManager.subscriptions, "orders")
+}
+
 // Define static error for testing.
 var errHookFailed = errors.New("hook failed")
 
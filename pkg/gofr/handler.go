@@ -41,6 +41,7 @@ type handler struct {
 	function       Handler
 	container      *container.Container
 	requestTimeout time.Duration
+	encoders       *gofrHTTP.Encoders
 }
 
 type ErrorLogEntry struct {
@@ -53,7 +54,7 @@ func (el *ErrorLogEntry) PrettyPrint(writer io.Writer) {
 }
 
 func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	c := newContext(gofrHTTP.NewResponder(w, r.Method), gofrHTTP.NewRequest(r), h.container)
+	c := newContext(gofrHTTP.NewResponderWithEncoders(w, r, h.encoders), gofrHTTP.NewRequest(r), h.container)
 
 	traceID := trace.SpanFromContext(r.Context()).SpanContext().TraceID().String()
 
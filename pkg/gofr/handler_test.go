@@ -70,6 +70,27 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestHandler_ServeHTTP_NegotiatedEncoding(t *testing.T) {
+	encoders := gofrHTTP.NewEncoders()
+	encoders.Register(gofrHTTP.CSVEncoder{})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	r.Header.Set("Accept", "text/csv")
+
+	handler{
+		function: func(*Context) (any, error) {
+			return []map[string]any{{"id": 1}, {"id": 2}}, nil
+		},
+		container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		encoders:  encoders,
+	}.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, "id\n1\n2\n", w.Body.String())
+}
+
 func TestHandler_ServeHTTP_Timeout(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QualityValue is an entry of a header carrying quality values, such as Accept or Accept-Encoding.
+type QualityValue struct {
+	Value string
+	Q     float64
+}
+
+// ParseQualityValues parses the comma separated entries of a header together with their "q" parameter,
+// ordered by decreasing quality. Entries of equal quality keep the order in which the client listed them,
+// and parameters other than "q" are dropped, so "application/json;charset=utf-8" yields "application/json".
+func ParseQualityValues(header string) []QualityValue {
+	var values []QualityValue
+
+	for _, entry := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(entry, ";")
+
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		values = append(values, QualityValue{Value: value, Q: parseQuality(params)})
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Q > values[j].Q
+	})
+
+	return values
+}
+
+func parseQuality(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.TrimSpace(key) != "q" {
+			continue
+		}
+
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q < 0 {
+			return 0
+		}
+
+		return min(q, 1)
+	}
+
+	return 1
+}
+
+// mediaType returns the media type of a Content-Type value without its parameters.
+func mediaType(contentType string) string {
+	t, _, _ := strings.Cut(contentType, ";")
+
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// matchesMediaRange reports whether a media type is covered by a range such as "*/*", "text/*" or "text/csv".
+func matchesMediaRange(mediaRange, contentType string) bool {
+	if mediaRange == "*/*" || mediaRange == "*" {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(contentType, prefix+"/")
+	}
+
+	return mediaRange == contentType
+}
+
+// MatchesMediaRange reports whether the media type of a Content-Type value is covered by one of the given
+// ranges, such as "*/*", "text/*" or "application/json".
+func MatchesMediaRange(contentType string, ranges []string) bool {
+	contentType = mediaType(contentType)
+
+	for _, r := range ranges {
+		if matchesMediaRange(mediaType(r), contentType) {
+			return true
+		}
+	}
+
+	return false
+}
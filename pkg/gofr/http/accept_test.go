@@ -0,0 +1,37 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQualityValues(t *testing.T) {
+	values := ParseQualityValues("text/html;level=1, application/xml;q=0.9, */*;q=0.8, Application/JSON, gzip;q=abc")
+
+	assert.Equal(t, []QualityValue{
+		{Value: "text/html", Q: 1},
+		{Value: "application/json", Q: 1},
+		{Value: "application/xml", Q: 0.9},
+		{Value: "*/*", Q: 0.8},
+		{Value: "gzip", Q: 0},
+	}, values)
+}
+
+func TestMatchesMediaRange(t *testing.T) {
+	tests := []struct {
+		contentType string
+		ranges      []string
+		expected    bool
+	}{
+		{"application/json; charset=utf-8", []string{"application/json"}, true},
+		{"text/csv", []string{"text/*"}, true},
+		{"image/png", []string{"*/*"}, true},
+		{"image/png", []string{"text/*", "application/json"}, false},
+		{"application/jsonp", []string{"application/json"}, false},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, MatchesMediaRange(tc.contentType, tc.ranges), "TEST[%d], Failed.\n", i)
+	}
+}
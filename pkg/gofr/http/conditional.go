@@ -0,0 +1,56 @@
+package http
+
+import (
+	"strings"
+)
+
+// etagHashLength is the number of bytes of the body digest used in computed entity tags.
+const etagHashLength = 16
+
+// ETag returns the strong entity tag of a resource version, for instance "\"3\"" for the version "3".
+func ETag(version string) string {
+	return `"` + strings.ReplaceAll(version, `"`, "") + `"`
+}
+
+// IfMatch reports whether the value of an If-Match header allows a request to modify a resource whose current
+// entity tag is etag. The condition holds when the header is empty, is "*", or lists etag; as required for
+// If-Match, weak entity tags never match.
+func IfMatch(header, etag string) bool {
+	if strings.TrimSpace(header) == "" {
+		return true
+	}
+
+	return matchETags(header, etag, false)
+}
+
+// matchETags reports whether the comma separated entity tags of a conditional header include etag, comparing
+// them weakly, ignoring the W/ prefix, for If-None-Match and strongly for If-Match.
+func matchETags(header, etag string, weak bool) bool {
+	if header == "" || etag == "" {
+		return false
+	}
+
+	if weak {
+		etag = strings.TrimPrefix(etag, "W/")
+	} else if strings.HasPrefix(etag, "W/") {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag == "*" {
+			return true
+		}
+
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		if tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
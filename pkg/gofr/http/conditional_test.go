@@ -0,0 +1,94 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"3"`, ETag("3"))
+	assert.Equal(t, `"v1"`, ETag(`"v1"`))
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		header   string
+		etag     string
+		expected bool
+	}{
+		{"", `"1"`, true},
+		{"*", `"1"`, true},
+		{`"1"`, `"1"`, true},
+		{`"0", "1"`, `"1"`, true},
+		{`"2"`, `"1"`, false},
+		{`W/"1"`, `"1"`, false},
+		{`"1"`, `W/"1"`, false},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, IfMatch(tc.header, tc.etag), "TEST[%d], Failed.\n%s", i, tc.header)
+	}
+}
+
+func TestResponder_ETag(t *testing.T) {
+	body := `{"data":{"id":1}}` + "\n"
+	sum := sha256.Sum256([]byte(body))
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	tests := []struct {
+		desc         string
+		method       string
+		ifNoneMatch  string
+		declared     string
+		err          error
+		statusCode   int
+		expectedETag string
+		expectedBody string
+	}{
+		{desc: "computed for get", method: http.MethodGet, statusCode: http.StatusOK, expectedETag: etag, expectedBody: body},
+		{desc: "not modified", method: http.MethodGet, ifNoneMatch: etag, statusCode: http.StatusNotModified, expectedETag: etag},
+		{desc: "weakly matching", method: http.MethodGet, ifNoneMatch: `"x", W/` + etag, statusCode: http.StatusNotModified,
+			expectedETag: etag},
+		{desc: "modified", method: http.MethodGet, ifNoneMatch: `"x"`, statusCode: http.StatusOK, expectedETag: etag, expectedBody: body},
+		{desc: "declared version", method: http.MethodGet, declared: `"7"`, ifNoneMatch: `"7"`, statusCode: http.StatusNotModified,
+			expectedETag: `"7"`},
+		{desc: "not computed for put", method: http.MethodPut, statusCode: http.StatusOK, expectedBody: body},
+		{desc: "declared for put", method: http.MethodPut, declared: `"8"`, ifNoneMatch: `"8"`, statusCode: http.StatusOK,
+			expectedETag: `"8"`, expectedBody: body},
+		{desc: "not computed for errors", method: http.MethodGet, err: ErrorEntityNotFound{Name: "id", Value: "1"},
+			statusCode: http.StatusPartialContent, expectedBody: `{"error":{"message":"No entity found with id: 1"},"data":{"id":1}}` + "\n"},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(tc.method, "/users/1", http.NoBody)
+		req.Header.Set("If-None-Match", tc.ifNoneMatch)
+
+		w := httptest.NewRecorder()
+
+		responder := NewResponderWithEncoders(w, req, nil)
+		responder.SetETag(tc.declared)
+		responder.Respond(map[string]any{"id": 1}, tc.err)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expectedETag, w.Header().Get("ETag"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expectedBody, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_ETagSetByHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody)
+	req.Header.Set("If-None-Match", `"custom"`)
+
+	w := httptest.NewRecorder()
+	w.Header().Set("ETag", `"custom"`)
+
+	NewResponderWithEncoders(w, req, nil).Respond(map[string]any{"id": 1}, nil)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+)
+
+var errUnsupportedValue = errors.New("value cannot be encoded in the negotiated media type")
+
+// ResponseEncoder renders the body of a response in the media type it produces. The value passed to Encode is
+// the one GoFr would otherwise serialize as JSON: the response envelope holding data, error and metadata,
+// or the data of a response.Raw. An encoder returning an error, for instance because the value has no
+// representation in its media type, makes the response fall back to JSON.
+type ResponseEncoder interface {
+	ContentType() string
+	Encode(w io.Writer, v any) error
+}
+
+// Encoders holds the encoders among which the media type of a response is negotiated from the Accept header
+// of the request. JSON is always available and is used whenever the client accepts it, accepts any media type,
+// or accepts none of the registered ones. A nil *Encoders always selects JSON.
+type Encoders struct {
+	mu       sync.RWMutex
+	encoders []ResponseEncoder
+}
+
+// NewEncoders returns Encoders holding the JSON encoder.
+func NewEncoders() *Encoders {
+	return &Encoders{encoders: []ResponseEncoder{JSONEncoder{}}}
+}
+
+// Register adds encoders, replacing any previously registered encoder producing the same media type.
+func (e *Encoders) Register(encoders ...ResponseEncoder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, enc := range encoders {
+		if enc == nil {
+			continue
+		}
+
+		replaced := false
+
+		for i := range e.encoders {
+			if mediaType(e.encoders[i].ContentType()) == mediaType(enc.ContentType()) {
+				e.encoders[i] = enc
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			e.encoders = append(e.encoders, enc)
+		}
+	}
+}
+
+// Negotiate returns the encoder producing the media type most preferred by the given Accept header.
+func (e *Encoders) Negotiate(accept string) ResponseEncoder {
+	if e == nil {
+		return JSONEncoder{}
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, qv := range ParseQualityValues(accept) {
+		if qv.Q == 0 {
+			continue
+		}
+
+		for _, enc := range e.encoders {
+			if matchesMediaRange(qv.Value, mediaType(enc.ContentType())) {
+				return enc
+			}
+		}
+	}
+
+	// the JSON encoder, possibly replaced by the application, is registered first.
+	if len(e.encoders) == 0 {
+		return JSONEncoder{}
+	}
+
+	return e.encoders[0]
+}
+
+// negotiable reports whether more than one media type can be negotiated, in which case
+// responses vary by the Accept header.
+func (e *Encoders) negotiable() bool {
+	if e == nil {
+		return false
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.encoders) > 1
+}
+
+// JSONEncoder renders responses as JSON, the default media type of GoFr responses.
+type JSONEncoder struct{}
+
+func (JSONEncoder) ContentType() string { return "application/json" }
+
+func (JSONEncoder) Encode(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// member is a key of a JSON object along with its value, kept in the order of the document.
+type member struct {
+	key   string
+	value any
+}
+
+// toTree converts v to its JSON representation, so that encoders of other media types honour the json tags
+// and Marshaler implementations of the values. Objects are returned as []member to preserve the order of
+// their keys, arrays as []any, numbers as json.Number and other values as string, bool or nil.
+func toTree(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	return decodeTree(dec)
+}
+
+func decodeTree(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	if delim == '[' {
+		items := make([]any, 0)
+
+		for dec.More() {
+			item, err := decodeTree(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		_, err = dec.Token()
+
+		return items, err
+	}
+
+	members := make([]member, 0)
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := decodeTree(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		name, _ := key.(string)
+
+		members = append(members, member{key: name, value: value})
+	}
+
+	_, err = dec.Token()
+
+	return members, err
+}
+
+// responseData returns the data of a response envelope, or v itself for the data of a response.Raw.
+func responseData(v any) any {
+	if resp, ok := v.(response); ok {
+		return resp.Data
+	}
+
+	return v
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+const csvValueColumn = "value"
+
+// CSVEncoder renders the data of responses as CSV. A list of objects is written as one row per object under
+// a header holding the union of their keys, a single object as one row, and a list of scalars as a single
+// "value" column; nested values are written as JSON. Responses without tabular data, such as errors,
+// fall back to JSON.
+type CSVEncoder struct{}
+
+func (CSVEncoder) ContentType() string { return "text/csv" }
+
+func (CSVEncoder) Encode(w io.Writer, v any) error {
+	data := responseData(v)
+	if data == nil {
+		return errUnsupportedValue
+	}
+
+	tree, err := toTree(data)
+	if err != nil {
+		return err
+	}
+
+	var rows []any
+
+	switch val := tree.(type) {
+	case []any:
+		rows = val
+	case []member:
+		rows = []any{val}
+	default:
+		return errUnsupportedValue
+	}
+
+	header := csvHeader(rows)
+	cw := csv.NewWriter(w)
+
+	if err = cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err = cw.Write(csvRecord(header, row)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// csvHeader returns the keys of the objects in rows in order of first appearance.
+func csvHeader(rows []any) []string {
+	var header []string
+
+	seen := make(map[string]bool)
+
+	for _, row := range rows {
+		members, ok := row.([]member)
+		if !ok {
+			members = []member{{key: csvValueColumn}}
+		}
+
+		for _, m := range members {
+			if !seen[m.key] {
+				seen[m.key] = true
+				header = append(header, m.key)
+			}
+		}
+	}
+
+	return header
+}
+
+func csvRecord(header []string, row any) []string {
+	values := make(map[string]any)
+
+	if members, ok := row.([]member); ok {
+		for _, m := range members {
+			values[m.key] = m.value
+		}
+	} else {
+		values[csvValueColumn] = row
+	}
+
+	record := make([]string, len(header))
+
+	for i, key := range header {
+		record[i] = csvCell(values[key])
+	}
+
+	return record
+}
+
+func csvCell(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return xmlText(val)
+	}
+
+	b, _ := json.Marshal(treeValue(v))
+
+	return string(b)
+}
+
+// treeValue converts a value returned by toTree back to values encoding/json renders in the same order.
+func treeValue(v any) any {
+	switch val := v.(type) {
+	case []any:
+		out := make([]any, len(val))
+
+		for i := range val {
+			out[i] = treeValue(val[i])
+		}
+
+		return out
+	case []member:
+		return orderedObject(val)
+	}
+
+	return v
+}
+
+// orderedObject marshals the members of an object in their original order.
+type orderedObject []member
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	b := []byte{'{'}
+
+	for i, m := range o {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(treeValue(m.value))
+		if err != nil {
+			return nil, err
+		}
+
+		b = append(append(append(b, key...), ':'), value...)
+	}
+
+	return append(b, '}'), nil
+}
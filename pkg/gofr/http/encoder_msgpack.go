@@ -0,0 +1,175 @@
+package http
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+)
+
+// MessagePack format bytes, see https://github.com/msgpack/msgpack/blob/master/spec.md.
+const (
+	msgpackNil        = 0xc0
+	msgpackFalse      = 0xc2
+	msgpackTrue       = 0xc3
+	msgpackFloat64    = 0xcb
+	msgpackUint8      = 0xcc
+	msgpackUint16     = 0xcd
+	msgpackUint32     = 0xce
+	msgpackUint64     = 0xcf
+	msgpackInt8       = 0xd0
+	msgpackInt16      = 0xd1
+	msgpackInt32      = 0xd2
+	msgpackInt64      = 0xd3
+	msgpackFixStr     = 0xa0
+	msgpackStr8       = 0xd9
+	msgpackStr16      = 0xda
+	msgpackStr32      = 0xdb
+	msgpackFixArray   = 0x90
+	msgpackArray16    = 0xdc
+	msgpackArray32    = 0xdd
+	msgpackFixMap     = 0x80
+	msgpackMap16      = 0xde
+	msgpackMap32      = 0xdf
+	msgpackNegFixInt  = -32
+	msgpackMaxFixInt  = 127
+	msgpackMaxFixStr  = 31
+	msgpackMaxFixColl = 15
+)
+
+// MessagePackEncoder renders responses as MessagePack. Values are converted from their JSON representation,
+// so the json tags of structs name the keys of the encoded maps.
+type MessagePackEncoder struct{}
+
+func (MessagePackEncoder) ContentType() string { return "application/msgpack" }
+
+func (MessagePackEncoder) Encode(w io.Writer, v any) error {
+	tree, err := toTree(v)
+	if err != nil {
+		return err
+	}
+
+	var buf []byte
+
+	buf, err = appendMsgpack(buf, tree)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf)
+
+	return err
+}
+
+func appendMsgpack(b []byte, v any) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return append(b, msgpackNil), nil
+	case bool:
+		if val {
+			return append(b, msgpackTrue), nil
+		}
+
+		return append(b, msgpackFalse), nil
+	case json.Number:
+		return appendMsgpackNumber(b, val)
+	case string:
+		return appendMsgpackString(b, val), nil
+	case []any:
+		b = appendMsgpackHeader(b, len(val), msgpackFixArray, msgpackArray16, msgpackArray32)
+
+		for _, item := range val {
+			var err error
+
+			if b, err = appendMsgpack(b, item); err != nil {
+				return nil, err
+			}
+		}
+
+		return b, nil
+	case []member:
+		b = appendMsgpackHeader(b, len(val), msgpackFixMap, msgpackMap16, msgpackMap32)
+
+		for _, m := range val {
+			var err error
+
+			b = appendMsgpackString(b, m.key)
+
+			if b, err = appendMsgpack(b, m.value); err != nil {
+				return nil, err
+			}
+		}
+
+		return b, nil
+	}
+
+	return nil, errUnsupportedValue
+}
+
+func appendMsgpackNumber(b []byte, n json.Number) ([]byte, error) {
+	if i, err := n.Int64(); err == nil {
+		return appendMsgpackInt(b, i), nil
+	}
+
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return binary.BigEndian.AppendUint64(append(b, msgpackUint64), u), nil
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return nil, err
+	}
+
+	return binary.BigEndian.AppendUint64(append(b, msgpackFloat64), math.Float64bits(f)), nil
+}
+
+func appendMsgpackInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= msgpackMaxFixInt, i < 0 && i >= msgpackNegFixInt:
+		return append(b, byte(i))
+	case i >= 0 && i <= math.MaxUint8:
+		return append(b, msgpackUint8, byte(i))
+	case i >= 0 && i <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackUint16), uint16(i))
+	case i >= 0 && i <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, msgpackUint32), uint32(i))
+	case i >= 0:
+		return binary.BigEndian.AppendUint64(append(b, msgpackUint64), uint64(i))
+	case i >= math.MinInt8:
+		return append(b, msgpackInt8, byte(i))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackInt16), uint16(i))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, msgpackInt32), uint32(i))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, msgpackInt64), uint64(i))
+	}
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= msgpackMaxFixStr:
+		b = append(b, msgpackFixStr|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, msgpackStr8, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, msgpackStr16), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, msgpackStr32), uint32(n))
+	}
+
+	return append(b, s...)
+}
+
+// appendMsgpackHeader appends the header of an array or a map holding n entries.
+func appendMsgpackHeader(b []byte, n int, fix, header16, header32 byte) []byte {
+	switch {
+	case n <= msgpackMaxFixColl:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, header16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, header32), uint32(n))
+	}
+}
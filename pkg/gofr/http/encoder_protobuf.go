@@ -0,0 +1,30 @@
+package http
+
+import (
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtobufEncoder renders the data of responses holding a proto.Message in the protobuf wire format.
+// As the response envelope has no protobuf schema, only the data is written; responses holding
+// other values, or errors without data, fall back to JSON.
+type ProtobufEncoder struct{}
+
+func (ProtobufEncoder) ContentType() string { return "application/x-protobuf" }
+
+func (ProtobufEncoder) Encode(w io.Writer, v any) error {
+	msg, ok := responseData(v).(proto.Message)
+	if !ok {
+		return errUnsupportedValue
+	}
+
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
@@ -0,0 +1,243 @@
+package http
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	resTypes "gofr.dev/pkg/gofr/http/response"
+)
+
+type encodedUser struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Tags    []string `json:"tags,omitempty"`
+	Manager *struct {
+		Name string `json:"name"`
+	} `json:"manager,omitempty"`
+}
+
+func TestEncoders_Negotiate(t *testing.T) {
+	encoders := NewEncoders()
+	encoders.Register(XMLEncoder{}, CSVEncoder{}, MessagePackEncoder{})
+
+	tests := []struct {
+		desc     string
+		accept   string
+		expected ResponseEncoder
+	}{
+		{"no accept header", "", JSONEncoder{}},
+		{"any media type", "*/*", JSONEncoder{}},
+		{"exact media type", "application/xml", XMLEncoder{}},
+		{"media range", "text/*", CSVEncoder{}},
+		{"quality ordering", "application/xml;q=0.5, application/msgpack", MessagePackEncoder{}},
+		{"excluded media type", "text/csv;q=0, application/xml;q=0.1", XMLEncoder{}},
+		{"unsupported media type", "image/png", JSONEncoder{}},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, encoders.Negotiate(tc.accept), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	var nilEncoders *Encoders
+
+	assert.Equal(t, JSONEncoder{}, nilEncoders.Negotiate("application/xml"))
+}
+
+func TestEncoders_RegisterReplacesMediaType(t *testing.T) {
+	custom := customJSONEncoder{}
+
+	encoders := NewEncoders()
+	encoders.Register(custom)
+
+	assert.Equal(t, custom, encoders.Negotiate(""))
+	assert.Equal(t, custom, encoders.Negotiate("application/json; charset=utf-8"))
+}
+
+type customJSONEncoder struct{ JSONEncoder }
+
+func (customJSONEncoder) ContentType() string { return "application/json; charset=utf-8" }
+
+func TestXMLEncoder(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := XMLEncoder{}.Encode(&buf, response{
+		Data:     []encodedUser{{ID: 1, Name: "A & B", Tags: []string{"x"}}},
+		Metadata: map[string]any{"1st page": true},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<response><metadata><_1st_page>true</_1st_page></metadata>`+
+		`<data><item><id>1</id><name>A &amp; B</name><tags><item>x</item></tags></item></data></response>`, buf.String())
+}
+
+func TestMessagePackEncoder(t *testing.T) {
+	tests := []struct {
+		desc     string
+		value    any
+		expected string
+	}{
+		{"nil", nil, "c0"},
+		{"booleans", []bool{true, false}, "92c3c2"},
+		{"fixint", 5, "05"},
+		{"negative fixint", -3, "fd"},
+		{"uint8", 200, "ccc8"},
+		{"uint16", 1000, "cd03e8"},
+		{"int8", -100, "d09c"},
+		{"int32", -70000, "d2fffeee90"},
+		{"float", 1.5, "cb3ff8000000000000"},
+		{"fixstr", "hi", "a26869"},
+		{"map keeps key order", encodedUser{ID: 1, Name: "a"}, "82a2696401a46e616d65a161"},
+	}
+
+	for i, tc := range tests {
+		var buf bytes.Buffer
+
+		err := MessagePackEncoder{}.Encode(&buf, tc.value)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expected, hex.EncodeToString(buf.Bytes()), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCSVEncoder(t *testing.T) {
+	manager := &struct {
+		Name string `json:"name"`
+	}{Name: "Z"}
+
+	tests := []struct {
+		desc     string
+		value    any
+		expected string
+		err      error
+	}{
+		{
+			desc:     "list of objects",
+			value:    response{Data: []encodedUser{{ID: 1, Name: "a"}, {ID: 2, Name: "b, c", Tags: []string{"x"}, Manager: manager}}},
+			expected: "id,name,tags,manager\n1,a,,\n2,\"b, c\",\"[\"\"x\"\"]\",\"{\"\"name\"\":\"\"Z\"\"}\"\n",
+		},
+		{
+			desc:     "single object",
+			value:    response{Data: map[string]any{"count": 3}},
+			expected: "count\n3\n",
+		},
+		{
+			desc:     "list of scalars",
+			value:    []string{"a", "b"},
+			expected: "value\na\nb\n",
+		},
+		{
+			desc:  "error without data",
+			value: response{Error: map[string]any{"message": "not found"}},
+			err:   errUnsupportedValue,
+		},
+		{
+			desc:  "scalar data",
+			value: response{Data: "hello"},
+			err:   errUnsupportedValue,
+		},
+	}
+
+	for i, tc := range tests {
+		var buf bytes.Buffer
+
+		err := CSVEncoder{}.Encode(&buf, tc.value)
+
+		require.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expected, buf.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestProtobufEncoder(t *testing.T) {
+	msg := wrapperspb.String("hello")
+
+	var buf bytes.Buffer
+
+	require.NoError(t, ProtobufEncoder{}.Encode(&buf, response{Data: msg}))
+
+	decoded := &wrapperspb.StringValue{}
+
+	require.NoError(t, proto.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal(t, "hello", decoded.GetValue())
+
+	require.ErrorIs(t, ProtobufEncoder{}.Encode(&buf, response{Data: "hello"}), errUnsupportedValue)
+}
+
+func TestResponder_Negotiation(t *testing.T) {
+	encoders := NewEncoders()
+	encoders.Register(XMLEncoder{}, CSVEncoder{})
+
+	tests := []struct {
+		desc         string
+		accept       string
+		data         any
+		err          error
+		contentType  string
+		expectedBody string
+	}{
+		{
+			desc:         "json by default",
+			data:         map[string]any{"id": 1},
+			contentType:  "application/json",
+			expectedBody: `{"data":{"id":1}}` + "\n",
+		},
+		{
+			desc:         "negotiated media type",
+			accept:       "text/csv",
+			data:         []encodedUser{{ID: 1, Name: "a"}},
+			contentType:  "text/csv",
+			expectedBody: "id,name\n1,a\n",
+		},
+		{
+			desc:         "raw data",
+			accept:       "application/xml",
+			data:         resTypes.Raw{Data: map[string]any{"id": 1}},
+			contentType:  "application/xml",
+			expectedBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n<response><id>1</id></response>",
+		},
+		{
+			desc:         "fallback to json when the value cannot be encoded",
+			accept:       "text/csv",
+			err:          ErrorEntityNotFound{Name: "id", Value: "2"},
+			contentType:  "application/json",
+			expectedBody: `{"error":{"message":"No entity found with id: 2"}}` + "\n",
+		},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.Header.Set("Accept", tc.accept)
+
+		w := httptest.NewRecorder()
+
+		NewResponderWithEncoders(w, req, encoders).Respond(tc.data, tc.err)
+
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expectedBody, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "Accept", w.Header().Get("Vary"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_NegotiationKeepsCustomContentType(t *testing.T) {
+	encoders := NewEncoders()
+	encoders.Register(XMLEncoder{})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+	req.Header.Set("Accept", "application/xml")
+
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+
+	NewResponderWithEncoders(w, req, encoders).Respond(map[string]any{"id": 1}, nil)
+
+	assert.Equal(t, "application/vnd.api+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"data":{"id":1}}`, w.Body.String())
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"unicode"
+)
+
+const (
+	xmlRootElement = "response"
+	xmlItemElement = "item"
+)
+
+// XMLEncoder renders responses as XML. Values are converted from their JSON representation: objects become
+// elements named after their keys, array entries become <item> elements and the document is wrapped in
+// a <response> element, so {"data":{"id":1}} is rendered as <response><data><id>1</id></data></response>.
+type XMLEncoder struct{}
+
+func (XMLEncoder) ContentType() string { return "application/xml" }
+
+func (XMLEncoder) Encode(w io.Writer, v any) error {
+	tree, err := toTree(v)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+
+	if err = encodeXMLElement(enc, xmlRootElement, tree); err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+func encodeXMLElement(enc *xml.Encoder, name string, value any) error {
+	start := xml.StartElement{Name: xml.Name{Local: xmlName(name)}}
+
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch val := value.(type) {
+	case []member:
+		for _, m := range val {
+			if err := encodeXMLElement(enc, m.key, m.value); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if err := encodeXMLElement(enc, xmlItemElement, item); err != nil {
+				return err
+			}
+		}
+	case nil:
+	default:
+		if err := enc.EncodeToken(xml.CharData(xmlText(val))); err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+func xmlText(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		if val {
+			return "true"
+		}
+
+		return "false"
+	}
+
+	return ""
+}
+
+// xmlName turns a JSON key into a valid XML element name by replacing the characters
+// not allowed in names with '_'.
+func xmlName(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+
+		return '_'
+	}, key)
+
+	if first := rune(name[0]); !unicode.IsLetter(first) && first != '_' {
+		name = "_" + name
+	}
+
+	return name
+}
@@ -163,6 +163,38 @@ func (ErrorPanicRecovery) LogLevel() logging.Level {
 	return logging.ERROR
 }
 
+// ErrorPreconditionFailed represents an error for a conditional request, such as one carrying an If-Match header,
+// whose condition does not hold for the current version of the resource.
+type ErrorPreconditionFailed struct{}
+
+func (ErrorPreconditionFailed) Error() string {
+	return "resource has been modified"
+}
+
+func (ErrorPreconditionFailed) StatusCode() int {
+	return http.StatusPreconditionFailed
+}
+
+func (ErrorPreconditionFailed) LogLevel() logging.Level {
+	return logging.INFO
+}
+
+// ErrorPreconditionRequired represents an error for a request modifying a versioned resource without stating
+// the version it was based on.
+type ErrorPreconditionRequired struct{}
+
+func (ErrorPreconditionRequired) Error() string {
+	return "request must be conditional, send the version of the resource in the If-Match header"
+}
+
+func (ErrorPreconditionRequired) StatusCode() int {
+	return http.StatusPreconditionRequired
+}
+
+func (ErrorPreconditionRequired) LogLevel() logging.Level {
+	return logging.INFO
+}
+
 // validate the errors satisfy the underlying interfaces they depend on.
 var (
 	_ StatusCodeResponder = ErrorEntityNotFound{}
@@ -173,6 +205,8 @@ var (
 	_ StatusCodeResponder = ErrorRequestTimeout{}
 	_ StatusCodeResponder = ErrorPanicRecovery{}
 	_ StatusCodeResponder = ErrorServiceUnavailable{}
+	_ StatusCodeResponder = ErrorPreconditionFailed{}
+	_ StatusCodeResponder = ErrorPreconditionRequired{}
 	_ StatusCodeResponder = ErrorClientClosedRequest{}
 
 	_ logging.LogLevelResponder = ErrorClientClosedRequest{}
@@ -184,4 +218,6 @@ var (
 	_ logging.LogLevelResponder = ErrorRequestTimeout{}
 	_ logging.LogLevelResponder = ErrorPanicRecovery{}
 	_ logging.LogLevelResponder = ErrorServiceUnavailable{}
+	_ logging.LogLevelResponder = ErrorPreconditionFailed{}
+	_ logging.LogLevelResponder = ErrorPreconditionRequired{}
 )
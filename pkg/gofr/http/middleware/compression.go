@@ -0,0 +1,371 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gorilla/websocket"
+	"github.com/klauspost/compress/zstd"
+
+	gofrHttp "gofr.dev/pkg/gofr/http"
+)
+
+const defaultCompressionMinSize = 1024
+
+// Compressor compresses response bodies for a content coding of the Accept-Encoding header, such as "gzip".
+// Codings not built into GoFr, such as "deflate", can be supported by registering a Compressor wrapping a
+// third-party implementation.
+type Compressor interface {
+	Encoding() string
+	NewWriter(w io.Writer) io.WriteCloser
+}
+
+// CompressionConfig configures the compression of response bodies.
+type CompressionConfig struct {
+	Enabled bool
+	// Encodings lists the content codings the server may use, from the most to the least preferred.
+	Encodings []string
+	// MinSize is the size in bytes below which bodies are sent uncompressed.
+	MinSize int
+	// ContentTypes lists the media types, or media ranges such as "text/*", of the bodies to compress.
+	ContentTypes []string
+}
+
+func defaultCompressionEncodings() []string {
+	return []string{"zstd", "br", "gzip"}
+}
+
+func defaultCompressionContentTypes() []string {
+	return []string{
+		"text/*", "application/json", "application/xml", "application/javascript",
+		"application/msgpack", "application/x-ndjson", "image/svg+xml",
+	}
+}
+
+// Compressors holds the compressors available to the Compression middleware, keyed by content coding.
+type Compressors struct {
+	mu          sync.RWMutex
+	compressors map[string]Compressor
+}
+
+// NewCompressors returns Compressors holding the built-in gzip, zstd and brotli compressors.
+func NewCompressors() *Compressors {
+	c := &Compressors{compressors: make(map[string]Compressor)}
+	c.Register(newGzipCompressor(), newZstdCompressor(), newBrotliCompressor())
+
+	return c
+}
+
+// Register adds compressors, replacing any compressor previously registered for the same content coding.
+func (c *Compressors) Register(compressors ...Compressor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, comp := range compressors {
+		if comp != nil {
+			c.compressors[strings.ToLower(comp.Encoding())] = comp
+		}
+	}
+}
+
+func (c *Compressors) get(encoding string) Compressor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.compressors[encoding]
+}
+
+// Compression compresses response bodies with the content coding negotiated from the Accept-Encoding header
+// of the request. Bodies smaller than the configured minimum size, bodies whose Content-Type is not allowed,
+// and responses already carrying a Content-Encoding are sent as they are.
+func Compression(cfg CompressionConfig, compressors *Compressors) func(inner http.Handler) http.Handler {
+	if cfg.MinSize < 0 {
+		cfg.MinSize = 0
+	}
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			compressor := negotiateCompressor(r.Header.Get("Accept-Encoding"), cfg.Encodings, compressors)
+			if compressor == nil || r.Method == http.MethodHead {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressResponseWriter{ResponseWriter: w, cfg: &cfg, compressor: compressor}
+			defer cw.close()
+
+			inner.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateCompressor returns the compressor of the content coding most preferred by the client, preferring
+// the server's order among codings of equal quality, or nil if the body must not be compressed.
+func negotiateCompressor(acceptEncoding string, encodings []string, compressors *Compressors) Compressor {
+	if acceptEncoding == "" || compressors == nil {
+		return nil
+	}
+
+	accepted := gofrHttp.ParseQualityValues(acceptEncoding)
+
+	var (
+		best  Compressor
+		bestQ float64
+	)
+
+	for _, encoding := range encodings {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+
+		q := encodingQuality(accepted, encoding)
+		if q <= bestQ {
+			continue
+		}
+
+		if c := compressors.get(encoding); c != nil {
+			best, bestQ = c, q
+		}
+	}
+
+	return best
+}
+
+// encodingQuality returns the quality the client gave to a content coding, either explicitly or through "*".
+func encodingQuality(accepted []gofrHttp.QualityValue, encoding string) float64 {
+	wildcard := 0.0
+
+	for _, qv := range accepted {
+		switch qv.Value {
+		case encoding:
+			return qv.Q
+		case "*":
+			wildcard = qv.Q
+		}
+	}
+
+	return wildcard
+}
+
+// compressResponseWriter buffers the start of a body until it can decide whether to compress it, which
+// happens once the body reaches the minimum size, when it is flushed, or when the handler returns.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	cfg        *CompressionConfig
+	compressor Compressor
+
+	status  int
+	buf     []byte
+	decided bool
+	writer  io.WriteCloser
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.decided || w.status != 0 {
+		return
+	}
+
+	w.status = status
+
+	// informational responses and responses without a body are not buffered.
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		_ = w.decide(false)
+	}
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.writer != nil {
+			return w.writer.Write(p)
+		}
+
+		return w.ResponseWriter.Write(p)
+	}
+
+	if !w.compressible() {
+		_ = w.decide(false)
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+
+	if len(w.buf) >= w.cfg.MinSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (w *compressResponseWriter) compressible() bool {
+	h := w.Header()
+
+	return h.Get("Content-Encoding") == "" && h.Get("Content-Type") != "" &&
+		gofrHttp.MatchesMediaRange(h.Get("Content-Type"), w.cfg.ContentTypes)
+}
+
+// decide writes the header and the buffered body, compressing them if compress is true.
+func (w *compressResponseWriter) decide(compress bool) error {
+	w.decided = true
+
+	if compress {
+		w.Header().Set("Content-Encoding", w.compressor.Encoding())
+		w.Header().Del("Content-Length")
+
+		// the compressed body is a different representation, so its entity tag can only match weakly.
+		if etag := w.Header().Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			w.Header().Set("ETag", "W/"+etag)
+		}
+
+		w.writer = w.compressor.NewWriter(w.ResponseWriter)
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	buf := w.buf
+	w.buf = nil
+
+	if w.writer != nil {
+		_, err := w.writer.Write(buf)
+		return err
+	}
+
+	_, err := w.ResponseWriter.Write(buf)
+
+	return err
+}
+
+// Flush sends the buffered body, compressed only if it already reached the minimum size.
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(len(w.buf) >= w.cfg.MinSize && w.compressible())
+	}
+
+	if f, ok := w.writer.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface.
+func (w *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+
+	return nil, nil, fmt.Errorf("%w: cannot hijack connection", errHijackNotSupported)
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for use by http.ResponseController.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressResponseWriter) close() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+
+	if w.writer != nil {
+		_ = w.writer.Close()
+	}
+}
+
+// pooledCompressor reuses the writers of a compressor, as creating them allocates their internal state.
+type pooledCompressor struct {
+	encoding string
+	pool     sync.Pool
+	reset    func(writer io.WriteCloser, w io.Writer)
+}
+
+func (c *pooledCompressor) Encoding() string { return c.encoding }
+
+func (c *pooledCompressor) NewWriter(w io.Writer) io.WriteCloser {
+	writer, _ := c.pool.Get().(io.WriteCloser)
+	c.reset(writer, w)
+
+	return &pooledWriter{WriteCloser: writer, release: func() { c.pool.Put(writer) }}
+}
+
+type pooledWriter struct {
+	io.WriteCloser
+	release func()
+}
+
+func (w *pooledWriter) Flush() error {
+	if f, ok := w.WriteCloser.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+func (w *pooledWriter) Close() error {
+	err := w.WriteCloser.Close()
+	w.release()
+
+	return err
+}
+
+func newGzipCompressor() Compressor {
+	return &pooledCompressor{
+		encoding: "gzip",
+		pool:     sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }},
+		reset: func(writer io.WriteCloser, w io.Writer) {
+			if gw, ok := writer.(*gzip.Writer); ok {
+				gw.Reset(w)
+			}
+		},
+	}
+}
+
+func newZstdCompressor() Compressor {
+	return &pooledCompressor{
+		encoding: "zstd",
+		pool: sync.Pool{New: func() any {
+			// options are valid, so creating the encoder cannot fail.
+			enc, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+
+			return enc
+		}},
+		reset: func(writer io.WriteCloser, w io.Writer) {
+			if enc, ok := writer.(*zstd.Encoder); ok {
+				enc.Reset(w)
+			}
+		},
+	}
+}
+
+func newBrotliCompressor() Compressor {
+	return &pooledCompressor{
+		encoding: "br",
+		pool:     sync.Pool{New: func() any { return brotli.NewWriterLevel(io.Discard, brotli.DefaultCompression) }},
+		reset: func(writer io.WriteCloser, w io.Writer) {
+			if bw, ok := writer.(*brotli.Writer); ok {
+				bw.Reset(w)
+			}
+		},
+	}
+}
@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/config"
+)
+
+func testCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		Enabled:      true,
+		Encodings:    defaultCompressionEncodings(),
+		MinSize:      16,
+		ContentTypes: defaultCompressionContentTypes(),
+	}
+}
+
+func bodyHandler(contentType, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, body)
+	})
+}
+
+func decompress(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+
+	var (
+		r   io.Reader
+		err error
+	)
+
+	switch encoding {
+	case "gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "zstd":
+		var dec *zstd.Decoder
+
+		dec, err = zstd.NewReader(bytes.NewReader(body))
+		r = dec
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return string(body)
+	}
+
+	require.NoError(t, err)
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func TestCompression(t *testing.T) {
+	largeBody := strings.Repeat(`{"name":"gofr"}`, 10)
+
+	tests := []struct {
+		desc           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		encoding       string
+	}{
+		{"gzip", "gzip", "application/json", largeBody, "gzip"},
+		{"server preference among equal qualities", "gzip, zstd", "application/json", largeBody, "zstd"},
+		{"client quality", "gzip, zstd;q=0.5", "application/json", largeBody, "gzip"},
+		{"wildcard", "*", "text/plain; charset=utf-8", largeBody, "zstd"},
+		{"excluded coding", "zstd;q=0, *", "application/json", largeBody, "br"},
+		{"brotli", "br, gzip;q=0.5", "application/json", largeBody, "br"},
+		{"unsupported coding", "deflate", "application/json", largeBody, ""},
+		{"no accept-encoding", "", "application/json", largeBody, ""},
+		{"below minimum size", "gzip", "application/json", `{"a":1}`, ""},
+		{"content type not allowed", "gzip", "image/png", largeBody, ""},
+		{"content type not set", "gzip", "", largeBody, ""},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+
+		w := httptest.NewRecorder()
+
+		Compression(testCompressionConfig(), NewCompressors())(bodyHandler(tc.contentType, tc.body)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.encoding, w.Header().Get("Content-Encoding"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, decompress(t, tc.encoding, w.Body.Bytes()), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCompression_KeepsExistingContentEncoding(t *testing.T) {
+	body := strings.Repeat("a", 64)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Encoding", "identity")
+		_, _ = io.WriteString(w, body)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	w := httptest.NewRecorder()
+
+	Compression(testCompressionConfig(), NewCompressors())(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, "identity", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, w.Body.String())
+}
+
+func TestCompression_WeakensETag(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"abc"`)
+		_, _ = io.WriteString(w, strings.Repeat("a", 64))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	w := httptest.NewRecorder()
+
+	Compression(testCompressionConfig(), NewCompressors())(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, `W/"abc"`, w.Header().Get("ETag"))
+}
+
+func TestCompression_Flush(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		_, _ = io.WriteString(w, strings.Repeat("data: 1\n\n", 4))
+		w.(http.Flusher).Flush()
+		_, _ = io.WriteString(w, "data: 2\n\n")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	w := httptest.NewRecorder()
+
+	Compression(testCompressionConfig(), NewCompressors())(handler).ServeHTTP(w, req)
+
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, strings.Repeat("data: 1\n\n", 4)+"data: 2\n\n", decompress(t, "gzip", w.Body.Bytes()))
+}
+
+type upperCompressor struct{}
+
+func (upperCompressor) Encoding() string { return "deflate" }
+
+func (upperCompressor) NewWriter(w io.Writer) io.WriteCloser { return upperWriter{w} }
+
+type upperWriter struct{ io.Writer }
+
+func (u upperWriter) Write(p []byte) (int, error) { return u.Writer.Write(bytes.ToUpper(p)) }
+
+func (upperWriter) Close() error { return nil }
+
+func TestCompression_RegisteredCompressor(t *testing.T) {
+	compressors := NewCompressors()
+	compressors.Register(upperCompressor{})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip;q=0.5, deflate")
+
+	w := httptest.NewRecorder()
+
+	cfg := testCompressionConfig()
+	cfg.Encodings = append(cfg.Encodings, "deflate")
+
+	Compression(cfg, compressors)(bodyHandler("text/plain", strings.Repeat("ab", 10))).ServeHTTP(w, req)
+
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, strings.Repeat("AB", 10), w.Body.String())
+}
+
+func TestGetCompressionConfig(t *testing.T) {
+	cfg := GetConfigs(config.NewMockConfig(map[string]string{
+		"HTTP_COMPRESSION_ENABLED":       "true",
+		"HTTP_COMPRESSION_ENCODINGS":     "gzip, zstd",
+		"HTTP_COMPRESSION_MIN_SIZE":      "256",
+		"HTTP_COMPRESSION_CONTENT_TYPES": "application/json,text/*",
+	})).Compression
+
+	assert.Equal(t, CompressionConfig{
+		Enabled:      true,
+		Encodings:    []string{"gzip", "zstd"},
+		MinSize:      256,
+		ContentTypes: []string{"application/json", "text/*"},
+	}, cfg)
+
+	defaults := GetConfigs(config.NewMockConfig(map[string]string{"HTTP_COMPRESSION_MIN_SIZE": "-1"})).Compression
+
+	assert.Equal(t, CompressionConfig{
+		Encodings:    defaultCompressionEncodings(),
+		MinSize:      defaultCompressionMinSize,
+		ContentTypes: defaultCompressionContentTypes(),
+	}, defaults)
+}
@@ -14,6 +14,7 @@ import (
 type Config struct {
 	CorsHeaders map[string]string
 	LogProbes   LogProbes
+	Compression CompressionConfig
 }
 
 type LogProbes struct {
@@ -50,9 +51,47 @@ func GetConfigs(c config.Config) Config {
 		middlewareConfigs.LogProbes.Disabled = value
 	}
 
+	middlewareConfigs.Compression = getCompressionConfig(c)
+
 	return middlewareConfigs
 }
 
+func getCompressionConfig(c config.Config) CompressionConfig {
+	cfg := CompressionConfig{
+		Encodings:    splitConfigList(c.Get("HTTP_COMPRESSION_ENCODINGS")),
+		ContentTypes: splitConfigList(c.Get("HTTP_COMPRESSION_CONTENT_TYPES")),
+		MinSize:      defaultCompressionMinSize,
+	}
+
+	cfg.Enabled, _ = strconv.ParseBool(c.GetOrDefault("HTTP_COMPRESSION_ENABLED", "false"))
+
+	if len(cfg.Encodings) == 0 {
+		cfg.Encodings = defaultCompressionEncodings()
+	}
+
+	if len(cfg.ContentTypes) == 0 {
+		cfg.ContentTypes = defaultCompressionContentTypes()
+	}
+
+	if size, err := strconv.Atoi(c.Get("HTTP_COMPRESSION_MIN_SIZE")); err == nil && size >= 0 {
+		cfg.MinSize = size
+	}
+
+	return cfg
+}
+
+func splitConfigList(value string) []string {
+	var list []string
+
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
+
 func convertHeaderNames(header string) string {
 	words := strings.Split(header, "_")
 	titleCaser := cases.Title(language.Und)
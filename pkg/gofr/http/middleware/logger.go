@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/trace"
+
+	"gofr.dev/pkg/gofr/logging"
 )
 
 var errHijackNotSupported = errors.New("response writer does not support hijacking")
@@ -65,6 +67,15 @@ func (rl *RequestLog) PrettyPrint(writer io.Writer) {
 		"%8d\u001B[38;5;8mµs\u001B[0m %s %s \n", rl.TraceID, colorForStatusCode(rl.Response), rl.Response, rl.ResponseTime, rl.Method, rl.URI)
 }
 
+// Redact returns a copy of the log with the sensitive query parameters of the URI redacted.
+func (rl *RequestLog) Redact(r *logging.Redactor) any {
+	redacted := *rl
+	redacted.URI = r.URL(rl.URI)
+	redacted.UserAgent = r.String(rl.UserAgent)
+
+	return &redacted
+}
+
 func colorForStatusCode(status int) int {
 	const (
 		blue   = 34
@@ -108,6 +119,9 @@ func Logging(probes LogProbes, logger logger) func(inner http.Handler) http.Hand
 				return
 			}
 
+			// the fields added to the request by the handler are written on its request log.
+			r = r.WithContext(logging.ContextWithFields(r.Context()))
+
 			defer handleRequestLog(srw, r, start, traceID, spanID, logger)
 
 			inner.ServeHTTP(srw, r)
@@ -129,6 +143,8 @@ func handleRequestLog(srw *StatusResponseWriter, r *http.Request, start time.Tim
 	}
 
 	if logger != nil {
+		logger = logging.LoggerWithFields(r.Context(), logger)
+
 		if srw.status >= http.StatusInternalServerError {
 			logger.Error(l)
 		} else {
@@ -61,6 +61,26 @@ func Test_LoggingMiddleware(t *testing.T) {
 	assert.Contains(t, logs, "GET    200")
 }
 
+func Test_LoggingMiddlewareRequestFields(t *testing.T) {
+	logs := testutil.StdoutOutputForFunc(func() {
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://dummy", http.NoBody)
+
+		rr := httptest.NewRecorder()
+		logger := logging.NewMockLogger(logging.DEBUG)
+
+		handler := Logging(LogProbes{}, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logging.NewContextLogger(r.Context(), logger).AddLogFields("user_id", "u-1")
+
+			testHandler(w, r)
+		}))
+
+		handler.ServeHTTP(rr, req)
+	})
+
+	assert.Contains(t, logs, "GET    200")
+	assert.Contains(t, logs, "user_id=u-1")
+}
+
 func Test_LoggingMiddlewareProbesEnable(t *testing.T) {
 	logs := testutil.StdoutOutputForFunc(func() {
 		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://dummy/.well-known/alive", http.NoBody)
@@ -211,6 +231,16 @@ func TestRequestLog_PrettyPrint(t *testing.T) {
 		"     1432\u001B[38;5;8mµs\u001B[0m GET /test \n", w.String())
 }
 
+func TestRequestLog_Redact(t *testing.T) {
+	rl := &RequestLog{Method: "GET", URI: "/login?user=bob&password=p@ss", Response: 200}
+
+	redacted, ok := rl.Redact(logging.DefaultRedactor()).(*RequestLog)
+	require.True(t, ok)
+
+	assert.Equal(t, "/login?user=bob&password=[REDACTED]", redacted.URI)
+	assert.Equal(t, "/login?user=bob&password=p@ss", rl.URI)
+}
+
 func Test_ColorForStatusCode(t *testing.T) {
 	testCases := []struct {
 		desc   string
@@ -44,6 +44,11 @@ func (r *Request) Param(key string) string {
 	return r.req.URL.Query().Get(key)
 }
 
+// Header returns the value of the request header with the given key.
+func (r *Request) Header(key string) string {
+	return r.req.Header.Get(key)
+}
+
 // Context returns the context of the request.
 func (r *Request) Context() context.Context {
 	return r.req.Context()
@@ -60,7 +65,7 @@ func (r *Request) Bind(i any) error {
 	contentType := strings.Split(v, ";")[0]
 
 	switch contentType {
-	case "application/json":
+	case "application/json", "application/merge-patch+json", "application/json-patch+json":
 		body, err := r.body()
 		if err != nil {
 			return err
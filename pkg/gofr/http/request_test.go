@@ -42,6 +42,18 @@ func TestBind(t *testing.T) {
 	}
 }
 
+func TestBind_JSONPatch(t *testing.T) {
+	for _, contentType := range []string{"application/merge-patch+json", "application/json-patch+json; charset=utf-8"} {
+		r := httptest.NewRequest(http.MethodPatch, "/abc", strings.NewReader(`[{"op": "remove", "path": "/a"}]`))
+		r.Header.Set("Content-Type", contentType)
+
+		var patch []map[string]string
+
+		require.NoError(t, NewRequest(r).Bind(&patch), contentType)
+		assert.Equal(t, []map[string]string{{"op": "remove", "path": "/a"}}, patch, contentType)
+	}
+}
+
 func TestBind_FileSuccess(t *testing.T) {
 	r := NewRequest(generateMultipartRequestZip(t))
 	x := struct {
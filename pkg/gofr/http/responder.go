@@ -1,6 +1,9 @@
 package http
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -18,10 +21,25 @@ func NewResponder(w http.ResponseWriter, method string) *Responder {
 	return &Responder{w: w, method: method}
 }
 
+// NewResponderWithEncoders creates a Responder rendering responses in the media type negotiated
+// between the Accept header of the request and the given encoders.
+func NewResponderWithEncoders(w http.ResponseWriter, r *http.Request, encoders *Encoders) *Responder {
+	return &Responder{w: w, method: r.Method, header: r.Header, encoders: encoders}
+}
+
 // Responder encapsulates an http.ResponseWriter and is responsible for crafting structured responses.
 type Responder struct {
-	w      http.ResponseWriter
-	method string
+	w        http.ResponseWriter
+	method   string
+	header   http.Header
+	encoders *Encoders
+	etag     string
+}
+
+// SetETag sets the entity tag of the resource the response represents, as returned by ETag, replacing the one
+// computed from the body of GET responses.
+func (r *Responder) SetETag(etag string) {
+	r.etag = etag
 }
 
 // Respond sends a response with the given data and handles potential errors, setting appropriate
@@ -49,13 +67,77 @@ func (r Responder) Respond(data any, err error) {
 		resp = response{Data: data, Error: errorObj}
 	}
 
+	contentType, body := r.encode(resp)
+
 	if r.w.Header().Get("Content-Type") == "" {
-		r.w.Header().Set("Content-Type", "application/json")
+		r.w.Header().Set("Content-Type", contentType)
+	}
+
+	r.writeBody(statusCode, body)
+}
+
+// encode serializes the response in the media type negotiated from the Accept header, falling back to JSON
+// when the handler already set the Content-Type or when the negotiated encoder cannot encode the response.
+func (r Responder) encode(resp any) (contentType string, body []byte) {
+	var buf bytes.Buffer
+
+	if r.w.Header().Get("Content-Type") == "" {
+		if r.encoders.negotiable() {
+			r.w.Header().Add("Vary", "Accept")
+		}
+
+		enc := r.encoders.Negotiate(r.header.Get("Accept"))
+		if err := enc.Encode(&buf, resp); err == nil {
+			return enc.ContentType(), buf.Bytes()
+		}
+
+		buf.Reset()
+	}
+
+	_ = json.NewEncoder(&buf).Encode(resp)
+
+	return JSONEncoder{}.ContentType(), buf.Bytes()
+}
+
+// writeBody writes the status code and body of a response along with its entity tag, answering conditional
+// GET requests whose If-None-Match header matches the entity tag with 304 Not Modified.
+func (r Responder) writeBody(statusCode int, body []byte) {
+	if etag := r.entityTag(statusCode, body); etag != "" {
+		r.w.Header().Set("ETag", etag)
+
+		if r.isCacheableRead(statusCode) && matchETags(r.header.Get("If-None-Match"), etag, true) {
+			r.w.WriteHeader(http.StatusNotModified)
+			return
+		}
 	}
 
 	r.w.WriteHeader(statusCode)
 
-	_ = json.NewEncoder(r.w).Encode(resp)
+	_, _ = r.w.Write(body)
+}
+
+// entityTag returns the entity tag set by the handler, either as a header or through SetETag,
+// or a strong entity tag computed from the body of successful GET responses.
+func (r Responder) entityTag(statusCode int, body []byte) string {
+	if etag := r.w.Header().Get("ETag"); etag != "" {
+		return etag
+	}
+
+	if r.etag != "" {
+		return r.etag
+	}
+
+	if !r.isCacheableRead(statusCode) {
+		return ""
+	}
+
+	sum := sha256.Sum256(body)
+
+	return ETag(hex.EncodeToString(sum[:etagHashLength]))
+}
+
+func (r Responder) isCacheableRead(statusCode int) bool {
+	return statusCode == http.StatusOK && (r.method == http.MethodGet || r.method == http.MethodHead)
 }
 
 // handleSpecialResponseTypes handles special response types that bypass JSON encoding.
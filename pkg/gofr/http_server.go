@@ -22,6 +22,8 @@ type httpServer struct {
 	certFile    string
 	keyFile     string
 	staticFiles map[string]string
+	encoders    *gofrHTTP.Encoders
+	compressors *middleware.Compressors
 }
 
 var (
@@ -32,6 +34,7 @@ var (
 func newHTTPServer(c *container.Container, port int, middlewareConfigs middleware.Config) *httpServer {
 	r := gofrHTTP.NewRouter()
 	wsManager := websocket.New()
+	compressors := middleware.NewCompressors()
 
 	r.Use(
 		middleware.Tracer,
@@ -40,10 +43,16 @@ func newHTTPServer(c *container.Container, port int, middlewareConfigs middlewar
 		middleware.Metrics(c.Metrics()),
 	)
 
+	if middlewareConfigs.Compression.Enabled {
+		r.Use(middleware.Compression(middlewareConfigs.Compression, compressors))
+	}
+
 	return &httpServer{
-		router: r,
-		port:   port,
-		ws:     wsManager,
+		router:      r,
+		port:        port,
+		ws:          wsManager,
+		encoders:    gofrHTTP.NewEncoders(),
+		compressors: compressors,
 	}
 }
 
@@ -2,6 +2,8 @@ package logging
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"go.opentelemetry.io/otel/trace"
 )
@@ -10,10 +12,15 @@ import (
 // trace ID (if present in the context) into log messages automatically.
 //
 // It is intended for use within request-scoped contexts where OpenTelemetry
-// trace information is available.
+// trace information is available. The fields added to the request with
+// AddLogFields are written on every line of the ContextLoggers of the request.
 type ContextLogger struct {
 	base    Logger
 	traceID string
+	// request holds the fields of the request, shared with the ContextLoggers created from the same context.
+	request *requestFields
+	// fields are the fields of a child created by With.
+	fields []any
 }
 
 // NewContextLogger creates a new ContextLogger that wraps the provided base logger
@@ -28,7 +35,12 @@ func NewContextLogger(ctx context.Context, base Logger) *ContextLogger {
 		traceID = sc.TraceID().String()
 	}
 
-	return &ContextLogger{base: base, traceID: traceID}
+	request, ok := ctx.Value(requestFieldsKey{}).(*requestFields)
+	if !ok {
+		request = &requestFields{}
+	}
+
+	return &ContextLogger{base: base, traceID: traceID, request: request}
 }
 
 // withTraceInfo appends the trace ID from the context (if available).
@@ -41,26 +53,94 @@ func (l *ContextLogger) withTraceInfo(args ...any) []any {
 	return args
 }
 
-func (l *ContextLogger) logWithTraceID(lf func(args ...any), args ...any) {
-	lf(l.withTraceInfo(args...)...)
+// logger returns the logger writing the lines of l, which holds the fields of the request and of l, followed by the
+// given ones, when the base logger implements FieldLogger. Otherwise, the base logger is returned along with the
+// formatted fields, to be appended to the message.
+func (l *ContextLogger) logger(fields []any) (Logger, string) {
+	all := append(append(l.request.get(), l.fields...), fields...)
+	if len(all) == 0 {
+		return l.base, ""
+	}
+
+	if base, ok := l.base.(FieldLogger); ok {
+		return base.With(all...), ""
+	}
+
+	return l.base, formatFields(all)
 }
 
-func (l *ContextLogger) logWithTraceIDf(lf func(f string, args ...any), f string, args ...any) {
-	lf(f, l.withTraceInfo(args...)...)
+func (l *ContextLogger) logWithTraceID(lf func(Logger, ...any), args ...any) {
+	base, fields := l.logger(nil)
+	if fields != "" {
+		args = append(args, fields)
+	}
+
+	lf(base, l.withTraceInfo(args...)...)
+}
+
+func (l *ContextLogger) logWithTraceIDf(lf func(Logger, string, ...any), f string, args ...any) {
+	base, fields := l.logger(nil)
+	if fields != "" {
+		f += " " + strings.ReplaceAll(fields, "%", "%%")
+	}
+
+	lf(base, f, l.withTraceInfo(args...)...)
 }
 
-func (l *ContextLogger) Debug(args ...any)             { l.logWithTraceID(l.base.Debug, args...) }
-func (l *ContextLogger) Debugf(f string, args ...any)  { l.logWithTraceIDf(l.base.Debugf, f, args...) }
-func (l *ContextLogger) Log(args ...any)               { l.logWithTraceID(l.base.Log, args...) }
-func (l *ContextLogger) Logf(f string, args ...any)    { l.logWithTraceIDf(l.base.Logf, f, args...) }
-func (l *ContextLogger) Info(args ...any)              { l.logWithTraceID(l.base.Info, args...) }
-func (l *ContextLogger) Infof(f string, args ...any)   { l.logWithTraceIDf(l.base.Infof, f, args...) }
-func (l *ContextLogger) Notice(args ...any)            { l.logWithTraceID(l.base.Notice, args...) }
-func (l *ContextLogger) Noticef(f string, args ...any) { l.logWithTraceIDf(l.base.Noticef, f, args...) }
-func (l *ContextLogger) Warn(args ...any)              { l.logWithTraceID(l.base.Warn, args...) }
-func (l *ContextLogger) Warnf(f string, args ...any)   { l.logWithTraceIDf(l.base.Warnf, f, args...) }
-func (l *ContextLogger) Error(args ...any)             { l.logWithTraceID(l.base.Error, args...) }
-func (l *ContextLogger) Errorf(f string, args ...any)  { l.logWithTraceIDf(l.base.Errorf, f, args...) }
-func (l *ContextLogger) Fatal(args ...any)             { l.logWithTraceID(l.base.Fatal, args...) }
-func (l *ContextLogger) Fatalf(f string, args ...any)  { l.logWithTraceIDf(l.base.Fatalf, f, args...) }
+// logWithFields writes msg through a child of the base logger holding the given fields. Base loggers which do not
+// implement FieldLogger get the fields appended to msg.
+func (l *ContextLogger) logWithFields(lf func(Logger, ...any), msg string, fields []any) {
+	base, formatted := l.logger(fields)
+	if formatted != "" {
+		msg += " " + formatted
+	}
+
+	lf(base, l.withTraceInfo(msg)...)
+}
+
+func (l *ContextLogger) Debug(args ...any)             { l.logWithTraceID(Logger.Debug, args...) }
+func (l *ContextLogger) Debugf(f string, args ...any)  { l.logWithTraceIDf(Logger.Debugf, f, args...) }
+func (l *ContextLogger) Log(args ...any)               { l.logWithTraceID(Logger.Log, args...) }
+func (l *ContextLogger) Logf(f string, args ...any)    { l.logWithTraceIDf(Logger.Logf, f, args...) }
+func (l *ContextLogger) Info(args ...any)              { l.logWithTraceID(Logger.Info, args...) }
+func (l *ContextLogger) Infof(f string, args ...any)   { l.logWithTraceIDf(Logger.Infof, f, args...) }
+func (l *ContextLogger) Notice(args ...any)            { l.logWithTraceID(Logger.Notice, args...) }
+func (l *ContextLogger) Noticef(f string, args ...any) { l.logWithTraceIDf(Logger.Noticef, f, args...) }
+func (l *ContextLogger) Warn(args ...any)              { l.logWithTraceID(Logger.Warn, args...) }
+func (l *ContextLogger) Warnf(f string, args ...any)   { l.logWithTraceIDf(Logger.Warnf, f, args...) }
+func (l *ContextLogger) Error(args ...any)             { l.logWithTraceID(Logger.Error, args...) }
+func (l *ContextLogger) Errorf(f string, args ...any)  { l.logWithTraceIDf(Logger.Errorf, f, args...) }
+func (l *ContextLogger) Fatal(args ...any)             { l.logWithTraceID(Logger.Fatal, args...) }
+func (l *ContextLogger) Fatalf(f string, args ...any)  { l.logWithTraceIDf(Logger.Fatalf, f, args...) }
 func (l *ContextLogger) ChangeLevel(level Level)       { l.base.ChangeLevel(level) }
+
+func (l *ContextLogger) Debugw(msg string, f ...any)  { l.logWithFields(Logger.Debug, msg, f) }
+func (l *ContextLogger) Infow(msg string, f ...any)   { l.logWithFields(Logger.Info, msg, f) }
+func (l *ContextLogger) Noticew(msg string, f ...any) { l.logWithFields(Logger.Notice, msg, f) }
+func (l *ContextLogger) Warnw(msg string, f ...any)   { l.logWithFields(Logger.Warn, msg, f) }
+func (l *ContextLogger) Errorw(msg string, f ...any)  { l.logWithFields(Logger.Error, msg, f) }
+func (l *ContextLogger) Fatalw(msg string, f ...any)  { l.logWithFields(Logger.Fatal, msg, f) }
+
+// AddLogFields adds the given key-value pairs to the fields of the request, which are written on every following log
+// line of the request: the lines of the ContextLoggers of the request, including l, its children and the copies of
+// l, the request log, and the queries logged by the datasources given the context of the request:
+//
+//	ctx.AddLogFields("user_id", userID, "tenant", tenant)
+//	ctx.Info("order placed") // includes user_id and tenant
+//
+// Base loggers which do not implement FieldLogger get the fields appended to the messages.
+func (l *ContextLogger) AddLogFields(fields ...any) {
+	l.request.add(fields)
+}
+
+// With returns a child ContextLogger carrying the same trace ID and request fields, which adds the given key-value
+// pairs to every log line it writes. The receiver is left unchanged, so the child can be used alongside it:
+//
+//	log := ctx.With("order_id", orderID)
+//	log.Info("order placed") // includes order_id
+//
+// Base loggers which do not implement FieldLogger get the fields appended to the messages.
+func (l *ContextLogger) With(fields ...any) FieldLogger {
+	return &ContextLogger{base: l.base, traceID: l.traceID, request: l.request,
+		fields: append(slices.Clip(l.fields), fields...)}
+}
@@ -54,6 +54,25 @@ func (m *mockLogger) Fatalf(format string, _ ...any) {
 	m.logs = append(m.logs, logEntry{Level: FATAL, Message: format})
 }
 func (*mockLogger) ChangeLevel(_ Level) {}
+func (m *mockLogger) With(fields ...any) FieldLogger {
+	return &fieldMockLogger{mockLogger: m, fields: fields}
+}
+func (m *mockLogger) Debugw(msg string, fields ...any)  { m.With(fields...).Debug(msg) }
+func (m *mockLogger) Infow(msg string, fields ...any)   { m.With(fields...).Info(msg) }
+func (m *mockLogger) Noticew(msg string, fields ...any) { m.With(fields...).Notice(msg) }
+func (m *mockLogger) Warnw(msg string, fields ...any)   { m.With(fields...).Warn(msg) }
+func (m *mockLogger) Errorw(msg string, fields ...any)  { m.With(fields...).Error(msg) }
+func (m *mockLogger) Fatalw(msg string, fields ...any)  { m.With(fields...).Fatal(msg) }
+
+// fieldMockLogger records the fields it was created with on every entry written through it.
+type fieldMockLogger struct {
+	*mockLogger
+	fields []any
+}
+
+func (m *fieldMockLogger) Info(args ...any) {
+	m.logs = append(m.logs, logEntry{Level: INFO, Message: args, Fields: fieldsFromArgs(m.fields)})
+}
 
 // mockTracerProvider creates a context with a valid trace ID for testing.
 func mockTracedContext() (ctx context.Context, id string) {
@@ -222,3 +241,127 @@ func TestContextLogger_ChangeLevel(t *testing.T) {
 
 	assert.Equal(t, DEBUG, baseLogger.level)
 }
+
+func TestContextLogger_WithRequestFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	realLogger := &logger{level: DEBUG, normalOut: buf, errorOut: buf}
+
+	ctx, expectedTraceID := mockTracedContext()
+
+	ctxLogger := NewContextLogger(ctx, realLogger)
+	requestLogger := ctxLogger.With("user_id", "u-1")
+
+	requestLogger.Info("first")
+	requestLogger.Warnw("second", "attempt", 2)
+	ctxLogger.Info("third")
+
+	dec := json.NewDecoder(buf)
+
+	var first, second, third map[string]any
+
+	require.NoError(t, dec.Decode(&first))
+	require.NoError(t, dec.Decode(&second))
+	require.NoError(t, dec.Decode(&third))
+
+	assert.Equal(t, "u-1", first["user_id"])
+	assert.Equal(t, expectedTraceID, first["trace_id"])
+
+	assert.Equal(t, "second", second["message"])
+	assert.Equal(t, "u-1", second["user_id"])
+	assert.InDelta(t, 2, second["attempt"], 0)
+	assert.Equal(t, expectedTraceID, second["trace_id"])
+
+	assert.NotContains(t, third, "user_id")
+	assert.Equal(t, expectedTraceID, third["trace_id"])
+}
+
+func TestContextLogger_FieldsWithoutFieldLogger(t *testing.T) {
+	baseLogger := &mockLogger{}
+
+	// the embedded Logger hides the FieldLogger methods of the mock.
+	ctxLogger := NewContextLogger(t.Context(), struct{ Logger }{baseLogger})
+
+	ctxLogger.Infow("message", "tenant", "acme")
+	ctxLogger.With("tenant", "acme").Info("child message")
+	ctxLogger.AddLogFields("user_id", "u-1")
+	ctxLogger.Logf("100%% of %s", "orders")
+
+	require.Len(t, baseLogger.logs, 3)
+	assert.Equal(t, []any{"message tenant=acme"}, baseLogger.logs[0].Message)
+	assert.Equal(t, []any{"child message", "tenant=acme"}, baseLogger.logs[1].Message)
+	assert.Empty(t, baseLogger.logs[1].Fields)
+	assert.Equal(t, "100%% of %s user_id=u-1", baseLogger.logs[2].Message)
+}
+
+func TestContextLogger_AddLogFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	realLogger := &logger{level: DEBUG, normalOut: buf, errorOut: buf}
+
+	ctx := ContextWithFields(t.Context())
+
+	ctxLogger := NewContextLogger(ctx, realLogger)
+	child := ctxLogger.With("order_id", 7)
+	requestCopy := *NewContextLogger(ctx, realLogger)
+
+	ctxLogger.Info("before")
+	ctxLogger.AddLogFields("user_id", "u-1")
+	ctxLogger.Infof("after %d", 1)
+	child.Infow("child", "attempt", 2)
+	requestCopy.Info("copy")
+	LoggerWithFields[Logger](ctx, realLogger).Info("datasource")
+
+	dec := json.NewDecoder(buf)
+
+	var before, after, fromChild, fromCopy, datasource map[string]any
+
+	require.NoError(t, dec.Decode(&before))
+	require.NoError(t, dec.Decode(&after))
+	require.NoError(t, dec.Decode(&fromChild))
+	require.NoError(t, dec.Decode(&fromCopy))
+	require.NoError(t, dec.Decode(&datasource))
+
+	assert.NotContains(t, before, "user_id")
+	assert.Equal(t, "after 1", after["message"])
+	assert.Equal(t, "u-1", after["user_id"])
+	assert.Equal(t, "u-1", fromChild["user_id"])
+	assert.InDelta(t, 7, fromChild["order_id"], 0)
+	assert.InDelta(t, 2, fromChild["attempt"], 0)
+	assert.Equal(t, "u-1", fromCopy["user_id"])
+	assert.Equal(t, "u-1", datasource["user_id"])
+}
+
+func TestLoggerWithFields(t *testing.T) {
+	baseLogger := &mockLogger{}
+
+	// no fields are held by a context which is not the one of a request, or added to.
+	assert.Equal(t, Logger(baseLogger), LoggerWithFields[Logger](t.Context(), baseLogger))
+
+	ctx := ContextWithFields(t.Context())
+	assert.Equal(t, ctx, ContextWithFields(ctx))
+	assert.Equal(t, Logger(baseLogger), LoggerWithFields[Logger](ctx, baseLogger))
+
+	NewContextLogger(ctx, baseLogger).AddLogFields("tenant", "acme")
+
+	LoggerWithFields[Logger](ctx, baseLogger).Info("message")
+
+	// loggers without fields are returned as they are.
+	plain := struct{ Logger }{baseLogger}
+	assert.Equal(t, Logger(plain), LoggerWithFields[Logger](ctx, plain))
+
+	require.Len(t, baseLogger.logs, 1)
+	assert.Equal(t, []field{{key: "tenant", value: "acme"}}, baseLogger.logs[0].Fields)
+}
+
+func TestContextLogger_With(t *testing.T) {
+	baseLogger := &mockLogger{}
+
+	ctxLogger := NewContextLogger(t.Context(), baseLogger)
+
+	child := ctxLogger.With("tenant", "acme")
+	child.Info("child message")
+	ctxLogger.Info("parent message")
+
+	require.Len(t, baseLogger.logs, 2)
+	assert.Equal(t, []field{{key: "tenant", value: "acme"}}, baseLogger.logs[0].Fields)
+	assert.Empty(t, baseLogger.logs[1].Fields)
+}
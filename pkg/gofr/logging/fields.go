@@ -0,0 +1,202 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// fieldPrefix is added to the key of a field that collides with one of the standard log entry keys,
+// so that the field never overwrites the level, time, message, trace ID or version of the entry.
+const fieldPrefix = "fields."
+
+// field is a single key-value pair attached to a log entry.
+type field struct {
+	key   string
+	value any
+}
+
+// fieldsFromArgs converts alternating keys and values into fields. Keys which are not strings are
+// formatted with fmt.Sprint, and a trailing key without a value is recorded with a nil value.
+func fieldsFromArgs(args []any) []field {
+	if len(args) == 0 {
+		return nil
+	}
+
+	fields := make([]field, 0, (len(args)+1)/2)
+
+	for i := 0; i < len(args); i += 2 {
+		key, ok := args[i].(string)
+		if !ok {
+			key = fmt.Sprint(args[i])
+		}
+
+		var value any
+
+		if i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		fields = append(fields, field{key: key, value: value})
+	}
+
+	return fields
+}
+
+// mergeFields returns a new slice holding the fields of base followed by the fields of extra.
+// A key present in both keeps its position from base and takes its value from extra.
+func mergeFields(base, extra []field) []field {
+	if len(extra) == 0 {
+		return base
+	}
+
+	merged := make([]field, len(base), len(base)+len(extra))
+	copy(merged, base)
+
+	for _, f := range extra {
+		replaced := false
+
+		for i := range merged {
+			if merged[i].key == f.key {
+				merged[i].value = f.value
+				replaced = true
+
+				break
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, f)
+		}
+	}
+
+	return merged
+}
+
+// formatFields formats key-value pairs as key=value, for the base loggers which do not implement FieldLogger.
+func formatFields(args []any) string {
+	fields := mergeFields(nil, fieldsFromArgs(args))
+	formatted := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		formatted = append(formatted, fmt.Sprintf("%s=%v", f.key, f.value))
+	}
+
+	return strings.Join(formatted, " ")
+}
+
+type requestFieldsKey struct{}
+
+// requestFields holds the fields of a request, which any goroutine of the request may add.
+type requestFields struct {
+	mu     sync.RWMutex
+	fields []any
+}
+
+func (r *requestFields) add(fields []any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fields = append(r.fields, fields...)
+}
+
+func (r *requestFields) get() []any {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.fields)
+}
+
+// ContextWithFields returns a copy of ctx holding the fields of a request, which the ContextLoggers created from it
+// share and LoggerWithFields reads. It is called by the middleware logging the requests, unless ctx already holds
+// them.
+func ContextWithFields(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(requestFieldsKey{}).(*requestFields); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, requestFieldsKey{}, &requestFields{})
+}
+
+// LoggerWithFields returns a child of logger adding the fields of the request of ctx to its log lines, for loggers
+// which are not request-scoped, like the ones of the datasources. It returns logger when the request has no fields,
+// or when logger doesn't implement FieldLogger.
+func LoggerWithFields[L any](ctx context.Context, logger L) L {
+	request, ok := ctx.Value(requestFieldsKey{}).(*requestFields)
+	if !ok {
+		return logger
+	}
+
+	fields := request.get()
+	if len(fields) == 0 {
+		return logger
+	}
+
+	base, ok := any(logger).(FieldLogger)
+	if !ok {
+		return logger
+	}
+
+	if child, ok := base.With(fields...).(L); ok {
+		return child
+	}
+
+	return logger
+}
+
+// MarshalJSON encodes the entry with its fields flattened to the top level of the JSON object.
+func (e logEntry) MarshalJSON() ([]byte, error) {
+	type entry logEntry
+
+	b, err := json.Marshal(entry(e))
+	if err != nil || len(e.Fields) == 0 {
+		return b, err
+	}
+
+	buf := bytes.NewBuffer(b[:len(b)-1])
+
+	for _, f := range e.Fields {
+		key := f.key
+		if isReservedKey(key) {
+			key = fieldPrefix + key
+		}
+
+		k, _ := json.Marshal(key)
+
+		buf.WriteByte(',')
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(marshalFieldValue(f.value))
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func marshalFieldValue(value any) []byte {
+	// errors usually have no exported fields, so they are logged by their message instead.
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+
+	return v
+}
+
+func isReservedKey(key string) bool {
+	switch key {
+	case "level", "time", "message", "trace_id", "gofrVersion":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errFieldTest = errors.New("connection refused")
+
+func TestFieldsFromArgs(t *testing.T) {
+	tests := []struct {
+		desc     string
+		args     []any
+		expected []field
+	}{
+		{"no args", nil, nil},
+		{"key value pairs", []any{"a", 1, "b", "two"}, []field{{key: "a", value: 1}, {key: "b", value: "two"}}},
+		{"non string key", []any{7, true}, []field{{key: "7", value: true}}},
+		{"key without value", []any{"a", 1, "b"}, []field{{key: "a", value: 1}, {key: "b"}}},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, fieldsFromArgs(tc.args), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMergeFields(t *testing.T) {
+	base := []field{{key: "a", value: 1}, {key: "b", value: 2}}
+
+	merged := mergeFields(base, []field{{key: "b", value: 3}, {key: "c", value: 4}})
+
+	assert.Equal(t, []field{{key: "a", value: 1}, {key: "b", value: 3}, {key: "c", value: 4}}, merged)
+	assert.Equal(t, []field{{key: "a", value: 1}, {key: "b", value: 2}}, base, "base fields must not be modified")
+}
+
+func TestLogEntry_MarshalJSON(t *testing.T) {
+	entry := logEntry{
+		Level:   INFO,
+		Message: "hello",
+		Fields: []field{
+			{key: "user_id", value: 42},
+			{key: "message", value: "shadowed"},
+			{key: "err", value: errFieldTest},
+		},
+	}
+
+	b, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	var out map[string]any
+
+	require.NoError(t, json.Unmarshal(b, &out))
+
+	assert.Equal(t, "INFO", out["level"])
+	assert.Equal(t, "hello", out["message"])
+	assert.InDelta(t, 42, out["user_id"], 0)
+	assert.Equal(t, "shadowed", out["fields.message"])
+	assert.Equal(t, "connection refused", out["err"])
+}
@@ -1,10 +1,12 @@
 package logging
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"golang.org/x/term"
@@ -41,12 +43,34 @@ type Logger interface {
 	ChangeLevel(level Level)
 }
 
+// FieldLogger is implemented by the loggers that can write structured key-value fields, like the loggers
+// created by NewLogger. Fields are passed as alternating keys and values, e.g. With("user_id", 42, "tenant", "acme").
+type FieldLogger interface {
+	Logger
+
+	// With returns a child logger that adds the given key-value pairs to every log line it writes.
+	With(fields ...any) FieldLogger
+
+	Debugw(msg string, fields ...any)
+	Infow(msg string, fields ...any)
+	Noticew(msg string, fields ...any)
+	Warnw(msg string, fields ...any)
+	Errorw(msg string, fields ...any)
+	Fatalw(msg string, fields ...any)
+}
+
 type logger struct {
 	level      Level
 	normalOut  io.Writer
 	errorOut   io.Writer
 	isTerminal bool
 	lock       chan struct{}
+	fields     []field
+	redactor   *Redactor
+
+	// parent is set on loggers created through With, so that level changes
+	// made on any logger of the family apply to all of them.
+	parent *logger
 }
 
 type logEntry struct {
@@ -55,10 +79,23 @@ type logEntry struct {
 	Message     any       `json:"message"`
 	TraceID     string    `json:"trace_id,omitempty"`
 	GofrVersion string    `json:"gofrVersion"`
+	Fields      []field   `json:"-"`
 }
 
 func (l *logger) logf(level Level, format string, args ...any) {
-	if level < l.level {
+	l.write(level, l.fields, format, args...)
+}
+
+func (l *logger) logw(level Level, msg string, fields []any) {
+	if level < l.currentLevel() {
+		return
+	}
+
+	l.write(level, mergeFields(l.fields, fieldsFromArgs(fields)), "", msg)
+}
+
+func (l *logger) write(level Level, fields []field, format string, args ...any) {
+	if level < l.currentLevel() {
 		return
 	}
 
@@ -67,14 +104,18 @@ func (l *logger) logf(level Level, format string, args ...any) {
 		out = l.errorOut
 	}
 
+	redactor := l.currentRedactor()
+
 	entry := logEntry{
 		Level:       level,
 		Time:        time.Now(),
 		GofrVersion: version.Framework,
+		Fields:      redactor.entryFields(fields),
 	}
 
 	traceID, filteredArgs := extractTraceIDAndFilterArgs(args)
 	entry.TraceID = traceID
+	filteredArgs = redactor.args(filteredArgs)
 
 	switch {
 	case len(filteredArgs) == 1 && format == "":
@@ -82,7 +123,7 @@ func (l *logger) logf(level Level, format string, args ...any) {
 	case len(filteredArgs) != 1 && format == "":
 		entry.Message = filteredArgs
 	case format != "":
-		entry.Message = fmt.Sprintf(format, filteredArgs...)
+		entry.Message = redactor.String(fmt.Sprintf(format, filteredArgs...))
 	}
 
 	if l.isTerminal {
@@ -142,19 +183,58 @@ func (l *logger) Errorf(format string, args ...any) {
 
 func (l *logger) Fatal(args ...any) {
 	l.logf(FATAL, "", args...)
-
-	// Flush output before exiting
-	if f, ok := l.errorOut.(*os.File); ok {
-		_ = f.Sync() // Ignore sync error as we're about to exit
-	}
-
-	//nolint:revive // exit status is 1 as it denotes failure as signified by Fatal log
-	os.Exit(1)
+	l.exit()
 }
 
 func (l *logger) Fatalf(format string, args ...any) {
 	l.logf(FATAL, format, args...)
+	l.exit()
+}
+
+func (l *logger) Debugw(msg string, fields ...any) {
+	l.logw(DEBUG, msg, fields)
+}
+
+func (l *logger) Infow(msg string, fields ...any) {
+	l.logw(INFO, msg, fields)
+}
+
+func (l *logger) Noticew(msg string, fields ...any) {
+	l.logw(NOTICE, msg, fields)
+}
+
+func (l *logger) Warnw(msg string, fields ...any) {
+	l.logw(WARN, msg, fields)
+}
+
+func (l *logger) Errorw(msg string, fields ...any) {
+	l.logw(ERROR, msg, fields)
+}
+
+func (l *logger) Fatalw(msg string, fields ...any) {
+	l.logw(FATAL, msg, fields)
+	l.exit()
+}
 
+// With returns a child logger that writes to the same outputs and shares the log level of l,
+// adding the given key-value pairs to every entry.
+func (l *logger) With(fields ...any) FieldLogger {
+	root := l
+	if l.parent != nil {
+		root = l.parent
+	}
+
+	return &logger{
+		normalOut:  l.normalOut,
+		errorOut:   l.errorOut,
+		isTerminal: l.isTerminal,
+		lock:       l.lock,
+		fields:     mergeFields(l.fields, fieldsFromArgs(fields)),
+		parent:     root,
+	}
+}
+
+func (l *logger) exit() {
 	// Flush output before exiting
 	if f, ok := l.errorOut.(*os.File); ok {
 		_ = f.Sync() // Ignore sync error as we're about to exit
@@ -184,21 +264,44 @@ func (l *logger) prettyPrint(e *logEntry, out io.Writer) {
 
 	fmt.Fprint(out, " ")
 
+	if len(e.Fields) == 0 {
+		printMessage(out, e.Message)
+
+		return
+	}
+
+	// The message is rendered into a buffer first so that the fields can be appended on the same line,
+	// as PrettyPrint implementations terminate their output with a newline.
+	msg := &bytes.Buffer{}
+	printMessage(msg, e.Message)
+
+	fmt.Fprint(out, strings.TrimRight(msg.String(), "\n"))
+
+	for _, f := range e.Fields {
+		fmt.Fprintf(out, " \u001B[38;5;8m%s=\u001B[0m%v", f.key, f.value)
+	}
+
+	fmt.Fprintln(out)
+}
+
+func printMessage(out io.Writer, message any) {
 	// Print the message
-	if fn, ok := e.Message.(PrettyPrint); ok {
+	if fn, ok := message.(PrettyPrint); ok {
 		fn.PrettyPrint(out)
 	} else {
-		fmt.Fprintf(out, "%v\n", e.Message)
+		fmt.Fprintf(out, "%v\n", message)
 	}
 }
 
 // NewLogger creates a new Logger instance configured with the given log level.
-// Logs will be printed to stdout and stderr depending on the level.
+// Logs will be printed to stdout and stderr depending on the level, with sensitive values
+// redacted according to DefaultRedactor.
 func NewLogger(level Level) Logger {
 	l := &logger{
 		normalOut: os.Stdout,
 		errorOut:  os.Stderr,
 		lock:      make(chan struct{}, 1),
+		redactor:  DefaultRedactor(),
 	}
 
 	l.level = level
@@ -214,6 +317,7 @@ func NewFileLogger(path string) Logger {
 	l := &logger{
 		normalOut: io.Discard,
 		errorOut:  io.Discard,
+		redactor:  DefaultRedactor(),
 	}
 
 	if path == "" {
@@ -248,9 +352,43 @@ func checkIfTerminal(w io.Writer) bool {
 // ChangeLevel changes the log level of the logger.
 // This allows dynamic adjustment of the logging verbosity.
 func (l *logger) ChangeLevel(level Level) {
+	if l.parent != nil {
+		l.parent.ChangeLevel(level)
+
+		return
+	}
+
 	l.level = level
 }
 
+// SetRedactor replaces the rules used to redact sensitive values from the entries written by the logger
+// and all the loggers derived from it through With.
+func (l *logger) SetRedactor(r *Redactor) {
+	if l.parent != nil {
+		l.parent.SetRedactor(r)
+
+		return
+	}
+
+	l.redactor = r
+}
+
+func (l *logger) currentRedactor() *Redactor {
+	if l.parent != nil {
+		return l.parent.redactor
+	}
+
+	return l.redactor
+}
+
+func (l *logger) currentLevel() Level {
+	if l.parent != nil {
+		return l.parent.level
+	}
+
+	return l.level
+}
+
 // LogLevelResponder provides a method to get the log level.
 type LogLevelResponder interface {
 	LogLevel() Level
@@ -281,3 +281,71 @@ func TestNewFileLogger_NilPath(t *testing.T) {
 	assert.Equal(t, io.Discard, logger.normalOut)
 	assert.Equal(t, io.Discard, logger.errorOut)
 }
+
+func TestLogger_WithFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := &logger{level: INFO, normalOut: buf, errorOut: buf}
+
+	child := l.With("user_id", 42, "tenant", "acme")
+	child.Info("order placed")
+	child.Infow("order shipped", "order_id", "o-1", "tenant", "globex")
+	child.Debugw("not logged", "order_id", "o-2")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, "order placed", first["message"])
+	assert.InDelta(t, 42, first["user_id"], 0)
+	assert.Equal(t, "acme", first["tenant"])
+
+	assert.Equal(t, "order shipped", second["message"])
+	assert.Equal(t, "o-1", second["order_id"])
+	assert.Equal(t, "globex", second["tenant"], "field passed to Infow should override the inherited value")
+
+	// parent logger must not carry the child's fields
+	buf.Reset()
+	l.Info("parent")
+
+	assert.NotContains(t, buf.String(), "user_id")
+}
+
+func TestLogger_WithSharesLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := &logger{level: INFO, normalOut: buf, errorOut: buf}
+
+	child := l.With("component", "worker")
+
+	child.Debug("hidden")
+	assert.Empty(t, buf.String())
+
+	l.ChangeLevel(DEBUG)
+	child.Debug("visible")
+
+	assert.Contains(t, buf.String(), "visible")
+
+	child.ChangeLevel(ERROR)
+
+	assert.Equal(t, ERROR, l.level)
+}
+
+func TestPrettyPrint_WithFields(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := &logger{isTerminal: true, lock: make(chan struct{}, 1)}
+
+	l.prettyPrint(&logEntry{
+		Level:   INFO,
+		Message: &mockLog{msg: "pretty message"},
+		Fields:  []field{{key: "user_id", value: 42}},
+	}, out)
+
+	outputLog := out.String()
+
+	assert.Contains(t, outputLog, "TEST pretty message")
+	assert.Contains(t, outputLog, "user_id=\u001B[0m42")
+	assert.Equal(t, 1, strings.Count(outputLog, "\n"))
+}
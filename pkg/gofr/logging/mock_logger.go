@@ -10,6 +10,7 @@ type MockLogger struct {
 	level  Level
 	out    io.Writer
 	errOut io.Writer
+	fields []field
 }
 
 func NewMockLogger(level Level) Logger {
@@ -41,7 +42,13 @@ func (m *MockLogger) logf(level Level, format string, args ...any) {
 		message = fmt.Sprintf(format, args...)
 	}
 
-	fmt.Fprintf(out, "%v\n", message)
+	fmt.Fprintf(out, "%v", message)
+
+	for _, f := range m.fields {
+		fmt.Fprintf(out, " %s=%v", f.key, f.value)
+	}
+
+	fmt.Fprintln(out)
 }
 
 func (m *MockLogger) Debug(args ...any) {
@@ -103,3 +110,36 @@ func (m *MockLogger) Logf(format string, args ...any) {
 func (m *MockLogger) ChangeLevel(level Level) {
 	m.level = level
 }
+
+func (m *MockLogger) With(fields ...any) FieldLogger {
+	return &MockLogger{
+		level:  m.level,
+		out:    m.out,
+		errOut: m.errOut,
+		fields: mergeFields(m.fields, fieldsFromArgs(fields)),
+	}
+}
+
+func (m *MockLogger) Debugw(msg string, fields ...any) {
+	m.With(fields...).Debug(msg)
+}
+
+func (m *MockLogger) Infow(msg string, fields ...any) {
+	m.With(fields...).Info(msg)
+}
+
+func (m *MockLogger) Noticew(msg string, fields ...any) {
+	m.With(fields...).Notice(msg)
+}
+
+func (m *MockLogger) Warnw(msg string, fields ...any) {
+	m.With(fields...).Warn(msg)
+}
+
+func (m *MockLogger) Errorw(msg string, fields ...any) {
+	m.With(fields...).Error(msg)
+}
+
+func (m *MockLogger) Fatalw(msg string, fields ...any) {
+	m.With(fields...).Fatal(msg)
+}
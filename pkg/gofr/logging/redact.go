@@ -0,0 +1,492 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// RedactedValue replaces every value removed by a Redactor.
+const RedactedValue = "[REDACTED]"
+
+// redactTag is the struct tag which marks a field as sensitive, e.g. Password string `redact:"true"`.
+const redactTag = "redact"
+
+// maxRedactDepth bounds the recursion into nested structs, maps and slices.
+const maxRedactDepth = 5
+
+// Redactable is implemented by log messages whose sensitive values cannot be identified by field name alone,
+// such as the query parameters of a URI or the arguments of a SQL statement. Redact returns a copy of the
+// message with the sensitive values replaced; the receiver must not be modified.
+type Redactable interface {
+	Redact(r *Redactor) any
+}
+
+// RedactorSetter is implemented by loggers whose redaction rules can be replaced.
+type RedactorSetter interface {
+	SetRedactor(r *Redactor)
+}
+
+// Redactor masks sensitive values in log entries and span attributes. Values are identified by the name of the
+// field, map key, column or query parameter holding them, by the name of the HTTP header carrying them, by
+// the `redact:"true"` struct tag, or by matching one of the configured regular expressions.
+//
+// A nil *Redactor is valid and leaves every value unchanged.
+type Redactor struct {
+	fields   []string
+	names    map[string]struct{}
+	headers  map[string]struct{}
+	patterns []*regexp.Regexp
+}
+
+// DefaultRedactor returns a Redactor covering credentials commonly found in requests and queries:
+// the Authorization and Cookie headers, password, secret and token like fields, bearer tokens and JWTs.
+func DefaultRedactor() *Redactor {
+	return NewRedactor(nil, nil)
+}
+
+// NewRedactor returns a Redactor applying the default rules together with the given field names, header names
+// and patterns. Field names are matched case-insensitively ignoring '-', '_' and '.', and also match the names
+// ending with them as whole words, so "password" covers "db_password" and "newPassword" but not "nopassword".
+func NewRedactor(fields, headers []string, patterns ...*regexp.Regexp) *Redactor {
+	r := &Redactor{names: make(map[string]struct{}), headers: make(map[string]struct{})}
+
+	for _, n := range defaultRedactNames() {
+		r.names[n] = struct{}{}
+	}
+
+	for _, f := range append(defaultRedactFields(), fields...) {
+		if f = normalizeFieldName(f); f != "" {
+			r.fields = append(r.fields, f)
+		}
+	}
+
+	for _, h := range append(defaultRedactHeaders(), headers...) {
+		if h = strings.TrimSpace(h); h != "" {
+			r.headers[http.CanonicalHeaderKey(h)] = struct{}{}
+		}
+	}
+
+	r.patterns = append(defaultRedactPatterns(), patterns...)
+
+	return r
+}
+
+func defaultRedactFields() []string {
+	return []string{
+		"password", "passwd", "pwd", "passphrase", "secret", "apikey", "authorization", "cookie", "credentials",
+		"privatekey", "creditcard", "cardnumber", "cvv", "ssn", "accesstoken", "refreshtoken", "idtoken", "authtoken",
+		"bearertoken", "sessiontoken", "csrftoken", "xsrftoken",
+	}
+}
+
+// defaultRedactNames returns the names which are only sensitive on their own, as the names ending with them are
+// often harmless, like "next_page_token".
+func defaultRedactNames() []string {
+	return []string{"token", "jwt"}
+}
+
+func defaultRedactHeaders() []string {
+	return []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+}
+
+func defaultRedactPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		// bearer and basic credentials in free text
+		regexp.MustCompile(`(?i)\b(bearer|basic)\s+[a-z0-9\-._~+/]+=*`),
+		// JSON web tokens
+		regexp.MustCompile(`\beyJ[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+`),
+	}
+}
+
+func normalizeFieldName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	return strings.NewReplacer("-", "", "_", "", ".", "").Replace(name)
+}
+
+// IsSensitiveField reports whether values stored under the given field, key or column name must be redacted.
+func (r *Redactor) IsSensitiveField(name string) bool {
+	if r == nil {
+		return false
+	}
+
+	if _, ok := r.names[normalizeFieldName(name)]; ok {
+		return true
+	}
+
+	words := fieldWords(name)
+
+	for i := range words {
+		suffix := strings.Join(words[i:], "")
+
+		if slices.Contains(r.fields, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldWords splits a field name into its lowercase words, delimited by '-', '_', '.', spaces or camel case.
+func fieldWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+
+	runes := []rune(strings.TrimSpace(name))
+
+	for i, c := range runes {
+		switch {
+		case c == '-' || c == '_' || c == '.' || unicode.IsSpace(c):
+			if len(word) > 0 {
+				words, word = append(words, string(word)), nil
+			}
+
+			continue
+		case unicode.IsUpper(c) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			words, word = append(words, string(word)), nil
+		}
+
+		word = append(word, unicode.ToLower(c))
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
+
+// IsSensitiveHeader reports whether the given HTTP header must be redacted.
+func (r *Redactor) IsSensitiveHeader(name string) bool {
+	if r == nil {
+		return false
+	}
+
+	_, ok := r.headers[http.CanonicalHeaderKey(name)]
+
+	return ok
+}
+
+// String replaces every match of the configured patterns in s.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, p := range r.patterns {
+		s = p.ReplaceAllString(s, RedactedValue)
+	}
+
+	return s
+}
+
+// URL redacts the password of the user info and the sensitive query parameters of a URL or request URI.
+func (r *Redactor) URL(raw string) string {
+	if r == nil || raw == "" {
+		return raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return r.String(raw)
+	}
+
+	redacted := false
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), RedactedValue)
+		redacted = true
+	}
+
+	if u.RawQuery != "" {
+		query, changed := r.query(u.RawQuery)
+		u.RawQuery = query
+		redacted = redacted || changed
+	}
+
+	// the URL is logged as it was received, unless a value has been redacted.
+	if !redacted {
+		return raw
+	}
+
+	// brackets are kept readable, as the encoded form of the redacted value would otherwise be logged.
+	return strings.NewReplacer("%5BREDACTED%5D", RedactedValue).Replace(u.String())
+}
+
+// query redacts the sensitive parameters of a raw query, leaving the other parameters and their order untouched.
+func (r *Redactor) query(rawQuery string) (query string, changed bool) {
+	params := strings.Split(rawQuery, "&")
+
+	for i, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+
+		if r.IsSensitiveField(name) {
+			params[i], changed = key+"="+RedactedValue, true
+
+			continue
+		}
+
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			if masked := r.String(unescaped); masked != unescaped {
+				params[i], changed = key+"="+url.QueryEscape(masked), true
+			}
+		}
+	}
+
+	return strings.Join(params, "&"), changed
+}
+
+// Header returns the value of a header, or RedactedValue if the header is sensitive.
+func (r *Redactor) Header(name, value string) string {
+	if r.IsSensitiveHeader(name) {
+		return RedactedValue
+	}
+
+	return r.String(value)
+}
+
+// JSON redacts a JSON document held in a string, such as a message payload. Strings that are not valid JSON
+// objects or arrays are only matched against the configured patterns.
+func (r *Redactor) JSON(s string) string {
+	if r == nil {
+		return s
+	}
+
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return r.String(s)
+	}
+
+	var doc any
+
+	if err := json.Unmarshal([]byte(trimmed), &doc); err != nil {
+		return r.String(s)
+	}
+
+	b, err := json.Marshal(r.value(doc, 0))
+	if err != nil {
+		return r.String(s)
+	}
+
+	return string(b)
+}
+
+// Field returns the value to be logged for the given key: RedactedValue if the key is sensitive,
+// otherwise the value with its own sensitive contents redacted.
+func (r *Redactor) Field(key string, value any) any {
+	if r == nil {
+		return value
+	}
+
+	if r.IsSensitiveField(key) {
+		return RedactedValue
+	}
+
+	return r.value(value, 0)
+}
+
+// Value returns a copy of v with its sensitive contents redacted. Redactable values redact themselves,
+// structs are redacted by field name and the `redact:"true"` tag, maps by key and http.Header by header name.
+func (r *Redactor) Value(v any) any {
+	if r == nil {
+		return v
+	}
+
+	return r.value(v, 0)
+}
+
+func (r *Redactor) value(v any, depth int) any {
+	if v == nil || depth > maxRedactDepth {
+		return v
+	}
+
+	switch val := v.(type) {
+	case Redactable:
+		return val.Redact(r)
+	case string:
+		return r.String(val)
+	case []any:
+		out := make([]any, len(val))
+
+		for i := range val {
+			out[i] = r.value(val[i], depth+1)
+		}
+
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(val))
+
+		for k, item := range val {
+			if r.IsSensitiveField(k) {
+				out[k] = RedactedValue
+			} else {
+				out[k] = r.value(item, depth+1)
+			}
+		}
+
+		return out
+	case http.Header:
+		return r.header(val)
+	case map[string][]string:
+		return map[string][]string(r.header(val))
+	}
+
+	return r.reflectValue(v, depth)
+}
+
+func (r *Redactor) header(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+
+	for name, values := range h {
+		redacted := make([]string, len(values))
+
+		for i := range values {
+			redacted[i] = r.Header(name, values[i])
+		}
+
+		out[name] = redacted
+	}
+
+	return out
+}
+
+// reflectValue redacts structs and pointers to structs, returning v itself when nothing had to change
+// so that the type, and therefore any PrettyPrint implementation, of the message is preserved.
+func (r *Redactor) reflectValue(v any, depth int) any {
+	rv := reflect.ValueOf(v)
+
+	switch {
+	case rv.Kind() == reflect.Struct:
+		if redacted, changed := r.redactStruct(rv, depth); changed {
+			return redacted.Interface()
+		}
+	case rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct:
+		if redacted, changed := r.redactStruct(rv.Elem(), depth); changed {
+			ptr := reflect.New(redacted.Type())
+			ptr.Elem().Set(redacted)
+
+			return ptr.Interface()
+		}
+	}
+
+	return v
+}
+
+func (r *Redactor) redactStruct(v reflect.Value, depth int) (reflect.Value, bool) {
+	t := v.Type()
+	out := reflect.New(t).Elem()
+	out.Set(v)
+
+	changed := false
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := out.Field(i)
+
+		if sf.Tag.Get(redactTag) == "true" || r.IsSensitiveField(fieldName(sf)) {
+			if !f.IsZero() {
+				maskField(f)
+
+				changed = true
+			}
+
+			continue
+		}
+
+		if f.Kind() == reflect.Interface && f.IsNil() {
+			continue
+		}
+
+		if f.Kind() == reflect.String || f.Kind() == reflect.Struct || f.Kind() == reflect.Pointer ||
+			f.Kind() == reflect.Interface || f.Kind() == reflect.Map || f.Kind() == reflect.Slice {
+			changed = r.redactNested(f, depth) || changed
+		}
+	}
+
+	return out, changed
+}
+
+func (r *Redactor) redactNested(f reflect.Value, depth int) bool {
+	if (f.Kind() == reflect.Pointer || f.Kind() == reflect.Map || f.Kind() == reflect.Slice) && f.IsNil() {
+		return false
+	}
+
+	original := f.Interface()
+	redacted := r.value(original, depth+1)
+
+	rv := reflect.ValueOf(redacted)
+	if !rv.IsValid() || !rv.Type().AssignableTo(f.Type()) || reflect.DeepEqual(original, redacted) {
+		return false
+	}
+
+	f.Set(rv)
+
+	return true
+}
+
+func maskField(f reflect.Value) {
+	if f.Kind() == reflect.String {
+		f.SetString(RedactedValue)
+		return
+	}
+
+	if f.Kind() == reflect.Interface {
+		f.Set(reflect.ValueOf(RedactedValue))
+		return
+	}
+
+	f.SetZero()
+}
+
+func fieldName(sf reflect.StructField) string {
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+
+	return sf.Name
+}
+
+func (r *Redactor) args(args []any) []any {
+	if r == nil {
+		return args
+	}
+
+	out := make([]any, len(args))
+
+	for i := range args {
+		out[i] = r.value(args[i], 0)
+	}
+
+	return out
+}
+
+func (r *Redactor) entryFields(fields []field) []field {
+	if r == nil || len(fields) == 0 {
+		return fields
+	}
+
+	out := make([]field, len(fields))
+
+	for i, f := range fields {
+		out[i] = field{key: f.key, value: r.Field(f.key, f.value)}
+	}
+
+	return out
+}
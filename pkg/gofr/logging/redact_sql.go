@@ -0,0 +1,159 @@
+package logging
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	sqlPlaceholder     = regexp.MustCompile(`\?|\$\d+`)
+	sqlComparedColumn  = regexp.MustCompile(`(?i)([\w.` + "`" + `"]+)\s*(?:=|<>|!=|\bLIKE)\s*$`)
+	sqlInsertColumns   = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+[^(]+\(([^)]*)\)\s*VALUES\s*`)
+	sqlComparedLiteral = regexp.MustCompile(`(?i)([\w.` + "`" + `"]+)(\s*(?:=|<>|!=|\bLIKE)\s*)'(?:[^']|'')*'`)
+)
+
+// SQL redacts string literals compared against sensitive columns, such as password = 'secret',
+// and matches of the configured patterns in a SQL statement.
+func (r *Redactor) SQL(query string) string {
+	if r == nil {
+		return query
+	}
+
+	query = sqlComparedLiteral.ReplaceAllStringFunc(query, func(match string) string {
+		parts := sqlComparedLiteral.FindStringSubmatch(match)
+		if !r.IsSensitiveField(unquoteColumn(parts[1])) {
+			return match
+		}
+
+		return parts[1] + parts[2] + "'" + RedactedValue + "'"
+	})
+
+	return r.String(query)
+}
+
+// SQLArgs returns a copy of the arguments of a SQL statement in which the arguments bound to sensitive columns
+// are redacted. Columns are resolved for placeholders ('?' or '$n') compared against a column, as in
+// "WHERE password = ?", and for the values of an INSERT with an explicit column list.
+func (r *Redactor) SQLArgs(query string, args []any) []any {
+	if r == nil || len(args) == 0 {
+		return args
+	}
+
+	out := make([]any, len(args))
+	copy(out, args)
+
+	valueColumns := sqlValueColumns(query)
+
+	for i, loc := range sqlPlaceholder.FindAllStringIndex(query, -1) {
+		idx := placeholderIndex(query[loc[0]:loc[1]], i)
+		if idx < 0 || idx >= len(out) {
+			continue
+		}
+
+		column, ok := valueColumns[loc[0]]
+		if !ok {
+			if m := sqlComparedColumn.FindStringSubmatch(query[:loc[0]]); m != nil {
+				column = unquoteColumn(m[1])
+			}
+		}
+
+		if r.IsSensitiveField(column) {
+			out[idx] = RedactedValue
+		} else {
+			out[idx] = r.value(out[idx], 0)
+		}
+	}
+
+	return out
+}
+
+// sqlValueColumns returns the columns of the placeholders in the VALUES tuples of an INSERT statement having an
+// explicit column list, by the offset of the placeholders. The placeholders following the tuples, as in the
+// ON DUPLICATE KEY UPDATE or ON CONFLICT clause of an upsert, are not part of them.
+func sqlValueColumns(query string) map[int]string {
+	loc := sqlInsertColumns.FindStringSubmatchIndex(query)
+	if loc == nil {
+		return nil
+	}
+
+	var columns []string
+
+	for _, c := range strings.Split(query[loc[2]:loc[3]], ",") {
+		columns = append(columns, unquoteColumn(c))
+	}
+
+	placeholders := make(map[int]string)
+	depth, field := 0, 0
+
+	for i := loc[1]; i < len(query); i++ {
+		ch := query[i]
+
+		switch {
+		case ch == '\'':
+			i = skipQuoted(query, i)
+		case ch == '(':
+			if depth == 0 {
+				field = 0
+			}
+
+			depth++
+		case ch == ')':
+			depth--
+		case depth == 0 && ch != ',' && !unicode.IsSpace(rune(ch)):
+			return placeholders
+		case depth == 1 && ch == ',':
+			field++
+		case (ch == '?' || ch == '$') && field < len(columns):
+			placeholders[i] = columns[field]
+		}
+	}
+
+	return placeholders
+}
+
+// skipQuoted returns the offset of the quote closing the string literal opened at offset start, skipping the
+// doubled quotes escaping a quote.
+func skipQuoted(query string, start int) int {
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != '\'' {
+			continue
+		}
+
+		if i+1 < len(query) && query[i+1] == '\'' {
+			i++
+
+			continue
+		}
+
+		return i
+	}
+
+	return len(query)
+}
+
+// placeholderIndex returns the index of the argument bound to a placeholder; '$n' placeholders are
+// numbered explicitly while '?' placeholders are bound in order of appearance.
+func placeholderIndex(placeholder string, position int) int {
+	if placeholder == "?" {
+		return position
+	}
+
+	n, err := strconv.Atoi(placeholder[1:])
+	if err != nil {
+		return -1
+	}
+
+	return n - 1
+}
+
+func unquoteColumn(column string) string {
+	column = strings.Trim(strings.TrimSpace(column), "`\"")
+
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		column = column[i+1:]
+	}
+
+	return strings.Trim(column, "`\"")
+}
@@ -0,0 +1,43 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor_SQLArgs(t *testing.T) {
+	r := DefaultRedactor()
+
+	tests := []struct {
+		desc     string
+		query    string
+		args     []any
+		expected []any
+	}{
+		{"where clause", "SELECT * FROM users WHERE email = ? AND password = ?",
+			[]any{"a@b.c", "p@ss"}, []any{"a@b.c", RedactedValue}},
+		{"postgres placeholders", `UPDATE users SET "password" = $2 WHERE id = $1`,
+			[]any{1, "hash"}, []any{1, RedactedValue}},
+		{"insert column list", "INSERT INTO users (name, api_key, age) VALUES (?, ?, ?), (?, ?, ?)",
+			[]any{"a", "k1", 1, "b", "k2", 2}, []any{"a", RedactedValue, 1, "b", RedactedValue, 2}},
+		{"insert with expressions", "INSERT INTO users (name, created_at, password) VALUES (LOWER(?), NOW(), 'x''y' || ?)",
+			[]any{"a", "p@ss"}, []any{"a", RedactedValue}},
+		{"mysql upsert", "INSERT INTO users (name,email) VALUES (?,?) ON DUPLICATE KEY UPDATE password = ?",
+			[]any{"a", "a@b.c", "p@ss"}, []any{"a", "a@b.c", RedactedValue}},
+		{"postgres upsert", `INSERT INTO users (name, email) VALUES ($1, $2) ON CONFLICT (email) DO UPDATE SET password = $3`,
+			[]any{"a", "a@b.c", "p@ss"}, []any{"a", "a@b.c", RedactedValue}},
+		{"no sensitive columns", "SELECT * FROM users WHERE id = ?", []any{1}, []any{1}},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, r.SQLArgs(tc.query, tc.args), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestRedactor_SQL(t *testing.T) {
+	r := DefaultRedactor()
+
+	assert.Equal(t, "SELECT * FROM users WHERE name = 'bob' AND users.password = '[REDACTED]'",
+		r.SQL("SELECT * FROM users WHERE name = 'bob' AND users.password = 'it''s secret'"))
+}
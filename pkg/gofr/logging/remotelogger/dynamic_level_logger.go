@@ -161,6 +161,35 @@ type remoteLogger struct {
 	logging.Logger
 }
 
+// fieldLogger returns the underlying logger as a FieldLogger, wrapping it when it cannot write fields itself.
+func (r *remoteLogger) fieldLogger() logging.FieldLogger {
+	if fl, ok := r.Logger.(logging.FieldLogger); ok {
+		return fl
+	}
+
+	return logging.NewContextLogger(context.Background(), r.Logger)
+}
+
+// With returns a child of the underlying logger adding the given key-value pairs to every log line. The child follows
+// the log levels fetched from the remote configuration.
+func (r *remoteLogger) With(fields ...any) logging.FieldLogger {
+	return r.fieldLogger().With(fields...)
+}
+
+func (r *remoteLogger) Debugw(msg string, fields ...any)  { r.fieldLogger().Debugw(msg, fields...) }
+func (r *remoteLogger) Infow(msg string, fields ...any)   { r.fieldLogger().Infow(msg, fields...) }
+func (r *remoteLogger) Noticew(msg string, fields ...any) { r.fieldLogger().Noticew(msg, fields...) }
+func (r *remoteLogger) Warnw(msg string, fields ...any)   { r.fieldLogger().Warnw(msg, fields...) }
+func (r *remoteLogger) Errorw(msg string, fields ...any)  { r.fieldLogger().Errorw(msg, fields...) }
+func (r *remoteLogger) Fatalw(msg string, fields ...any)  { r.fieldLogger().Fatalw(msg, fields...) }
+
+// SetRedactor replaces the redaction rules of the underlying logger, if it supports them.
+func (r *remoteLogger) SetRedactor(redactor *logging.Redactor) {
+	if rs, ok := r.Logger.(logging.RedactorSetter); ok {
+		rs.SetRedactor(redactor)
+	}
+}
+
 // UpdateLogLevel continuously fetches the log level from the remote configuration URL at the specified interval
 // and updates the underlying log level if it has changed.
 func (r *remoteLogger) UpdateLogLevel() {
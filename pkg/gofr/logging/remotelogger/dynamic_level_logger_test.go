@@ -142,6 +142,20 @@ func TestDynamicLoggerSuccess(t *testing.T) {
 	}
 }
 
+func TestRemoteLogger_Fields(t *testing.T) {
+	log := testutil.StdoutOutputForFunc(func() {
+		rl, ok := New(logging.INFO, "", time.Second).(logging.FieldLogger)
+		require.True(t, ok)
+
+		rl.With("tenant", "acme").Info("child message")
+		rl.Infow("field message", "order_id", "o-1")
+	})
+
+	assert.Contains(t, log, "tenant")
+	assert.Contains(t, log, "acme")
+	assert.Contains(t, log, "order_id")
+}
+
 // TestHTTPLogFilter_NonHTTPLogs tests regular non-HTTP logs are passed through.
 func TestHTTPLogFilter_NonHTTPLogs(t *testing.T) {
 	var buf strings.Builder
@@ -0,0 +1,156 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+// couchbaseMigrationKey is the key of the document holding the records of the migrations, in the default collection
+// of the bucket, as the documents of other collections are only queried by N1QL with an index.
+const couchbaseMigrationKey = "gofr_migrations"
+
+var errCouchbaseNotManaged = errors.New("couchbase client does not manage buckets, scopes and collections")
+
+type couchbaseDS struct {
+	container.Couchbase
+}
+
+// manager returns the client as a container.CouchbaseManager, which the migrations changing buckets, scopes and
+// collections require.
+func (ds couchbaseDS) manager() (container.CouchbaseManager, error) {
+	m, ok := ds.Couchbase.(container.CouchbaseManager)
+	if !ok {
+		return nil, errCouchbaseNotManaged
+	}
+
+	return m, nil
+}
+
+func (ds couchbaseDS) CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error {
+	m, err := ds.manager()
+	if err != nil {
+		return err
+	}
+
+	return m.CreateBucket(ctx, bucket, ramQuotaMB)
+}
+
+func (ds couchbaseDS) DropBucket(ctx context.Context, bucket string) error {
+	m, err := ds.manager()
+	if err != nil {
+		return err
+	}
+
+	return m.DropBucket(ctx, bucket)
+}
+
+func (ds couchbaseDS) CreateScope(ctx context.Context, scope string) error {
+	m, err := ds.manager()
+	if err != nil {
+		return err
+	}
+
+	return m.CreateScope(ctx, scope)
+}
+
+func (ds couchbaseDS) DropScope(ctx context.Context, scope string) error {
+	m, err := ds.manager()
+	if err != nil {
+		return err
+	}
+
+	return m.DropScope(ctx, scope)
+}
+
+func (ds couchbaseDS) CreateCollection(ctx context.Context, scope, collection string) error {
+	m, err := ds.manager()
+	if err != nil {
+		return err
+	}
+
+	return m.CreateCollection(ctx, scope, collection)
+}
+
+func (ds couchbaseDS) DropCollection(ctx context.Context, scope, collection string) error {
+	m, err := ds.manager()
+	if err != nil {
+		return err
+	}
+
+	return m.DropCollection(ctx, scope, collection)
+}
+
+type couchbaseMigrator struct {
+	container.Couchbase
+	migrator
+}
+
+// apply initializes couchbaseMigrator using the Couchbase interface.
+func (ds couchbaseDS) apply(m migrator) migrator {
+	return couchbaseMigrator{
+		Couchbase: ds.Couchbase,
+		migrator:  m,
+	}
+}
+
+// checkAndCreateMigrationTable creates the document of the records of the migrations if it doesn't exist.
+func (cm couchbaseMigrator) checkAndCreateMigrationTable(c *container.Container) error {
+	var records []documentMigrationRecord
+
+	// inserting fails when the document exists, which is only created when it could not be read.
+	if err := cm.Couchbase.Get(context.Background(), couchbaseMigrationKey, &records); err != nil {
+		if err = cm.Couchbase.Insert(context.Background(), couchbaseMigrationKey, []documentMigrationRecord{}, nil); err != nil {
+			return fmt.Errorf("failed to create gofr_migrations document: %w", err)
+		}
+	}
+
+	return cm.migrator.checkAndCreateMigrationTable(c)
+}
+
+// getLastMigration retrieves the latest migration version from Couchbase.
+func (cm couchbaseMigrator) getLastMigration(c *container.Container) int64 {
+	var records []documentMigrationRecord
+
+	if err := cm.Couchbase.Get(context.Background(), couchbaseMigrationKey, &records); err != nil {
+		c.Errorf("Failed to fetch migrations from Couchbase: %v", err)
+		return 0
+	}
+
+	lastMigration := lastMigrationVersion(records)
+
+	c.Debugf("Couchbase last migration fetched value is: %v", lastMigration)
+
+	return max(lastMigration, cm.migrator.getLastMigration(c))
+}
+
+func (cm couchbaseMigrator) beginTransaction(c *container.Container) transactionData {
+	return cm.migrator.beginTransaction(c)
+}
+
+// commitMigration appends the record of the migration to the document of the records.
+func (cm couchbaseMigrator) commitMigration(c *container.Container, data transactionData) error {
+	var records []documentMigrationRecord
+
+	if err := cm.Couchbase.Get(context.Background(), couchbaseMigrationKey, &records); err != nil {
+		return err
+	}
+
+	records = append(records, newDocumentMigrationRecord(data))
+
+	if err := cm.Couchbase.Upsert(context.Background(), couchbaseMigrationKey, records, nil); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	c.Debugf("inserted record for migration %v in Couchbase gofr_migrations document", data.MigrationNumber)
+
+	return cm.migrator.commitMigration(c, data)
+}
+
+func (cm couchbaseMigrator) rollback(c *container.Container, data transactionData) {
+	cm.migrator.rollback(c, data)
+
+	c.Fatalf("Migration %v failed.", data.MigrationNumber)
+}
@@ -0,0 +1,125 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+var errCouchbaseConn = errors.New("error connecting to couchbase")
+
+func couchbaseSetup(t *testing.T) (migrator, *container.MockCouchbase, *container.Container) {
+	t.Helper()
+
+	mockContainer, mocks := container.NewMockContainer(t)
+
+	ds := Datasource{Couchbase: couchbaseDS{mockContainer.Couchbase}}
+
+	return couchbaseDS{Couchbase: mocks.Couchbase}.apply(&ds), mocks.Couchbase, mockContainer
+}
+
+// setRecords returns the function setting the records read by Get.
+func setRecords(records ...documentMigrationRecord) func(any, string, any) error {
+	return func(_ any, _ string, result any) error {
+		*result.(*[]documentMigrationRecord) = records
+
+		return nil
+	}
+}
+
+func Test_CouchbaseCheckAndCreateMigrationTable(t *testing.T) {
+	migratorWithCouchbase, mockCouchbase, mockContainer := couchbaseSetup(t)
+
+	mockCouchbase.EXPECT().Get(gomock.Any(), couchbaseMigrationKey, gomock.Any()).DoAndReturn(setRecords())
+
+	require.NoError(t, migratorWithCouchbase.checkAndCreateMigrationTable(mockContainer))
+
+	mockCouchbase.EXPECT().Get(gomock.Any(), couchbaseMigrationKey, gomock.Any()).Return(errCouchbaseConn)
+	mockCouchbase.EXPECT().Insert(gomock.Any(), couchbaseMigrationKey, []documentMigrationRecord{}, nil).Return(nil)
+
+	require.NoError(t, migratorWithCouchbase.checkAndCreateMigrationTable(mockContainer))
+
+	mockCouchbase.EXPECT().Get(gomock.Any(), couchbaseMigrationKey, gomock.Any()).Return(errCouchbaseConn)
+	mockCouchbase.EXPECT().Insert(gomock.Any(), couchbaseMigrationKey, gomock.Any(), nil).Return(errCouchbaseConn)
+
+	require.ErrorIs(t, migratorWithCouchbase.checkAndCreateMigrationTable(mockContainer), errCouchbaseConn)
+}
+
+func Test_CouchbaseGetLastMigration(t *testing.T) {
+	migratorWithCouchbase, mockCouchbase, mockContainer := couchbaseSetup(t)
+
+	mockCouchbase.EXPECT().Get(gomock.Any(), couchbaseMigrationKey, gomock.Any()).
+		DoAndReturn(setRecords(documentMigrationRecord{Version: 4}, documentMigrationRecord{Version: 2}))
+
+	assert.Equal(t, int64(4), migratorWithCouchbase.getLastMigration(mockContainer))
+
+	mockCouchbase.EXPECT().Get(gomock.Any(), couchbaseMigrationKey, gomock.Any()).Return(errCouchbaseConn)
+
+	assert.Equal(t, int64(0), migratorWithCouchbase.getLastMigration(mockContainer))
+}
+
+func Test_CouchbaseCommitMigration(t *testing.T) {
+	migratorWithCouchbase, mockCouchbase, mockContainer := couchbaseSetup(t)
+
+	td := transactionData{
+		StartTime:       time.Now(),
+		MigrationNumber: 5,
+	}
+
+	mockCouchbase.EXPECT().Get(gomock.Any(), couchbaseMigrationKey, gomock.Any()).
+		DoAndReturn(setRecords(documentMigrationRecord{Version: 4}))
+	mockCouchbase.EXPECT().Upsert(gomock.Any(), couchbaseMigrationKey, gomock.Any(), nil).DoAndReturn(
+		func(_ any, _ string, document, _ any) error {
+			records := document.([]documentMigrationRecord)
+
+			assert.Len(t, records, 2)
+			assert.Equal(t, int64(5), records[1].Version)
+			assert.Equal(t, "UP", records[1].Method)
+
+			return nil
+		})
+
+	require.NoError(t, migratorWithCouchbase.commitMigration(mockContainer, td))
+
+	mockCouchbase.EXPECT().Get(gomock.Any(), couchbaseMigrationKey, gomock.Any()).DoAndReturn(setRecords())
+	mockCouchbase.EXPECT().Upsert(gomock.Any(), couchbaseMigrationKey, gomock.Any(), nil).Return(errCouchbaseConn)
+
+	require.ErrorIs(t, migratorWithCouchbase.commitMigration(mockContainer, td), errCouchbaseConn)
+}
+
+// managedCouchbase is a Couchbase client managing buckets, scopes and collections.
+type managedCouchbase struct {
+	*container.MockCouchbase
+	*container.MockCouchbaseManager
+}
+
+func Test_CouchbaseMigrationCreateScopeAndIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCouchbase := container.NewMockCouchbase(ctrl)
+	mockManager := container.NewMockCouchbaseManager(ctrl)
+
+	ds := Datasource{Couchbase: couchbaseDS{managedCouchbase{mockCouchbase, mockManager}}}
+
+	mockManager.EXPECT().CreateScope(gomock.Any(), "sales").Return(nil)
+	mockManager.EXPECT().CreateCollection(gomock.Any(), "sales", "orders").Return(nil)
+	mockCouchbase.EXPECT().Query(gomock.Any(), "CREATE INDEX idx_status ON `gofr`.sales.orders(status)", nil, nil).
+		Return(nil)
+
+	require.NoError(t, ds.Couchbase.CreateScope(t.Context(), "sales"))
+	require.NoError(t, ds.Couchbase.CreateCollection(t.Context(), "sales", "orders"))
+	require.NoError(t, ds.Couchbase.Query(t.Context(), "CREATE INDEX idx_status ON `gofr`.sales.orders(status)", nil, nil))
+}
+
+func Test_CouchbaseNotManaged(t *testing.T) {
+	_, mockCouchbase, _ := couchbaseSetup(t)
+
+	ds := Datasource{Couchbase: couchbaseDS{mockCouchbase}}
+
+	require.ErrorIs(t, ds.Couchbase.CreateScope(t.Context(), "sales"), errCouchbaseNotManaged)
+}
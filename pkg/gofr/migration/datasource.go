@@ -20,6 +20,10 @@ type Datasource struct {
 	ScyllaDB      ScyllaDB
 	Elasticsearch Elasticsearch
 	OpenTSDB      OpenTSDB
+	Couchbase     Couchbase
+	Solr          Solr
+	InfluxDB      InfluxDB
+	KVStore       KVStore
 }
 
 // It is a base implementation for migration manager, on this other database drivers have been wrapped.
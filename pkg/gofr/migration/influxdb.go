@@ -0,0 +1,156 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+const (
+	// influxMigrationBucket is the bucket of the records of the migrations, which are points of the measurement of
+	// the same name.
+	influxMigrationBucket = "gofr_migrations"
+
+	getLastInfluxMigrationQuery = `from(bucket: "gofr_migrations")
+  |> range(start: 0)
+  |> filter(fn: (r) => r._measurement == "gofr_migrations" and r._field == "version")
+  |> group()
+  |> max()`
+)
+
+var (
+	errInfluxOrgNotConfigured = errors.New("the organization of InfluxDB is not configured")
+	errInfluxOrgNotFound      = errors.New("organization of InfluxDB not found")
+	errInfluxNotManaged       = errors.New("influxdb client does not manage the retention of buckets")
+)
+
+type influxDBDS struct {
+	container.InfluxDB
+}
+
+// UpdateBucketRetention sets the retention of a bucket, provided the client implements
+// container.InfluxDBRetentionManager.
+func (ds influxDBDS) UpdateBucketRetention(ctx context.Context, bucketID string, retention time.Duration) error {
+	m, ok := ds.InfluxDB.(container.InfluxDBRetentionManager)
+	if !ok {
+		return errInfluxNotManaged
+	}
+
+	return m.UpdateBucketRetention(ctx, bucketID, retention)
+}
+
+type influxDBMigrator struct {
+	container.InfluxDB
+	migrator
+
+	// org is the organization of the application, configured in the InfluxDB driver, holding the records.
+	org string
+}
+
+// apply initializes influxDBMigrator using the InfluxDB interface.
+func (ds influxDBDS) apply(m migrator) migrator {
+	im := influxDBMigrator{
+		InfluxDB: ds.InfluxDB,
+		migrator: m,
+	}
+
+	if configured, ok := ds.InfluxDB.(interface{ Org() string }); ok {
+		im.org = configured.Org()
+	}
+
+	return im
+}
+
+// checkAndCreateMigrationTable creates the bucket of the records of the migrations, in the organization of the
+// application, if it doesn't exist.
+func (im influxDBMigrator) checkAndCreateMigrationTable(c *container.Container) error {
+	ctx := context.Background()
+
+	if im.org == "" {
+		return errInfluxOrgNotConfigured
+	}
+
+	orgs, err := im.InfluxDB.ListOrganization(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list InfluxDB organizations: %w", err)
+	}
+
+	orgID := findID(orgs, im.org)
+	if orgID == "" {
+		return fmt.Errorf("%w: %s", errInfluxOrgNotFound, im.org)
+	}
+
+	buckets, err := im.InfluxDB.ListBuckets(ctx, im.org)
+	if err != nil {
+		return fmt.Errorf("failed to list InfluxDB buckets: %w", err)
+	}
+
+	if findID(buckets, influxMigrationBucket) == "" {
+		if _, err = im.InfluxDB.CreateBucket(ctx, orgID, influxMigrationBucket); err != nil {
+			return fmt.Errorf("failed to create gofr_migrations bucket: %w", err)
+		}
+	}
+
+	return im.migrator.checkAndCreateMigrationTable(c)
+}
+
+// findID returns the ID of the organization or bucket of the given name, in the maps of IDs to names of InfluxDB.
+func findID(names map[string]string, name string) string {
+	for id, n := range names {
+		if n == name {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// getLastMigration retrieves the latest migration version from InfluxDB.
+func (im influxDBMigrator) getLastMigration(c *container.Container) int64 {
+	records, err := im.InfluxDB.Query(context.Background(), im.org, getLastInfluxMigrationQuery)
+	if err != nil {
+		c.Errorf("Failed to fetch migrations from InfluxDB: %v", err)
+		return 0
+	}
+
+	var lastMigration int64
+
+	if len(records) > 0 {
+		// the integer fields are read as int64.
+		lastMigration, _ = records[0]["_value"].(int64)
+	}
+
+	c.Debugf("InfluxDB last migration fetched value is: %v", lastMigration)
+
+	return max(lastMigration, im.migrator.getLastMigration(c))
+}
+
+func (im influxDBMigrator) beginTransaction(c *container.Container) transactionData {
+	return im.migrator.beginTransaction(c)
+}
+
+// commitMigration writes the record of the migration as a point at its start time.
+func (im influxDBMigrator) commitMigration(c *container.Container, data transactionData) error {
+	record := newDocumentMigrationRecord(data)
+
+	err := im.InfluxDB.WritePoint(context.Background(), im.org, influxMigrationBucket, influxMigrationBucket,
+		map[string]string{"method": record.Method},
+		map[string]any{"version": record.Version, "duration": record.Duration},
+		data.StartTime)
+	if err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	c.Debugf("inserted record for migration %v in InfluxDB gofr_migrations bucket", data.MigrationNumber)
+
+	return im.migrator.commitMigration(c, data)
+}
+
+func (im influxDBMigrator) rollback(c *container.Container, data transactionData) {
+	im.migrator.rollback(c, data)
+
+	c.Fatalf("Migration %v failed.", data.MigrationNumber)
+}
@@ -0,0 +1,147 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+const influxOrg = "app"
+
+var errInfluxConn = errors.New("error connecting to influxdb")
+
+// configuredInfluxDB is an InfluxDB driver configured with the organization of the application.
+type configuredInfluxDB struct {
+	*container.MockInfluxDB
+}
+
+func (configuredInfluxDB) Org() string {
+	return influxOrg
+}
+
+func influxDBSetup(t *testing.T) (migrator, *container.MockInfluxDB, *container.Container) {
+	t.Helper()
+
+	mockContainer, _ := container.NewMockContainer(t)
+	mockInflux := container.NewMockInfluxDB(gomock.NewController(t))
+
+	mockContainer.InfluxDB = mockInflux
+
+	ds := Datasource{InfluxDB: influxDBDS{mockInflux}}
+
+	return influxDBDS{InfluxDB: configuredInfluxDB{mockInflux}}.apply(&ds), mockInflux, mockContainer
+}
+
+func Test_InfluxDBCheckAndCreateMigrationTable(t *testing.T) {
+	migratorWithInflux, mockInflux, mockContainer := influxDBSetup(t)
+
+	// the bucket exists.
+	mockInflux.EXPECT().ListOrganization(gomock.Any()).Return(map[string]string{"o1": "other", "o2": influxOrg}, nil)
+	mockInflux.EXPECT().ListBuckets(gomock.Any(), influxOrg).Return(map[string]string{"b1": influxMigrationBucket}, nil)
+
+	require.NoError(t, migratorWithInflux.checkAndCreateMigrationTable(mockContainer))
+
+	// the bucket is created in the organization of the application.
+	mockInflux.EXPECT().ListOrganization(gomock.Any()).Return(map[string]string{"o2": influxOrg}, nil)
+	mockInflux.EXPECT().ListBuckets(gomock.Any(), influxOrg).Return(map[string]string{}, nil)
+	mockInflux.EXPECT().CreateBucket(gomock.Any(), "o2", influxMigrationBucket).Return("b2", nil)
+
+	require.NoError(t, migratorWithInflux.checkAndCreateMigrationTable(mockContainer))
+
+	// the bucket is not created.
+	mockInflux.EXPECT().ListOrganization(gomock.Any()).Return(map[string]string{"o2": influxOrg}, nil)
+	mockInflux.EXPECT().ListBuckets(gomock.Any(), influxOrg).Return(map[string]string{}, nil)
+	mockInflux.EXPECT().CreateBucket(gomock.Any(), "o2", influxMigrationBucket).Return("", errInfluxConn)
+
+	require.ErrorIs(t, migratorWithInflux.checkAndCreateMigrationTable(mockContainer), errInfluxConn)
+
+	// the organization of the application is not created.
+	mockInflux.EXPECT().ListOrganization(gomock.Any()).Return(map[string]string{"o1": "other"}, nil)
+
+	require.ErrorIs(t, migratorWithInflux.checkAndCreateMigrationTable(mockContainer), errInfluxOrgNotFound)
+
+	mockInflux.EXPECT().ListOrganization(gomock.Any()).Return(nil, errInfluxConn)
+
+	require.ErrorIs(t, migratorWithInflux.checkAndCreateMigrationTable(mockContainer), errInfluxConn)
+}
+
+func Test_InfluxDBOrgNotConfigured(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	mockInflux := container.NewMockInfluxDB(gomock.NewController(t))
+
+	migratorWithInflux := influxDBDS{InfluxDB: mockInflux}.apply(&Datasource{})
+
+	require.ErrorIs(t, migratorWithInflux.checkAndCreateMigrationTable(mockContainer), errInfluxOrgNotConfigured)
+}
+
+func Test_InfluxDBGetLastMigration(t *testing.T) {
+	migratorWithInflux, mockInflux, mockContainer := influxDBSetup(t)
+
+	testCases := []struct {
+		desc    string
+		records []map[string]any
+		err     error
+		last    int64
+	}{
+		{"no migrations", nil, nil, 0},
+		{"migrations", []map[string]any{{"_field": "version", "_value": int64(8)}}, nil, 8},
+		{"connection failed", nil, errInfluxConn, 0},
+	}
+
+	for i, tc := range testCases {
+		mockInflux.EXPECT().Query(gomock.Any(), influxOrg, getLastInfluxMigrationQuery).Return(tc.records, tc.err)
+
+		assert.Equal(t, tc.last, migratorWithInflux.getLastMigration(mockContainer), "TEST[%v]\n %v Failed! ", i, tc.desc)
+	}
+}
+
+func Test_InfluxDBCommitMigration(t *testing.T) {
+	migratorWithInflux, mockInflux, mockContainer := influxDBSetup(t)
+
+	td := transactionData{
+		StartTime:       time.Now(),
+		MigrationNumber: 9,
+	}
+
+	mockInflux.EXPECT().WritePoint(gomock.Any(), influxOrg, influxMigrationBucket, influxMigrationBucket,
+		map[string]string{"method": "UP"}, gomock.Any(), td.StartTime).DoAndReturn(
+		func(_ any, _, _, _ string, _ map[string]string, fields map[string]any, _ time.Time) error {
+			assert.Equal(t, int64(9), fields["version"])
+
+			return nil
+		})
+
+	require.NoError(t, migratorWithInflux.commitMigration(mockContainer, td))
+
+	mockInflux.EXPECT().WritePoint(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Return(errInfluxConn)
+
+	require.ErrorIs(t, migratorWithInflux.commitMigration(mockContainer, td), errInfluxConn)
+}
+
+// retentionInfluxDB is an InfluxDB driver changing the retention of buckets.
+type retentionInfluxDB struct {
+	*container.MockInfluxDB
+	*container.MockInfluxDBRetentionManager
+}
+
+func Test_InfluxDBUpdateBucketRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRetention := container.NewMockInfluxDBRetentionManager(ctrl)
+
+	ds := Datasource{InfluxDB: influxDBDS{retentionInfluxDB{container.NewMockInfluxDB(ctrl), mockRetention}}}
+
+	mockRetention.EXPECT().UpdateBucketRetention(gomock.Any(), "b1", time.Hour).Return(nil)
+
+	require.NoError(t, ds.InfluxDB.UpdateBucketRetention(t.Context(), "b1", time.Hour))
+
+	ds = Datasource{InfluxDB: influxDBDS{container.NewMockInfluxDB(ctrl)}}
+
+	require.ErrorIs(t, ds.InfluxDB.UpdateBucketRetention(t.Context(), "b1", time.Hour), errInfluxNotManaged)
+}
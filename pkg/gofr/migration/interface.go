@@ -1,6 +1,7 @@
 package migration
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"time"
@@ -68,6 +69,9 @@ type Mongo interface {
 	Drop(ctx context.Context, collection string) error
 	CreateCollection(ctx context.Context, name string) error
 	StartSession() (any, error)
+	CreateIndex(ctx context.Context, collection string, keys any, opts container.IndexOptions) (string, error)
+	ListIndexes(ctx context.Context, collection string) ([]map[string]any, error)
+	DropIndex(ctx context.Context, collection, name string) error
 }
 
 // ArangoDB is an interface representing an ArangoDB database client with common CRUD operations.
@@ -176,6 +180,76 @@ type Elasticsearch interface {
 	Bulk(ctx context.Context, operations []map[string]any) (map[string]any, error)
 }
 
+// Couchbase is an interface representing a Couchbase client for migration operations.
+type Couchbase interface {
+	// CreateBucket creates a bucket in the cluster with a RAM quota of ramQuotaMB megabytes.
+	CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error
+	// DropBucket deletes a bucket of the cluster with all its documents.
+	DropBucket(ctx context.Context, bucket string) error
+
+	// CreateScope creates a scope in the bucket of the client.
+	CreateScope(ctx context.Context, scope string) error
+	// DropScope deletes a scope of the bucket of the client with all its collections.
+	DropScope(ctx context.Context, scope string) error
+
+	// CreateCollection creates a collection in a scope of the bucket of the client.
+	CreateCollection(ctx context.Context, scope, collection string) error
+	// DropCollection deletes a collection of a scope of the bucket of the client with all its documents.
+	DropCollection(ctx context.Context, scope, collection string) error
+
+	// Query executes a N1QL statement, such as the ones creating or dropping indexes. The result is nil for the
+	// statements returning no rows.
+	Query(ctx context.Context, statement string, params map[string]any, result any) error
+
+	// Upsert inserts or replaces a document of the default collection, for seeding data.
+	Upsert(ctx context.Context, key string, document any, result any) error
+	// Remove deletes a document of the default collection.
+	Remove(ctx context.Context, key string) error
+}
+
+// Solr is an interface representing a Solr client for migration operations.
+type Solr interface {
+	// CreateCollection creates a collection, params holding parameters like numShards and collection.configName.
+	CreateCollection(ctx context.Context, collection string, params map[string]any) (any, error)
+	// DeleteCollection deletes a collection with all its documents.
+	DeleteCollection(ctx context.Context, collection string) (any, error)
+
+	// AddField, UpdateField and DeleteField change the schema of a collection, with the commands of the schema API.
+	AddField(ctx context.Context, collection string, document *bytes.Buffer) (any, error)
+	UpdateField(ctx context.Context, collection string, document *bytes.Buffer) (any, error)
+	DeleteField(ctx context.Context, collection string, document *bytes.Buffer) (any, error)
+
+	// Create and Delete index and delete documents, for seeding data.
+	Create(ctx context.Context, collection string, document *bytes.Buffer, params map[string]any) (any, error)
+	Delete(ctx context.Context, collection string, document *bytes.Buffer, params map[string]any) (any, error)
+}
+
+// InfluxDB is an interface representing an InfluxDB client for migration operations.
+type InfluxDB interface {
+	// CreateOrganization creates an organization, returning its ID.
+	CreateOrganization(ctx context.Context, org string) (string, error)
+	// DeleteOrganization deletes an organization by its ID.
+	DeleteOrganization(ctx context.Context, orgID string) error
+
+	// CreateBucket creates a bucket in the organization of the given ID, returning the ID of the bucket.
+	CreateBucket(ctx context.Context, orgID, bucket string) (string, error)
+	// DeleteBucket deletes a bucket by its ID.
+	DeleteBucket(ctx context.Context, bucketID string) error
+	// UpdateBucketRetention sets how long the data of a bucket is kept, 0 keeping it forever.
+	UpdateBucketRetention(ctx context.Context, bucketID string, retention time.Duration) error
+
+	// WritePoint writes a point to a bucket, for seeding data.
+	WritePoint(ctx context.Context, org, bucket, measurement string, tags map[string]string, fields map[string]any,
+		timestamp time.Time) error
+}
+
+// KVStore is an interface representing a key-value store for seeding and removing data in migrations.
+type KVStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string) error
+	Delete(ctx context.Context, key string) error
+}
+
 // keeping the migrator interface unexported as, right now it is not being implemented directly, by the externalDB drivers.
 // keeping the implementations for externalDB at one place such that if any change in migration logic, we would change directly here.
 type migrator interface {
@@ -0,0 +1,193 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+const (
+	// kvStoreMigrationKey is the key holding the records of the migrations, as a JSON array.
+	kvStoreMigrationKey = "gofr_migrations"
+	// maxRecordAttempts bounds the attempts to swap in the records of the migrations, written concurrently.
+	maxRecordAttempts = 5
+)
+
+var errRecordConflict = errors.New("records of migrations written concurrently")
+
+// documentMigrationRecord is the record of a migration, for the datastores keeping the records of all the migrations in a
+// single document or value.
+type documentMigrationRecord struct {
+	Version   int64  `json:"version"`
+	Method    string `json:"method"`
+	StartTime string `json:"start_time"`
+	Duration  int64  `json:"duration"`
+}
+
+func newDocumentMigrationRecord(data transactionData) documentMigrationRecord {
+	return documentMigrationRecord{
+		Version:   data.MigrationNumber,
+		Method:    "UP",
+		StartTime: data.StartTime.UTC().Format(time.RFC3339),
+		Duration:  time.Since(data.StartTime).Milliseconds(),
+	}
+}
+
+func lastMigrationVersion(records []documentMigrationRecord) int64 {
+	var lastMigration int64
+
+	for _, r := range records {
+		lastMigration = max(lastMigration, r.Version)
+	}
+
+	return lastMigration
+}
+
+type kvStoreDS struct {
+	container.KVStore
+}
+
+type kvStoreMigrator struct {
+	container.KVStore
+	migrator
+}
+
+// apply initializes kvStoreMigrator using the KVStore interface.
+func (ds kvStoreDS) apply(m migrator) migrator {
+	return kvStoreMigrator{
+		KVStore:  ds.KVStore,
+		migrator: m,
+	}
+}
+
+// checkAndCreateMigrationTable initializes the key of the records of the migrations if it doesn't exist.
+func (km kvStoreMigrator) checkAndCreateMigrationTable(c *container.Container) error {
+	_, err := km.KVStore.Get(context.Background(), kvStoreMigrationKey)
+	if errors.Is(err, container.ErrKeyNotFound) {
+		err = km.KVStore.Set(context.Background(), kvStoreMigrationKey, "[]")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return km.migrator.checkAndCreateMigrationTable(c)
+}
+
+// getLastMigration retrieves the latest migration version from the key-value store.
+func (km kvStoreMigrator) getLastMigration(c *container.Container) int64 {
+	records, err := km.records()
+	if err != nil {
+		c.Errorf("Failed to fetch migrations from KVStore: %v", err)
+		return 0
+	}
+
+	lastMigration := lastMigrationVersion(records)
+
+	c.Debugf("KVStore last migration fetched value is: %v", lastMigration)
+
+	return max(lastMigration, km.migrator.getLastMigration(c))
+}
+
+func (km kvStoreMigrator) beginTransaction(c *container.Container) transactionData {
+	return km.migrator.beginTransaction(c)
+}
+
+// commitMigration appends the record of the migration to the ones of the key-value store. The stores implementing
+// container.KVStoreCAS swap the records in, for the records committed concurrently by other instances not to be lost.
+func (km kvStoreMigrator) commitMigration(c *container.Container, data transactionData) error {
+	record := newDocumentMigrationRecord(data)
+
+	var err error
+
+	if cas, ok := km.KVStore.(container.KVStoreCAS); ok {
+		err = swapRecord(cas, record)
+	} else {
+		err = km.setRecord(record)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	c.Debugf("inserted record for migration %v in KVStore gofr_migrations key", data.MigrationNumber)
+
+	return km.migrator.commitMigration(c, data)
+}
+
+func (km kvStoreMigrator) setRecord(record documentMigrationRecord) error {
+	value, err := km.KVStore.Get(context.Background(), kvStoreMigrationKey)
+	if err != nil {
+		return err
+	}
+
+	value, err = appendRecord(value, record)
+	if err != nil {
+		return err
+	}
+
+	return km.KVStore.Set(context.Background(), kvStoreMigrationKey, value)
+}
+
+// swapRecord appends record to the records of the migrations if they were not written since they were read,
+// reading them again otherwise.
+func swapRecord(cas container.KVStoreCAS, record documentMigrationRecord) error {
+	for range maxRecordAttempts {
+		value, revision, err := cas.GetWithRevision(context.Background(), kvStoreMigrationKey)
+		if err != nil {
+			return err
+		}
+
+		value, err = appendRecord(value, record)
+		if err != nil {
+			return err
+		}
+
+		_, swapped, err := cas.CompareAndSwap(context.Background(), kvStoreMigrationKey, value, revision)
+		if err != nil || swapped {
+			return err
+		}
+	}
+
+	return errRecordConflict
+}
+
+func appendRecord(value string, record documentMigrationRecord) (string, error) {
+	records, err := parseRecords(value)
+	if err != nil {
+		return "", err
+	}
+
+	updated, err := json.Marshal(append(records, record))
+
+	return string(updated), err
+}
+
+func (km kvStoreMigrator) rollback(c *container.Container, data transactionData) {
+	km.migrator.rollback(c, data)
+
+	c.Fatalf("Migration %v failed.", data.MigrationNumber)
+}
+
+func (km kvStoreMigrator) records() ([]documentMigrationRecord, error) {
+	value, err := km.KVStore.Get(context.Background(), kvStoreMigrationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRecords(value)
+}
+
+func parseRecords(value string) ([]documentMigrationRecord, error) {
+	var records []documentMigrationRecord
+
+	if err := json.Unmarshal([]byte(value), &records); err != nil {
+		return nil, fmt.Errorf("invalid records of migrations: %w", err)
+	}
+
+	return records, nil
+}
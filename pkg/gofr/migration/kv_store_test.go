@@ -0,0 +1,149 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+var (
+	errKVKeyNotFound = fmt.Errorf("%w: %s", container.ErrKeyNotFound, kvStoreMigrationKey)
+	errKVConn        = errors.New("error connecting to key-value store")
+)
+
+func kvStoreSetup(t *testing.T) (migrator, *container.MockKVStore, *container.Container) {
+	t.Helper()
+
+	mockContainer, mocks := container.NewMockContainer(t)
+
+	ds := Datasource{KVStore: mockContainer.KVStore}
+
+	return kvStoreDS{KVStore: mocks.KVStore}.apply(&ds), mocks.KVStore, mockContainer
+}
+
+func Test_KVStoreCheckAndCreateMigrationTable(t *testing.T) {
+	migratorWithKV, mockKV, mockContainer := kvStoreSetup(t)
+
+	testCases := []struct {
+		desc   string
+		getErr error
+		setErr error
+		err    error
+	}{
+		{"key exists", nil, nil, nil},
+		{"key created", errKVKeyNotFound, nil, nil},
+		{"key not created", errKVKeyNotFound, errKVConn, errKVConn},
+		{"connection failed", errKVConn, nil, errKVConn},
+	}
+
+	for i, tc := range testCases {
+		mockKV.EXPECT().Get(gomock.Any(), kvStoreMigrationKey).Return(`[]`, tc.getErr)
+
+		if errors.Is(tc.getErr, errKVKeyNotFound) {
+			mockKV.EXPECT().Set(gomock.Any(), kvStoreMigrationKey, "[]").Return(tc.setErr)
+		}
+
+		err := migratorWithKV.checkAndCreateMigrationTable(mockContainer)
+
+		assert.Equal(t, tc.err, err, "TEST[%v]\n %v Failed! ", i, tc.desc)
+	}
+}
+
+func Test_KVStoreGetLastMigration(t *testing.T) {
+	migratorWithKV, mockKV, mockContainer := kvStoreSetup(t)
+
+	testCases := []struct {
+		desc  string
+		value string
+		err   error
+		resp  int64
+	}{
+		{"no migrations", `[]`, nil, 0},
+		{"migrations", `[{"version":3,"method":"UP"},{"version":7,"method":"UP"},{"version":5,"method":"UP"}]`, nil, 7},
+		{"invalid records", `{`, nil, 0},
+		{"connection failed", ``, errKVConn, 0},
+	}
+
+	for i, tc := range testCases {
+		mockKV.EXPECT().Get(gomock.Any(), kvStoreMigrationKey).Return(tc.value, tc.err)
+
+		resp := migratorWithKV.getLastMigration(mockContainer)
+
+		assert.Equal(t, tc.resp, resp, "TEST[%v]\n %v Failed! ", i, tc.desc)
+	}
+}
+
+func Test_KVStoreCommitMigration(t *testing.T) {
+	migratorWithKV, mockKV, mockContainer := kvStoreSetup(t)
+
+	td := transactionData{
+		StartTime:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		MigrationNumber: 10,
+	}
+
+	mockKV.EXPECT().Get(gomock.Any(), kvStoreMigrationKey).Return(`[{"version":9,"method":"UP"}]`, nil)
+	mockKV.EXPECT().Set(gomock.Any(), kvStoreMigrationKey, gomock.Any()).DoAndReturn(
+		func(_ any, _, value string) error {
+			assert.Contains(t, value, `{"version":9,"method":"UP","start_time":"","duration":0},`+
+				`{"version":10,"method":"UP","start_time":"2025-01-02T03:04:05Z"`)
+
+			return nil
+		})
+
+	require.NoError(t, migratorWithKV.commitMigration(mockContainer, td))
+
+	mockKV.EXPECT().Get(gomock.Any(), kvStoreMigrationKey).Return(`[]`, nil)
+	mockKV.EXPECT().Set(gomock.Any(), kvStoreMigrationKey, gomock.Any()).Return(errKVConn)
+
+	require.ErrorIs(t, migratorWithKV.commitMigration(mockContainer, td), errKVConn)
+
+	mockKV.EXPECT().Get(gomock.Any(), kvStoreMigrationKey).Return(``, errKVConn)
+
+	require.ErrorIs(t, migratorWithKV.commitMigration(mockContainer, td), errKVConn)
+}
+
+// casKVStore is a key-value store updating keys conditionally.
+type casKVStore struct {
+	*container.MockKVStore
+	*container.MockKVStoreCAS
+}
+
+func Test_KVStoreCommitMigrationWithCAS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockContainer, _ := container.NewMockContainer(t)
+	mockCAS := container.NewMockKVStoreCAS(ctrl)
+
+	migratorWithKV := kvStoreDS{KVStore: casKVStore{container.NewMockKVStore(ctrl), mockCAS}}.apply(&Datasource{})
+
+	td := transactionData{StartTime: time.Now(), MigrationNumber: 10}
+
+	gomock.InOrder(
+		mockCAS.EXPECT().GetWithRevision(gomock.Any(), kvStoreMigrationKey).Return(`[]`, uint64(3), nil),
+		mockCAS.EXPECT().CompareAndSwap(gomock.Any(), kvStoreMigrationKey, gomock.Any(), uint64(3)).
+			Return(uint64(0), false, nil),
+		mockCAS.EXPECT().GetWithRevision(gomock.Any(), kvStoreMigrationKey).Return(`[{"version":9}]`, uint64(4), nil),
+		mockCAS.EXPECT().CompareAndSwap(gomock.Any(), kvStoreMigrationKey, gomock.Any(), uint64(4)).DoAndReturn(
+			func(_ any, _, value string, _ uint64) (uint64, bool, error) {
+				assert.Contains(t, value, `{"version":9,`, "the record committed concurrently is kept")
+				assert.Contains(t, value, `{"version":10,`)
+
+				return 5, true, nil
+			}),
+	)
+
+	require.NoError(t, migratorWithKV.commitMigration(mockContainer, td))
+
+	mockCAS.EXPECT().GetWithRevision(gomock.Any(), kvStoreMigrationKey).Return(`[]`, uint64(5), nil).
+		Times(maxRecordAttempts)
+	mockCAS.EXPECT().CompareAndSwap(gomock.Any(), kvStoreMigrationKey, gomock.Any(), uint64(5)).
+		Return(uint64(0), false, nil).Times(maxRecordAttempts)
+
+	require.ErrorIs(t, migratorWithKV.commitMigration(mockContainer, td), errRecordConflict)
+}
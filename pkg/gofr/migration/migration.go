@@ -217,6 +217,30 @@ func initializeDatasources(c *container.Container, ds *Datasource, mg migrator)
 			apply:         func(m migrator) migrator { return scyllaDS{c.ScyllaDB}.apply(m) },
 			logIdentifier: "ScyllaDB",
 		},
+		{
+			condition:     func() bool { return !isNil(c.Couchbase) },
+			setDS:         func() { ds.Couchbase = couchbaseDS{c.Couchbase} },
+			apply:         func(m migrator) migrator { return couchbaseDS{c.Couchbase}.apply(m) },
+			logIdentifier: "Couchbase",
+		},
+		{
+			condition:     func() bool { return !isNil(c.Solr) },
+			setDS:         func() { ds.Solr = solrDS{c.Solr} },
+			apply:         func(m migrator) migrator { return solrDS{c.Solr}.apply(m) },
+			logIdentifier: "Solr",
+		},
+		{
+			condition:     func() bool { return !isNil(c.InfluxDB) },
+			setDS:         func() { ds.InfluxDB = influxDBDS{c.InfluxDB} },
+			apply:         func(m migrator) migrator { return influxDBDS{c.InfluxDB}.apply(m) },
+			logIdentifier: "InfluxDB",
+		},
+		{
+			condition:     func() bool { return !isNil(c.KVStore) },
+			setDS:         func() { ds.KVStore = c.KVStore },
+			apply:         func(m migrator) migrator { return kvStoreDS{c.KVStore}.apply(m) },
+			logIdentifier: "KVStore",
+		},
 	}
 
 	for _, init := range initializers {
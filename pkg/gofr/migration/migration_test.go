@@ -202,6 +202,8 @@ func initializeClickHouseRunMocks(t *testing.T) (*MockClickhouse, *container.Con
 	mockContainer.Elasticsearch = nil
 	mockContainer.OpenTSDB = nil
 	mockContainer.ScyllaDB = nil
+	mockContainer.Couchbase = nil
+	mockContainer.KVStore = nil
 	mockContainer.Oracle = nil
 	mockContainer.Logger = logging.NewMockLogger(logging.DEBUG)
 	mockContainer.Clickhouse = mockClickHouse
@@ -10,6 +10,7 @@
 package migration
 
 import (
+	bytes "bytes"
 	context "context"
 	sql "database/sql"
 	reflect "reflect"
@@ -600,6 +601,21 @@ func (mr *MockMongoMockRecorder) CreateCollection(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockMongo)(nil).CreateCollection), ctx, name)
 }
 
+// CreateIndex mocks base method.
+func (m *MockMongo) CreateIndex(ctx context.Context, collection string, keys any, opts container.IndexOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", ctx, collection, keys, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockMongoMockRecorder) CreateIndex(ctx, collection, keys, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockMongo)(nil).CreateIndex), ctx, collection, keys, opts)
+}
+
 // DeleteMany mocks base method.
 func (m *MockMongo) DeleteMany(ctx context.Context, collection string, filter any) (int64, error) {
 	m.ctrl.T.Helper()
@@ -644,6 +660,20 @@ func (mr *MockMongoMockRecorder) Drop(ctx, collection any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockMongo)(nil).Drop), ctx, collection)
 }
 
+// DropIndex mocks base method.
+func (m *MockMongo) DropIndex(ctx context.Context, collection string, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropIndex", ctx, collection, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropIndex indicates an expected call of DropIndex.
+func (mr *MockMongoMockRecorder) DropIndex(ctx, collection, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropIndex", reflect.TypeOf((*MockMongo)(nil).DropIndex), ctx, collection, name)
+}
+
 // Find mocks base method.
 func (m *MockMongo) Find(ctx context.Context, collection string, filter, results any) error {
 	m.ctrl.T.Helper()
@@ -702,6 +732,21 @@ func (mr *MockMongoMockRecorder) InsertOne(ctx, collection, document any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOne", reflect.TypeOf((*MockMongo)(nil).InsertOne), ctx, collection, document)
 }
 
+// ListIndexes mocks base method.
+func (m *MockMongo) ListIndexes(ctx context.Context, collection string) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIndexes", ctx, collection)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIndexes indicates an expected call of ListIndexes.
+func (mr *MockMongoMockRecorder) ListIndexes(ctx, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIndexes", reflect.TypeOf((*MockMongo)(nil).ListIndexes), ctx, collection)
+}
+
 // StartSession mocks base method.
 func (m *MockMongo) StartSession() (any, error) {
 	m.ctrl.T.Helper()
@@ -1184,6 +1229,123 @@ func (mr *MockElasticsearchMockRecorder) UpdateDocument(ctx, index, id, update a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDocument", reflect.TypeOf((*MockElasticsearch)(nil).UpdateDocument), ctx, index, id, update)
 }
 
+// ClearScroll mocks base method.
+func (m *MockElasticsearch) ClearScroll(ctx context.Context, scrollID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearScroll", ctx, scrollID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearScroll indicates an expected call of ClearScroll.
+func (mr *MockElasticsearchMockRecorder) ClearScroll(ctx, scrollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearScroll", reflect.TypeOf((*MockElasticsearch)(nil).ClearScroll), ctx, scrollID)
+}
+
+// ClosePointInTime mocks base method.
+func (m *MockElasticsearch) ClosePointInTime(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePointInTime", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePointInTime indicates an expected call of ClosePointInTime.
+func (mr *MockElasticsearchMockRecorder) ClosePointInTime(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePointInTime", reflect.TypeOf((*MockElasticsearch)(nil).ClosePointInTime), ctx, id)
+}
+
+// GetAliasIndices mocks base method.
+func (m *MockElasticsearch) GetAliasIndices(ctx context.Context, alias string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAliasIndices", ctx, alias)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAliasIndices indicates an expected call of GetAliasIndices.
+func (mr *MockElasticsearchMockRecorder) GetAliasIndices(ctx, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAliasIndices", reflect.TypeOf((*MockElasticsearch)(nil).GetAliasIndices), ctx, alias)
+}
+
+// OpenPointInTime mocks base method.
+func (m *MockElasticsearch) OpenPointInTime(ctx context.Context, indices []string, keepAlive time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenPointInTime", ctx, indices, keepAlive)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenPointInTime indicates an expected call of OpenPointInTime.
+func (mr *MockElasticsearchMockRecorder) OpenPointInTime(ctx, indices, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPointInTime", reflect.TypeOf((*MockElasticsearch)(nil).OpenPointInTime), ctx, indices, keepAlive)
+}
+
+// ScrollNext mocks base method.
+func (m *MockElasticsearch) ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScrollNext", ctx, scrollID, keepAlive)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScrollNext indicates an expected call of ScrollNext.
+func (mr *MockElasticsearchMockRecorder) ScrollNext(ctx, scrollID, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScrollNext", reflect.TypeOf((*MockElasticsearch)(nil).ScrollNext), ctx, scrollID, keepAlive)
+}
+
+// SearchScroll mocks base method.
+func (m *MockElasticsearch) SearchScroll(ctx context.Context, indices []string, query map[string]any, keepAlive time.Duration) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchScroll", ctx, indices, query, keepAlive)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchScroll indicates an expected call of SearchScroll.
+func (mr *MockElasticsearchMockRecorder) SearchScroll(ctx, indices, query, keepAlive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchScroll", reflect.TypeOf((*MockElasticsearch)(nil).SearchScroll), ctx, indices, query, keepAlive)
+}
+
+// SwapAlias mocks base method.
+func (m *MockElasticsearch) SwapAlias(ctx context.Context, alias, index string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapAlias", ctx, alias, index)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapAlias indicates an expected call of SwapAlias.
+func (mr *MockElasticsearchMockRecorder) SwapAlias(ctx, alias, index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapAlias", reflect.TypeOf((*MockElasticsearch)(nil).SwapAlias), ctx, alias, index)
+}
+
+// UpdateAliases mocks base method.
+func (m *MockElasticsearch) UpdateAliases(ctx context.Context, actions []map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAliases", ctx, actions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAliases indicates an expected call of UpdateAliases.
+func (mr *MockElasticsearchMockRecorder) UpdateAliases(ctx, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAliases", reflect.TypeOf((*MockElasticsearch)(nil).UpdateAliases), ctx, actions)
+}
+
 // MockScyllaDB is a mock of ScyllaDB interface.
 type MockScyllaDB struct {
 	ctrl     *gomock.Controller
@@ -1602,6 +1764,462 @@ type DataPoint struct {
 	Tags      map[string]string `json:"tags"`
 }
 
+// MockCouchbase is a mock of Couchbase interface.
+type MockCouchbase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouchbaseMockRecorder
+	isgomock struct{}
+}
+
+// MockCouchbaseMockRecorder is the mock recorder for MockCouchbase.
+type MockCouchbaseMockRecorder struct {
+	mock *MockCouchbase
+}
+
+// NewMockCouchbase creates a new mock instance.
+func NewMockCouchbase(ctrl *gomock.Controller) *MockCouchbase {
+	mock := &MockCouchbase{ctrl: ctrl}
+	mock.recorder = &MockCouchbaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouchbase) EXPECT() *MockCouchbaseMockRecorder {
+	return m.recorder
+}
+
+// CreateBucket mocks base method.
+func (m *MockCouchbase) CreateBucket(ctx context.Context, bucket string, ramQuotaMB uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", ctx, bucket, ramQuotaMB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBucket indicates an expected call of CreateBucket.
+func (mr *MockCouchbaseMockRecorder) CreateBucket(ctx, bucket, ramQuotaMB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockCouchbase)(nil).CreateBucket), ctx, bucket, ramQuotaMB)
+}
+
+// CreateCollection mocks base method.
+func (m *MockCouchbase) CreateCollection(ctx context.Context, scope, collection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, scope, collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockCouchbaseMockRecorder) CreateCollection(ctx, scope, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockCouchbase)(nil).CreateCollection), ctx, scope, collection)
+}
+
+// CreateScope mocks base method.
+func (m *MockCouchbase) CreateScope(ctx context.Context, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScope", ctx, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateScope indicates an expected call of CreateScope.
+func (mr *MockCouchbaseMockRecorder) CreateScope(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScope", reflect.TypeOf((*MockCouchbase)(nil).CreateScope), ctx, scope)
+}
+
+// DropBucket mocks base method.
+func (m *MockCouchbase) DropBucket(ctx context.Context, bucket string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropBucket", ctx, bucket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropBucket indicates an expected call of DropBucket.
+func (mr *MockCouchbaseMockRecorder) DropBucket(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropBucket", reflect.TypeOf((*MockCouchbase)(nil).DropBucket), ctx, bucket)
+}
+
+// DropCollection mocks base method.
+func (m *MockCouchbase) DropCollection(ctx context.Context, scope, collection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropCollection", ctx, scope, collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropCollection indicates an expected call of DropCollection.
+func (mr *MockCouchbaseMockRecorder) DropCollection(ctx, scope, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropCollection", reflect.TypeOf((*MockCouchbase)(nil).DropCollection), ctx, scope, collection)
+}
+
+// DropScope mocks base method.
+func (m *MockCouchbase) DropScope(ctx context.Context, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropScope", ctx, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropScope indicates an expected call of DropScope.
+func (mr *MockCouchbaseMockRecorder) DropScope(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropScope", reflect.TypeOf((*MockCouchbase)(nil).DropScope), ctx, scope)
+}
+
+// Query mocks base method.
+func (m *MockCouchbase) Query(ctx context.Context, statement string, params map[string]any, result any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, statement, params, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockCouchbaseMockRecorder) Query(ctx, statement, params, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockCouchbase)(nil).Query), ctx, statement, params, result)
+}
+
+// Remove mocks base method.
+func (m *MockCouchbase) Remove(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCouchbaseMockRecorder) Remove(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCouchbase)(nil).Remove), ctx, key)
+}
+
+// Upsert mocks base method.
+func (m *MockCouchbase) Upsert(ctx context.Context, key string, document, result any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, key, document, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockCouchbaseMockRecorder) Upsert(ctx, key, document, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCouchbase)(nil).Upsert), ctx, key, document, result)
+}
+
+// MockSolr is a mock of Solr interface.
+type MockSolr struct {
+	ctrl     *gomock.Controller
+	recorder *MockSolrMockRecorder
+	isgomock struct{}
+}
+
+// MockSolrMockRecorder is the mock recorder for MockSolr.
+type MockSolrMockRecorder struct {
+	mock *MockSolr
+}
+
+// NewMockSolr creates a new mock instance.
+func NewMockSolr(ctrl *gomock.Controller) *MockSolr {
+	mock := &MockSolr{ctrl: ctrl}
+	mock.recorder = &MockSolrMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSolr) EXPECT() *MockSolrMockRecorder {
+	return m.recorder
+}
+
+// AddField mocks base method.
+func (m *MockSolr) AddField(ctx context.Context, collection string, document *bytes.Buffer) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddField", ctx, collection, document)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddField indicates an expected call of AddField.
+func (mr *MockSolrMockRecorder) AddField(ctx, collection, document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddField", reflect.TypeOf((*MockSolr)(nil).AddField), ctx, collection, document)
+}
+
+// Create mocks base method.
+func (m *MockSolr) Create(ctx context.Context, collection string, document *bytes.Buffer, params map[string]any) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, collection, document, params)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSolrMockRecorder) Create(ctx, collection, document, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSolr)(nil).Create), ctx, collection, document, params)
+}
+
+// CreateCollection mocks base method.
+func (m *MockSolr) CreateCollection(ctx context.Context, collection string, params map[string]any) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, collection, params)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockSolrMockRecorder) CreateCollection(ctx, collection, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockSolr)(nil).CreateCollection), ctx, collection, params)
+}
+
+// Delete mocks base method.
+func (m *MockSolr) Delete(ctx context.Context, collection string, document *bytes.Buffer, params map[string]any) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, collection, document, params)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSolrMockRecorder) Delete(ctx, collection, document, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSolr)(nil).Delete), ctx, collection, document, params)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockSolr) DeleteCollection(ctx context.Context, collection string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, collection)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockSolrMockRecorder) DeleteCollection(ctx, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockSolr)(nil).DeleteCollection), ctx, collection)
+}
+
+// DeleteField mocks base method.
+func (m *MockSolr) DeleteField(ctx context.Context, collection string, document *bytes.Buffer) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteField", ctx, collection, document)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteField indicates an expected call of DeleteField.
+func (mr *MockSolrMockRecorder) DeleteField(ctx, collection, document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteField", reflect.TypeOf((*MockSolr)(nil).DeleteField), ctx, collection, document)
+}
+
+// UpdateField mocks base method.
+func (m *MockSolr) UpdateField(ctx context.Context, collection string, document *bytes.Buffer) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateField", ctx, collection, document)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateField indicates an expected call of UpdateField.
+func (mr *MockSolrMockRecorder) UpdateField(ctx, collection, document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateField", reflect.TypeOf((*MockSolr)(nil).UpdateField), ctx, collection, document)
+}
+
+// MockInfluxDB is a mock of InfluxDB interface.
+type MockInfluxDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockInfluxDBMockRecorder
+	isgomock struct{}
+}
+
+// MockInfluxDBMockRecorder is the mock recorder for MockInfluxDB.
+type MockInfluxDBMockRecorder struct {
+	mock *MockInfluxDB
+}
+
+// NewMockInfluxDB creates a new mock instance.
+func NewMockInfluxDB(ctrl *gomock.Controller) *MockInfluxDB {
+	mock := &MockInfluxDB{ctrl: ctrl}
+	mock.recorder = &MockInfluxDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInfluxDB) EXPECT() *MockInfluxDBMockRecorder {
+	return m.recorder
+}
+
+// CreateBucket mocks base method.
+func (m *MockInfluxDB) CreateBucket(ctx context.Context, orgID, bucket string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", ctx, orgID, bucket)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBucket indicates an expected call of CreateBucket.
+func (mr *MockInfluxDBMockRecorder) CreateBucket(ctx, orgID, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockInfluxDB)(nil).CreateBucket), ctx, orgID, bucket)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockInfluxDB) CreateOrganization(ctx context.Context, org string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, org)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockInfluxDBMockRecorder) CreateOrganization(ctx, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockInfluxDB)(nil).CreateOrganization), ctx, org)
+}
+
+// DeleteBucket mocks base method.
+func (m *MockInfluxDB) DeleteBucket(ctx context.Context, bucketID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBucket", ctx, bucketID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBucket indicates an expected call of DeleteBucket.
+func (mr *MockInfluxDBMockRecorder) DeleteBucket(ctx, bucketID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucket", reflect.TypeOf((*MockInfluxDB)(nil).DeleteBucket), ctx, bucketID)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockInfluxDB) DeleteOrganization(ctx context.Context, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockInfluxDBMockRecorder) DeleteOrganization(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockInfluxDB)(nil).DeleteOrganization), ctx, orgID)
+}
+
+// UpdateBucketRetention mocks base method.
+func (m *MockInfluxDB) UpdateBucketRetention(ctx context.Context, bucketID string, retention time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBucketRetention", ctx, bucketID, retention)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBucketRetention indicates an expected call of UpdateBucketRetention.
+func (mr *MockInfluxDBMockRecorder) UpdateBucketRetention(ctx, bucketID, retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBucketRetention", reflect.TypeOf((*MockInfluxDB)(nil).UpdateBucketRetention), ctx, bucketID, retention)
+}
+
+// WritePoint mocks base method.
+func (m *MockInfluxDB) WritePoint(ctx context.Context, org, bucket, measurement string, tags map[string]string, fields map[string]any, timestamp time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WritePoint", ctx, org, bucket, measurement, tags, fields, timestamp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WritePoint indicates an expected call of WritePoint.
+func (mr *MockInfluxDBMockRecorder) WritePoint(ctx, org, bucket, measurement, tags, fields, timestamp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritePoint", reflect.TypeOf((*MockInfluxDB)(nil).WritePoint), ctx, org, bucket, measurement, tags, fields, timestamp)
+}
+
+// MockKVStore is a mock of KVStore interface.
+type MockKVStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockKVStoreMockRecorder is the mock recorder for MockKVStore.
+type MockKVStoreMockRecorder struct {
+	mock *MockKVStore
+}
+
+// NewMockKVStore creates a new mock instance.
+func NewMockKVStore(ctrl *gomock.Controller) *MockKVStore {
+	mock := &MockKVStore{ctrl: ctrl}
+	mock.recorder = &MockKVStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStore) EXPECT() *MockKVStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockKVStore) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKVStoreMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKVStore)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockKVStore) Get(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKVStoreMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKVStore)(nil).Get), ctx, key)
+}
+
+// Set mocks base method.
+func (m *MockKVStore) Set(ctx context.Context, key, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockKVStoreMockRecorder) Set(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockKVStore)(nil).Set), ctx, key, value)
+}
+
 // Mockmigrator is a mock of migrator interface.
 type Mockmigrator struct {
 	ctrl     *gomock.Controller
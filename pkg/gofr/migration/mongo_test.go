@@ -113,3 +113,20 @@ func Test_MongoCommitMigration(t *testing.T) {
 		assert.Equal(t, tc.err, err, "TEST[%v]\n %v Failed! ", i, tc.desc)
 	}
 }
+
+func Test_MongoMigrationCreateIndex(t *testing.T) {
+	_, mockMongo, _ := mongoSetup(t)
+
+	ds := Datasource{Mongo: mongoDS{Mongo: mockMongo}}
+
+	keys := map[string]any{"email": 1}
+
+	mockMongo.EXPECT().CreateIndex(gomock.Any(), "users", keys, container.IndexOptions{Unique: true}).Return("email_1", nil)
+	mockMongo.EXPECT().DropIndex(gomock.Any(), "users", "email_1").Return(errMongoConn)
+
+	name, err := ds.Mongo.CreateIndex(t.Context(), "users", keys, container.IndexOptions{Unique: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "email_1", name)
+	assert.Equal(t, errMongoConn, ds.Mongo.DropIndex(t.Context(), "users", name))
+}
@@ -197,6 +197,8 @@ func initializeOracleRunMocks(t *testing.T) (*container.MockOracleDB, *container
 	mockContainer.Elasticsearch = nil
 	mockContainer.OpenTSDB = nil
 	mockContainer.ScyllaDB = nil
+	mockContainer.Couchbase = nil
+	mockContainer.KVStore = nil
 	mockContainer.Clickhouse = nil
 
 	// Initialize Oracle mock and Logger.
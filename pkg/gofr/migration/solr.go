@@ -0,0 +1,205 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+// solrMigrationCollection is the collection of the records of the migrations.
+const solrMigrationCollection = "gofr_migrations"
+
+var (
+	errSolrRequest    = errors.New("solr request failed")
+	errSolrNotManaged = errors.New("solr client does not manage collections")
+)
+
+type solrDS struct {
+	container.Solr
+}
+
+// collections returns the client as a container.SolrCollectionManager, which the records of the migrations and the
+// migrations changing collections require.
+func (ds solrDS) collections() (container.SolrCollectionManager, error) {
+	m, ok := ds.Solr.(container.SolrCollectionManager)
+	if !ok {
+		return nil, errSolrNotManaged
+	}
+
+	return m, nil
+}
+
+func (ds solrDS) ListCollections(ctx context.Context) (any, error) {
+	m, err := ds.collections()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ListCollections(ctx)
+}
+
+func (ds solrDS) CreateCollection(ctx context.Context, collection string, params map[string]any) (any, error) {
+	m, err := ds.collections()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.CreateCollection(ctx, collection, params)
+}
+
+func (ds solrDS) DeleteCollection(ctx context.Context, collection string) (any, error) {
+	m, err := ds.collections()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.DeleteCollection(ctx, collection)
+}
+
+type solrMigrator struct {
+	solrDS
+	migrator
+}
+
+// apply initializes solrMigrator using the Solr interface.
+func (ds solrDS) apply(m migrator) migrator {
+	return solrMigrator{
+		solrDS:   ds,
+		migrator: m,
+	}
+}
+
+// checkAndCreateMigrationTable creates the collection of the records of the migrations, with the fields of the
+// records, if it doesn't exist.
+func (sm solrMigrator) checkAndCreateMigrationTable(c *container.Container) error {
+	var list struct {
+		Collections []string `json:"collections"`
+	}
+
+	resp, err := sm.ListCollections(context.Background())
+	if err = decodeSolrResponse(resp, err, &list); err != nil {
+		return fmt.Errorf("failed to list Solr collections: %w", err)
+	}
+
+	if slices.Contains(list.Collections, solrMigrationCollection) {
+		return sm.migrator.checkAndCreateMigrationTable(c)
+	}
+
+	resp, err = sm.CreateCollection(context.Background(), solrMigrationCollection, map[string]any{"numShards": 1})
+	if err = decodeSolrResponse(resp, err, nil); err != nil {
+		return fmt.Errorf("failed to create gofr_migrations collection: %w", err)
+	}
+
+	// the types guessed by Solr for new fields are multivalued, by which the records could not be sorted.
+	fields := bytes.NewBufferString(`{"add-field": [
+		{"name": "version", "type": "plong", "stored": true},
+		{"name": "method", "type": "string", "stored": true},
+		{"name": "start_time", "type": "pdate", "stored": true},
+		{"name": "duration", "type": "plong", "stored": true}]}`)
+
+	resp, err = sm.Solr.AddField(context.Background(), solrMigrationCollection, fields)
+	if err = decodeSolrResponse(resp, err, nil); err != nil {
+		return fmt.Errorf("failed to add fields to gofr_migrations collection: %w", err)
+	}
+
+	return sm.migrator.checkAndCreateMigrationTable(c)
+}
+
+// getLastMigration retrieves the latest migration version from Solr.
+func (sm solrMigrator) getLastMigration(c *container.Container) int64 {
+	var result struct {
+		Response struct {
+			Docs []struct {
+				Version int64 `json:"version"`
+			} `json:"docs"`
+		} `json:"response"`
+	}
+
+	resp, err := sm.Solr.Search(context.Background(), solrMigrationCollection, map[string]any{
+		"q": "*:*", "sort": "version desc", "rows": 1, "fl": "version",
+	})
+	if err = decodeSolrResponse(resp, err, &result); err != nil {
+		c.Errorf("Failed to fetch migrations from Solr: %v", err)
+		return 0
+	}
+
+	var lastMigration int64
+
+	if len(result.Response.Docs) > 0 {
+		lastMigration = result.Response.Docs[0].Version
+	}
+
+	c.Debugf("Solr last migration fetched value is: %v", lastMigration)
+
+	return max(lastMigration, sm.migrator.getLastMigration(c))
+}
+
+func (sm solrMigrator) beginTransaction(c *container.Container) transactionData {
+	return sm.migrator.beginTransaction(c)
+}
+
+// commitMigration indexes the record of the migration, with the migration number as its id.
+func (sm solrMigrator) commitMigration(c *container.Container, data transactionData) error {
+	document, err := json.Marshal([]any{struct {
+		ID string `json:"id"`
+		documentMigrationRecord
+	}{ID: strconv.FormatInt(data.MigrationNumber, 10), documentMigrationRecord: newDocumentMigrationRecord(data)}})
+	if err != nil {
+		return err
+	}
+
+	resp, err := sm.Solr.Create(context.Background(), solrMigrationCollection, bytes.NewBuffer(document),
+		map[string]any{"commit": true})
+	if err = decodeSolrResponse(resp, err, nil); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	c.Debugf("inserted record for migration %v in Solr gofr_migrations collection", data.MigrationNumber)
+
+	return sm.migrator.commitMigration(c, data)
+}
+
+func (sm solrMigrator) rollback(c *container.Container, data transactionData) {
+	sm.migrator.rollback(c, data)
+
+	c.Fatalf("Migration %v failed.", data.MigrationNumber)
+}
+
+// decodeSolrResponse decodes the body of a response of Solr into v, unless nil. The Solr client returns the
+// responses of the failed requests along with their status code, which are returned as errors.
+func decodeSolrResponse(resp any, err error, v any) error {
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	var r struct {
+		Code int
+		Data json.RawMessage
+	}
+
+	if err = json.Unmarshal(b, &r); err != nil {
+		return err
+	}
+
+	if r.Code >= http.StatusBadRequest {
+		return fmt.Errorf("%w with status %d: %s", errSolrRequest, r.Code, r.Data)
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.Unmarshal(r.Data, v)
+}
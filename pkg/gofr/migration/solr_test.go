@@ -0,0 +1,138 @@
+package migration
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+var errSolrConn = errors.New("error connecting to solr")
+
+// solrResponse has the fields of the responses of the Solr client.
+type solrResponse struct {
+	Code int
+	Data any
+}
+
+// managedSolr is a Solr client managing collections.
+type managedSolr struct {
+	*container.MockSolr
+	*container.MockSolrCollectionManager
+}
+
+func solrSetup(t *testing.T) (migrator, *container.MockSolr, *container.MockSolrCollectionManager, *container.Container) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockContainer, _ := container.NewMockContainer(t)
+	mockSolr := container.NewMockSolr(ctrl)
+	mockCollections := container.NewMockSolrCollectionManager(ctrl)
+
+	client := managedSolr{MockSolr: mockSolr, MockSolrCollectionManager: mockCollections}
+	mockContainer.Solr = client
+
+	ds := Datasource{Solr: solrDS{Solr: client}}
+
+	return solrDS{Solr: client}.apply(&ds), mockSolr, mockCollections, mockContainer
+}
+
+func Test_SolrCheckAndCreateMigrationTable(t *testing.T) {
+	migratorWithSolr, mockSolr, mockCollections, mockContainer := solrSetup(t)
+
+	ok := solrResponse{Code: http.StatusOK, Data: map[string]any{}}
+
+	// the collection exists.
+	mockCollections.EXPECT().ListCollections(gomock.Any()).
+		Return(solrResponse{Code: http.StatusOK, Data: map[string]any{"collections": []string{solrMigrationCollection}}}, nil)
+
+	require.NoError(t, migratorWithSolr.checkAndCreateMigrationTable(mockContainer))
+
+	// the collection is created.
+	mockCollections.EXPECT().ListCollections(gomock.Any()).Return(ok, nil)
+	mockCollections.EXPECT().CreateCollection(gomock.Any(), solrMigrationCollection, map[string]any{"numShards": 1}).Return(ok, nil)
+	mockSolr.EXPECT().AddField(gomock.Any(), solrMigrationCollection, gomock.Any()).DoAndReturn(
+		func(_ any, _ string, document *bytes.Buffer) (any, error) {
+			assert.Contains(t, document.String(), `{"name": "version", "type": "plong", "stored": true}`)
+
+			return ok, nil
+		})
+
+	require.NoError(t, migratorWithSolr.checkAndCreateMigrationTable(mockContainer))
+
+	// the collection is not created.
+	mockCollections.EXPECT().ListCollections(gomock.Any()).Return(ok, nil)
+	mockCollections.EXPECT().CreateCollection(gomock.Any(), solrMigrationCollection, gomock.Any()).
+		Return(solrResponse{Code: http.StatusBadRequest, Data: map[string]any{"error": "no configset"}}, nil)
+
+	require.ErrorIs(t, migratorWithSolr.checkAndCreateMigrationTable(mockContainer), errSolrRequest)
+
+	mockCollections.EXPECT().ListCollections(gomock.Any()).Return(nil, errSolrConn)
+
+	require.ErrorIs(t, migratorWithSolr.checkAndCreateMigrationTable(mockContainer), errSolrConn)
+}
+
+func Test_SolrCollectionsNotManaged(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ds := solrDS{Solr: container.NewMockSolr(gomock.NewController(t))}
+
+	require.ErrorIs(t, ds.apply(&Datasource{}).checkAndCreateMigrationTable(mockContainer), errSolrNotManaged)
+
+	_, err := ds.DeleteCollection(t.Context(), "products")
+	require.ErrorIs(t, err, errSolrNotManaged)
+}
+
+func Test_SolrGetLastMigration(t *testing.T) {
+	migratorWithSolr, mockSolr, _, mockContainer := solrSetup(t)
+
+	params := map[string]any{"q": "*:*", "sort": "version desc", "rows": 1, "fl": "version"}
+
+	testCases := []struct {
+		desc string
+		resp any
+		err  error
+		last int64
+	}{
+		{"no migrations", solrResponse{Code: http.StatusOK, Data: map[string]any{"response": map[string]any{"docs": []any{}}}}, nil, 0},
+		{"migrations", solrResponse{Code: http.StatusOK, Data: map[string]any{
+			"response": map[string]any{"docs": []any{map[string]any{"version": 12}}},
+		}}, nil, 12},
+		{"missing collection", solrResponse{Code: http.StatusNotFound, Data: map[string]any{}}, nil, 0},
+		{"connection failed", nil, errSolrConn, 0},
+	}
+
+	for i, tc := range testCases {
+		mockSolr.EXPECT().Search(gomock.Any(), solrMigrationCollection, params).Return(tc.resp, tc.err)
+
+		assert.Equal(t, tc.last, migratorWithSolr.getLastMigration(mockContainer), "TEST[%v]\n %v Failed! ", i, tc.desc)
+	}
+}
+
+func Test_SolrCommitMigration(t *testing.T) {
+	migratorWithSolr, mockSolr, _, mockContainer := solrSetup(t)
+
+	td := transactionData{
+		StartTime:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		MigrationNumber: 3,
+	}
+
+	mockSolr.EXPECT().Create(gomock.Any(), solrMigrationCollection, gomock.Any(), map[string]any{"commit": true}).DoAndReturn(
+		func(_ any, _ string, document *bytes.Buffer, _ map[string]any) (any, error) {
+			assert.Contains(t, document.String(), `[{"id":"3","version":3,"method":"UP","start_time":"2025-01-02T03:04:05Z"`)
+
+			return solrResponse{Code: http.StatusOK}, nil
+		})
+
+	require.NoError(t, migratorWithSolr.commitMigration(mockContainer, td))
+
+	mockSolr.EXPECT().Create(gomock.Any(), solrMigrationCollection, gomock.Any(), gomock.Any()).Return(nil, errSolrConn)
+
+	require.ErrorIs(t, migratorWithSolr.commitMigration(mockContainer, td), errSolrConn)
+}
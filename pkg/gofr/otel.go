@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/zipkin"
 	"go.opentelemetry.io/otel/propagation"
@@ -52,6 +53,10 @@ func (a *App) initTracer() {
 		a.container.Error(err)
 	}
 
+	if exporter != nil {
+		exporter = newRedactingExporter(exporter, a.container.GetRedactor())
+	}
+
 	batcher := sdktrace.NewBatchSpanProcessor(exporter)
 	tp.RegisterSpanProcessor(batcher)
 }
@@ -193,6 +198,86 @@ func buildGoFrExporter(logger logging.Logger, url string) sdktrace.SpanExporter
 	return NewExporter(url, logging.NewLogger(logging.INFO))
 }
 
+// redactingExporter redacts the sensitive attributes of spans before handing them to the wrapped exporter.
+type redactingExporter struct {
+	sdktrace.SpanExporter
+	redactor *logging.Redactor
+}
+
+func newRedactingExporter(exporter sdktrace.SpanExporter, redactor *logging.Redactor) sdktrace.SpanExporter {
+	if redactor == nil {
+		return exporter
+	}
+
+	return &redactingExporter{SpanExporter: exporter, redactor: redactor}
+}
+
+func (e *redactingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	redacted := make([]sdktrace.ReadOnlySpan, len(spans))
+
+	for i, span := range spans {
+		redacted[i] = &redactedSpan{ReadOnlySpan: span, attributes: redactAttributes(e.redactor, span.Attributes())}
+	}
+
+	return e.SpanExporter.ExportSpans(ctx, redacted)
+}
+
+// redactedSpan overrides the attributes of a span with their redacted copy.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+	attributes []attribute.KeyValue
+}
+
+func (s *redactedSpan) Attributes() []attribute.KeyValue {
+	return s.attributes
+}
+
+// headerAttributePrefixes are the prefixes under which HTTP instrumentation records headers as span attributes.
+func headerAttributePrefixes() []string {
+	return []string{"http.request.header.", "http.response.header."}
+}
+
+func redactAttributes(r *logging.Redactor, attrs []attribute.KeyValue) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, len(attrs))
+
+	for i, kv := range attrs {
+		out[i] = redactAttribute(r, kv)
+	}
+
+	return out
+}
+
+func redactAttribute(r *logging.Redactor, kv attribute.KeyValue) attribute.KeyValue {
+	key := string(kv.Key)
+
+	for _, prefix := range headerAttributePrefixes() {
+		if name, ok := strings.CutPrefix(key, prefix); ok && r.IsSensitiveHeader(name) {
+			return kv.Key.String(logging.RedactedValue)
+		}
+	}
+
+	if r.IsSensitiveField(key) {
+		return kv.Key.String(logging.RedactedValue)
+	}
+
+	if kv.Value.Type() != attribute.STRING {
+		return kv
+	}
+
+	value := kv.Value.AsString()
+
+	switch {
+	case strings.HasSuffix(key, "url") || strings.HasSuffix(key, ".target") || key == "url.full":
+		value = r.URL(value)
+	case strings.HasPrefix(key, "db.statement") || key == "db.query.text":
+		value = r.SQL(value)
+	default:
+		value = r.String(value)
+	}
+
+	return kv.Key.String(value)
+}
+
 type otelErrorHandler struct {
 	logger logging.Logger
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 
 	"gofr.dev/pkg/gofr/config"
 	"gofr.dev/pkg/gofr/logging"
@@ -263,3 +264,32 @@ func TestOtelErrorHandler_NilErrorNoop(t *testing.T) {
 
 	require.Empty(t, cl.loggedErrors)
 }
+
+func TestRedactAttributes(t *testing.T) {
+	r := logging.DefaultRedactor()
+
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.header.authorization", "Bearer abc"),
+		attribute.String("http.url", "http://svc/api?token=t-1&page=2"),
+		attribute.String("db.statement", "SELECT * FROM users WHERE password = 'p@ss'"),
+		attribute.String("user.password", "p@ss"),
+		attribute.Int("http.status_code", 200),
+	}
+
+	redacted := redactAttributes(r, attrs)
+
+	require.Equal(t, []attribute.KeyValue{
+		attribute.String("http.request.header.authorization", logging.RedactedValue),
+		attribute.String("http.url", "http://svc/api?token=[REDACTED]&page=2"),
+		attribute.String("db.statement", "SELECT * FROM users WHERE password = '[REDACTED]'"),
+		attribute.String("user.password", logging.RedactedValue),
+		attribute.Int("http.status_code", 200),
+	}, redacted)
+	require.Equal(t, "Bearer abc", attrs[0].Value.AsString(), "original attributes must not be modified")
+}
+
+func TestNewRedactingExporter_NilRedactor(t *testing.T) {
+	exporter := NewExporter("http://localhost", logging.NewMockLogger(logging.INFO))
+
+	require.Same(t, exporter, newRedactingExporter(exporter, nil))
+}
@@ -46,18 +46,43 @@ func (a *App) add(method, pattern string, h Handler) {
 		function:       h,
 		container:      a.container,
 		requestTimeout: time.Duration(reqTimeout) * time.Second,
+		encoders:       a.httpServer.encoders,
 	})
 }
 
 // AddRESTHandlers creates and registers CRUD routes for the given struct, the struct should always be passed by reference.
-func (a *App) AddRESTHandlers(object any) error {
+// The entities are stored with SQL, unless a Repository is given WithRepository.
+func (a *App) AddRESTHandlers(object any, opts ...RESTOption) error {
 	cfg, err := scanEntity(object)
 	if err != nil {
 		a.container.Logger.Errorf(err.Error())
 		return err
 	}
 
-	a.registerCRUDHandlers(cfg, object)
+	var options restOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.repository == nil {
+		if err := cfg.resolveRelations(); err != nil {
+			a.container.Logger.Errorf(err.Error())
+			return err
+		}
+
+		a.registerCRUDHandlers(cfg, object, cfg)
+
+		return nil
+	}
+
+	handlers, err := newRepositoryHandlers(cfg, options.repository)
+	if err != nil {
+		a.container.Logger.Errorf(err.Error())
+		return err
+	}
+
+	a.registerCRUDHandlers(cfg, object, handlers)
 
 	return nil
 }
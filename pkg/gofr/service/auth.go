@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"net/http"
 )
 
@@ -82,3 +83,33 @@ func (a *authProvider) DeleteWithHeaders(ctx context.Context, path string, body
 
 	return a.HTTP.DeleteWithHeaders(ctx, path, body, headers)
 }
+
+func (a *authProvider) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	headers, err := a.auth(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamer(a.HTTP).PostStream(ctx, path, queryParams, body, headers)
+}
+
+func (a *authProvider) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	headers, err := a.auth(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamer(a.HTTP).PutStream(ctx, path, queryParams, body, headers)
+}
+
+func (a *authProvider) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	headers, err := a.auth(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamer(a.HTTP).PatchStream(ctx, path, queryParams, body, headers)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultMaxConcurrent = 10
+
+// BulkheadConfig caps the number of calls to a service in flight at once, isolating the application from a slow
+// service which would otherwise hold an ever-growing number of goroutines and connections. A call is in flight until
+// the body of its response is closed.
+//
+// The calls exceeding the limit wait in a queue of MaxQueue calls for a slot to be released, and fail with a
+// *BulkheadError when the queue is full or once they waited QueueTimeout.
+type BulkheadConfig struct {
+	// MaxConcurrent is the number of calls in flight at once, 10 by default.
+	MaxConcurrent int
+	// MaxQueue is the number of calls waiting for a slot, none by default.
+	MaxQueue int
+	// QueueTimeout is how long a call waits for a slot, as long as its context allows by default.
+	QueueTimeout time.Duration
+}
+
+// BulkheadError is returned by the calls rejected by the bulkhead of a service.
+type BulkheadError struct {
+	ServiceURL string
+	Reason     string // "queue full" or "queue timeout"
+}
+
+func (e *BulkheadError) Error() string {
+	return fmt.Sprintf("bulkhead rejected call to service %s: %s", e.ServiceURL, e.Reason)
+}
+
+// StatusCode Implement StatusCodeResponder so Responder picks correct HTTP code.
+func (*BulkheadError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+func (c *BulkheadConfig) AddOption(h HTTP) HTTP {
+	maxConcurrent := c.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrent
+	}
+
+	b := &bulkhead{
+		slots:        make(chan struct{}, maxConcurrent),
+		maxQueue:     int64(max(c.MaxQueue, 0)),
+		queueTimeout: c.QueueTimeout,
+		HTTP:         h,
+	}
+
+	if httpSvc := extractHTTPService(h); httpSvc != nil {
+		b.url = httpSvc.url
+		b.metrics, _ = httpSvc.Metrics.(counter)
+	}
+
+	return b
+}
+
+type bulkhead struct {
+	slots        chan struct{}
+	queued       atomic.Int64
+	maxQueue     int64
+	queueTimeout time.Duration
+
+	url     string
+	metrics counter
+
+	HTTP
+}
+
+// acquire takes a slot for a call, returning the function releasing it.
+func (b *bulkhead) acquire(ctx context.Context) (func(), error) {
+	select {
+	case b.slots <- struct{}{}:
+		return b.release(), nil
+	default:
+	}
+
+	if b.queued.Add(1) > b.maxQueue {
+		b.queued.Add(-1)
+
+		return nil, b.reject(ctx, "queue full")
+	}
+
+	defer b.queued.Add(-1)
+
+	var timeout <-chan time.Time
+
+	if b.queueTimeout > 0 {
+		timer := time.NewTimer(b.queueTimeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	start := time.Now()
+
+	select {
+	case b.slots <- struct{}{}:
+		trace.SpanFromContext(ctx).AddEvent("http.client.bulkhead.queued", trace.WithAttributes(
+			attribute.String("http.bulkhead.wait", time.Since(start).String()),
+		))
+
+		return b.release(), nil
+	case <-timeout:
+		return nil, b.reject(ctx, "queue timeout")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *bulkhead) release() func() {
+	var once sync.Once
+
+	return func() { once.Do(func() { <-b.slots }) }
+}
+
+func (b *bulkhead) reject(ctx context.Context, reason string) error {
+	trace.SpanFromContext(ctx).AddEvent("http.client.bulkhead.rejected", trace.WithAttributes(
+		attribute.String("http.bulkhead.reason", reason),
+	))
+
+	if b.metrics != nil {
+		b.metrics.IncrementCounter(ctx, "app_http_service_bulkhead_rejected_count", "path", b.url, "reason", reason)
+	}
+
+	return &BulkheadError{ServiceURL: b.url, Reason: reason}
+}
+
+// do sends a call once it holds a slot, which is released when the body of its response is closed.
+func (b *bulkhead) do(ctx context.Context, send func() (*http.Response, error)) (*http.Response, error) {
+	release, err := b.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := send()
+
+	return cancelOnClose(resp, release), err
+}
+
+func (b *bulkhead) Get(ctx context.Context, path string, queryParams map[string]any) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) { return b.HTTP.Get(ctx, path, queryParams) })
+}
+
+func (b *bulkhead) GetWithHeaders(ctx context.Context, path string, queryParams map[string]any,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) { return b.HTTP.GetWithHeaders(ctx, path, queryParams, headers) })
+}
+
+func (b *bulkhead) Post(ctx context.Context, path string, queryParams map[string]any,
+	body []byte) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) { return b.HTTP.Post(ctx, path, queryParams, body) })
+}
+
+func (b *bulkhead) PostWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.PostWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkhead) Put(ctx context.Context, path string, queryParams map[string]any,
+	body []byte) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) { return b.HTTP.Put(ctx, path, queryParams, body) })
+}
+
+func (b *bulkhead) PutWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.PutWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkhead) Patch(ctx context.Context, path string, queryParams map[string]any,
+	body []byte) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) { return b.HTTP.Patch(ctx, path, queryParams, body) })
+}
+
+func (b *bulkhead) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return b.HTTP.PatchWithHeaders(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkhead) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) { return b.HTTP.Delete(ctx, path, body) })
+}
+
+func (b *bulkhead) DeleteWithHeaders(ctx context.Context, path string, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) { return b.HTTP.DeleteWithHeaders(ctx, path, body, headers) })
+}
+
+func (b *bulkhead) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return streamer(b.HTTP).PostStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkhead) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return streamer(b.HTTP).PutStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (b *bulkhead) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return b.do(ctx, func() (*http.Response, error) {
+		return streamer(b.HTTP).PatchStream(ctx, path, queryParams, body, headers)
+	})
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+type countingMetrics struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (*countingMetrics) RecordHistogram(context.Context, string, float64, ...string) {}
+
+// IncrementCounter counts the increments of a counter by the value of its last label.
+func (m *countingMetrics) IncrementCounter(_ context.Context, name string, labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.counts == nil {
+		m.counts = make(map[string]int)
+	}
+
+	m.counts[name+":"+labels[len(labels)-1]]++
+}
+
+func (m *countingMetrics) count(key string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.counts[key]
+}
+
+// newBlockingServer responds once release is closed, reporting the requests it received on started.
+func newBlockingServer(t *testing.T) (server *httptest.Server, started chan struct{}, release chan struct{}) {
+	t.Helper()
+
+	started, release = make(chan struct{}, 10), make(chan struct{})
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		started <- struct{}{}
+		<-release
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, started, release
+}
+
+func TestBulkhead_Rejections(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config BulkheadConfig
+		reason string
+	}{
+		{desc: "no queue", config: BulkheadConfig{MaxConcurrent: 1}, reason: "queue full"},
+		{desc: "queue timeout", config: BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: 10 * time.Millisecond},
+			reason: "queue timeout"},
+	}
+
+	for i, tc := range testCases {
+		server, started, release := newBlockingServer(t)
+		metrics := &countingMetrics{}
+		svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), metrics, &tc.config)
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			resp, err := svc.Get(t.Context(), "slow", nil)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+
+		<-started
+
+		_, err := svc.Get(t.Context(), "slow", nil)
+
+		var bulkheadErr *BulkheadError
+
+		require.ErrorAs(t, err, &bulkheadErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.reason, bulkheadErr.Reason, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, http.StatusServiceUnavailable, bulkheadErr.StatusCode(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, 1, metrics.count("app_http_service_bulkhead_rejected_count:"+tc.reason),
+			"TEST[%d], Failed.\n%s", i, tc.desc)
+
+		close(release)
+		<-done
+	}
+}
+
+func TestBulkhead_Queue(t *testing.T) {
+	server, started, release := newBlockingServer(t)
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil, &BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1})
+
+	first, queued := make(chan *http.Response), make(chan error)
+
+	go func() {
+		resp, _ := svc.Get(t.Context(), "slow", nil)
+		first <- resp
+	}()
+
+	<-started
+
+	go func() {
+		resp, err := svc.Get(t.Context(), "slow", nil)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		queued <- err
+	}()
+
+	close(release)
+
+	resp := <-first
+
+	select {
+	case <-queued:
+		t.Fatal("the queued call was sent before the body of the first response was closed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	resp.Body.Close()
+
+	require.NoError(t, <-queued)
+}
+
+func TestBulkhead_ContextCancelled(t *testing.T) {
+	server, started, release := newBlockingServer(t)
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil, &BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1})
+
+	defer close(release)
+
+	go func() {
+		resp, err := svc.Get(context.Background(), "slow", nil)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := svc.Get(ctx, "slow", nil)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
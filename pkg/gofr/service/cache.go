@@ -0,0 +1,549 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// revalidationRetention is how long a stale response holding validators is kept to revalidate it.
+const revalidationRetention = time.Hour
+
+// CacheConfig caches the responses of the GET requests to a service, following the HTTP caching rules of RFC 9111
+// for shared caches: responses are stored when their Cache-Control, Expires or validators allow it, served while
+// fresh, and revalidated with If-None-Match/If-Modified-Since once stale. A stale response whose Cache-Control holds
+// stale-while-revalidate is served while it is revalidated in the background.
+//
+// Successful POST, PUT, PATCH and DELETE requests invalidate the cached response of their URL. The cache of a call
+// is bypassed by a context returned by BypassCache, or with a Cache-Control request header.
+type CacheConfig struct {
+	// Store holds the cached responses, a MemoryCacheStore of 1000 entries by default.
+	Store CacheStore
+}
+
+func (c *CacheConfig) AddOption(h HTTP) HTTP {
+	cp := &cacheProvider{store: c.Store, HTTP: h}
+
+	if cp.store == nil {
+		cp.store = NewMemoryCacheStore(defaultCacheCapacity)
+	}
+
+	if httpSvc := extractHTTPService(h); httpSvc != nil {
+		cp.url = httpSvc.url
+		cp.logger = httpSvc.Logger
+		cp.metrics, _ = httpSvc.Metrics.(counter)
+	}
+
+	return cp
+}
+
+type bypassCacheKey struct{}
+
+// BypassCache returns a context making the requests it is used for neither served from nor stored in the cache.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+type cacheProvider struct {
+	store   CacheStore
+	url     string
+	logger  Logger
+	metrics counter
+
+	revalidating sync.Map // keys of the responses being revalidated in the background
+
+	HTTP
+}
+
+// cachedResponse is a response stored in the cache.
+type cachedResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Header     http.Header       `json:"header"`
+	Body       []byte            `json:"body"`
+	StoredAt   time.Time         `json:"storedAt"`
+	Vary       map[string]string `json:"vary,omitempty"` // values of the request headers named by Vary
+}
+
+func (c *cacheProvider) Get(ctx context.Context, path string, queryParams map[string]any) (*http.Response, error) {
+	return c.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (c *cacheProvider) GetWithHeaders(ctx context.Context, path string, queryParams map[string]any,
+	headers map[string]string) (*http.Response, error) {
+	reqCC := parseCacheControl(headerValue(headers, "Cache-Control"))
+
+	if bypassed, _ := ctx.Value(bypassCacheKey{}).(bool); bypassed || reqCC.has("no-store") || isConditional(headers) {
+		return c.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
+	}
+
+	req := c.request(ctx, path, queryParams)
+	key := req.URL.String()
+
+	entry := c.load(ctx, key, headers)
+	if entry == nil {
+		c.record(ctx, "miss")
+
+		return c.fetch(ctx, key, headers, func(h map[string]string) (*http.Response, error) {
+			return c.HTTP.GetWithHeaders(ctx, path, queryParams, h)
+		})
+	}
+
+	age := entry.age(time.Now())
+	lifetime := entry.freshnessLifetime()
+
+	if maxAge, ok := reqCC.seconds("max-age"); ok {
+		lifetime = min(lifetime, maxAge)
+	}
+
+	switch {
+	case reqCC.has("no-cache"):
+	case age < lifetime:
+		c.record(ctx, "hit")
+
+		return entry.response(req, age), nil
+	case age < lifetime+entry.staleWhileRevalidate():
+		c.record(ctx, "stale")
+		c.revalidateInBackground(ctx, key, entry, req, path, queryParams, headers)
+
+		return entry.response(req, age), nil
+	}
+
+	return c.revalidate(ctx, key, entry, req, headers, func(h map[string]string) (*http.Response, error) {
+		return c.HTTP.GetWithHeaders(ctx, path, queryParams, mergeHeaders(headers, h))
+	})
+}
+
+// request returns the request a GET call is cached for, its URL being the key of the cached response.
+func (c *cacheProvider) request(ctx context.Context, path string, queryParams map[string]any) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.url+"/"+path, "/"), http.NoBody)
+	if req == nil {
+		req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "/", http.NoBody)
+	}
+
+	encodeQueryParameters(req, queryParams)
+
+	return req
+}
+
+// load returns the cached response of key, if any, provided it was stored for the same values of the
+// request headers named by its Vary header.
+func (c *cacheProvider) load(ctx context.Context, key string, headers map[string]string) *cachedResponse {
+	value, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.log("failed to read the cached response of " + key + ": " + err.Error())
+	}
+
+	if !ok {
+		return nil
+	}
+
+	var entry cachedResponse
+
+	if json.Unmarshal(value, &entry) != nil {
+		return nil
+	}
+
+	for name, v := range entry.Vary {
+		if headerValue(headers, name) != v {
+			return nil
+		}
+	}
+
+	return &entry
+}
+
+// fetch sends a request and stores its response when it can be cached.
+func (c *cacheProvider) fetch(ctx context.Context, key string, headers map[string]string,
+	send func(headers map[string]string) (*http.Response, error)) (*http.Response, error) {
+	resp, err := send(headers)
+	if err != nil {
+		return resp, err
+	}
+
+	cc := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if !isStorable(resp, cc, headers) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return resp, err
+	}
+
+	entry := &cachedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body, StoredAt: time.Now()}
+
+	for _, name := range varyHeaders(resp.Header) {
+		if entry.Vary == nil {
+			entry.Vary = make(map[string]string)
+		}
+
+		entry.Vary[name] = headerValue(headers, name)
+	}
+
+	c.save(ctx, key, entry)
+
+	return resp, nil
+}
+
+// revalidate asks the service whether a stale response is still valid, serving it again on 304 Not Modified.
+func (c *cacheProvider) revalidate(ctx context.Context, key string, entry *cachedResponse, req *http.Request,
+	headers map[string]string, send func(headers map[string]string) (*http.Response, error)) (*http.Response, error) {
+	conditions := make(map[string]string)
+
+	if etag := entry.Header.Get("ETag"); etag != "" {
+		conditions["If-None-Match"] = etag
+	}
+
+	if modified := entry.Header.Get("Last-Modified"); modified != "" {
+		conditions["If-Modified-Since"] = modified
+	}
+
+	if len(conditions) == 0 {
+		c.record(ctx, "miss")
+
+		return c.fetch(ctx, key, headers, send)
+	}
+
+	resp, err := send(conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusNotModified {
+		c.record(ctx, "miss")
+
+		return c.fetch(ctx, key, headers, func(map[string]string) (*http.Response, error) { return resp, nil })
+	}
+
+	discard(resp)
+	c.record(ctx, "revalidated")
+
+	for name, values := range resp.Header {
+		if name != "Content-Length" {
+			entry.Header[name] = values
+		}
+	}
+
+	entry.Header.Del("Age")
+	entry.StoredAt = time.Now()
+
+	c.save(ctx, key, entry)
+
+	return entry.response(req, 0), nil
+}
+
+// revalidateInBackground revalidates a response served stale, unless it is already being revalidated.
+func (c *cacheProvider) revalidateInBackground(ctx context.Context, key string, entry *cachedResponse, req *http.Request,
+	path string, queryParams map[string]any, headers map[string]string) {
+	if _, loaded := c.revalidating.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		defer c.revalidating.Delete(key)
+
+		resp, err := c.revalidate(ctx, key, entry, req, headers, func(h map[string]string) (*http.Response, error) {
+			return c.HTTP.GetWithHeaders(ctx, path, queryParams, mergeHeaders(headers, h))
+		})
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+}
+
+func (c *cacheProvider) save(ctx context.Context, key string, entry *cachedResponse) {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	ttl := entry.freshnessLifetime() + entry.staleWhileRevalidate()
+
+	if entry.Header.Get("ETag") != "" || entry.Header.Get("Last-Modified") != "" {
+		ttl += revalidationRetention
+	}
+
+	// a response that can neither be served nor revalidated is not worth storing
+	if ttl <= 0 {
+		return
+	}
+
+	if err = c.store.Set(ctx, key, value, ttl); err != nil {
+		c.log("failed to cache the response of " + key + ": " + err.Error())
+	}
+}
+
+// invalidate removes the cached response of a URL once a request modifying it succeeded.
+func (c *cacheProvider) invalidate(ctx context.Context, path string, queryParams map[string]any,
+	resp *http.Response, err error) (*http.Response, error) {
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		return resp, err
+	}
+
+	key := c.request(ctx, path, queryParams).URL.String()
+
+	if delErr := c.store.Delete(ctx, key); delErr != nil {
+		c.log("failed to invalidate the cached response of " + key + ": " + delErr.Error())
+	}
+
+	return resp, err
+}
+
+func (c *cacheProvider) record(ctx context.Context, result string) {
+	if c.metrics != nil {
+		c.metrics.IncrementCounter(ctx, "app_http_service_cache_count", "path", c.url, "result", result)
+	}
+}
+
+func (c *cacheProvider) log(message string) {
+	if c.logger != nil {
+		c.logger.Log(message)
+	}
+}
+
+func (c *cacheProvider) Post(ctx context.Context, path string, queryParams map[string]any,
+	body []byte) (*http.Response, error) {
+	resp, err := c.HTTP.Post(ctx, path, queryParams, body)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) PostWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := c.HTTP.PostWithHeaders(ctx, path, queryParams, body, headers)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) Put(ctx context.Context, path string, queryParams map[string]any,
+	body []byte) (*http.Response, error) {
+	resp, err := c.HTTP.Put(ctx, path, queryParams, body)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) PutWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := c.HTTP.PutWithHeaders(ctx, path, queryParams, body, headers)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) Patch(ctx context.Context, path string, queryParams map[string]any,
+	body []byte) (*http.Response, error) {
+	resp, err := c.HTTP.Patch(ctx, path, queryParams, body)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := c.HTTP.PatchWithHeaders(ctx, path, queryParams, body, headers)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	resp, err := c.HTTP.Delete(ctx, path, body)
+
+	return c.invalidate(ctx, path, nil, resp, err)
+}
+
+func (c *cacheProvider) DeleteWithHeaders(ctx context.Context, path string, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := c.HTTP.DeleteWithHeaders(ctx, path, body, headers)
+
+	return c.invalidate(ctx, path, nil, resp, err)
+}
+
+func (c *cacheProvider) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := streamer(c.HTTP).PostStream(ctx, path, queryParams, body, headers)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := streamer(c.HTTP).PutStream(ctx, path, queryParams, body, headers)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+func (c *cacheProvider) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	resp, err := streamer(c.HTTP).PatchStream(ctx, path, queryParams, body, headers)
+
+	return c.invalidate(ctx, path, queryParams, resp, err)
+}
+
+// response returns a copy of the cached response, with an Age header holding its age.
+func (e *cachedResponse) response(req *http.Request, age time.Duration) *http.Response {
+	header := e.Header.Clone()
+	header.Set("Age", strconv.Itoa(int(age.Seconds())))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// age returns the age of the response, including the age it had when it was stored.
+func (e *cachedResponse) age(now time.Time) time.Duration {
+	initial, _ := strconv.Atoi(e.Header.Get("Age"))
+
+	return now.Sub(e.StoredAt) + time.Duration(initial)*time.Second
+}
+
+// freshnessLifetime returns how long the response is fresh, given by s-maxage, max-age or Expires in this order.
+func (e *cachedResponse) freshnessLifetime() time.Duration {
+	cc := parseCacheControl(e.Header.Get("Cache-Control"))
+
+	if cc.has("no-cache") {
+		return 0
+	}
+
+	if lifetime, ok := cc.seconds("s-maxage"); ok {
+		return lifetime
+	}
+
+	if lifetime, ok := cc.seconds("max-age"); ok {
+		return lifetime
+	}
+
+	expires, err := http.ParseTime(e.Header.Get("Expires"))
+	if err != nil {
+		return 0
+	}
+
+	date, err := http.ParseTime(e.Header.Get("Date"))
+	if err != nil {
+		date = e.StoredAt
+	}
+
+	return max(expires.Sub(date), 0)
+}
+
+func (e *cachedResponse) staleWhileRevalidate() time.Duration {
+	swr, _ := parseCacheControl(e.Header.Get("Cache-Control")).seconds("stale-while-revalidate")
+
+	return swr
+}
+
+// isStorable reports whether a shared cache may store a response, following section 3 of RFC 9111.
+func isStorable(resp *http.Response, cc cacheControl, reqHeaders map[string]string) bool {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusNoContent, http.StatusMultipleChoices,
+		http.StatusMovedPermanently, http.StatusNotFound, http.StatusGone:
+	default:
+		return false
+	}
+
+	if cc.has("no-store") || cc.has("private") || slices.Contains(varyHeaders(resp.Header), "*") {
+		return false
+	}
+
+	if headerValue(reqHeaders, "Authorization") != "" && !cc.has("public") && !cc.has("s-maxage") &&
+		!cc.has("must-revalidate") {
+		return false
+	}
+
+	return cc.has("max-age") || cc.has("s-maxage") || cc.has("no-cache") || resp.Header.Get("Expires") != "" ||
+		resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+func varyHeaders(header http.Header) []string {
+	var names []string
+
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	return names
+}
+
+// isConditional reports whether a request carries its own validators, whose responses are left to the caller.
+func isConditional(headers map[string]string) bool {
+	return headerValue(headers, "If-None-Match") != "" || headerValue(headers, "If-Modified-Since") != ""
+}
+
+func headerValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// mergeHeaders returns a new map holding the headers of both maps, the values of extra taking precedence.
+func mergeHeaders(headers, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(headers)+len(extra))
+
+	for k, v := range headers {
+		merged[k] = v
+	}
+
+	for k, v := range extra {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// cacheControl holds the directives of a Cache-Control header, by lowercase name.
+type cacheControl map[string]string
+
+func parseCacheControl(header string) cacheControl {
+	cc := make(cacheControl)
+
+	for _, directive := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if name != "" {
+			cc[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+
+	return ok
+}
+
+func (cc cacheControl) seconds(directive string) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(cc[directive])
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultCacheCapacity = 1000
+
+// CacheStore stores the responses cached by CacheConfig.
+type CacheStore interface {
+	// Get returns the value stored under key, reporting false when there is none.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for the given duration, which is always positive.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the value stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryCacheStore is an in-memory CacheStore holding a bounded number of entries, evicting
+// the least recently used ones first.
+type MemoryCacheStore struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // most recently used entries first
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore creates a MemoryCacheStore holding up to capacity entries, 1000 when capacity is not positive.
+func NewMemoryCacheStore(capacity int) *MemoryCacheStore {
+	if capacity <= 0 {
+		capacity = defaultCacheCapacity
+	}
+
+	return &MemoryCacheStore{capacity: capacity, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (m *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry, ok := elem.Value.(*memoryCacheEntry)
+	if !ok || time.Now().After(entry.expiresAt) {
+		m.remove(elem)
+
+		return nil, false, nil
+	}
+
+	m.order.MoveToFront(elem)
+
+	return entry.value, true, nil
+}
+
+func (m *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value = entry
+		m.order.MoveToFront(elem)
+
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(entry)
+
+	for m.order.Len() > m.capacity {
+		m.remove(m.order.Back())
+	}
+
+	return nil
+}
+
+func (m *MemoryCacheStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.remove(elem)
+	}
+
+	return nil
+}
+
+func (m *MemoryCacheStore) remove(elem *list.Element) {
+	m.order.Remove(elem)
+
+	if entry, ok := elem.Value.(*memoryCacheEntry); ok {
+		delete(m.entries, entry.key)
+	}
+}
+
+// RedisCacheStore implements CacheStore using Redis, sharing the cached responses between the instances of an application.
+type RedisCacheStore struct {
+	client RedisCacheClient
+}
+
+// RedisCacheClient is the subset of the Redis commands used by RedisCacheStore. It is implemented by the Redis
+// datasource of GoFr as well as by the clients of go-redis.
+type RedisCacheClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+func NewRedisCacheStore(client RedisCacheClient) *RedisCacheStore {
+	return &RedisCacheStore{client: client}
+}
+
+func (r *RedisCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, "gofr:httpcache:"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (r *RedisCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, "gofr:httpcache:"+key, value, ttl).Err()
+}
+
+func (r *RedisCacheStore) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, "gofr:httpcache:"+key).Err()
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gofrRedis "gofr.dev/pkg/gofr/datasource/redis"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+type cacheMetrics struct {
+	mu      sync.Mutex
+	results map[string]int
+}
+
+func (*cacheMetrics) RecordHistogram(context.Context, string, float64, ...string) {}
+
+func (m *cacheMetrics) IncrementCounter(_ context.Context, name string, labels ...string) {
+	if name != "app_http_service_cache_count" {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.results == nil {
+		m.results = make(map[string]int)
+	}
+
+	m.results[labels[3]]++
+}
+
+func (m *cacheMetrics) count(result string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.results[result]
+}
+
+// newCachingServer responds to GET requests with the given headers and a body counting the requests,
+// answering 304 Not Modified to requests holding the ETag of its responses.
+func newCachingServer(t *testing.T, headers http.Header) (server *httptest.Server, calls *atomic.Int32) {
+	t.Helper()
+
+	calls = new(atomic.Int32)
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		for k, v := range headers {
+			w.Header()[http.CanonicalHeaderKey(k)] = v
+		}
+
+		if etag := w.Header().Get("ETag"); etag != "" && r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		_, _ = w.Write([]byte{byte('0' + n)})
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, calls
+}
+
+func newCachedService(t *testing.T, url string, store CacheStore) (HTTP, *cacheMetrics) {
+	t.Helper()
+
+	metrics := &cacheMetrics{}
+
+	return NewHTTPService(url, logging.NewMockLogger(logging.INFO), metrics, &CacheConfig{Store: store}), metrics
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestCacheProvider_Storable(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		headers http.Header
+		cached  bool
+	}{
+		{desc: "max-age", headers: http.Header{"Cache-Control": {"max-age=60"}}, cached: true},
+		{desc: "s-maxage", headers: http.Header{"Cache-Control": {"public, s-maxage=60"}}, cached: true},
+		{desc: "expires", headers: http.Header{"Expires": {time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)},
+			"Date": {time.Now().UTC().Format(http.TimeFormat)}}, cached: true},
+		{desc: "no-store", headers: http.Header{"Cache-Control": {"no-store, max-age=60"}}},
+		{desc: "private", headers: http.Header{"Cache-Control": {"private, max-age=60"}}},
+		{desc: "vary on everything", headers: http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"*"}}},
+		{desc: "no freshness", headers: http.Header{}},
+	}
+
+	for i, tc := range testCases {
+		server, calls := newCachingServer(t, tc.headers)
+		svc, _ := newCachedService(t, server.URL, nil)
+
+		first, err := svc.Get(t.Context(), "items", nil)
+		require.NoError(t, err)
+
+		second, err := svc.Get(t.Context(), "items", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1", readBody(t, first), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.cached {
+			assert.Equal(t, "1", readBody(t, second), "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, int32(1), calls.Load(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		} else {
+			assert.Equal(t, "2", readBody(t, second), "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, int32(2), calls.Load(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestCacheProvider_Hit(t *testing.T) {
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=60"}})
+	svc, metrics := newCachedService(t, server.URL, nil)
+
+	resp, err := svc.Get(t.Context(), "items", map[string]any{"page": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp))
+
+	resp, err = svc.Get(t.Context(), "items", map[string]any{"page": 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "0", resp.Header.Get("Age"))
+	assert.Equal(t, "1", readBody(t, resp))
+
+	resp, err = svc.Get(t.Context(), "items", map[string]any{"page": 2})
+	require.NoError(t, err)
+	assert.Equal(t, "2", readBody(t, resp), "other query parameters are cached separately")
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 1, metrics.count("hit"))
+	assert.Equal(t, 2, metrics.count("miss"))
+}
+
+func TestCacheProvider_Revalidation(t *testing.T) {
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=0"}, "ETag": {`"v1"`}})
+	svc, metrics := newCachedService(t, server.URL, nil)
+
+	resp, err := svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp))
+
+	resp, err = svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1", readBody(t, resp), "the cached body is served on 304 Not Modified")
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 1, metrics.count("revalidated"))
+}
+
+func TestCacheProvider_StaleWhileRevalidate(t *testing.T) {
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=0, stale-while-revalidate=60"}})
+	svc, metrics := newCachedService(t, server.URL, nil)
+
+	resp, err := svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp))
+
+	resp, err = svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp), "the stale response is served at once")
+
+	assert.Eventually(t, func() bool { return calls.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, metrics.count("stale"))
+
+	assert.Eventually(t, func() bool {
+		resp, err := svc.Get(t.Context(), "items", nil)
+
+		return err == nil && readBody(t, resp) == "2"
+	}, 5*time.Second, 10*time.Millisecond, "the response is refreshed in the background")
+}
+
+func TestCacheProvider_Bypass(t *testing.T) {
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=60"}})
+	svc, _ := newCachedService(t, server.URL, nil)
+
+	resp, err := svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp))
+
+	resp, err = svc.Get(BypassCache(t.Context()), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2", readBody(t, resp))
+
+	resp, err = svc.GetWithHeaders(t.Context(), "items", nil, map[string]string{"Cache-Control": "no-store"})
+	require.NoError(t, err)
+	assert.Equal(t, "3", readBody(t, resp))
+
+	resp, err = svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp), "bypassing calls leave the cache untouched")
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCacheProvider_Authorization(t *testing.T) {
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=60"}})
+	svc, _ := newCachedService(t, server.URL, nil)
+
+	for range 2 {
+		resp, err := svc.GetWithHeaders(t.Context(), "items", nil, map[string]string{"Authorization": "Bearer token"})
+		require.NoError(t, err)
+
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, int32(2), calls.Load(), "responses to authorized calls are not shared unless public")
+}
+
+func TestCacheProvider_Invalidation(t *testing.T) {
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=60"}})
+	svc, _ := newCachedService(t, server.URL, nil)
+
+	resp, err := svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp))
+
+	resp, err = svc.Put(t.Context(), "items", nil, []byte(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "3", readBody(t, resp))
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCacheProvider_RedisStore(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	store := NewRedisCacheStore(&gofrRedis.Redis{Client: redis.NewClient(&redis.Options{Addr: s.Addr()})})
+
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=60"}})
+	svc, _ := newCachedService(t, server.URL, store)
+	other, _ := newCachedService(t, server.URL, store)
+
+	resp, err := svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp))
+
+	resp, err = other.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", readBody(t, resp), "the cached responses are shared through Redis")
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Len(t, s.Keys(), 1)
+}
+
+func TestCacheProvider_ZeroLifetimeNotStored(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	store := NewRedisCacheStore(redis.NewClient(&redis.Options{Addr: s.Addr()}))
+
+	server, calls := newCachingServer(t, http.Header{"Cache-Control": {"max-age=0"}})
+	svc, _ := newCachedService(t, server.URL, store)
+
+	for range 2 {
+		resp, err := svc.Get(t.Context(), "items", nil)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Empty(t, s.Keys(), "a response without lifetime must not be stored without expiry")
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	ctx := t.Context()
+	store := NewMemoryCacheStore(2)
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, store.Set(ctx, "b", []byte("2"), time.Minute))
+
+	_, ok, _ := store.Get(ctx, "a") // a becomes the most recently used entry
+	assert.True(t, ok)
+
+	require.NoError(t, store.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, ok, _ = store.Get(ctx, "b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+
+	require.NoError(t, store.Set(ctx, "d", []byte("4"), -time.Second))
+
+	_, ok, _ = store.Get(ctx, "d")
+	assert.False(t, ok, "expired entries are not returned")
+
+	require.NoError(t, store.Delete(ctx, "a"))
+
+	_, ok, _ = store.Get(ctx, "a")
+	assert.False(t, ok)
+}
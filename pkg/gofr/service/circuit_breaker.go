@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"sync"
 	"time"
@@ -268,3 +269,38 @@ func (cb *circuitBreaker) Delete(ctx context.Context, path string, body []byte)
 	*http.Response, error) {
 	return cb.doRequest(ctx, http.MethodDelete, path, nil, body, nil)
 }
+
+// PostStream is a wrapper for doStreamRequest with the POST method.
+func (cb *circuitBreaker) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return cb.doStreamRequest(ctx, func(ctx context.Context) (*http.Response, error) {
+		return streamer(cb.HTTP).PostStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+// PutStream is a wrapper for doStreamRequest with the PUT method.
+func (cb *circuitBreaker) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return cb.doStreamRequest(ctx, func(ctx context.Context) (*http.Response, error) {
+		return streamer(cb.HTTP).PutStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+// PatchStream is a wrapper for doStreamRequest with the PATCH method.
+func (cb *circuitBreaker) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return cb.doStreamRequest(ctx, func(ctx context.Context) (*http.Response, error) {
+		return streamer(cb.HTTP).PatchStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+// doStreamRequest sends a request with a streamed body through the circuit breaker. As with the other requests,
+// the body is not read when the circuit is open.
+func (cb *circuitBreaker) doStreamRequest(ctx context.Context, f func(ctx context.Context) (*http.Response,
+	error)) (*http.Response, error) {
+	if cb.isOpen() && !cb.tryCircuitRecovery() {
+		return nil, ErrCircuitOpen
+	}
+
+	return cb.handleCircuitBreakerResult(cb.executeWithCircuitBreaker(ctx, f))
+}
@@ -151,6 +151,16 @@ func extractHTTPService(h HTTP) *httpService {
 		return extractHTTPService(ch.HTTP)
 	}
 
+	// Check if it's a caching, bulkhead or hedging wrapper
+	switch w := h.(type) {
+	case *cacheProvider:
+		return extractHTTPService(w.HTTP)
+	case *bulkhead:
+		return extractHTTPService(w.HTTP)
+	case *hedgingProvider:
+		return extractHTTPService(w.HTTP)
+	}
+
 	// If we can't extract it, return nil
 	return nil
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"net/http"
 )
 
@@ -80,6 +81,27 @@ func (a *customHeader) DeleteWithHeaders(ctx context.Context, path string, body
 	return a.HTTP.DeleteWithHeaders(ctx, path, body, headers)
 }
 
+func (a *customHeader) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	headers = setCustomHeader(headers, a.Headers)
+
+	return streamer(a.HTTP).PostStream(ctx, path, queryParams, body, headers)
+}
+
+func (a *customHeader) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	headers = setCustomHeader(headers, a.Headers)
+
+	return streamer(a.HTTP).PutStream(ctx, path, queryParams, body, headers)
+}
+
+func (a *customHeader) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	headers = setCustomHeader(headers, a.Headers)
+
+	return streamer(a.HTTP).PatchStream(ctx, path, queryParams, body, headers)
+}
+
 func setCustomHeader(headers, customHeader map[string]string) map[string]string {
 	if headers == nil {
 		headers = make(map[string]string)
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultHedgePercentile   = 95
+	defaultHedgeDelay        = 100 * time.Millisecond
+	defaultHedgeMinSamples   = 20
+	defaultHedgeLatencyCount = 100
+)
+
+// HedgingConfig sends a second, hedged request for the GET calls to a service which take longer than most of them,
+// returning the response received first and cancelling the other request. This trims the tail latency of a service
+// whose slow responses come from some of its instances or connections, at the cost of the extra requests.
+//
+// The delay after which a call is hedged is the given percentile of the latencies of the last 100 GET calls, and
+// InitialDelay until MinSamples of them are known. Other methods are never hedged, as they are not idempotent.
+type HedgingConfig struct {
+	// Percentile of the latencies after which a call is hedged, 95 by default.
+	Percentile float64
+	// InitialDelay is the delay used until enough latencies are known, 100ms by default.
+	InitialDelay time.Duration
+	// MinSamples is the number of latencies needed to use the percentile, 20 by default.
+	MinSamples int
+}
+
+func (c *HedgingConfig) AddOption(h HTTP) HTTP {
+	hp := &hedgingProvider{
+		percentile:   c.Percentile,
+		initialDelay: c.InitialDelay,
+		minSamples:   c.MinSamples,
+		latencies:    make([]time.Duration, 0, defaultHedgeLatencyCount),
+		HTTP:         h,
+	}
+
+	if hp.percentile <= 0 || hp.percentile > 100 {
+		hp.percentile = defaultHedgePercentile
+	}
+
+	if hp.initialDelay <= 0 {
+		hp.initialDelay = defaultHedgeDelay
+	}
+
+	if hp.minSamples <= 0 {
+		hp.minSamples = defaultHedgeMinSamples
+	}
+
+	if httpSvc := extractHTTPService(h); httpSvc != nil {
+		hp.url = httpSvc.url
+		hp.metrics, _ = httpSvc.Metrics.(counter)
+	}
+
+	return hp
+}
+
+type hedgingProvider struct {
+	percentile   float64
+	initialDelay time.Duration
+	minSamples   int
+
+	mu        sync.Mutex
+	latencies []time.Duration // ring of the latest latencies
+	next      int
+
+	url     string
+	metrics counter
+
+	HTTP
+}
+
+type hedgeResult struct {
+	resp    *http.Response
+	err     error
+	attempt int // 0 for the first request, 1 for the hedged one
+}
+
+func (hp *hedgingProvider) Get(ctx context.Context, path string, queryParams map[string]any) (*http.Response, error) {
+	return hp.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (hp *hedgingProvider) GetWithHeaders(ctx context.Context, path string, queryParams map[string]any,
+	headers map[string]string) (*http.Response, error) {
+	return hp.hedge(ctx, func(ctx context.Context) (*http.Response, error) {
+		// the headers are copied, as the options may modify them while both requests are in flight.
+		return hp.HTTP.GetWithHeaders(ctx, path, queryParams, mergeHeaders(headers, nil))
+	})
+}
+
+// hedge sends a request, and a hedged one once the first is slower than the hedging delay.
+func (hp *hedgingProvider) hedge(ctx context.Context, send func(ctx context.Context) (*http.Response, error)) (
+	*http.Response, error) {
+	results := make(chan hedgeResult, 2)
+	start := time.Now()
+
+	cancels := []context.CancelFunc{hp.send(ctx, send, results, 0)}
+
+	delay := hp.delay()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case r := <-results:
+		hp.observe(time.Since(start))
+
+		return cancelOnClose(r.resp, cancels[0]), r.err
+	case <-ctx.Done():
+		r := <-results
+
+		return cancelOnClose(r.resp, cancels[0]), r.err
+	case <-timer.C:
+	}
+
+	trace.SpanFromContext(ctx).AddEvent("http.client.hedge", trace.WithAttributes(
+		attribute.String("http.hedge.delay", delay.String()),
+	))
+	hp.record(ctx, "sent")
+
+	cancels = append(cancels, hp.send(ctx, send, results, 1))
+
+	winner := <-results
+	if winner.err != nil {
+		// the other request may still succeed where this one failed.
+		cancels[winner.attempt]()
+		winner = <-results
+	} else {
+		cancels[1-winner.attempt]()
+
+		go func() { discard((<-results).resp) }()
+	}
+
+	hp.observe(time.Since(start))
+
+	if winner.attempt == 1 && winner.err == nil {
+		trace.SpanFromContext(ctx).AddEvent("http.client.hedge.won")
+		hp.record(ctx, "won")
+	}
+
+	return cancelOnClose(winner.resp, cancels[winner.attempt]), winner.err
+}
+
+// send sends a request in a goroutine, returning the function cancelling it.
+func (*hedgingProvider) send(ctx context.Context, send func(ctx context.Context) (*http.Response, error),
+	results chan<- hedgeResult, attempt int) context.CancelFunc {
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		resp, err := send(ctx)
+		results <- hedgeResult{resp: resp, err: err, attempt: attempt}
+	}()
+
+	return cancel
+}
+
+// delay returns the percentile of the latest latencies, or the initial delay until enough of them are known.
+func (hp *hedgingProvider) delay() time.Duration {
+	hp.mu.Lock()
+
+	if len(hp.latencies) < hp.minSamples {
+		hp.mu.Unlock()
+
+		return hp.initialDelay
+	}
+
+	sorted := slices.Clone(hp.latencies)
+
+	hp.mu.Unlock()
+
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(hp.percentile/100*float64(len(sorted)))) - 1
+
+	return sorted[max(rank, 0)]
+}
+
+func (hp *hedgingProvider) observe(latency time.Duration) {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	if len(hp.latencies) < cap(hp.latencies) {
+		hp.latencies = append(hp.latencies, latency)
+
+		return
+	}
+
+	hp.latencies[hp.next] = latency
+	hp.next = (hp.next + 1) % len(hp.latencies)
+}
+
+func (hp *hedgingProvider) record(ctx context.Context, result string) {
+	if hp.metrics != nil {
+		hp.metrics.IncrementCounter(ctx, "app_http_service_hedge_count", "path", hp.url, "result", result)
+	}
+}
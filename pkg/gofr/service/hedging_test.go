@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// slowFirstHTTP hangs its first GET call until it is cancelled, and responds at once to the next ones.
+type slowFirstHTTP struct {
+	calls     atomic.Int32
+	cancelled chan struct{}
+
+	HTTP
+}
+
+func (s *slowFirstHTTP) GetWithHeaders(ctx context.Context, _ string, _ map[string]any,
+	_ map[string]string) (*http.Response, error) {
+	if s.calls.Add(1) == 1 {
+		<-ctx.Done()
+		close(s.cancelled)
+
+		return nil, ctx.Err()
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("hedged"))}, nil
+}
+
+func TestHedgingProvider_HedgedRequestWins(t *testing.T) {
+	fake := &slowFirstHTTP{cancelled: make(chan struct{})}
+	metrics := &countingMetrics{}
+
+	hp := (&HedgingConfig{InitialDelay: 10 * time.Millisecond}).AddOption(fake).(*hedgingProvider)
+	hp.metrics = metrics
+
+	resp, err := hp.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hedged", readBody(t, resp))
+	assert.Equal(t, int32(2), fake.calls.Load())
+	assert.Equal(t, 1, metrics.count("app_http_service_hedge_count:sent"))
+	assert.Equal(t, 1, metrics.count("app_http_service_hedge_count:won"))
+
+	select {
+	case <-fake.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the losing request was not cancelled")
+	}
+}
+
+func TestHedgingProvider_FastResponse(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil, &HedgingConfig{InitialDelay: 5 * time.Second})
+
+	resp, err := svc.Get(t.Context(), "items", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ok", readBody(t, resp))
+	assert.Equal(t, int32(1), calls.Load())
+
+	resp, err = svc.Post(t.Context(), "items", nil, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, int32(2), calls.Load(), "other methods are not hedged")
+}
+
+func TestHedgingProvider_Delay(t *testing.T) {
+	hp := (&HedgingConfig{Percentile: 90, InitialDelay: time.Second, MinSamples: 10}).AddOption(nil).(*hedgingProvider)
+
+	assert.Equal(t, time.Second, hp.delay(), "the initial delay is used until enough latencies are known")
+
+	for i := 1; i <= 10; i++ {
+		hp.observe(time.Duration(i) * time.Millisecond)
+	}
+
+	assert.Equal(t, 9*time.Millisecond, hp.delay())
+
+	for range defaultHedgeLatencyCount {
+		hp.observe(time.Millisecond)
+	}
+
+	assert.Equal(t, time.Millisecond, hp.delay(), "only the latest latencies are kept")
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResponseError is returned by the JSON helpers when the downstream service responds with a status code outside
+// of the 2xx range, or with an error alongside partial data.
+type ResponseError struct {
+	// StatusCode is the status code of the response.
+	StatusCode int
+	// Message is the error message sent by the downstream service, if any.
+	Message string
+	// Body is the raw body of the response.
+	Body []byte
+}
+
+func (e *ResponseError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("downstream service responded with status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("downstream service responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// GetJSON performs a GET request and decodes the JSON body of the response into a value of type T.
+// Both bodies wrapped in the {"data": ..., "error": ...} envelope of GoFr services and plain JSON bodies are decoded.
+// Responses with a status code outside of the 2xx range are returned as a *ResponseError.
+func GetJSON[T any](ctx context.Context, svc httpClient, path string, queryParams map[string]any) (T, error) {
+	return decodeJSON[T](svc.GetWithHeaders(ctx, path, queryParams, jsonHeaders()))
+}
+
+// PostJSON performs a POST request with body encoded as JSON, and decodes the JSON body of the response
+// into a value of type Resp, as GetJSON does.
+func PostJSON[Req, Resp any](ctx context.Context, svc httpClient, path string, queryParams map[string]any,
+	body Req) (Resp, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		var zero Resp
+
+		return zero, err
+	}
+
+	return decodeJSON[Resp](svc.PostWithHeaders(ctx, path, queryParams, payload, jsonHeaders()))
+}
+
+// PutJSON performs a PUT request with body encoded as JSON, and decodes the JSON body of the response
+// into a value of type Resp, as GetJSON does.
+func PutJSON[Req, Resp any](ctx context.Context, svc httpClient, path string, queryParams map[string]any,
+	body Req) (Resp, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		var zero Resp
+
+		return zero, err
+	}
+
+	return decodeJSON[Resp](svc.PutWithHeaders(ctx, path, queryParams, payload, jsonHeaders()))
+}
+
+// PatchJSON performs a PATCH request with body encoded as JSON, and decodes the JSON body of the response
+// into a value of type Resp, as GetJSON does.
+func PatchJSON[Req, Resp any](ctx context.Context, svc httpClient, path string, queryParams map[string]any,
+	body Req) (Resp, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		var zero Resp
+
+		return zero, err
+	}
+
+	return decodeJSON[Resp](svc.PatchWithHeaders(ctx, path, queryParams, payload, jsonHeaders()))
+}
+
+// DeleteJSON performs a DELETE request and decodes the JSON body of the response, if any, into a value
+// of type T, as GetJSON does.
+func DeleteJSON[T any](ctx context.Context, svc httpClient, path string) (T, error) {
+	return decodeJSON[T](svc.DeleteWithHeaders(ctx, path, nil, jsonHeaders()))
+}
+
+// jsonHeaders returns the headers of the requests of the JSON helpers. A new map is returned for every request,
+// as the auth and default header options add their headers to it.
+func jsonHeaders() map[string]string {
+	return map[string]string{"Accept": "application/json", "Content-Type": "application/json"}
+}
+
+// envelope is the body of the responses of GoFr services.
+type envelope struct {
+	Data  json.RawMessage `json:"data"`
+	Error json.RawMessage `json:"error"`
+}
+
+func decodeJSON[T any](resp *http.Response, err error) (T, error) {
+	var result T
+
+	if err != nil {
+		return result, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	env, isEnvelope := parseEnvelope(body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return result, &ResponseError{StatusCode: resp.StatusCode, Message: errorMessage(body), Body: body}
+	}
+
+	data := body
+	if isEnvelope {
+		data = env.Data
+	}
+
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &result); err != nil {
+			return result, err
+		}
+	}
+
+	if isEnvelope && isPresent(env.Error) {
+		return result, &ResponseError{StatusCode: resp.StatusCode, Message: errorMessage(body), Body: body}
+	}
+
+	return result, nil
+}
+
+// parseEnvelope reports whether body is an object holding nothing but the data, error and metadata of a GoFr response.
+func parseEnvelope(body []byte) (envelope, bool) {
+	var fields map[string]json.RawMessage
+
+	if json.Unmarshal(body, &fields) != nil || len(fields) == 0 {
+		return envelope{}, false
+	}
+
+	for key := range fields {
+		if key != "data" && key != "error" && key != "metadata" {
+			return envelope{}, false
+		}
+	}
+
+	return envelope{Data: fields["data"], Error: fields["error"]}, true
+}
+
+// errorMessage extracts the error message of a response, given either as {"error": {"message": ...}},
+// {"error": "..."} or {"message": ...}.
+func errorMessage(body []byte) string {
+	var plain struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+	}
+
+	if json.Unmarshal(body, &plain) != nil {
+		return ""
+	}
+
+	var detail struct {
+		Message string `json:"message"`
+	}
+
+	var text string
+
+	switch {
+	case json.Unmarshal(plain.Error, &detail) == nil && detail.Message != "":
+		return detail.Message
+	case json.Unmarshal(plain.Error, &text) == nil && text != "":
+		return text
+	}
+
+	return plain.Message
+}
+
+func isPresent(raw json.RawMessage) bool {
+	return len(raw) > 0 && string(raw) != "null"
+}
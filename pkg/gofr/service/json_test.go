@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+type jsonUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newJSONServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGetJSON(t *testing.T) {
+	tests := []struct {
+		desc   string
+		status int
+		body   string
+		result jsonUser
+		err    error
+	}{
+		{"GoFr envelope", http.StatusOK, `{"data":{"id":1,"name":"gofr"}}`, jsonUser{ID: 1, Name: "gofr"}, nil},
+		{"plain body", http.StatusOK, `{"id":1,"name":"gofr"}`, jsonUser{ID: 1, Name: "gofr"}, nil},
+		{"empty body", http.StatusNoContent, ``, jsonUser{}, nil},
+		{"GoFr error", http.StatusNotFound, `{"error":{"message":"No entity found with id: 1"}}`, jsonUser{},
+			&ResponseError{StatusCode: http.StatusNotFound, Message: "No entity found with id: 1",
+				Body: []byte(`{"error":{"message":"No entity found with id: 1"}}`)}},
+		{"plain error", http.StatusBadRequest, `{"message":"invalid id"}`, jsonUser{},
+			&ResponseError{StatusCode: http.StatusBadRequest, Message: "invalid id", Body: []byte(`{"message":"invalid id"}`)}},
+		{"string error", http.StatusUnauthorized, `{"error":"unauthorized"}`, jsonUser{},
+			&ResponseError{StatusCode: http.StatusUnauthorized, Message: "unauthorized", Body: []byte(`{"error":"unauthorized"}`)}},
+		{"non JSON error", http.StatusBadGateway, `bad gateway`, jsonUser{},
+			&ResponseError{StatusCode: http.StatusBadGateway, Body: []byte(`bad gateway`)}},
+		{"partial content", http.StatusPartialContent, `{"data":{"id":1},"error":{"message":"name unavailable"}}`,
+			jsonUser{ID: 1}, &ResponseError{StatusCode: http.StatusPartialContent, Message: "name unavailable",
+				Body: []byte(`{"data":{"id":1},"error":{"message":"name unavailable"}}`)}},
+	}
+
+	for i, tc := range tests {
+		server := newJSONServer(t, tc.status, tc.body)
+		svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil)
+
+		result, err := GetJSON[jsonUser](t.Context(), svc, "users/1", nil)
+
+		assert.Equal(t, tc.result, result, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGetJSON_PaginatedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gofrHTTP.NewResponder(w, r.Method).Respond(response.Response{
+			Data:     []jsonUser{{ID: 1, Name: "gofr"}, {ID: 2, Name: "zop"}},
+			Metadata: map[string]any{"page": 1, "total": 2},
+		}, nil)
+	}))
+	defer server.Close()
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil)
+
+	users, err := GetJSON[[]jsonUser](t.Context(), svc, "users", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []jsonUser{{ID: 1, Name: "gofr"}, {ID: 2, Name: "zop"}}, users)
+}
+
+func TestJSONHelpers_RequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var user jsonUser
+
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "value", r.Header.Get("X-Default"))
+
+		if r.Method != http.MethodDelete {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+			assert.Equal(t, jsonUser{ID: 1, Name: "gofr"}, user)
+		}
+
+		_, _ = w.Write([]byte(`{"data":"` + r.Method + `"}`))
+	}))
+	defer server.Close()
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil,
+		&DefaultHeaders{Headers: map[string]string{"X-Default": "value"}}, &RetryConfig{MaxRetries: 1})
+	user := jsonUser{ID: 1, Name: "gofr"}
+
+	calls := map[string]func(ctx context.Context) (string, error){
+		http.MethodPost: func(ctx context.Context) (string, error) {
+			return PostJSON[jsonUser, string](ctx, svc, "users", nil, user)
+		},
+		http.MethodPut: func(ctx context.Context) (string, error) {
+			return PutJSON[jsonUser, string](ctx, svc, "users/1", nil, user)
+		},
+		http.MethodPatch: func(ctx context.Context) (string, error) {
+			return PatchJSON[jsonUser, string](ctx, svc, "users/1", nil, user)
+		},
+		http.MethodDelete: func(ctx context.Context) (string, error) {
+			return DeleteJSON[string](ctx, svc, "users/1")
+		},
+	}
+
+	for method, call := range calls {
+		result, err := call(t.Context())
+		require.NoError(t, err, method)
+
+		assert.Equal(t, method, result)
+	}
+}
+
+func TestPostJSON_MarshalError(t *testing.T) {
+	_, err := PostJSON[chan int, jsonUser](t.Context(), &mockHTTP{}, "users", nil, make(chan int))
+
+	require.Error(t, err)
+}
+
+func TestResponseError_Error(t *testing.T) {
+	assert.Equal(t, "downstream service responded with status 502", (&ResponseError{StatusCode: 502}).Error())
+	assert.Equal(t, "downstream service responded with status 404: not found",
+		(&ResponseError{StatusCode: 404, Message: "not found"}).Error())
+}
@@ -0,0 +1,507 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hash/crc32"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// LoadBalancingStrategy selects the endpoint each request is sent to.
+type LoadBalancingStrategy int
+
+const (
+	// RoundRobin sends the requests to the endpoints in turn.
+	RoundRobin LoadBalancingStrategy = iota
+	// LeastOutstandingRequests sends each request to the endpoint with the fewest requests in flight.
+	LeastOutstandingRequests
+	// ConsistentHash sends the requests with the same hash key to the same endpoint, moving only the keys of an
+	// endpoint which becomes unavailable.
+	ConsistentHash
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultDNSRefreshInterval  = 30 * time.Second
+	hashRingReplicas           = 100
+)
+
+// ErrNoAvailableEndpoint is returned when all the endpoints of a load balanced service are unhealthy or ejected.
+var ErrNoAvailableEndpoint = errors.New("no available endpoint for service")
+
+// LoadBalancerConfig spreads the requests to a service across several instances, given as a list of endpoints,
+// resolved from DNS, or both. The service address given to AddHTTPService is used only while no endpoint is known.
+//
+// Endpoints are ejected while their health check reports them down, when HealthConfig is set, and while their
+// circuit is open, when CircuitBreaker is set: an endpoint failing more than CircuitBreaker.Threshold consecutive
+// requests, with an error or a 5xx status, is ejected for CircuitBreaker.Interval before being tried again.
+//
+// Example:
+//
+//	app.AddHTTPService("orders", "http://orders",
+//	    &service.LoadBalancerConfig{
+//	        Endpoints:      []string{"http://10.0.0.1:8000", "http://10.0.0.2:8000"},
+//	        Strategy:       service.LeastOutstandingRequests,
+//	        HealthConfig:   &service.HealthConfig{HealthEndpoint: ".well-known/health"},
+//	        CircuitBreaker: &service.CircuitBreakerConfig{Threshold: 3, Interval: 10 * time.Second},
+//	    },
+//	)
+type LoadBalancerConfig struct {
+	// Endpoints are the base URLs of the instances of the service.
+	Endpoints []string
+	// DNS resolves the instances of the service from DNS records, refreshed periodically.
+	DNS *DNSDiscovery
+	// Strategy selects the endpoint of each request, RoundRobin by default.
+	Strategy LoadBalancingStrategy
+	// HashKey returns the key of a request for the ConsistentHash strategy, its path by default.
+	HashKey func(ctx context.Context, path string) string
+	// HealthConfig enables the periodic health checks of the endpoints, probing HealthEndpoint,
+	// or /.well-known/alive when empty.
+	HealthConfig *HealthConfig
+	// HealthCheckInterval is the interval between two health checks of the endpoints, 10s by default.
+	HealthCheckInterval time.Duration
+	// CircuitBreaker enables the ejection of the endpoints failing consecutive requests.
+	CircuitBreaker *CircuitBreakerConfig
+}
+
+// DNSDiscovery resolves the endpoints of a service from the A/AAAA records of Host, or from the SRV records
+// of _Service._Proto.Host when Service is set.
+type DNSDiscovery struct {
+	Host string
+	// Service and Proto name the SRV records resolved; Proto defaults to "tcp".
+	Service string
+	Proto   string
+	// Port of the endpoints resolved from A/AAAA records, 80 or 443 by default depending on Scheme.
+	Port int
+	// Scheme of the endpoint URLs, "http" by default.
+	Scheme string
+	// RefreshInterval is the interval between two resolutions, 30s by default.
+	RefreshInterval time.Duration
+	// Resolver resolves the records, net.DefaultResolver by default.
+	Resolver DNSResolver
+}
+
+// DNSResolver is implemented by *net.Resolver.
+type DNSResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// AddOption attaches the load balancer to the base HTTP service, so that all the options of the service,
+// such as retries, apply to every endpoint, each retry possibly being sent to another endpoint.
+func (c *LoadBalancerConfig) AddOption(h HTTP) HTTP {
+	httpSvc := extractHTTPService(h)
+	if httpSvc == nil {
+		logging.NewLogger(logging.INFO).Errorf("load balancer not attached: the service is wrapped by an option " +
+			"GoFr cannot see through, add LoadBalancerConfig before it")
+
+		return h
+	}
+
+	lb := newLoadBalancer(c, httpSvc)
+	httpSvc.balancer = lb
+
+	lb.start()
+
+	return h
+}
+
+type loadBalancer struct {
+	config  LoadBalancerConfig
+	svc     *httpService
+	hashKey func(ctx context.Context, path string) string
+
+	mu        sync.RWMutex
+	endpoints []*endpoint
+	ring      []ringEntry
+
+	next atomic.Uint64
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// endpoint is an instance of a load balanced service.
+type endpoint struct {
+	url         string
+	outstanding atomic.Int64
+
+	mu        sync.Mutex
+	down      bool
+	failures  int
+	openUntil time.Time
+}
+
+type ringEntry struct {
+	hash     uint32
+	endpoint *endpoint
+}
+
+func newLoadBalancer(c *LoadBalancerConfig, svc *httpService) *loadBalancer {
+	lb := &loadBalancer{config: *c, svc: svc, hashKey: c.HashKey, done: make(chan struct{})}
+
+	if lb.hashKey == nil {
+		lb.hashKey = func(_ context.Context, path string) string { return path }
+	}
+
+	if lb.config.HealthCheckInterval <= 0 {
+		lb.config.HealthCheckInterval = defaultHealthCheckInterval
+	}
+
+	lb.setEndpoints(c.Endpoints)
+
+	return lb
+}
+
+// start resolves the endpoints of the service and starts their periodic resolution and health checks.
+func (lb *loadBalancer) start() {
+	if lb.config.DNS != nil {
+		lb.resolve(context.Background())
+
+		go lb.every(lb.config.DNS.refreshInterval(), lb.resolve)
+	}
+
+	if lb.config.HealthConfig != nil {
+		go lb.every(lb.config.HealthCheckInterval, lb.checkHealth)
+	}
+}
+
+// every calls f at every interval until the load balancer is stopped.
+func (lb *loadBalancer) every(interval time.Duration, f func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lb.done:
+			return
+		case <-ticker.C:
+			f(context.Background())
+		}
+	}
+}
+
+// stop ends the periodic resolution and health checks of the endpoints.
+func (lb *loadBalancer) stop() {
+	lb.closeOnce.Do(func() { close(lb.done) })
+}
+
+// Close stops the background work of a service created by NewHTTPService, such as the periodic resolution and
+// health checks of the endpoints of its load balancer. The service keeps sending requests to the endpoints known
+// when it was closed.
+func Close(h HTTP) error {
+	if httpSvc := extractHTTPService(h); httpSvc != nil && httpSvc.balancer != nil {
+		httpSvc.balancer.stop()
+	}
+
+	return nil
+}
+
+// resolve replaces the resolved endpoints of the service, keeping the previous ones when the resolution fails.
+func (lb *loadBalancer) resolve(ctx context.Context) {
+	urls, err := lb.config.DNS.resolve(ctx)
+	if err != nil || len(urls) == 0 {
+		lb.svc.Log("failed to resolve the endpoints of " + lb.svc.url + " from DNS: " + errorText(err))
+
+		return
+	}
+
+	lb.setEndpoints(append(slices.Clone(lb.config.Endpoints), urls...))
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return "no record found"
+	}
+
+	return err.Error()
+}
+
+// setEndpoints updates the endpoints of the service, keeping the state of the endpoints already known.
+func (lb *loadBalancer) setEndpoints(urls []string) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	known := make(map[string]*endpoint, len(lb.endpoints))
+
+	for _, e := range lb.endpoints {
+		known[e.url] = e
+	}
+
+	endpoints := make([]*endpoint, 0, len(urls))
+	ring := make([]ringEntry, 0, len(urls)*hashRingReplicas)
+
+	for _, u := range urls {
+		u = strings.TrimRight(u, "/")
+
+		e, ok := known[u]
+		if !ok {
+			e = &endpoint{url: u}
+		}
+
+		if slices.Contains(endpoints, e) {
+			continue
+		}
+
+		endpoints = append(endpoints, e)
+
+		for i := range hashRingReplicas {
+			ring = append(ring, ringEntry{hash: crc32.ChecksumIEEE([]byte(u + "#" + strconv.Itoa(i))), endpoint: e})
+		}
+	}
+
+	sort.Slice(ring, func(i, j int) bool { return ring[i].hash < ring[j].hash })
+
+	lb.endpoints, lb.ring = endpoints, ring
+}
+
+// pick selects the endpoint of a request, returning nil when no endpoint is known.
+func (lb *loadBalancer) pick(ctx context.Context, path string) (*endpoint, error) {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	if len(lb.endpoints) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	var e *endpoint
+
+	switch lb.config.Strategy {
+	case LeastOutstandingRequests:
+		e = lb.leastOutstanding(now)
+	case ConsistentHash:
+		e = lb.consistentHash(lb.hashKey(ctx, path), now)
+	default:
+		e = lb.roundRobin(now)
+	}
+
+	if e == nil {
+		return nil, ErrNoAvailableEndpoint
+	}
+
+	return e, nil
+}
+
+func (lb *loadBalancer) roundRobin(now time.Time) *endpoint {
+	n := uint64(len(lb.endpoints))
+	start := lb.next.Add(1) - 1
+
+	for i := range n {
+		if e := lb.endpoints[(start+i)%n]; e.available(now) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// leastOutstanding returns the available endpoint with the fewest requests in flight, starting
+// the search at a rotating index so that ties are spread across the endpoints.
+func (lb *loadBalancer) leastOutstanding(now time.Time) *endpoint {
+	var best *endpoint
+
+	n := uint64(len(lb.endpoints))
+	start := lb.next.Add(1) - 1
+
+	for i := range n {
+		e := lb.endpoints[(start+i)%n]
+
+		if e.available(now) && (best == nil || e.outstanding.Load() < best.outstanding.Load()) {
+			best = e
+		}
+	}
+
+	return best
+}
+
+// consistentHash returns the first available endpoint following the hash of key on the ring.
+func (lb *loadBalancer) consistentHash(key string, now time.Time) *endpoint {
+	hash := crc32.ChecksumIEEE([]byte(key))
+	start := sort.Search(len(lb.ring), func(i int) bool { return lb.ring[i].hash >= hash })
+
+	for i := range lb.ring {
+		if e := lb.ring[(start+i)%len(lb.ring)].endpoint; e.available(now) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// checkHealth probes all the endpoints of the service concurrently, ejecting the ones reported down.
+func (lb *loadBalancer) checkHealth(ctx context.Context) {
+	lb.mu.RLock()
+	endpoints := slices.Clone(lb.endpoints)
+	lb.mu.RUnlock()
+
+	healthEndpoint := lb.config.HealthConfig.HealthEndpoint
+	if healthEndpoint == "" {
+		healthEndpoint = strings.TrimPrefix(AlivePath, "/")
+	}
+
+	timeout := lb.config.HealthConfig.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	var wg sync.WaitGroup
+
+	for _, e := range endpoints {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			health := lb.svc.getHealthResponseForEndpoint(withEndpoint(ctx, e.url), healthEndpoint, timeout)
+
+			e.mu.Lock()
+			e.down = health.Status != serviceUp
+			e.mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (e *endpoint) available(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return !e.down && !now.Before(e.openUntil)
+}
+
+// acquire counts a request sent to the endpoint, returning the function recording its outcome.
+func (e *endpoint) acquire(breaker *CircuitBreakerConfig) func(resp *http.Response, err error) {
+	e.outstanding.Add(1)
+
+	return func(resp *http.Response, err error) {
+		e.outstanding.Add(-1)
+
+		if breaker == nil {
+			return
+		}
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			e.failures = 0
+
+			return
+		}
+
+		e.failures++
+
+		if e.failures > breaker.Threshold {
+			e.openUntil = time.Now().Add(breaker.Interval)
+		}
+	}
+}
+
+func (d *DNSDiscovery) refreshInterval() time.Duration {
+	if d.RefreshInterval <= 0 {
+		return defaultDNSRefreshInterval
+	}
+
+	return d.RefreshInterval
+}
+
+// resolve returns the sorted URLs of the endpoints found in DNS.
+func (d *DNSDiscovery) resolve(ctx context.Context) ([]string, error) {
+	resolver := d.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	scheme := d.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	var urls []string
+
+	if d.Service != "" {
+		proto := d.Proto
+		if proto == "" {
+			proto = "tcp"
+		}
+
+		_, records, err := resolver.LookupSRV(ctx, d.Service, proto, d.Host)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range records {
+			urls = append(urls, scheme+"://"+net.JoinHostPort(strings.TrimSuffix(r.Target, "."), strconv.Itoa(int(r.Port))))
+		}
+	} else {
+		addrs, err := resolver.LookupHost(ctx, d.Host)
+		if err != nil {
+			return nil, err
+		}
+
+		port := d.Port
+		if port == 0 {
+			port = defaultPort(scheme)
+		}
+
+		for _, addr := range addrs {
+			urls = append(urls, scheme+"://"+net.JoinHostPort(addr, strconv.Itoa(port)))
+		}
+	}
+
+	slices.Sort(urls)
+
+	return urls, nil
+}
+
+func defaultPort(scheme string) int {
+	if scheme == "https" {
+		return 443
+	}
+
+	return 80
+}
+
+type endpointKey struct{}
+
+// withEndpoint sends the requests made with the returned context to the given endpoint, bypassing the load balancer.
+func withEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, url)
+}
+
+// baseURL returns the base URL a request is sent to, along with the function recording its outcome.
+func (h *httpService) baseURL(ctx context.Context, path string) (string, func(*http.Response, error), error) {
+	done := func(*http.Response, error) {}
+
+	if url, ok := ctx.Value(endpointKey{}).(string); ok {
+		return url, done, nil
+	}
+
+	if h.balancer == nil {
+		return h.url, done, nil
+	}
+
+	e, err := h.balancer.pick(ctx, path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if e == nil {
+		return h.url, done, nil
+	}
+
+	return e.url, e.acquire(h.balancer.config.CircuitBreaker), nil
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+var errDNS = errors.New("dns failure")
+
+// newInstance starts a test server counting its requests and responding with the given status.
+func newInstance(t *testing.T, status *atomic.Int64) (server *httptest.Server, hits *atomic.Int64) {
+	t.Helper()
+
+	hits = new(atomic.Int64)
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == AlivePath {
+			w.WriteHeader(int(status.Load()))
+			return
+		}
+
+		hits.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, hits
+}
+
+func newStatus(code int) *atomic.Int64 {
+	s := new(atomic.Int64)
+	s.Store(int64(code))
+
+	return s
+}
+
+func TestLoadBalancer_RoundRobin(t *testing.T) {
+	s1, hits1 := newInstance(t, newStatus(http.StatusOK))
+	s2, hits2 := newInstance(t, newStatus(http.StatusOK))
+
+	svc := NewHTTPService("http://unused", logging.NewMockLogger(logging.INFO), nil,
+		&LoadBalancerConfig{Endpoints: []string{s1.URL, s2.URL + "/"}})
+
+	for range 4 {
+		resp, err := svc.Get(t.Context(), "test", nil)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, int64(2), hits1.Load())
+	assert.Equal(t, int64(2), hits2.Load())
+}
+
+func TestLoadBalancer_LeastOutstandingRequests(t *testing.T) {
+	lb := newLoadBalancer(&LoadBalancerConfig{Endpoints: []string{"http://a", "http://b", "http://c"},
+		Strategy: LeastOutstandingRequests}, &httpService{})
+
+	lb.endpoints[0].outstanding.Store(2)
+	lb.endpoints[1].outstanding.Store(1)
+	lb.endpoints[2].outstanding.Store(3)
+
+	for range 3 {
+		e, err := lb.pick(t.Context(), "test")
+		require.NoError(t, err)
+
+		assert.Equal(t, "http://b", e.url)
+	}
+}
+
+func TestLoadBalancer_ConsistentHash(t *testing.T) {
+	urls := []string{"http://a", "http://b", "http://c"}
+	lb := newLoadBalancer(&LoadBalancerConfig{Endpoints: urls, Strategy: ConsistentHash}, &httpService{})
+
+	picked := make(map[string]string)
+
+	for i := range 50 {
+		key := "users/" + strconv.Itoa(i)
+
+		e, err := lb.pick(t.Context(), key)
+		require.NoError(t, err)
+
+		again, _ := lb.pick(t.Context(), key)
+		assert.Equal(t, e, again, "the same key must be sent to the same endpoint")
+
+		picked[key] = e.url
+	}
+
+	assert.Len(t, uniqueValues(picked), len(urls), "keys must be spread across the endpoints")
+
+	// ejecting an endpoint moves only its own keys.
+	lb.endpoints[0].down = true
+
+	for key, url := range picked {
+		e, err := lb.pick(t.Context(), key)
+		require.NoError(t, err)
+
+		if url == "http://a" {
+			assert.NotEqual(t, url, e.url)
+		} else {
+			assert.Equal(t, url, e.url)
+		}
+	}
+}
+
+func uniqueValues(m map[string]string) map[string]bool {
+	values := make(map[string]bool)
+
+	for _, v := range m {
+		values[v] = true
+	}
+
+	return values
+}
+
+func TestLoadBalancer_CircuitBreaker(t *testing.T) {
+	failing := newStatus(http.StatusServiceUnavailable)
+	s1, hits1 := newInstance(t, failing)
+	s2, hits2 := newInstance(t, newStatus(http.StatusOK))
+
+	svc := NewHTTPService("http://unused", logging.NewMockLogger(logging.INFO), nil, &LoadBalancerConfig{
+		Endpoints:      []string{s1.URL, s2.URL},
+		CircuitBreaker: &CircuitBreakerConfig{Threshold: 1, Interval: time.Hour},
+	})
+
+	for range 8 {
+		resp, err := svc.Get(t.Context(), "test", nil)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, int64(2), hits1.Load(), "the failing endpoint must be ejected once the threshold is exceeded")
+	assert.Equal(t, int64(6), hits2.Load())
+}
+
+func TestLoadBalancer_HealthCheck(t *testing.T) {
+	status := newStatus(http.StatusServiceUnavailable)
+	s1, hits1 := newInstance(t, status)
+	s2, _ := newInstance(t, newStatus(http.StatusOK))
+
+	svc := NewHTTPService("http://unused", logging.NewMockLogger(logging.INFO), nil, &LoadBalancerConfig{
+		Endpoints: []string{s1.URL, s2.URL}, HealthConfig: &HealthConfig{}, HealthCheckInterval: time.Hour,
+	})
+
+	lb := svc.(*httpService).balancer
+	lb.checkHealth(t.Context())
+
+	for range 4 {
+		resp, err := svc.Get(t.Context(), "test", nil)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, int64(0), hits1.Load(), "the endpoint reported down must be ejected")
+
+	status.Store(http.StatusOK)
+	lb.checkHealth(t.Context())
+
+	resp, err := svc.Get(t.Context(), "test", nil)
+	require.NoError(t, err)
+
+	resp.Body.Close()
+
+	resp, err = svc.Get(t.Context(), "test", nil)
+	require.NoError(t, err)
+
+	resp.Body.Close()
+
+	assert.Equal(t, int64(1), hits1.Load(), "the endpoint reported up must be restored")
+}
+
+func TestLoadBalancer_NoAvailableEndpoint(t *testing.T) {
+	lb := newLoadBalancer(&LoadBalancerConfig{Endpoints: []string{"http://a"}}, &httpService{})
+	lb.endpoints[0].down = true
+
+	_, err := lb.pick(t.Context(), "test")
+
+	require.ErrorIs(t, err, ErrNoAvailableEndpoint)
+}
+
+type mockResolver struct {
+	hosts []string
+	srv   []*net.SRV
+	err   error
+}
+
+func (m *mockResolver) LookupHost(context.Context, string) ([]string, error) {
+	return m.hosts, m.err
+}
+
+func (m *mockResolver) LookupSRV(context.Context, string, string, string) (string, []*net.SRV, error) {
+	return "", m.srv, m.err
+}
+
+func TestDNSDiscovery_resolve(t *testing.T) {
+	tests := []struct {
+		desc      string
+		discovery DNSDiscovery
+		urls      []string
+		err       error
+	}{
+		{"A records", DNSDiscovery{Host: "orders", Resolver: &mockResolver{hosts: []string{"10.0.0.2", "10.0.0.1"}}},
+			[]string{"http://10.0.0.1:80", "http://10.0.0.2:80"}, nil},
+		{"AAAA records with port", DNSDiscovery{Host: "orders", Port: 8000, Scheme: "https",
+			Resolver: &mockResolver{hosts: []string{"::1"}}}, []string{"https://[::1]:8000"}, nil},
+		{"SRV records", DNSDiscovery{Host: "svc.cluster.local", Service: "http",
+			Resolver: &mockResolver{srv: []*net.SRV{{Target: "orders-1.svc.cluster.local.", Port: 8000}}}},
+			[]string{"http://orders-1.svc.cluster.local:8000"}, nil},
+		{"resolution failure", DNSDiscovery{Host: "orders", Resolver: &mockResolver{err: errDNS}}, nil, errDNS},
+	}
+
+	for i, tc := range tests {
+		urls, err := tc.discovery.resolve(t.Context())
+
+		assert.Equal(t, tc.urls, urls, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestLoadBalancer_DNSRefresh(t *testing.T) {
+	resolver := &mockResolver{hosts: []string{"10.0.0.1"}}
+
+	svc := NewHTTPService("http://orders", logging.NewMockLogger(logging.INFO), nil, &LoadBalancerConfig{
+		Endpoints: []string{"http://static"},
+		DNS:       &DNSDiscovery{Host: "orders", Port: 8000, Resolver: resolver, RefreshInterval: time.Hour},
+	})
+
+	lb := svc.(*httpService).balancer
+	assert.Equal(t, []string{"http://static", "http://10.0.0.1:8000"}, endpointURLs(lb))
+
+	lb.endpoints[1].failures = 3
+
+	resolver.hosts = []string{"10.0.0.1", "10.0.0.3"}
+	lb.resolve(t.Context())
+
+	assert.Equal(t, []string{"http://static", "http://10.0.0.1:8000", "http://10.0.0.3:8000"}, endpointURLs(lb))
+	assert.Equal(t, 3, lb.endpoints[1].failures, "the state of known endpoints must be kept")
+
+	resolver.err = errDNS
+	lb.resolve(t.Context())
+
+	assert.Len(t, lb.endpoints, 3, "the endpoints must be kept when the resolution fails")
+}
+
+func TestLoadBalancer_Close(t *testing.T) {
+	svc := NewHTTPService("http://unused", logging.NewMockLogger(logging.INFO), nil, &LoadBalancerConfig{
+		Endpoints: []string{"http://static"}, HealthCheckInterval: time.Hour,
+	})
+
+	lb := svc.(*httpService).balancer
+	calls := new(atomic.Int64)
+	stopped := make(chan struct{})
+
+	go func() {
+		lb.every(time.Millisecond, func(context.Context) { calls.Add(1) })
+		close(stopped)
+	}()
+
+	require.Eventually(t, func() bool { return calls.Load() > 0 }, time.Second, time.Millisecond)
+
+	require.NoError(t, Close(&retryProvider{HTTP: svc}))
+	require.NoError(t, Close(svc), "closing twice must not panic")
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the periodic task must stop when the service is closed")
+	}
+}
+
+func TestLoadBalancer_UnwrappableService(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		svc := (&LoadBalancerConfig{Endpoints: []string{"http://static"}}).AddOption(&mockHTTP{})
+
+		assert.IsType(t, &mockHTTP{}, svc)
+	})
+
+	assert.Contains(t, logs, "load balancer not attached")
+}
+
+func endpointURLs(lb *loadBalancer) []string {
+	urls := make([]string, 0, len(lb.endpoints))
+
+	for _, e := range lb.endpoints {
+		urls = append(urls, e.url)
+	}
+
+	return urls
+}
+
+func TestLoadBalancer_MetricsLabel(t *testing.T) {
+	s1, _ := newInstance(t, newStatus(http.StatusOK))
+
+	metrics := &labelRecorder{}
+	svc := NewHTTPService("http://unused", logging.NewMockLogger(logging.INFO), metrics,
+		&LoadBalancerConfig{Endpoints: []string{s1.URL}})
+
+	resp, err := svc.Get(t.Context(), "test", nil)
+	require.NoError(t, err)
+
+	resp.Body.Close()
+
+	assert.Equal(t, s1.URL, metrics.path)
+}
+
+type labelRecorder struct {
+	path string
+}
+
+func (l *labelRecorder) RecordHistogram(_ context.Context, _ string, _ float64, labels ...string) {
+	l.path = labels[1]
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"time"
+
+	"gofr.dev/pkg/gofr/logging"
 )
 
 type Logger interface {
@@ -25,6 +27,14 @@ func (l *Log) PrettyPrint(writer io.Writer) {
 		l.ResponseCode, l.ResponseTime, l.HTTPMethod, l.URI)
 }
 
+// Redact returns a copy of the log with the sensitive query parameters of the URI redacted.
+func (l *Log) Redact(r *logging.Redactor) any {
+	redacted := *l
+	redacted.URI = r.URL(l.URI)
+
+	return &redacted
+}
+
 type ErrorLog struct {
 	*Log
 	ErrorMessage string `json:"errorMessage"`
@@ -36,6 +46,17 @@ func (el *ErrorLog) PrettyPrint(writer io.Writer) {
 		el.ResponseCode, el.ResponseTime, el.HTTPMethod, el.URI)
 }
 
+// Redact returns a copy of the log with the URI and the error message redacted.
+func (el *ErrorLog) Redact(r *logging.Redactor) any {
+	redacted := &ErrorLog{ErrorMessage: r.String(el.ErrorMessage)}
+
+	if el.Log != nil {
+		redacted.Log, _ = el.Log.Redact(r).(*Log)
+	}
+
+	return redacted
+}
+
 func colorForStatusCode(status int) int {
 	const (
 		blue   = 34
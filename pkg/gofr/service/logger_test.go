@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
 )
 
 func TestLog_PrettyPrint(t *testing.T) {
@@ -63,3 +66,17 @@ func Test_ColorForStatusCode(t *testing.T) {
 		assert.Equal(t, tc.expOut, out)
 	}
 }
+
+func TestErrorLog_Redact(t *testing.T) {
+	l := &ErrorLog{
+		Log:          &Log{HTTPMethod: "GET", URI: "http://svc/api?api_key=k-1&page=2"},
+		ErrorMessage: "upstream rejected Bearer abc",
+	}
+
+	redacted, ok := l.Redact(logging.DefaultRedactor()).(*ErrorLog)
+	require.True(t, ok)
+
+	assert.Equal(t, "http://svc/api?api_key=[REDACTED]&page=2", redacted.URI)
+	assert.Equal(t, "upstream rejected [REDACTED]", redacted.ErrorMessage)
+	assert.Equal(t, "http://svc/api?api_key=k-1&page=2", l.URI, "original log must not be modified")
+}
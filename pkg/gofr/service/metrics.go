@@ -5,3 +5,7 @@ import "context"
 type Metrics interface {
 	RecordHistogram(ctx context.Context, name string, value float64, labels ...string)
 }
+
+type counter interface {
+	IncrementCounter(ctx context.Context, name string, labels ...string)
+}
@@ -11,6 +11,7 @@ package service
 
 import (
 	context "context"
+	io "io"
 	http "net/http"
 	reflect "reflect"
 
@@ -21,6 +22,7 @@ import (
 type MockHTTP struct {
 	ctrl     *gomock.Controller
 	recorder *MockHTTPMockRecorder
+	isgomock struct{}
 }
 
 // MockHTTPMockRecorder is the mock recorder for MockHTTP.
@@ -222,6 +224,7 @@ func (mr *MockHTTPMockRecorder) getHealthResponseForEndpoint(ctx, endpoint, time
 type MockhttpClient struct {
 	ctrl     *gomock.Controller
 	recorder *MockhttpClientMockRecorder
+	isgomock struct{}
 }
 
 // MockhttpClientMockRecorder is the mock recorder for MockhttpClient.
@@ -390,3 +393,72 @@ func (mr *MockhttpClientMockRecorder) PutWithHeaders(ctx, api, queryParams, body
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutWithHeaders", reflect.TypeOf((*MockhttpClient)(nil).PutWithHeaders), ctx, api, queryParams, body, headers)
 }
+
+// MockStreamHTTP is a mock of StreamHTTP interface.
+type MockStreamHTTP struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamHTTPMockRecorder
+	isgomock struct{}
+}
+
+// MockStreamHTTPMockRecorder is the mock recorder for MockStreamHTTP.
+type MockStreamHTTPMockRecorder struct {
+	mock *MockStreamHTTP
+}
+
+// NewMockStreamHTTP creates a new mock instance.
+func NewMockStreamHTTP(ctrl *gomock.Controller) *MockStreamHTTP {
+	mock := &MockStreamHTTP{ctrl: ctrl}
+	mock.recorder = &MockStreamHTTPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamHTTP) EXPECT() *MockStreamHTTPMockRecorder {
+	return m.recorder
+}
+
+// PatchStream mocks base method.
+func (m *MockStreamHTTP) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader, headers map[string]string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchStream", ctx, path, queryParams, body, headers)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchStream indicates an expected call of PatchStream.
+func (mr *MockStreamHTTPMockRecorder) PatchStream(ctx, path, queryParams, body, headers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchStream", reflect.TypeOf((*MockStreamHTTP)(nil).PatchStream), ctx, path, queryParams, body, headers)
+}
+
+// PostStream mocks base method.
+func (m *MockStreamHTTP) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader, headers map[string]string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostStream", ctx, path, queryParams, body, headers)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostStream indicates an expected call of PostStream.
+func (mr *MockStreamHTTPMockRecorder) PostStream(ctx, path, queryParams, body, headers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostStream", reflect.TypeOf((*MockStreamHTTP)(nil).PostStream), ctx, path, queryParams, body, headers)
+}
+
+// PutStream mocks base method.
+func (m *MockStreamHTTP) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader, headers map[string]string) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutStream", ctx, path, queryParams, body, headers)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutStream indicates an expected call of PutStream.
+func (mr *MockStreamHTTPMockRecorder) PutStream(ctx, path, queryParams, body, headers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutStream", reflect.TypeOf((*MockStreamHTTP)(nil).PutStream), ctx, path, queryParams, body, headers)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptrace"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -21,6 +23,8 @@ type httpService struct {
 	url string
 	Logger
 	Metrics
+
+	balancer *loadBalancer
 }
 
 type HTTP interface {
@@ -63,6 +67,21 @@ type httpClient interface {
 	DeleteWithHeaders(ctx context.Context, api string, body []byte, headers map[string]string) (*http.Response, error)
 }
 
+// StreamHTTP is implemented by the services sending requests whose body is streamed from a reader, such as the
+// services created by NewHTTPService, whatever their options. A service decorated by an option not implementing it
+// fails these requests with ErrStreamNotSupported.
+type StreamHTTP interface {
+	// PostStream performs an HTTP POST request streaming the body from the reader.
+	PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+		headers map[string]string) (*http.Response, error)
+	// PutStream performs an HTTP PUT request streaming the body from the reader.
+	PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+		headers map[string]string) (*http.Response, error)
+	// PatchStream performs an HTTP PATCH request streaming the body from the reader.
+	PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+		headers map[string]string) (*http.Response, error)
+}
+
 // NewHTTPService function creates a new instance of the httpService struct, which implements the HTTP interface.
 // It initializes the http.Client, url, Tracer, and Logger fields of the httpService struct with the provided values.
 func NewHTTPService(serviceAddress string, logger Logger, metrics Metrics, options ...Options) HTTP {
@@ -93,7 +112,7 @@ func (h *httpService) Get(ctx context.Context, path string, queryParams map[stri
 
 func (h *httpService) GetWithHeaders(ctx context.Context, path string, queryParams map[string]any,
 	headers map[string]string) (*http.Response, error) {
-	return h.createAndSendRequest(ctx, http.MethodGet, path, queryParams, nil, headers)
+	return h.createAndSendRequest(ctx, http.MethodGet, path, queryParams, http.NoBody, headers)
 }
 
 func (h *httpService) Post(ctx context.Context, path string, queryParams map[string]any,
@@ -103,7 +122,7 @@ func (h *httpService) Post(ctx context.Context, path string, queryParams map[str
 
 func (h *httpService) PostWithHeaders(ctx context.Context, path string, queryParams map[string]any,
 	body []byte, headers map[string]string) (*http.Response, error) {
-	return h.createAndSendRequest(ctx, http.MethodPost, path, queryParams, body, headers)
+	return h.createAndSendRequest(ctx, http.MethodPost, path, queryParams, bytes.NewBuffer(body), headers)
 }
 
 func (h *httpService) Patch(ctx context.Context, path string, queryParams map[string]any, body []byte) (*http.Response, error) {
@@ -112,7 +131,7 @@ func (h *httpService) Patch(ctx context.Context, path string, queryParams map[st
 
 func (h *httpService) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]any,
 	body []byte, headers map[string]string) (*http.Response, error) {
-	return h.createAndSendRequest(ctx, http.MethodPatch, path, queryParams, body, headers)
+	return h.createAndSendRequest(ctx, http.MethodPatch, path, queryParams, bytes.NewBuffer(body), headers)
 }
 
 func (h *httpService) Put(ctx context.Context, path string, queryParams map[string]any,
@@ -122,7 +141,7 @@ func (h *httpService) Put(ctx context.Context, path string, queryParams map[stri
 
 func (h *httpService) PutWithHeaders(ctx context.Context, path string, queryParams map[string]any,
 	body []byte, headers map[string]string) (*http.Response, error) {
-	return h.createAndSendRequest(ctx, http.MethodPut, path, queryParams, body, headers)
+	return h.createAndSendRequest(ctx, http.MethodPut, path, queryParams, bytes.NewBuffer(body), headers)
 }
 
 func (h *httpService) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
@@ -130,23 +149,55 @@ func (h *httpService) Delete(ctx context.Context, path string, body []byte) (*ht
 }
 
 func (h *httpService) DeleteWithHeaders(ctx context.Context, path string, body []byte, headers map[string]string) (*http.Response, error) {
-	return h.createAndSendRequest(ctx, http.MethodDelete, path, nil, body, headers)
+	return h.createAndSendRequest(ctx, http.MethodDelete, path, nil, bytes.NewBuffer(body), headers)
+}
+
+// PostStream streams the body of a POST request from the reader. The request is sent with chunked encoding,
+// unless the length of the body is known, and its Content-Type defaults to application/octet-stream.
+// The reader is not closed.
+func (h *httpService) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return h.createAndSendRequest(ctx, http.MethodPost, path, queryParams, streamBody(body), streamHeaders(headers))
+}
+
+// PutStream streams the body of a PUT request from the reader, as PostStream does.
+func (h *httpService) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return h.createAndSendRequest(ctx, http.MethodPut, path, queryParams, streamBody(body), streamHeaders(headers))
+}
+
+// PatchStream streams the body of a PATCH request from the reader, as PostStream does.
+func (h *httpService) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	return h.createAndSendRequest(ctx, http.MethodPatch, path, queryParams, streamBody(body), streamHeaders(headers))
 }
 
 func (h *httpService) createAndSendRequest(ctx context.Context, method string, path string,
-	queryParams map[string]any, body []byte, headers map[string]string) (*http.Response, error) {
-	uri := h.url + "/" + path
+	queryParams map[string]any, body io.Reader, headers map[string]string) (*http.Response, error) {
+	baseURL, done, err := h.baseURL(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := baseURL + "/" + path
 	uri = strings.TrimRight(uri, "/")
 
 	ctx, span := h.Tracer.Start(ctx, uri)
 	defer span.End()
 
+	if attempt := retryAttempt(ctx); attempt > 0 {
+		span.SetAttributes(attribute.Int("http.request.resend_count", attempt))
+		h.recordRetry(ctx, baseURL, method)
+	}
+
 	// Attach client-side trace handling for HTTP request.
 	clientTraceCtx := httptrace.WithClientTrace(ctx, otelhttptrace.NewClientTrace(ctx))
 
 	// Create the HTTP request with the tracing context.
-	req, err := http.NewRequestWithContext(clientTraceCtx, method, uri, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(clientTraceCtx, method, uri, body)
 	if err != nil {
+		done(nil, err)
+
 		return nil, err
 	}
 
@@ -183,18 +234,20 @@ func (h *httpService) createAndSendRequest(ctx context.Context, method string, p
 
 	respTime := time.Since(requestStart)
 
+	done(resp, err)
+
 	log.ResponseTime = respTime.Microseconds()
 
 	if err != nil {
 		log.ResponseCode = http.StatusInternalServerError
 		h.Log(&ErrorLog{Log: log, ErrorMessage: err.Error()})
 
-		h.updateMetrics(clientTraceCtx, method, respTime.Seconds(), http.StatusInternalServerError)
+		h.updateMetrics(clientTraceCtx, baseURL, method, respTime.Seconds(), http.StatusInternalServerError)
 
 		return resp, err
 	}
 
-	h.updateMetrics(clientTraceCtx, method, respTime.Seconds(), resp.StatusCode)
+	h.updateMetrics(clientTraceCtx, baseURL, method, respTime.Seconds(), resp.StatusCode)
 	log.ResponseCode = resp.StatusCode
 
 	h.Log(log)
@@ -202,13 +255,20 @@ func (h *httpService) createAndSendRequest(ctx context.Context, method string, p
 	return resp, nil
 }
 
-func (h *httpService) updateMetrics(ctx context.Context, method string, timeTaken float64, statusCode int) {
+func (h *httpService) updateMetrics(ctx context.Context, baseURL, method string, timeTaken float64, statusCode int) {
 	if h.Metrics != nil {
-		h.RecordHistogram(ctx, "app_http_service_response", timeTaken, "path", h.url, "method", method,
+		h.RecordHistogram(ctx, "app_http_service_response", timeTaken, "path", baseURL, "method", method,
 			"status", fmt.Sprintf("%v", statusCode))
 	}
 }
 
+// recordRetry counts a retried request when the metrics of the service support counters.
+func (h *httpService) recordRetry(ctx context.Context, baseURL, method string) {
+	if c, ok := h.Metrics.(counter); ok {
+		c.IncrementCounter(ctx, "app_http_service_retry_count", "path", baseURL, "method", method)
+	}
+}
+
 func encodeQueryParameters(req *http.Request, queryParams map[string]any) {
 	q := req.URL.Query()
 
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
@@ -92,7 +93,7 @@ func TestHTTPService_createAndSendRequest(t *testing.T) {
 			"method", http.MethodPost, "status", fmt.Sprintf("%v", http.StatusOK)).Times(1)
 
 		resp, err := service.createAndSendRequest(ctx,
-			http.MethodPost, "test-path", tc.queryParams, tc.body, tc.headers)
+			http.MethodPost, "test-path", tc.queryParams, bytes.NewBuffer(tc.body), tc.headers)
 		if err != nil {
 			if resp != nil {
 				resp.Body.Close()
@@ -375,7 +376,7 @@ func TestHTTPService_createAndSendRequestCreateRequestFailure(t *testing.T) {
 	// when params value is of type []string then last value is sent in request
 	resp, err := service.createAndSendRequest(ctx,
 		"!@#$", "test-path", map[string]any{"key": "value", "name": []string{"gofr", "test"}},
-		[]byte("{Test Body}"), map[string]string{"header1": "value1"})
+		bytes.NewBufferString("{Test Body}"), map[string]string{"header1": "value1"})
 
 	validateResponse(t, resp, err, true)
 }
@@ -399,7 +400,7 @@ func TestHTTPService_createAndSendRequestServerError(t *testing.T) {
 	// when params value is of type []string then last value is sent in request
 	resp, err := service.createAndSendRequest(ctx,
 		http.MethodPost, "test-path", map[string]any{"key": "value", "name": []string{"gofr", "test"}},
-		[]byte("{Test Body}"), map[string]string{"header1": "value1"})
+		bytes.NewBufferString("{Test Body}"), map[string]string{"header1": "value1"})
 
 	validateResponse(t, resp, err, true)
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -216,3 +217,42 @@ func (rl *rateLimiter) DeleteWithHeaders(ctx context.Context, path string, body
 
 	return rl.HTTP.DeleteWithHeaders(ctx, path, body, headers)
 }
+
+// PostStream performs rate-limited HTTP POST request streaming the body.
+func (rl *rateLimiter) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	fullURL := rl.buildFullURL(path)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, http.NoBody)
+
+	if err := rl.checkRateLimit(req); err != nil {
+		return nil, err
+	}
+
+	return streamer(rl.HTTP).PostStream(ctx, path, queryParams, body, headers)
+}
+
+// PutStream performs rate-limited HTTP PUT request streaming the body.
+func (rl *rateLimiter) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	fullURL := rl.buildFullURL(path)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, fullURL, http.NoBody)
+
+	if err := rl.checkRateLimit(req); err != nil {
+		return nil, err
+	}
+
+	return streamer(rl.HTTP).PutStream(ctx, path, queryParams, body, headers)
+}
+
+// PatchStream performs rate-limited HTTP PATCH request streaming the body.
+func (rl *rateLimiter) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	fullURL := rl.buildFullURL(path)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPatch, fullURL, http.NoBody)
+
+	if err := rl.checkRateLimit(req); err != nil {
+		return nil, err
+	}
+
+	return streamer(rl.HTTP).PatchStream(ctx, path, queryParams, body, headers)
+}
@@ -2,43 +2,138 @@ package service
 
 import (
 	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	defaultInitialBackoff    = 100 * time.Millisecond
+	defaultMaxBackoff        = 5 * time.Second
+	defaultBackoffMultiplier = 2
+
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// maxDrainBytes limits how much of the body of a discarded response is read to reuse its connection.
+	maxDrainBytes = 4096
+)
+
+// RetryConfig retries the requests to a downstream HTTP service failing with a transient error. The delay between
+// attempts grows exponentially from InitialBackoff up to MaxBackoff and is randomized so that clients failing at the
+// same time do not retry in lockstep. A Retry-After header sent with a retryable response replaces the computed delay.
+//
+// Only idempotent requests are retried by default: POST and PATCH requests are retried when they carry an
+// Idempotency-Key header or when RetryNonIdempotent is set.
 type RetryConfig struct {
+	// MaxRetries is the maximum number of attempts made for a request, the first one included, so that a
+	// request is retried at most MaxRetries-1 times. Values below 1 make a single attempt.
 	MaxRetries int
+	// InitialBackoff is the delay before the first retry, 100ms by default.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, 5s by default. Requests whose Retry-After
+	// exceeds it are not retried.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after every retry, 2 by default.
+	Multiplier float64
+	// DisableJitter uses the computed delays as they are, instead of picking them randomly
+	// between half and the whole of the computed value.
+	DisableJitter bool
+	// Timeout is the budget of a call across all of its attempts. Retries which cannot start within
+	// the budget, or before the deadline of the request context, are not made.
+	Timeout time.Duration
+	// RetryableStatusCodes are the response status codes retried, by default 429, 500, 502, 503 and 504.
+	RetryableStatusCodes []int
+	// IsRetryableError reports whether a request failing with err is retried. By default, timeouts,
+	// refused and reset connections and connections closed before a response are retried.
+	IsRetryableError func(err error) bool
+	// RetryNonIdempotent enables the retries of all POST and PATCH requests.
+	RetryNonIdempotent bool
 }
 
 func (r *RetryConfig) AddOption(h HTTP) HTTP {
-	return &retryProvider{
-		maxRetries: r.MaxRetries,
-		HTTP:       h,
+	rp := &retryProvider{
+		maxAttempts:        max(r.MaxRetries, 1),
+		initialBackoff:     r.InitialBackoff,
+		maxBackoff:         r.MaxBackoff,
+		multiplier:         r.Multiplier,
+		jitter:             !r.DisableJitter,
+		timeout:            r.Timeout,
+		statusCodes:        make(map[int]bool),
+		isRetryableError:   r.IsRetryableError,
+		retryNonIdempotent: r.RetryNonIdempotent,
+		HTTP:               h,
+	}
+
+	if rp.initialBackoff <= 0 {
+		rp.initialBackoff = defaultInitialBackoff
+	}
+
+	if rp.maxBackoff <= 0 {
+		rp.maxBackoff = defaultMaxBackoff
+	}
+
+	if rp.multiplier < 1 {
+		rp.multiplier = defaultBackoffMultiplier
+	}
+
+	if rp.isRetryableError == nil {
+		rp.isRetryableError = isTransientError
+	}
+
+	statusCodes := r.RetryableStatusCodes
+	if statusCodes == nil {
+		statusCodes = []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	}
+
+	for _, code := range statusCodes {
+		rp.statusCodes[code] = true
 	}
+
+	return rp
 }
 
 type retryProvider struct {
-	maxRetries int
+	maxAttempts        int
+	initialBackoff     time.Duration
+	maxBackoff         time.Duration
+	multiplier         float64
+	jitter             bool
+	timeout            time.Duration
+	statusCodes        map[int]bool
+	isRetryableError   func(err error) bool
+	retryNonIdempotent bool
 
 	HTTP
 }
 
 func (rp *retryProvider) Get(ctx context.Context, path string, queryParams map[string]any) (*http.Response,
 	error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodGet, nil, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.Get(ctx, path, queryParams)
 	})
 }
 
 func (rp *retryProvider) GetWithHeaders(ctx context.Context, path string, queryParams map[string]any,
 	headers map[string]string) (*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodGet, headers, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
 	})
 }
 
 func (rp *retryProvider) Post(ctx context.Context, path string, queryParams map[string]any,
 	body []byte) (*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodPost, nil, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.Post(ctx, path, queryParams, body)
 	})
 }
@@ -46,64 +141,276 @@ func (rp *retryProvider) Post(ctx context.Context, path string, queryParams map[
 func (rp *retryProvider) PostWithHeaders(ctx context.Context, path string, queryParams map[string]any,
 	body []byte,
 	headers map[string]string) (*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodPost, headers, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.PostWithHeaders(ctx, path, queryParams, body, headers)
 	})
 }
 
 func (rp *retryProvider) Put(ctx context.Context, api string, queryParams map[string]any, body []byte) (
 	*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodPut, nil, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.Put(ctx, api, queryParams, body)
 	})
 }
 
 func (rp *retryProvider) PutWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
 	headers map[string]string) (*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodPut, headers, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.PutWithHeaders(ctx, path, queryParams, body, headers)
 	})
 }
 
 func (rp *retryProvider) Patch(ctx context.Context, path string, queryParams map[string]any, body []byte) (
 	*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodPatch, nil, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.Patch(ctx, path, queryParams, body)
 	})
 }
 
 func (rp *retryProvider) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]any, body []byte,
 	headers map[string]string) (*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodPatch, headers, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.PatchWithHeaders(ctx, path, queryParams, body, headers)
 	})
 }
 
 func (rp *retryProvider) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodDelete, nil, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.Delete(ctx, path, body)
 	})
 }
 
 func (rp *retryProvider) DeleteWithHeaders(ctx context.Context, path string, body []byte, headers map[string]string) (
 	*http.Response, error) {
-	return rp.doWithRetry(func() (*http.Response, error) {
+	return rp.doWithRetry(ctx, http.MethodDelete, headers, noRewind, func(ctx context.Context) (*http.Response, error) {
 		return rp.HTTP.DeleteWithHeaders(ctx, path, body, headers)
 	})
 }
 
-func (rp *retryProvider) doWithRetry(reqFunc func() (*http.Response, error)) (*http.Response, error) {
-	var (
-		resp *http.Response
-		err  error
-	)
+func (rp *retryProvider) PostStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	rewind, _ := rewinder(body)
+
+	return rp.doWithRetry(ctx, http.MethodPost, headers, rewind, func(ctx context.Context) (*http.Response, error) {
+		return streamer(rp.HTTP).PostStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (rp *retryProvider) PutStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	rewind, _ := rewinder(body)
+
+	return rp.doWithRetry(ctx, http.MethodPut, headers, rewind, func(ctx context.Context) (*http.Response, error) {
+		return streamer(rp.HTTP).PutStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+func (rp *retryProvider) PatchStream(ctx context.Context, path string, queryParams map[string]any, body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+	rewind, _ := rewinder(body)
+
+	return rp.doWithRetry(ctx, http.MethodPatch, headers, rewind, func(ctx context.Context) (*http.Response, error) {
+		return streamer(rp.HTTP).PatchStream(ctx, path, queryParams, body, headers)
+	})
+}
+
+// doWithRetry makes the attempts of a request, calling rewind to restart its body before every retry.
+// Requests whose body cannot be replayed, having a nil rewind, are not retried.
+func (rp *retryProvider) doWithRetry(ctx context.Context, method string, headers map[string]string, rewind func() error,
+	reqFunc func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	var cancel context.CancelFunc
+
+	if rp.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, rp.timeout)
+	}
+
+	retryable := rewind != nil &&
+		(rp.retryNonIdempotent || isIdempotent(method) || hasHeader(headers, idempotencyKeyHeader))
+
+	for attempt := 0; ; attempt++ {
+		resp, err := reqFunc(withRetryAttempt(ctx, attempt))
+
+		reason := rp.retryReason(resp, err)
+		if reason == "" || !retryable || attempt+1 >= rp.maxAttempts {
+			return cancelOnClose(resp, cancel), err
+		}
+
+		delay, ok := rp.delay(attempt, resp)
+		if !ok || !wait(ctx, delay) || rewind() != nil {
+			return cancelOnClose(resp, cancel), err
+		}
+
+		discard(resp)
 
-	for i := 0; i < rp.maxRetries; i++ {
-		resp, err = reqFunc()
-		if err == nil && resp.StatusCode != http.StatusInternalServerError {
-			return resp, nil
+		trace.SpanFromContext(ctx).AddEvent("http.client.retry", trace.WithAttributes(
+			attribute.Int("http.retry.attempt", attempt+1),
+			attribute.String("http.retry.reason", reason),
+			attribute.String("http.retry.delay", delay.String()),
+		))
+	}
+}
+
+// retryReason describes why the outcome of an attempt is retried, returning an empty string when it is not.
+func (rp *retryProvider) retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		if rp.isRetryableError(err) {
+			return err.Error()
+		}
+
+		return ""
+	}
+
+	if resp != nil && rp.statusCodes[resp.StatusCode] {
+		return "status " + strconv.Itoa(resp.StatusCode)
+	}
+
+	return ""
+}
+
+// delay returns the wait before the retry following the given attempt, reporting false when the response
+// asks for a longer wait than allowed by the maximum backoff.
+func (rp *retryProvider) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter, retryAfter <= rp.maxBackoff
 		}
 	}
 
-	return resp, err
+	backoff := float64(rp.initialBackoff) * math.Pow(rp.multiplier, float64(attempt))
+	delay := time.Duration(min(backoff, float64(rp.maxBackoff)))
+
+	if rp.jitter && delay > 1 {
+		delay = delay/2 + rand.N(delay/2) //nolint:gosec // jitter does not need a cryptographically secure source.
+	}
+
+	return delay, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(time.Until(date), 0), true
+}
+
+// wait blocks for the given delay, reporting false when the context is done, or would be, before it elapses.
+func wait(ctx context.Context, delay time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// isTransientError reports whether a request failed for a reason another attempt may not run into,
+// excluding the cancellation and expiry of its context.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// noRewind is the rewind function of requests whose body is held in memory.
+func noRewind() error { return nil }
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func hasHeader(headers map[string]string, key string) bool {
+	for k, v := range headers {
+		if strings.EqualFold(k, key) && v != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// discard drains and closes the body of a response which is not returned, so that its connection can be reused.
+func discard(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+	_ = resp.Body.Close()
+}
+
+// cancelOnClose releases the context of the attempts of a call once the body of its response is closed,
+// as the body is read after the call returns.
+func cancelOnClose(resp *http.Response, cancel context.CancelFunc) *http.Response {
+	if cancel == nil {
+		return resp
+	}
+
+	if resp == nil || resp.Body == nil {
+		cancel()
+
+		return resp
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
+
+type retryAttemptKey struct{}
+
+func withRetryAttempt(ctx context.Context, attempt int) context.Context {
+	if attempt == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, retryAttemptKey{}, attempt)
+}
+
+// retryAttempt returns the number of the retry a request is made for, zero for the first attempt.
+func retryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+
+	return attempt
 }
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -213,3 +217,233 @@ func TestRetryProvider_DeleteWithHeaders(t *testing.T) {
 
 	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 }
+
+type retryMetrics struct {
+	retries int
+}
+
+func (*retryMetrics) RecordHistogram(context.Context, string, float64, ...string) {}
+
+func (m *retryMetrics) IncrementCounter(_ context.Context, name string, _ ...string) {
+	if name == "app_http_service_retry_count" {
+		m.retries++
+	}
+}
+
+// newFailingServer responds with the given statuses in order, and with 200 once they are exhausted.
+func newFailingServer(t *testing.T, headers http.Header, statuses ...int) (server *httptest.Server, attempts *int) {
+	t.Helper()
+
+	attempts = new(int)
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		*attempts++
+
+		if *attempts > len(statuses) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		for k, v := range headers {
+			w.Header()[k] = v
+		}
+
+		w.WriteHeader(statuses[*attempts-1])
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, attempts
+}
+
+func TestRetryProvider_Retries(t *testing.T) {
+	config := RetryConfig{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	tests := []struct {
+		desc     string
+		statuses []int
+		call     func(h HTTP) (*http.Response, error)
+		status   int
+		attempts int
+	}{
+		{"retryable status of GET", []int{http.StatusServiceUnavailable}, func(h HTTP) (*http.Response, error) {
+			return h.Get(t.Context(), "test", nil)
+		}, http.StatusOK, 2},
+		{"retries exhausted", []int{http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusTooManyRequests},
+			func(h HTTP) (*http.Response, error) {
+				return h.Delete(t.Context(), "test", nil)
+			}, http.StatusTooManyRequests, 3},
+		{"non retryable status", []int{http.StatusBadRequest}, func(h HTTP) (*http.Response, error) {
+			return h.Put(t.Context(), "test", nil, nil)
+		}, http.StatusBadRequest, 1},
+		{"POST is not retried", []int{http.StatusServiceUnavailable}, func(h HTTP) (*http.Response, error) {
+			return h.Post(t.Context(), "test", nil, nil)
+		}, http.StatusServiceUnavailable, 1},
+		{"POST with idempotency key", []int{http.StatusServiceUnavailable}, func(h HTTP) (*http.Response, error) {
+			return h.PostWithHeaders(t.Context(), "test", nil, nil, map[string]string{"idempotency-key": "abc"})
+		}, http.StatusOK, 2},
+	}
+
+	for i, tc := range tests {
+		server, attempts := newFailingServer(t, nil, tc.statuses...)
+		metrics := &retryMetrics{}
+		svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), metrics, &config)
+
+		resp, err := tc.call(svc)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		resp.Body.Close()
+
+		assert.Equal(t, tc.status, resp.StatusCode, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.attempts, *attempts, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.attempts-1, metrics.retries, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestRetryProvider_RetryNonIdempotent(t *testing.T) {
+	server, attempts := newFailingServer(t, nil, http.StatusInternalServerError)
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil,
+		&RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, RetryNonIdempotent: true})
+
+	resp, err := svc.Patch(t.Context(), "test", nil, []byte("body"))
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, *attempts)
+}
+
+func TestRetryProvider_RetryAfter(t *testing.T) {
+	server, attempts := newFailingServer(t, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests)
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil,
+		&RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond})
+
+	start := time.Now()
+
+	resp, err := svc.Get(t.Context(), "test", nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, *attempts)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetryProvider_RetryAfterExceedsMaxBackoff(t *testing.T) {
+	server, attempts := newFailingServer(t, http.Header{"Retry-After": {"120"}}, http.StatusServiceUnavailable)
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil, &RetryConfig{MaxRetries: 3})
+
+	resp, err := svc.Get(t.Context(), "test", nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, *attempts)
+}
+
+func TestRetryProvider_Timeout(t *testing.T) {
+	server, attempts := newFailingServer(t, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil, &RetryConfig{
+		MaxRetries: 5, InitialBackoff: 10 * time.Second, MaxBackoff: time.Minute, DisableJitter: true, Timeout: 5 * time.Second,
+	})
+
+	resp, err := svc.Get(t.Context(), "test", nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, *attempts, "retries not fitting in the budget must not be made")
+}
+
+func TestRetryProvider_ConnectionError(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+
+		if attempts == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+
+			conn.Close()
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.INFO), nil,
+		&RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond})
+
+	resp, err := svc.Get(t.Context(), "test", nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryProvider_Delay(t *testing.T) {
+	rp := (&RetryConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, DisableJitter: true}).
+		AddOption(&mockHTTP{}).(*retryProvider)
+
+	tests := []struct {
+		attempt int
+		resp    *http.Response
+		delay   time.Duration
+		ok      bool
+	}{
+		{0, nil, 100 * time.Millisecond, true},
+		{2, nil, 400 * time.Millisecond, true},
+		{10, nil, time.Second, true},
+		{0, &http.Response{Header: http.Header{"Retry-After": {"0"}}}, 0, true},
+		{0, &http.Response{Header: http.Header{"Retry-After": {"2"}}}, 2 * time.Second, false},
+		{0, &http.Response{Header: http.Header{"Retry-After": {"invalid"}}}, 100 * time.Millisecond, true},
+	}
+
+	for i, tc := range tests {
+		delay, ok := rp.delay(tc.attempt, tc.resp)
+
+		assert.Equal(t, tc.delay, delay, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.ok, ok, "TEST[%d], Failed.\n", i)
+	}
+
+	rp.jitter = true
+
+	for attempt := range 5 {
+		delay, _ := rp.delay(attempt, nil)
+		backoff := min(100*time.Millisecond<<attempt, time.Second)
+
+		assert.GreaterOrEqual(t, delay, backoff/2)
+		assert.LessOrEqual(t, delay, backoff)
+	}
+}
+
+func Test_isTransientError(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{syscall.ECONNRESET, true},
+		{&url.Error{Op: "Get", Err: syscall.ECONNREFUSED}, true},
+		{io.ErrUnexpectedEOF, true},
+		{context.Canceled, false},
+		{&url.Error{Op: "Get", Err: context.DeadlineExceeded}, false},
+		{ErrCircuitOpen, false},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.transient, isTransientError(tc.err), "TEST[%d], Failed.\n%v", i, tc.err)
+	}
+}
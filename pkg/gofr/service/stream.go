@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const defaultStreamContentType = "application/octet-stream"
+
+var (
+	// ErrStreamNotSupported is returned for the requests with a streamed body sent through a service which does
+	// not implement StreamHTTP.
+	ErrStreamNotSupported = errors.New("service does not support streamed request bodies")
+
+	errMultipartNotRewindable = errors.New("multipart body holds a file which cannot be read again")
+)
+
+// streamer returns the StreamHTTP implementation of the service wrapped by an option, or one failing with
+// ErrStreamNotSupported when the service does not implement it.
+func streamer(h HTTP) StreamHTTP {
+	if s, ok := h.(StreamHTTP); ok {
+		return s
+	}
+
+	return unsupportedStream{}
+}
+
+// unsupportedStream fails the requests with a streamed body of the services not implementing StreamHTTP.
+type unsupportedStream struct{}
+
+func (unsupportedStream) PostStream(context.Context, string, map[string]any, io.Reader,
+	map[string]string) (*http.Response, error) {
+	return nil, ErrStreamNotSupported
+}
+
+func (unsupportedStream) PutStream(context.Context, string, map[string]any, io.Reader,
+	map[string]string) (*http.Response, error) {
+	return nil, ErrStreamNotSupported
+}
+
+func (unsupportedStream) PatchStream(context.Context, string, map[string]any, io.Reader,
+	map[string]string) (*http.Response, error) {
+	return nil, ErrStreamNotSupported
+}
+
+// Rewinder is implemented by streamed request bodies which can be read again from their start, allowing
+// the retry option to replay them. Bodies implementing io.Seeker are replayed as well.
+type Rewinder interface {
+	Rewind() error
+}
+
+// rewinder returns the function restarting a request body from its current position, reporting false
+// when the body cannot be read twice.
+func rewinder(body io.Reader) (func() error, bool) {
+	switch b := body.(type) {
+	case nil:
+		return func() error { return nil }, true
+	case Rewinder:
+		return b.Rewind, true
+	case io.Seeker:
+		offset, err := b.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, false
+		}
+
+		return func() error {
+			_, err := b.Seek(offset, io.SeekStart)
+
+			return err
+		}, true
+	}
+
+	return nil, false
+}
+
+// streamBody prepares a reader for being sent as a request body. Closers are hidden from the transport,
+// which would close them after the request, as the body belongs to the caller and may be replayed.
+func streamBody(body io.Reader) io.Reader {
+	switch body.(type) {
+	case nil:
+		return http.NoBody
+	case *multipartReader, multipartStream:
+		// closing them only stops the encoding of the parts in progress.
+		return body
+	case io.Closer:
+		return io.NopCloser(body)
+	}
+
+	return body
+}
+
+// streamHeaders returns the headers of a streamed request, defaulting its Content-Type to application/octet-stream.
+func streamHeaders(headers map[string]string) map[string]string {
+	for k := range headers {
+		if strings.EqualFold(k, "Content-Type") {
+			return headers
+		}
+	}
+
+	withContentType := make(map[string]string, len(headers)+1)
+
+	for k, v := range headers {
+		withContentType[k] = v
+	}
+
+	withContentType["Content-Type"] = defaultStreamContentType
+
+	return withContentType
+}
+
+// Multipart builds a multipart/form-data request body. The parts are streamed to the service as the request
+// is sent, so files are never loaded fully in memory:
+//
+//	form := service.NewMultipart().AddField("name", "report").AddFile("file", "report.pdf", file)
+//	resp, err := svc.PostStream(ctx, "upload", nil, form.Reader(), map[string]string{"Content-Type": form.ContentType()})
+type Multipart struct {
+	boundary string
+	parts    []multipartPart
+}
+
+type multipartPart struct {
+	field    string
+	value    string
+	filename string
+	content  io.Reader
+	offset   int64
+	seekable bool
+}
+
+// NewMultipart creates an empty multipart/form-data body.
+func NewMultipart() *Multipart {
+	return &Multipart{boundary: multipart.NewWriter(io.Discard).Boundary()}
+}
+
+// AddField adds a form field holding value.
+func (m *Multipart) AddField(name, value string) *Multipart {
+	m.parts = append(m.parts, multipartPart{field: name, value: value})
+
+	return m
+}
+
+// AddFile adds a file part read from content, whose Content-Type is derived from the extension of filename.
+// The content is not closed once sent; the body can be replayed on retries only if all file contents implement io.Seeker.
+func (m *Multipart) AddFile(field, filename string, content io.Reader) *Multipart {
+	part := multipartPart{field: field, filename: filename, content: content}
+
+	if s, ok := content.(io.Seeker); ok {
+		offset, err := s.Seek(0, io.SeekCurrent)
+		part.offset, part.seekable = offset, err == nil
+	}
+
+	m.parts = append(m.parts, part)
+
+	return m
+}
+
+// ContentType returns the Content-Type header of the body, holding its boundary.
+func (m *Multipart) ContentType() string {
+	return "multipart/form-data; boundary=" + m.boundary
+}
+
+// Reader returns a reader streaming the encoded body. The reader implements Rewinder when all file
+// contents can be read again.
+func (m *Multipart) Reader() io.Reader {
+	r := &multipartReader{form: m}
+
+	for _, part := range m.parts {
+		if part.content != nil && !part.seekable {
+			return multipartStream{r}
+		}
+	}
+
+	return r
+}
+
+func (m *Multipart) write(w io.Writer) error {
+	mw := multipart.NewWriter(w)
+
+	if err := mw.SetBoundary(m.boundary); err != nil {
+		return err
+	}
+
+	for _, part := range m.parts {
+		if part.content == nil {
+			if err := mw.WriteField(part.field, part.value); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		pw, err := mw.CreatePart(fileHeader(part.field, part.filename))
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.Copy(pw, part.content); err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+func fileHeader(field, filename string) textproto.MIMEHeader {
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = defaultStreamContentType
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(field), quoteEscaper.Replace(filename)))
+	h.Set("Content-Type", contentType)
+
+	return h
+}
+
+//nolint:gochecknoglobals // escapes the names of multipart parts, as mime/multipart does.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// multipartStream is the reader of a Multipart holding files which cannot be read again.
+type multipartStream struct {
+	io.ReadCloser
+}
+
+// multipartReader encodes the parts of a Multipart in a goroutine writing to a pipe, started on the first read.
+// Closing the reader stops the goroutine, which is done by the transport once the request is sent or has failed.
+type multipartReader struct {
+	form *Multipart
+
+	mu   sync.Mutex
+	pipe *io.PipeReader
+	done chan struct{}
+}
+
+func (r *multipartReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+
+	if r.pipe == nil {
+		pr, pw := io.Pipe()
+		r.pipe, r.done = pr, make(chan struct{})
+
+		go func(done chan struct{}) {
+			defer close(done)
+
+			pw.CloseWithError(r.form.write(pw))
+		}(r.done)
+	}
+
+	pipe := r.pipe
+
+	r.mu.Unlock()
+
+	return pipe.Read(p)
+}
+
+func (r *multipartReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pipe != nil {
+		return r.pipe.Close()
+	}
+
+	return nil
+}
+
+// Rewind stops the encoding in progress and moves the file contents back to their initial offsets.
+func (r *multipartReader) Rewind() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pipe != nil {
+		r.pipe.Close()
+		<-r.done
+
+		r.pipe = nil
+	}
+
+	for _, part := range r.form.parts {
+		if part.content == nil {
+			continue
+		}
+
+		s, ok := part.content.(io.Seeker)
+		if !ok {
+			return errMultipartNotRewindable
+		}
+
+		if _, err := s.Seek(part.offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
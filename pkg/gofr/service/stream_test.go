@@ -0,0 +1,208 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// nonSeekableReader hides the io.Seeker implementation of the wrapped reader.
+type nonSeekableReader struct {
+	io.Reader
+}
+
+func newStreamService(t *testing.T, url string, options ...Options) StreamHTTP {
+	t.Helper()
+
+	svc, ok := NewHTTPService(url, logging.NewMockLogger(logging.INFO), nil, options...).(StreamHTTP)
+	require.True(t, ok, "services created by NewHTTPService stream request bodies")
+
+	return svc
+}
+
+func TestHTTPService_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(r.Method + " " + string(body)))
+	}))
+	defer server.Close()
+
+	svc := newStreamService(t, server.URL)
+
+	tests := []struct {
+		desc        string
+		call        func() (*http.Response, error)
+		body        string
+		contentType string
+	}{
+		{"POST with default content type", func() (*http.Response, error) {
+			return svc.PostStream(t.Context(), "upload", nil, nonSeekableReader{strings.NewReader("data")}, nil)
+		}, "POST data", "application/octet-stream"},
+		{"PUT with content type", func() (*http.Response, error) {
+			return svc.PutStream(t.Context(), "upload", nil, strings.NewReader("data"),
+				map[string]string{"content-type": "text/plain"})
+		}, "PUT data", "text/plain"},
+		{"PATCH without body", func() (*http.Response, error) {
+			return svc.PatchStream(t.Context(), "upload", nil, nil, nil)
+		}, "PATCH ", "application/octet-stream"},
+	}
+
+	for i, tc := range tests {
+		resp, err := tc.call()
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		assert.Equal(t, tc.body, string(body), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.contentType, resp.Header.Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"chunked"}, r.TransferEncoding)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+
+		assert.Equal(t, "report", r.FormValue("name"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+
+		assert.Equal(t, `q"1.txt`, header.Filename)
+		assert.Equal(t, "text/plain; charset=utf-8", header.Header.Get("Content-Type"))
+		assert.Equal(t, "file content", string(content))
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	svc := newStreamService(t, server.URL)
+	form := NewMultipart().AddField("name", "report").AddFile("file", `q"1.txt`, strings.NewReader("file content"))
+
+	resp, err := svc.PostStream(t.Context(), "upload", nil, form.Reader(),
+		map[string]string{"Content-Type": form.ContentType()})
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestRetryProvider_Stream(t *testing.T) {
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	svc := newStreamService(t, server.URL, &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond})
+
+	form := NewMultipart().AddFile("file", "data.bin", strings.NewReader("file content"))
+
+	tests := []struct {
+		desc     string
+		body     func() io.Reader
+		status   int
+		attempts int
+	}{
+		{"seekable body is replayed", func() io.Reader {
+			return strings.NewReader("payload")
+		}, http.StatusOK, 2},
+		{"rewindable multipart body is replayed", form.Reader, http.StatusOK, 2},
+		{"non seekable body is sent once", func() io.Reader {
+			return nonSeekableReader{strings.NewReader("payload")}
+		}, http.StatusServiceUnavailable, 1},
+		{"multipart body with non seekable file is sent once", func() io.Reader {
+			return NewMultipart().AddFile("file", "data.bin", nonSeekableReader{strings.NewReader("file content")}).Reader()
+		}, http.StatusServiceUnavailable, 1},
+	}
+
+	for i, tc := range tests {
+		bodies = nil
+
+		resp, err := svc.PutStream(t.Context(), "upload", nil, tc.body(), nil)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		resp.Body.Close()
+
+		assert.Equal(t, tc.status, resp.StatusCode, "TEST[%d], Failed.\n%s", i, tc.desc)
+		require.Len(t, bodies, tc.attempts, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		for _, body := range bodies {
+			assert.Equal(t, bodies[0], body, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestStream_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "value", r.Header.Get("X-Default"))
+		assert.Equal(t, "Basic dXNlcjpwYXNzd29yZA==", r.Header.Get(AuthHeader))
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	basicAuth, err := NewBasicAuthConfig("user", "cGFzc3dvcmQ=")
+	require.NoError(t, err)
+
+	svc := newStreamService(t, server.URL, basicAuth,
+		&DefaultHeaders{Headers: map[string]string{"X-Default": "value"}},
+		&CircuitBreakerConfig{Threshold: 1, Interval: time.Hour},
+		&RateLimiterConfig{Requests: 10, Window: time.Second, Burst: 10})
+
+	for _, call := range []func() (*http.Response, error){
+		func() (*http.Response, error) {
+			return svc.PostStream(t.Context(), "upload", nil, strings.NewReader("a"), nil)
+		},
+		func() (*http.Response, error) {
+			return svc.PutStream(t.Context(), "upload", nil, strings.NewReader("a"), nil)
+		},
+		func() (*http.Response, error) {
+			return svc.PatchStream(t.Context(), "upload", nil, strings.NewReader("a"), nil)
+		},
+	} {
+		resp, err := call()
+		require.NoError(t, err)
+
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestStream_NotSupported(t *testing.T) {
+	for _, option := range []Options{&RetryConfig{MaxRetries: 1}, &DefaultHeaders{Headers: map[string]string{"X": "1"}}} {
+		svc, ok := option.AddOption(&mockHTTP{}).(StreamHTTP)
+		require.True(t, ok)
+
+		resp, err := svc.PostStream(t.Context(), "upload", nil, strings.NewReader("a"), nil)
+
+		assert.Nil(t, resp)
+		require.ErrorIs(t, err, ErrStreamNotSupported)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
 	"gofr.dev/pkg/gofr/logging"
 )
 
@@ -14,15 +15,28 @@ type SubscribeFunc func(c *Context) error
 type SubscriptionManager struct {
 	container     *container.Container
 	subscriptions map[string]SubscribeFunc
+	// subscribers holds the subscribers of the topics not subscribed to from the subscriber of the container,
+	// such as the changes of datasources.
+	subscribers map[string]pubsub.Subscriber
 }
 
 func newSubscriptionManager(c *container.Container) SubscriptionManager {
 	return SubscriptionManager{
 		container:     c,
 		subscriptions: make(map[string]SubscribeFunc),
+		subscribers:   make(map[string]pubsub.Subscriber),
 	}
 }
 
+// subscriber returns the subscriber of topic.
+func (s *SubscriptionManager) subscriber(topic string) pubsub.Subscriber {
+	if subscriber, ok := s.subscribers[topic]; ok {
+		return subscriber
+	}
+
+	return s.container.GetSubscriber()
+}
+
 // startSubscriber continuously subscribes to a topic and handles messages using the provided handler.
 func (s *SubscriptionManager) startSubscriber(ctx context.Context, topic string, handler SubscribeFunc) error {
 	var delay time.Duration
@@ -44,7 +58,7 @@ func (s *SubscriptionManager) startSubscriber(ctx context.Context, topic string,
 }
 
 func (s *SubscriptionManager) handleSubscription(ctx context.Context, topic string, handler SubscribeFunc) error {
-	msg, err := s.container.GetSubscriber().Subscribe(ctx, topic)
+	msg, err := s.subscriber(topic).Subscribe(ctx, topic)
 	if err != nil {
 		s.container.Logger.Errorf("error while reading from topic %v, err: %v", topic, err.Error())
 
@@ -0,0 +1,373 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// CassetteMode tells whether a ReplayServer serves the responses of its cassette or records them.
+type CassetteMode int
+
+const (
+	// ModeReplay serves the responses recorded in the cassette, failing the test on requests it does not hold.
+	ModeReplay CassetteMode = iota
+	// ModeRecord proxies the requests to the upstream service, and writes them to the cassette once the test ends.
+	ModeRecord
+)
+
+// MatchOn lists the parts of a request compared to the recorded ones to find its response.
+type MatchOn uint8
+
+const (
+	MatchMethod MatchOn = 1 << iota
+	MatchPath
+	MatchQuery
+	// MatchBody compares the request bodies, as JSON values when both are JSON.
+	MatchBody
+)
+
+// ReplayServerConfig configures a ReplayServer.
+type ReplayServerConfig struct {
+	// Cassette is the path of the JSON file holding the recorded requests and responses.
+	Cassette string
+	Mode     CassetteMode
+	// Upstream is the address of the service the requests are proxied to in ModeRecord.
+	Upstream string
+	// MatchOn is the parts of the requests matched, MatchMethod|MatchPath|MatchQuery by default.
+	MatchOn MatchOn
+}
+
+// ReplayServer is an in-process HTTP server standing for a downstream service in tests. Its URL is
+// given to AddHTTPService in place of the address of the service:
+//
+//	server := testutil.NewReplayServer(t, testutil.ReplayServerConfig{Cassette: "testdata/orders.json"})
+//	app.AddHTTPService("orders", server.URL)
+//
+// The cassette is recorded once against the real service with ModeRecord, and committed with the tests.
+type ReplayServer struct {
+	*httptest.Server
+
+	t      testing.TB
+	config ReplayServerConfig
+
+	mu           sync.Mutex
+	interactions []interaction
+	used         []bool
+}
+
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string   `json:"method"`
+	Path   string   `json:"path"`
+	Query  string   `json:"query,omitempty"`
+	Body   bodyData `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       bodyData    `json:"body,omitempty"`
+}
+
+// bodyData is a body kept as text in the cassette, unless it is binary.
+type bodyData []byte
+
+func (b bodyData) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *bodyData) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = []byte(text)
+
+		return nil
+	}
+
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	*b = decoded
+
+	return err
+}
+
+// NewReplayServer starts a ReplayServer, which is closed once the test ends. In ModeReplay, the test fails
+// when the cassette cannot be read.
+func NewReplayServer(t testing.TB, config ReplayServerConfig) *ReplayServer {
+	t.Helper()
+
+	if config.MatchOn == 0 {
+		config.MatchOn = MatchMethod | MatchPath | MatchQuery
+	}
+
+	s := &ReplayServer{t: t, config: config}
+
+	if config.Mode == ModeRecord && config.Upstream == "" {
+		t.Fatalf("replay server needs the Upstream address of the service to record cassette %s", config.Cassette)
+	}
+
+	if config.Mode == ModeReplay {
+		if err := s.load(); err != nil {
+			t.Fatalf("failed to read cassette %s, record it with testutil.ModeRecord: %v", config.Cassette, err)
+		}
+
+		s.Server = httptest.NewServer(http.HandlerFunc(s.replay))
+	} else {
+		s.Server = httptest.NewServer(http.HandlerFunc(s.record))
+	}
+
+	t.Cleanup(func() {
+		s.Close()
+
+		if config.Mode == ModeRecord {
+			if err := s.save(); err != nil {
+				t.Errorf("failed to write cassette %s: %v", config.Cassette, err)
+			}
+		}
+	})
+
+	return s
+}
+
+func (s *ReplayServer) load() error {
+	data, err := os.ReadFile(s.config.Cassette)
+	if err != nil {
+		return err
+	}
+
+	var c cassette
+
+	if err = json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	s.interactions = c.Interactions
+	s.used = make([]bool, len(c.Interactions))
+
+	return nil
+}
+
+func (s *ReplayServer) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(cassette{Interactions: s.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.config.Cassette), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.config.Cassette, append(data, '\n'), 0o600)
+}
+
+// replay serves the first recorded response to a matching request not served yet, or the last one when all were.
+func (s *ReplayServer) replay(w http.ResponseWriter, r *http.Request) {
+	req, err := newRecordedRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+
+	match := -1
+
+	for i := range s.interactions {
+		if s.mismatch(req, &s.interactions[i].Request) == "" {
+			match = i
+
+			if !s.used[i] {
+				break
+			}
+		}
+	}
+
+	if match < 0 {
+		diff := s.closestMismatch(req)
+
+		s.mu.Unlock()
+		s.t.Errorf("replay server received a request not in cassette %s: %s %s\n%s",
+			s.config.Cassette, req.Method, req.Path, diff)
+		http.Error(w, "no recorded response matches the request\n"+diff, http.StatusNotImplemented)
+
+		return
+	}
+
+	s.used[match] = true
+	resp := s.interactions[match].Response
+
+	s.mu.Unlock()
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+// record proxies a request to the upstream service, keeping its response for the cassette.
+func (s *ReplayServer) record(w http.ResponseWriter, r *http.Request) {
+	req, err := newRecordedRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.forward(r, req)
+	if err != nil {
+		s.t.Errorf("replay server failed to record %s %s: %v", req.Method, req.Path, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+
+		return
+	}
+
+	s.mu.Lock()
+	s.interactions = append(s.interactions, interaction{Request: *req, Response: *resp})
+	s.mu.Unlock()
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+func (s *ReplayServer) forward(r *http.Request, req *recordedRequest) (*recordedResponse, error) {
+	target := strings.TrimRight(s.config.Upstream, "/") + r.URL.RequestURI()
+
+	out, err := http.NewRequestWithContext(r.Context(), r.Method, target, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	out.Header = r.Header.Clone()
+
+	resp, err := http.DefaultTransport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Date")
+	header.Del("Content-Length")
+
+	return &recordedResponse{StatusCode: resp.StatusCode, Header: header, Body: body}, nil
+}
+
+func newRecordedRequest(r *http.Request) (*recordedRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query().Encode(), Body: body}, nil
+}
+
+// mismatch describes the differences between a request and a recorded one, returning an empty string when they match.
+func (s *ReplayServer) mismatch(got, recorded *recordedRequest) string {
+	var diff strings.Builder
+
+	on := s.config.MatchOn
+
+	if on&MatchMethod != 0 && got.Method != recorded.Method {
+		fmt.Fprintf(&diff, "  method: got %s, recorded %s\n", got.Method, recorded.Method)
+	}
+
+	if on&MatchPath != 0 && got.Path != recorded.Path {
+		fmt.Fprintf(&diff, "  path:   got %s, recorded %s\n", got.Path, recorded.Path)
+	}
+
+	if on&MatchQuery != 0 && !sameQuery(got.Query, recorded.Query) {
+		fmt.Fprintf(&diff, "  query:  got %q, recorded %q\n", got.Query, recorded.Query)
+	}
+
+	if on&MatchBody != 0 && !sameBody(got.Body, recorded.Body) {
+		fmt.Fprintf(&diff, "  body:   got %q, recorded %q\n", got.Body, recorded.Body)
+	}
+
+	return diff.String()
+}
+
+// closestMismatch describes how a request differs from the recorded request it is the closest to.
+func (s *ReplayServer) closestMismatch(req *recordedRequest) string {
+	if len(s.interactions) == 0 {
+		return "  the cassette holds no request"
+	}
+
+	closest := ""
+
+	for i := range s.interactions {
+		diff := s.mismatch(req, &s.interactions[i].Request)
+		if closest == "" || strings.Count(diff, "\n") < strings.Count(closest, "\n") {
+			closest = diff
+		}
+	}
+
+	return "closest recorded request differs by:\n" + closest
+}
+
+func sameQuery(a, b string) bool {
+	qa, errA := url.ParseQuery(a)
+	qb, errB := url.ParseQuery(b)
+
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return reflect.DeepEqual(qa, qb)
+}
+
+func sameBody(a, b []byte) bool {
+	var ja, jb any
+
+	if json.Unmarshal(a, &ja) == nil && json.Unmarshal(b, &jb) == nil {
+		return reflect.DeepEqual(ja, jb)
+	}
+
+	return bytes.Equal(a, b)
+}
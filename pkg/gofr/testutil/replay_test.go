@@ -0,0 +1,166 @@
+package testutil
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTB records the errors of a test instead of failing it.
+type recordingTB struct {
+	testing.TB
+
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func send(t *testing.T, method, url, body string) (status int, respBody string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(data)
+}
+
+// recordCassette records the responses of an upstream service to the given requests, counting from 1.
+func recordCassette(t *testing.T, path string, config ReplayServerConfig, requests [][3]string) {
+	t.Helper()
+
+	calls := 0
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		w.Header().Set("X-Call", fmt.Sprint(calls))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s %s %d", r.Method, r.URL.Path, calls)
+	}))
+	defer upstream.Close()
+
+	t.Run("record", func(t *testing.T) {
+		config.Cassette, config.Mode, config.Upstream = path, ModeRecord, upstream.URL
+		server := NewReplayServer(t, config)
+
+		for i, r := range requests {
+			status, body := send(t, r[0], server.URL+r[1], r[2])
+
+			assert.Equal(t, http.StatusCreated, status)
+			assert.Equal(t, fmt.Sprintf("%s %s %d", r[0], strings.Split(r[1], "?")[0], i+1), body)
+		}
+	})
+}
+
+func TestReplayServer_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "orders.json")
+
+	recordCassette(t, path, ReplayServerConfig{}, [][3]string{
+		{http.MethodGet, "/orders?page=1&size=10", ""},
+		{http.MethodPost, "/orders", `{"id":1}`},
+		{http.MethodGet, "/orders?page=1&size=10", ""},
+	})
+
+	server := NewReplayServer(t, ReplayServerConfig{Cassette: path})
+
+	testCases := []struct {
+		method string
+		url    string
+		body   string
+	}{
+		{method: http.MethodPost, url: "/orders", body: "POST /orders 2"},
+		{method: http.MethodGet, url: "/orders?size=10&page=1", body: "GET /orders 1"},
+		{method: http.MethodGet, url: "/orders?page=1&size=10", body: "GET /orders 3"},
+		// once all matching responses were served, the last one is served again.
+		{method: http.MethodGet, url: "/orders?page=1&size=10", body: "GET /orders 3"},
+	}
+
+	for i, tc := range testCases {
+		status, body := send(t, tc.method, server.URL+tc.url, "")
+
+		assert.Equal(t, http.StatusCreated, status, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc.body, body, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestReplayServer_MatchBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.json")
+	config := ReplayServerConfig{MatchOn: MatchMethod | MatchPath | MatchBody}
+
+	recordCassette(t, path, config, [][3]string{
+		{http.MethodPost, "/search", `{"q":"shoes","size":10}`},
+		{http.MethodPost, "/search", `{"q":"hats"}`},
+	})
+
+	config.Cassette = path
+	server := NewReplayServer(t, config)
+
+	_, body := send(t, http.MethodPost, server.URL+"/search?ignored=true", `{"size": 10, "q": "shoes"}`)
+	assert.Equal(t, "POST /search 1", body, "JSON bodies are compared as values")
+
+	_, body = send(t, http.MethodPost, server.URL+"/search", `{"q":"hats"}`)
+	assert.Equal(t, "POST /search 2", body)
+}
+
+func TestReplayServer_Unmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.json")
+
+	recordCassette(t, path, ReplayServerConfig{}, [][3]string{{http.MethodGet, "/orders?page=1", ""}})
+
+	tb := &recordingTB{TB: t}
+	server := NewReplayServer(tb, ReplayServerConfig{Cassette: path})
+
+	status, body := send(t, http.MethodDelete, server.URL+"/orders?page=2", "")
+
+	assert.Equal(t, http.StatusNotImplemented, status)
+	assert.Contains(t, body, "method: got DELETE, recorded GET")
+	assert.Contains(t, body, `query:  got "page=2", recorded "page=1"`)
+
+	require.Len(t, tb.errors, 1)
+	assert.Contains(t, tb.errors[0], "DELETE /orders")
+}
+
+func TestReplayServer_BinaryBody(t *testing.T) {
+	data := []byte{0xff, 0x00, 0xfe}
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer upstream.Close()
+
+	path := filepath.Join(t.TempDir(), "binary.json")
+
+	t.Run("record", func(t *testing.T) {
+		server := NewReplayServer(t, ReplayServerConfig{Cassette: path, Mode: ModeRecord, Upstream: upstream.URL})
+
+		_, body := send(t, http.MethodGet, server.URL+"/file", "")
+		assert.Equal(t, string(data), body)
+	})
+
+	server := NewReplayServer(t, ReplayServerConfig{Cassette: path})
+
+	_, body := send(t, http.MethodGet, server.URL+"/file", "")
+	assert.Equal(t, string(data), body)
+}